@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/infrastructure/database"
+	"irt-exam-system/backend/internal/infrastructure/repositories"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	subjectID := flag.Uint("subject", 0, "科目ID")
+	model := flag.String("model", "", "标定模型：Rasch、1PL、2PL、3PL 或 4PL，默认使用科目配置")
+	dryRun := flag.Bool("dry-run", false, "只输出标定结果，不写回数据库")
+	flag.Parse()
+
+	if *subjectID == 0 {
+		log.Fatal("subject is required")
+	}
+
+	_ = godotenv.Load()
+	db, err := database.NewConnection(database.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	calibrationService := services.NewCalibrationService(repositories.NewAbilityRepository(db), repositories.NewSubjectRepository(db))
+	result, err := calibrationService.CalibrateSubject(context.Background(), *subjectID, irt.ModelFamily(*model), *dryRun)
+	if err != nil {
+		log.Fatalf("Calibration failed: %v", err)
+	}
+
+	fmt.Printf("model=%s D=%.1f examinees=%d iterations=%d converged=%t max_change=%.5f log_likelihood=%.3f\n",
+		result.Model, result.D, result.Examinees, result.Iterations, result.Converged, result.MaxChange, result.LogLikelihood)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "question\tn\ta\tse(a)\tb\tse(b)\tc\tse(c)\td\tse(d)\t")
+	for _, item := range result.Items {
+		if item.Skipped {
+			fmt.Fprintf(w, "%d\t%d\tskipped\t\t\t\t\t\t\t\t\n", item.QuestionID, item.ResponseCount)
+			continue
+		}
+		fmt.Fprintf(w, "%d\t%d\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t\n",
+			item.QuestionID, item.ResponseCount,
+			item.Discrimination, item.DiscriminationSE,
+			item.Difficulty, item.DifficultySE,
+			item.Guessing, item.GuessingSE,
+			item.UpperAsymptote, item.UpperAsymptoteSE)
+	}
+	w.Flush()
+}
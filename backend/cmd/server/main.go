@@ -3,21 +3,102 @@ package main
 import (
 	"log"
 
-	"backend/internal/infrastructure/persistence"
-	"backend/internal/interfaces/api/routes"
+	"irt-exam-system/backend/internal/application/services"
+	domainservices "irt-exam-system/backend/internal/domain/services"
+	"irt-exam-system/backend/internal/infrastructure/database"
+	"irt-exam-system/backend/internal/infrastructure/repositories"
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/routes"
 
 	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
 )
 
 func main() {
-	db, err := persistence.InitDB()
+	_ = godotenv.Load()
+	db, err := database.NewConnection(database.ConfigFromEnv())
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
-	defer db.Close()
+	if err := database.Migrate(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	// 仓储
+	abilityRepo := repositories.NewAbilityRepository(db)
+	subjectRepo := repositories.NewSubjectRepository(db)
+	exposureRepo := repositories.NewExposureRepository(db)
+	questionRepo := repositories.NewQuestionRepository(db)
+	blueprintRepo := repositories.NewBlueprintRepository(db)
+	examRepo := repositories.NewExamRepository(db)
+	examSessionRepo := repositories.NewExamSessionRepository(db)
+	difRepo := repositories.NewDIFRepository(db)
+	pretestRepo := repositories.NewPretestRepository(db)
+	performanceLevelRepo := repositories.NewPerformanceLevelRepository(db)
+	linkingRepo := repositories.NewLinkingRepository(db)
+	knowledgeRepo := repositories.NewKnowledgeRepository(db)
+	responseTimeRepo := repositories.NewResponseTimeRepository(db)
+	forensicsRepo := repositories.NewForensicsRepository(db)
+	statisticsRepo := repositories.NewStatisticsRepository(db)
+	reportingRepo := repositories.NewReportingRepository(db)
+	standardSettingRepo := repositories.NewStandardSettingRepository(db)
+	mstRepo := repositories.NewMSTRepository(db)
+	mirtRepo := repositories.NewMIRTRepository(db)
+
+	// 应用服务
+	calibrationService := services.NewCalibrationService(abilityRepo, subjectRepo)
+	exposureService := services.NewExposureService(exposureRepo, questionRepo, subjectRepo)
+	blueprintService := services.NewBlueprintService(blueprintRepo, examRepo)
+	examService := services.NewExamService(examRepo, questionRepo)
+	fitService := services.NewFitService(abilityRepo, subjectRepo)
+	difService := services.NewDIFService(abilityRepo, difRepo, subjectRepo)
+	testInformationService := services.NewTestInformationService(examRepo, questionRepo, subjectRepo)
+	assemblyService := services.NewAssemblyService(questionRepo, examRepo, subjectRepo, pretestRepo)
+	abilityService := services.NewAbilityService(abilityRepo, subjectRepo, performanceLevelRepo)
+	subjectService := services.NewSubjectService(subjectRepo)
+	linkingService := services.NewLinkingService(linkingRepo, abilityRepo, subjectRepo)
+	pretestService := services.NewPretestService(pretestRepo, abilityRepo, subjectRepo)
+	diagnosisService := services.NewDiagnosisService(abilityRepo, questionRepo, knowledgeRepo)
+	responseTimeService := services.NewResponseTimeService(responseTimeRepo, abilityRepo, subjectRepo)
+	forensicsService := services.NewForensicsService(forensicsRepo, abilityRepo, responseTimeRepo, subjectRepo)
+	statisticsService := services.NewStatisticsService(statisticsRepo)
+	reportingService := services.NewReportingService(reportingRepo, performanceLevelRepo, subjectRepo)
+	standardSettingService := services.NewStandardSettingService(standardSettingRepo, performanceLevelRepo, abilityRepo, subjectRepo)
+	mstService := services.NewMSTService(mstRepo, examRepo, questionRepo, subjectRepo)
+	mirtService := services.NewMIRTService(mirtRepo, abilityRepo, questionRepo, knowledgeRepo, subjectRepo)
+	growthService := services.NewGrowthService(abilityRepo)
+
+	// 自适应考试会话
+	adaptiveExamService := domainservices.NewExamService(
+		questionRepo, examRepo, examSessionRepo, exposureRepo, blueprintRepo, subjectRepo, pretestRepo,
+		responseTimeRepo, reportingRepo, performanceLevelRepo, mstRepo, mirtRepo,
+		abilityService, domainservices.NewIRTService(),
+	)
 
 	router := gin.Default()
 	routes.SetupAuthRoutes(router)
+	routes.SetupCalibrationRoutes(router, handlers.NewCalibrationHandler(calibrationService))
+	routes.SetupExposureRoutes(router, handlers.NewExposureHandler(exposureService))
+	routes.SetupBlueprintRoutes(router, handlers.NewBlueprintHandler(blueprintService))
+	routes.SetupAdaptiveRoutes(router, handlers.NewExamHandler(examService))
+	routes.SetupExamSessionRoutes(router, handlers.NewExamSessionHandler(adaptiveExamService))
+	routes.SetupFitRoutes(router, handlers.NewFitHandler(fitService))
+	routes.SetupDIFRoutes(router, handlers.NewDIFHandler(difService))
+	routes.SetupTestInformationRoutes(router, handlers.NewTestInformationHandler(testInformationService))
+	routes.SetupAssemblyRoutes(router, handlers.NewAssemblyHandler(assemblyService))
+	routes.SetupItemModelRoutes(router, handlers.NewItemModelHandler(abilityService))
+	routes.SetupSubjectModelRoutes(router, handlers.NewSubjectModelHandler(subjectService))
+	routes.SetupLinkingRoutes(router, handlers.NewLinkingHandler(linkingService))
+	routes.SetupPretestRoutes(router, handlers.NewPretestHandler(pretestService))
+	routes.SetupDiagnosisRoutes(router, handlers.NewDiagnosisHandler(diagnosisService))
+	routes.SetupResponseTimeRoutes(router, handlers.NewResponseTimeHandler(responseTimeService))
+	routes.SetupForensicsRoutes(router, handlers.NewForensicsHandler(forensicsService))
+	routes.SetupStatisticsRoutes(router, handlers.NewStatisticsHandler(statisticsService))
+	routes.SetupReportingRoutes(router, handlers.NewReportingHandler(reportingService))
+	routes.SetupStandardSettingRoutes(router, handlers.NewStandardSettingHandler(standardSettingService))
+	routes.SetupMSTRoutes(router, handlers.NewMSTHandler(mstService))
+	routes.SetupMIRTRoutes(router, handlers.NewMIRTHandler(mirtService))
+	routes.SetupGrowthRoutes(router, handlers.NewGrowthHandler(growthService))
 
 	if err := router.Run(":8080"); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
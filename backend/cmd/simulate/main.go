@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/infrastructure/database"
+	"irt-exam-system/backend/internal/infrastructure/repositories"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	defaults := irt.DefaultCATSimulationConfig()
+	subjectID := flag.Uint("subject", 0, "科目ID")
+	paperID := flag.Uint("paper", 0, "试卷ID，指定时选题策略、终止规则与内容蓝图取自试卷配置")
+	examinees := flag.Int("n", defaults.Examinees, "模拟考生数")
+	distribution := flag.String("dist", string(defaults.Distribution), "真实能力分布：normal 或 uniform")
+	mean := flag.Float64("mean", defaults.Mean, "正态分布均值")
+	sd := flag.Float64("sd", defaults.SD, "正态分布标准差")
+	minTheta := flag.Float64("min", defaults.Min, "均匀分布下限")
+	maxTheta := flag.Float64("max", defaults.Max, "均匀分布上限")
+	strategy := flag.String("strategy", string(defaults.Strategy), "选题策略：max_info、kl、a_stratified、b_matching（未指定 -paper 时使用）")
+	minItems := flag.Int("min-items", defaults.Stopping.MinItems, "最少题数（未指定 -paper 时使用）")
+	maxItems := flag.Int("max-items", defaults.Stopping.MaxItems, "最大题数（未指定 -paper 时使用）")
+	targetSE := flag.Float64("target-se", defaults.Stopping.TargetSE, "目标标准误，0表示不启用（未指定 -paper 时使用）")
+	binWidth := flag.Float64("bin-width", defaults.BinWidth, "条件统计量的能力分组宽度")
+	top := flag.Int("top", 20, "输出曝光率最高的题目数，0表示全部")
+	seed := flag.Int64("seed", defaults.Seed, "随机种子")
+	flag.Parse()
+
+	if *subjectID == 0 {
+		log.Fatal("subject is required")
+	}
+
+	cfg := defaults
+	cfg.Examinees = *examinees
+	cfg.Distribution = irt.ThetaDistribution(*distribution)
+	cfg.Mean, cfg.SD = *mean, *sd
+	cfg.Min, cfg.Max = *minTheta, *maxTheta
+	cfg.Strategy = irt.SelectionStrategy(*strategy)
+	cfg.Stopping.MinItems = *minItems
+	cfg.Stopping.MaxItems = *maxItems
+	cfg.Stopping.TargetSE = *targetSE
+	cfg.BinWidth = *binWidth
+	cfg.Seed = *seed
+
+	_ = godotenv.Load()
+	db, err := database.NewConnection(database.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	simulationService := services.NewSimulationService(
+		repositories.NewAbilityRepository(db),
+		repositories.NewQuestionRepository(db),
+		repositories.NewExamRepository(db),
+		repositories.NewBlueprintRepository(db),
+		repositories.NewExposureRepository(db),
+		repositories.NewSubjectRepository(db),
+	)
+	result, err := simulationService.SimulateCAT(context.Background(), *subjectID, *paperID, cfg)
+	if err != nil {
+		log.Fatalf("Simulation failed: %v", err)
+	}
+
+	fmt.Printf("examinees=%d bias=%.4f rmse=%.4f correlation=%.4f mean_se=%.4f mean_length=%.2f min_length=%d max_length=%d\n",
+		result.Examinees, result.Bias, result.RMSE, result.Correlation, result.MeanSE,
+		result.MeanTestLength, result.MinTestLength, result.MaxTestLength)
+	if cfg.Stopping.Classification != irt.ClassificationNone || cfg.Stopping.CutScore != 0 || *paperID != 0 {
+		fmt.Printf("classification_accuracy=%.4f\n", result.ClassificationAccuracy)
+	}
+
+	reasons := make([]string, 0, len(result.StopReasons))
+	for reason := range result.StopReasons {
+		reasons = append(reasons, string(reason))
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		fmt.Printf("stop_reason=%s count=%d\n", reason, result.StopReasons[irt.StopReason(reason)])
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "theta\tn\tbias\trmse\tse\tlength\t")
+	for _, c := range result.Conditional {
+		fmt.Fprintf(w, "[%.2f, %.2f)\t%d\t%.4f\t%.4f\t%.4f\t%.2f\t\n",
+			c.ThetaMin, c.ThetaMax, c.Examinees, c.Bias, c.RMSE, c.MeanSE, c.MeanTestLength)
+	}
+	w.Flush()
+
+	fmt.Printf("\nitems=%d max_exposure_rate=%.4f unused_items=%d\n", len(result.Exposure), result.MaxExposureRate, result.UnusedItems)
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "question\tadministered\trate\t")
+	for i, e := range result.Exposure {
+		if *top > 0 && i >= *top {
+			break
+		}
+		fmt.Fprintf(w, "%d\t%d\t%.4f\t\n", e.QuestionID, e.Administered, e.Rate)
+	}
+	w.Flush()
+}
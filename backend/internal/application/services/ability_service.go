@@ -2,12 +2,16 @@ package services
 
 import (
 	"context"
-	"math"
+	"errors"
+	"fmt"
 
+	"irt-exam-system/backend/internal/domain/irt"
 	"irt-exam-system/backend/internal/domain/repositories"
 	"irt-exam-system/backend/models"
 )
 
+var ErrUncalibratedItem = errors.New("question has no calibrated IRT parameters")
+
 // AbilityService 能力值服务接口
 type AbilityService interface {
 	// 能力值管理
@@ -23,24 +27,39 @@ type AbilityService interface {
 	UpdateQuestionParameters(ctx context.Context, questionID uint, difficulty, discrimination, guessing float64) error
 	GetQuestionParameters(ctx context.Context, questionID uint) (*models.QuestionParameter, error)
 	BatchUpdateParameters(ctx context.Context, params []*models.QuestionParameter) error
+	// UpdateItemModel 设置题目的项目反应模型，GRM/GPCM 需给出按类别排列的阈值
+	UpdateItemModel(ctx context.Context, questionID uint, model irt.ItemModel, discrimination float64, thresholds []float64) (*models.QuestionParameter, error)
 
 	// IRT模型计算
 	CalculateResponseProbability(ctx context.Context, ability float64, questionID uint) (float64, error)
 	EstimateAbility(ctx context.Context, responses []*models.ExamResponse) (float64, float64, error)
+	EstimateAbilityWithMethod(ctx context.Context, responses []*models.ExamResponse, method irt.EstimationMethod) (*irt.AbilityEstimate, error)
+	SaveEstimation(ctx context.Context, record *models.ExamRecord, subjectID uint, method irt.EstimationMethod) (*models.AbilityEstimation, error)
+	// SaveSessionEstimation 保存自适应考试会话结束时的能力值估计并更新用户当前能力值
+	SaveSessionEstimation(ctx context.Context, userID, subjectID, sessionID uint, estimate *irt.AbilityEstimate) error
 	GetConfidenceInterval(ctx context.Context, ability, standardError float64) (float64, float64, error)
-	GetPerformanceLevel(ctx context.Context, ability float64) (string, error)
+	// GetPerformanceLevel 按科目启用的表现水平划分能力值，科目未设定表现水平时返回空字符串
+	GetPerformanceLevel(ctx context.Context, subjectID uint, ability float64) (string, error)
 	GenerateRecommendations(ctx context.Context, userID uint, ability float64) ([]string, error)
 }
 
 // NewAbilityService creates a new ability service instance
-func NewAbilityService(abilityRepo repositories.AbilityRepository) AbilityService {
+func NewAbilityService(
+	abilityRepo repositories.AbilityRepository,
+	subjectRepo repositories.SubjectRepository,
+	performanceLevelRepo repositories.PerformanceLevelRepository,
+) AbilityService {
 	return &abilityService{
-		abilityRepo: abilityRepo,
+		abilityRepo:          abilityRepo,
+		subjectRepo:          subjectRepo,
+		performanceLevelRepo: performanceLevelRepo,
 	}
 }
 
 type abilityService struct {
-	abilityRepo repositories.AbilityRepository
+	abilityRepo          repositories.AbilityRepository
+	subjectRepo          repositories.SubjectRepository
+	performanceLevelRepo repositories.PerformanceLevelRepository
 }
 
 // GetUserAbility implements AbilityService
@@ -89,28 +108,257 @@ func (s *abilityService) BatchUpdateParameters(ctx context.Context, params []*mo
 	return s.abilityRepo.BatchUpdateParameters(ctx, params)
 }
 
+// UpdateItemModel implements AbilityService
+func (s *abilityService) UpdateItemModel(ctx context.Context, questionID uint, model irt.ItemModel, discrimination float64, thresholds []float64) (*models.QuestionParameter, error) {
+	if model == "" {
+		model = irt.ModelThreePL
+	}
+	if err := irt.ValidateThresholds(model, thresholds); err != nil {
+		return nil, err
+	}
+
+	params, err := s.abilityRepo.GetQuestionParameters(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = &models.QuestionParameter{QuestionID: questionID, Discrimination: 1}
+	}
+	params.ItemModel = string(model)
+	params.Categories = nil
+	if model.Polytomous() {
+		params.Discrimination = discrimination
+		// 阈值均值作为题目的位置参数，供难度匹配类选题使用
+		var sum float64
+		for k, b := range thresholds {
+			sum += b
+			params.Categories = append(params.Categories, models.QuestionCategoryParameter{
+				QuestionID: questionID,
+				Category:   k + 1,
+				Threshold:  b,
+			})
+		}
+		params.Difficulty = sum / float64(len(thresholds))
+		params.Guessing = 0
+	}
+	if err := s.abilityRepo.SaveItemModel(ctx, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
 // CalculateResponseProbability implements AbilityService
+// 多级计分题返回期望得分率
 func (s *abilityService) CalculateResponseProbability(ctx context.Context, ability float64, questionID uint) (float64, error) {
 	params, err := s.abilityRepo.GetQuestionParameters(ctx, questionID)
 	if err != nil {
 		return 0, err
 	}
+	model, err := subjectModel(ctx, s.subjectRepo, params.Question.SubjectID)
+	if err != nil {
+		return 0, err
+	}
+	item := irt.ItemParams{
+		Difficulty:     params.Difficulty,
+		Discrimination: params.Discrimination,
+		Guessing:       params.Guessing,
+		UpperAsymptote: params.UpperAsymptote,
+	}
+	if itemModel := irt.ItemModel(params.ItemModel); itemModel.Polytomous() {
+		thresholds := categoryThresholds(params)
+		expected := model.ExpectedCategory(itemModel, ability, item, thresholds)
+		return expected / float64(len(thresholds)), nil
+	}
 
-	// 使用3PL模型计算作答概率
-	// P(θ) = c + (1-c)/(1+e^(-1.7a(θ-b)))
+	// 按科目配置的模型计算作答概率
+	// P(θ) = c + (d-c)/(1+e^(-Da(θ-b)))
 	// θ: 能力值
 	// a: 区分度
 	// b: 难度
 	// c: 猜测参数
-	exp := -1.7 * params.Discrimination * (ability - params.Difficulty)
-	prob := params.Guessing + (1-params.Guessing)/(1+math.Exp(exp))
-	return prob, nil
+	// d: 上渐近线，4PL 以外的模型为1
+	// D: 量尺因子，1.0 或 1.7
+	return model.Probability(ability, item), nil
 }
 
 // EstimateAbility implements AbilityService
+// 默认使用EAP估计，全对或全错的作答模式也能得到有限的能力值
 func (s *abilityService) EstimateAbility(ctx context.Context, responses []*models.ExamResponse) (float64, float64, error) {
-	// TODO: 实现最大似然估计(MLE)或贝叶斯估计(EAP)
-	return 0, 0, nil
+	estimate, err := s.EstimateAbilityWithMethod(ctx, responses, irt.MethodEAP)
+	if err != nil {
+		return 0, 0, err
+	}
+	return estimate.Theta, estimate.StandardError, nil
+}
+
+// EstimateAbilityWithMethod implements AbilityService
+// 按作答题目所属科目的模型估计
+func (s *abilityService) EstimateAbilityWithMethod(ctx context.Context, responses []*models.ExamResponse, method irt.EstimationMethod) (*irt.AbilityEstimate, error) {
+	return s.estimate(ctx, responses, 0, method)
+}
+
+// estimate 使用科目的项目反应模型估计能力值，subjectID 为0时取作答题目所属的科目
+func (s *abilityService) estimate(ctx context.Context, responses []*models.ExamResponse, subjectID uint, method irt.EstimationMethod) (*irt.AbilityEstimate, error) {
+	items, questionSubject, err := s.buildItemResponses(ctx, responses)
+	if err != nil {
+		return nil, err
+	}
+	if subjectID == 0 {
+		subjectID = questionSubject
+	}
+	model, err := subjectModel(ctx, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	estimator := irt.NewEstimator(method)
+	estimator.Model = model
+	return estimator.Estimate(items)
+}
+
+// SaveEstimation implements AbilityService
+// 估计整场考试的能力值，写入估计历史并更新用户当前能力值
+func (s *abilityService) SaveEstimation(ctx context.Context, record *models.ExamRecord, subjectID uint, method irt.EstimationMethod) (*models.AbilityEstimation, error) {
+	responses := make([]*models.ExamResponse, len(record.Responses))
+	for i := range record.Responses {
+		responses[i] = &record.Responses[i]
+	}
+
+	estimate, err := s.estimate(ctx, responses, subjectID, method)
+	if err != nil {
+		return nil, err
+	}
+
+	estimation := &models.AbilityEstimation{
+		UserID:        record.UserID,
+		SubjectID:     subjectID,
+		ExamRecordID:  record.ID,
+		Ability:       estimate.Theta,
+		StandardError: estimate.StandardError,
+		Method:        string(estimate.Method),
+	}
+	if err := s.saveEstimation(ctx, estimation); err != nil {
+		return nil, err
+	}
+	return estimation, nil
+}
+
+// SaveSessionEstimation implements AbilityService
+// 会话的能力值由会话作答记录估计，这里只写入估计历史
+func (s *abilityService) SaveSessionEstimation(ctx context.Context, userID, subjectID, sessionID uint, estimate *irt.AbilityEstimate) error {
+	return s.saveEstimation(ctx, &models.AbilityEstimation{
+		UserID:        userID,
+		SubjectID:     subjectID,
+		ExamSessionID: sessionID,
+		Ability:       estimate.Theta,
+		StandardError: estimate.StandardError,
+		Method:        string(estimate.Method),
+	})
+}
+
+// saveEstimation 写入估计历史，并以该估计更新用户在科目上的当前能力值
+func (s *abilityService) saveEstimation(ctx context.Context, estimation *models.AbilityEstimation) error {
+	if err := s.abilityRepo.CreateEstimation(ctx, estimation); err != nil {
+		return err
+	}
+
+	ability, err := s.abilityRepo.FindUserAbility(ctx, estimation.UserID, estimation.SubjectID)
+	if err != nil {
+		return err
+	}
+	if ability == nil {
+		ability = &models.UserAbility{
+			UserID:        estimation.UserID,
+			SubjectID:     estimation.SubjectID,
+			Ability:       estimation.Ability,
+			StandardError: estimation.StandardError,
+		}
+		return s.abilityRepo.CreateUserAbility(ctx, ability)
+	}
+	ability.Ability = estimation.Ability
+	ability.StandardError = estimation.StandardError
+	return s.abilityRepo.UpdateUserAbility(ctx, ability)
+}
+
+// buildItemResponses 读取作答题目及其IRT参数构造作答模式，并返回题目所属科目；
+// 试测题不计分，计分题未标定时返回 ErrUncalibratedItem
+func (s *abilityService) buildItemResponses(ctx context.Context, responses []*models.ExamResponse) ([]irt.ItemResponse, uint, error) {
+	questionIDs := make([]uint, 0, len(responses))
+	for _, response := range responses {
+		questionIDs = append(questionIDs, response.QuestionID)
+	}
+
+	questions, err := s.abilityRepo.ListQuestions(ctx, questionIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	questionsByID := make(map[uint]*models.Question, len(questions))
+	for _, q := range questions {
+		questionsByID[q.ID] = q
+	}
+	params, err := s.abilityRepo.ListQuestionParameters(ctx, questionIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	paramsByQuestion := make(map[uint]*models.QuestionParameter, len(params))
+	for _, p := range params {
+		paramsByQuestion[p.QuestionID] = p
+	}
+
+	var subjectID uint
+	items := make([]irt.ItemResponse, 0, len(responses))
+	for _, response := range responses {
+		question, ok := questionsByID[response.QuestionID]
+		if !ok {
+			return nil, 0, fmt.Errorf("question %d not found", response.QuestionID)
+		}
+		if subjectID == 0 {
+			subjectID = question.SubjectID
+		}
+		// 试测题不计分
+		if question.Pretest {
+			continue
+		}
+		p, ok := paramsByQuestion[response.QuestionID]
+		if !ok || p.Discrimination <= 0 {
+			return nil, 0, fmt.Errorf("%w: question %d", ErrUncalibratedItem, response.QuestionID)
+		}
+		item := irt.ItemResponse{
+			QuestionID:     response.QuestionID,
+			Correct:        response.IsCorrect,
+			Difficulty:     p.Difficulty,
+			Discrimination: p.Discrimination,
+			Guessing:       p.Guessing,
+			UpperAsymptote: p.UpperAsymptote,
+		}
+		if model := irt.ItemModel(p.ItemModel); model.Polytomous() && len(p.Categories) > 0 {
+			// 多选题等按得分率换算为部分得分类别
+			item.Model = model
+			item.Thresholds = categoryThresholds(p)
+			item.Category = irt.ScoreCategory(responseCredit(response, question), len(item.Thresholds)+1)
+		}
+		items = append(items, item)
+	}
+	return items, subjectID, nil
+}
+
+// categoryThresholds 按类别顺序取出多级计分题的阈值
+func categoryThresholds(params *models.QuestionParameter) []float64 {
+	thresholds := make([]float64, len(params.Categories))
+	for i, c := range params.Categories {
+		thresholds[i] = c.Threshold
+	}
+	return thresholds
+}
+
+// responseCredit 作答得分率，题目未设置分值时按对错计
+func responseCredit(response *models.ExamResponse, question *models.Question) float64 {
+	if question.Score <= 0 {
+		if response.IsCorrect {
+			return 1
+		}
+		return 0
+	}
+	return response.Score / question.Score
 }
 
 // GetConfidenceInterval implements AbilityService
@@ -122,19 +370,19 @@ func (s *abilityService) GetConfidenceInterval(ctx context.Context, ability, sta
 }
 
 // GetPerformanceLevel implements AbilityService
-func (s *abilityService) GetPerformanceLevel(ctx context.Context, ability float64) (string, error) {
-	switch {
-	case ability >= 2.0:
-		return "优秀", nil
-	case ability >= 1.0:
-		return "良好", nil
-	case ability >= -1.0:
-		return "中等", nil
-	case ability >= -2.0:
-		return "及格", nil
-	default:
-		return "不及格", nil
+func (s *abilityService) GetPerformanceLevel(ctx context.Context, subjectID uint, ability float64) (string, error) {
+	set, err := s.performanceLevelRepo.FindActiveSet(ctx, subjectID)
+	if err != nil {
+		return "", err
+	}
+	if set == nil {
+		return "", nil
+	}
+	level := set.Classify(ability)
+	if level == nil {
+		return "", nil
 	}
+	return level.Name, nil
 }
 
 // GenerateRecommendations implements AbilityService
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/domain/repositories"
+)
+
+// defaultQuestionSeconds 未设置预计作答时间的题目按60秒计
+const defaultQuestionSeconds = 60
+
+var (
+	ErrEmptyPool = errors.New("subject has no calibrated questions to assemble from")
+	ErrSelfEnemy = errors.New("a question cannot be its own enemy")
+)
+
+// AssemblyService 按目标测验信息函数自动组卷的服务接口
+type AssemblyService interface {
+	// Assemble 从科目题库中组出若干份平行卷，DryRun 为 false 时保存为试卷
+	Assemble(ctx context.Context, req *AssemblyRequest) (*AssemblyResult, error)
+
+	// 互斥题对维护
+	AddEnemy(ctx context.Context, questionID, enemyID uint) error
+	RemoveEnemy(ctx context.Context, questionID, enemyID uint) error
+}
+
+// AssemblyRequest 组卷请求
+type AssemblyRequest struct {
+	SubjectID   uint
+	Title       string
+	Description string
+	Duration    int // 考试时长（分钟），同时作为预计作答总时间上限，0表示不限
+	Spec        irt.AssemblySpec
+	DryRun      bool
+}
+
+// AssemblyResult 组卷结果
+type AssemblyResult struct {
+	SubjectID uint
+	PoolSize  int
+	Targets   []irt.TargetPoint
+	Forms     []AssembledPaper
+}
+
+// AssembledPaper 一份组好的平行卷，DryRun 时 ExamPaperID 为0
+type AssembledPaper struct {
+	ExamPaperID uint
+	Title       string
+	irt.AssembledForm
+	PretestQuestionIDs []uint // 按科目试测配置嵌入的试测题，不计分
+}
+
+// NewAssemblyService creates a new test assembly service instance
+func NewAssemblyService(questionRepo repositories.QuestionRepository, examRepo repositories.ExamRepository, subjectRepo repositories.SubjectRepository, pretestRepo repositories.PretestRepository) AssemblyService {
+	return &assemblyService{
+		questionRepo: questionRepo,
+		examRepo:     examRepo,
+		subjectRepo:  subjectRepo,
+		pretestRepo:  pretestRepo,
+	}
+}
+
+type assemblyService struct {
+	questionRepo repositories.QuestionRepository
+	examRepo     repositories.ExamRepository
+	subjectRepo  repositories.SubjectRepository
+	pretestRepo  repositories.PretestRepository
+}
+
+// Assemble implements AssemblyService
+func (s *assemblyService) Assemble(ctx context.Context, req *AssemblyRequest) (*AssemblyResult, error) {
+	questions, err := s.questionRepo.ListCandidates(ctx, req.SubjectID, nil)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := s.toAssemblyItems(ctx, questions)
+	if err != nil {
+		return nil, err
+	}
+	if len(pool) == 0 {
+		return nil, ErrEmptyPool
+	}
+	spec := req.Spec
+	if req.Duration > 0 {
+		spec.MaxSeconds = float64(req.Duration * 60)
+	}
+	spec.EnemyPairs, err = s.enemyPairs(ctx, questions)
+	if err != nil {
+		return nil, err
+	}
+	spec.Model, err = subjectModel(ctx, s.subjectRepo, req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	forms, err := irt.Assemble(pool, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[uint]float64, len(pool))
+	for _, item := range pool {
+		scores[item.QuestionID] = item.Score
+	}
+	plan, pretest, err := s.pretestSlots(ctx, req.SubjectID, forms)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AssemblyResult{
+		SubjectID: req.SubjectID,
+		PoolSize:  len(pool),
+		Targets:   spec.Targets,
+		Forms:     make([]AssembledPaper, len(forms)),
+	}
+	for f, form := range forms {
+		title := req.Title
+		if len(forms) > 1 {
+			title = fmt.Sprintf("%s (%c)", req.Title, 'A'+f)
+		}
+		result.Forms[f] = AssembledPaper{Title: title, AssembledForm: form, PretestQuestionIDs: pretest[f]}
+		if req.DryRun {
+			continue
+		}
+
+		paper := &models.ExamPaper{
+			Title:       title,
+			Description: req.Description,
+			Duration:    req.Duration,
+			TotalScore:  form.TotalScore,
+			SubjectID:   req.SubjectID,
+		}
+		// 试测题按随机位置插入计分题之间，分值为0
+		total := len(form.QuestionIDs) + len(pretest[f])
+		positions := make(map[int]bool, len(pretest[f]))
+		for _, pos := range plan.FormPositions(len(form.QuestionIDs), len(pretest[f])) {
+			positions[pos] = true
+		}
+		links := make([]*models.ExamPaperQuestion, total)
+		operational, seeded := 0, 0
+		for i := range links {
+			link := &models.ExamPaperQuestion{Order: int64(i + 1)}
+			if (positions[i] && seeded < len(pretest[f])) || operational == len(form.QuestionIDs) {
+				link.QuestionID = pretest[f][seeded]
+				link.Pretest = true
+				seeded++
+			} else {
+				link.QuestionID = form.QuestionIDs[operational]
+				link.Score = scores[link.QuestionID]
+				operational++
+			}
+			links[i] = link
+		}
+		if err := s.examRepo.CreatePaperWithQuestions(ctx, paper, links); err != nil {
+			return nil, err
+		}
+		result.Forms[f].ExamPaperID = paper.ID
+	}
+	return result, nil
+}
+
+// pretestSlots 按科目试测配置为各份固定卷选出嵌入的试测题，
+// 每个位置取已收集作答最少的试测题，使试测样本在各卷之间均衡
+func (s *assemblyService) pretestSlots(ctx context.Context, subjectID uint, forms []irt.AssembledForm) (irt.PretestPlan, [][]uint, error) {
+	slots := make([][]uint, len(forms))
+	setting, err := s.pretestRepo.FindSetting(ctx, subjectID)
+	if err != nil || setting == nil {
+		return irt.PretestPlan{}, slots, err
+	}
+	plan := irt.PretestPlan{Rate: setting.Rate, MaxItems: setting.MaxItems, StartAfter: setting.StartAfter}
+	items, err := s.pretestRepo.ListPretestItems(ctx, subjectID, nil)
+	if err != nil {
+		return plan, slots, err
+	}
+
+	counts := make([]int64, len(items))
+	for i, item := range items {
+		counts[i] = item.ResponseCount
+	}
+	for f, form := range forms {
+		chosen := make(map[int]bool)
+		for n := plan.FormCount(len(form.QuestionIDs)); len(slots[f]) < n && len(chosen) < len(items); {
+			available := make([]int, 0, len(items))
+			availableCounts := make([]int64, 0, len(items))
+			for i := range items {
+				if !chosen[i] {
+					available = append(available, i)
+					availableCounts = append(availableCounts, counts[i])
+				}
+			}
+			i := available[plan.Choose(availableCounts)]
+			chosen[i] = true
+			counts[i]++
+			slots[f] = append(slots[f], items[i].QuestionID)
+		}
+	}
+	return plan, slots, nil
+}
+
+// AddEnemy implements AssemblyService
+func (s *assemblyService) AddEnemy(ctx context.Context, questionID, enemyID uint) error {
+	if questionID == enemyID {
+		return ErrSelfEnemy
+	}
+	for _, id := range []uint{questionID, enemyID} {
+		if _, err := s.questionRepo.FindByID(ctx, id); err != nil {
+			return fmt.Errorf("question %d: %w", id, err)
+		}
+	}
+	return s.questionRepo.AddEnemy(ctx, questionID, enemyID)
+}
+
+// RemoveEnemy implements AssemblyService
+func (s *assemblyService) RemoveEnemy(ctx context.Context, questionID, enemyID uint) error {
+	return s.questionRepo.RemoveEnemy(ctx, questionID, enemyID)
+}
+
+// toAssemblyItems 将已标定的题目连同IRT参数、知识点关联转换为组卷引擎使用的结构，未标定的题目不进入题池
+func (s *assemblyService) toAssemblyItems(ctx context.Context, questions []*models.Question) ([]irt.AssemblyItem, error) {
+	ids := make([]uint, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+	}
+	params, err := itemParameters(ctx, s.questionRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.questionRepo.ListKnowledgePointLinks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	pointsByQuestion := make(map[uint][]uint, len(questions))
+	for _, link := range links {
+		pointsByQuestion[link.QuestionID] = append(pointsByQuestion[link.QuestionID], link.KnowledgePointID)
+	}
+
+	items := make([]irt.AssemblyItem, 0, len(questions))
+	for _, q := range questions {
+		p, ok := params[q.ID]
+		if !ok {
+			continue
+		}
+		seconds := q.EstimatedSeconds
+		if seconds <= 0 {
+			seconds = defaultQuestionSeconds
+		}
+		items = append(items, irt.AssemblyItem{
+			QuestionID:        q.ID,
+			Difficulty:        p.Difficulty,
+			Discrimination:    p.Discrimination,
+			Guessing:          p.Guessing,
+			UpperAsymptote:    p.UpperAsymptote,
+			Score:             q.Score,
+			Seconds:           float64(seconds),
+			KnowledgePointIDs: pointsByQuestion[q.ID],
+			QuestionType:      q.Type,
+		})
+	}
+	return items, nil
+}
+
+// enemyPairs 查询题库内部的互斥题对
+func (s *assemblyService) enemyPairs(ctx context.Context, questions []*models.Question) ([][2]uint, error) {
+	ids := make([]uint, len(questions))
+	inPool := make(map[uint]bool, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+		inPool[q.ID] = true
+	}
+	enemies, err := s.questionRepo.ListEnemyPairs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([][2]uint, 0, len(enemies))
+	for _, e := range enemies {
+		if inPool[e.QuestionID] && inPool[e.EnemyID] {
+			pairs = append(pairs, [2]uint{e.QuestionID, e.EnemyID})
+		}
+	}
+	return pairs, nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/domain/repositories"
+)
+
+var (
+	ErrInvalidConstraint = errors.New("constraint must target exactly one knowledge point or question type")
+	ErrInvalidBounds     = errors.New("constraint min count exceeds max count")
+	ErrProportionSum     = errors.New("target proportions of the same attribute exceed 1")
+)
+
+// BlueprintService 试卷内容蓝图服务接口
+type BlueprintService interface {
+	GetBlueprint(ctx context.Context, examPaperID uint) (*models.ExamBlueprint, error)
+	SaveBlueprint(ctx context.Context, blueprint *models.ExamBlueprint) error
+	DeleteBlueprint(ctx context.Context, examPaperID uint) error
+}
+
+// NewBlueprintService creates a new blueprint service instance
+func NewBlueprintService(blueprintRepo repositories.BlueprintRepository, examRepo repositories.ExamRepository) BlueprintService {
+	return &blueprintService{
+		blueprintRepo: blueprintRepo,
+		examRepo:      examRepo,
+	}
+}
+
+type blueprintService struct {
+	blueprintRepo repositories.BlueprintRepository
+	examRepo      repositories.ExamRepository
+}
+
+// GetBlueprint implements BlueprintService
+func (s *blueprintService) GetBlueprint(ctx context.Context, examPaperID uint) (*models.ExamBlueprint, error) {
+	return s.blueprintRepo.FindByPaperID(ctx, examPaperID)
+}
+
+// SaveBlueprint implements BlueprintService
+func (s *blueprintService) SaveBlueprint(ctx context.Context, blueprint *models.ExamBlueprint) error {
+	paper, err := s.examRepo.FindPaperByID(ctx, blueprint.ExamPaperID)
+	if err != nil {
+		return err
+	}
+	if paper == nil {
+		return errors.New("exam paper not found")
+	}
+	if err := validateBlueprint(blueprint); err != nil {
+		return err
+	}
+
+	existing, err := s.blueprintRepo.FindByPaperID(ctx, blueprint.ExamPaperID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		blueprint.ID = existing.ID
+		blueprint.CreatedAt = existing.CreatedAt
+	}
+	return s.blueprintRepo.Save(ctx, blueprint)
+}
+
+// DeleteBlueprint implements BlueprintService
+func (s *blueprintService) DeleteBlueprint(ctx context.Context, examPaperID uint) error {
+	return s.blueprintRepo.Delete(ctx, examPaperID)
+}
+
+// validateBlueprint 校验蓝图约束的一致性
+func validateBlueprint(blueprint *models.ExamBlueprint) error {
+	switch irt.BalancingMethod(blueprint.Method) {
+	case irt.BalancingMPI, irt.BalancingWDM:
+	default:
+		return irt.ErrUnknownBalancingMethod
+	}
+
+	var pointProportion, typeProportion float64
+	for _, c := range blueprint.Constraints {
+		if (c.KnowledgePointID == 0) == (c.QuestionType == "") {
+			return ErrInvalidConstraint
+		}
+		if c.MaxCount > 0 && c.MinCount > c.MaxCount {
+			return ErrInvalidBounds
+		}
+		if c.KnowledgePointID != 0 {
+			pointProportion += c.TargetProportion
+		} else {
+			typeProportion += c.TargetProportion
+		}
+	}
+	if pointProportion > 1 || typeProportion > 1 {
+		return ErrProportionSum
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+// CalibrationService 题目参数标定服务接口
+type CalibrationService interface {
+	// CalibrateSubject 使用科目内全部作答记录标定题目参数，dryRun为true时不写回数据库；
+	// family 为空时使用科目配置的模型族，量尺因子始终取科目配置
+	CalibrateSubject(ctx context.Context, subjectID uint, family irt.ModelFamily, dryRun bool) (*irt.CalibrationResult, error)
+}
+
+// NewCalibrationService creates a new calibration service instance
+func NewCalibrationService(abilityRepo repositories.AbilityRepository, subjectRepo repositories.SubjectRepository) CalibrationService {
+	return &calibrationService{
+		abilityRepo: abilityRepo,
+		subjectRepo: subjectRepo,
+	}
+}
+
+type calibrationService struct {
+	abilityRepo repositories.AbilityRepository
+	subjectRepo repositories.SubjectRepository
+}
+
+// CalibrateSubject implements CalibrationService
+func (s *calibrationService) CalibrateSubject(ctx context.Context, subjectID uint, family irt.ModelFamily, dryRun bool) (*irt.CalibrationResult, error) {
+	model, err := subjectModel(ctx, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if family != "" {
+		model.Family = family
+	}
+
+	responses, err := s.abilityRepo.ListSubjectResponses(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := irt.NewCalibrator(model).Calibrate(buildResponseMatrix(responses))
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := s.saveCalibration(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// saveCalibration 将标定结果写回题目参数表，作答人数不足的题目保持原值
+func (s *calibrationService) saveCalibration(ctx context.Context, result *irt.CalibrationResult) error {
+	questionIDs := make([]uint, 0, len(result.Items))
+	for _, item := range result.Items {
+		questionIDs = append(questionIDs, item.QuestionID)
+	}
+
+	existing, err := s.abilityRepo.ListQuestionParameters(ctx, questionIDs)
+	if err != nil {
+		return err
+	}
+	paramsByQuestion := make(map[uint]*models.QuestionParameter, len(existing))
+	for _, p := range existing {
+		paramsByQuestion[p.QuestionID] = p
+	}
+
+	now := time.Now()
+	params := make([]*models.QuestionParameter, 0, len(result.Items))
+	for _, item := range result.Items {
+		if item.Skipped {
+			continue
+		}
+		p, ok := paramsByQuestion[item.QuestionID]
+		if !ok {
+			p = &models.QuestionParameter{QuestionID: item.QuestionID}
+		}
+		// 多级计分题不参与二级计分标定，保留已设置的类别阈值
+		if irt.ItemModel(p.ItemModel).Polytomous() {
+			continue
+		}
+		p.Difficulty = item.Difficulty
+		p.Discrimination = item.Discrimination
+		p.Guessing = item.Guessing
+		p.UpperAsymptote = item.UpperAsymptote
+		p.DifficultySE = item.DifficultySE
+		p.DiscriminationSE = item.DiscriminationSE
+		p.GuessingSE = item.GuessingSE
+		p.UpperAsymptoteSE = item.UpperAsymptoteSE
+		p.CalibrationModel = string(result.Model)
+		p.CalibratedAt = &now
+		params = append(params, p)
+	}
+
+	return s.abilityRepo.BatchUpdateParameters(ctx, params)
+}
+
+// buildResponseMatrix 以考试记录为行、题目为列构造作答矩阵，试测题由在线标定单独处理
+func buildResponseMatrix(responses []*models.ExamResponse) *irt.ResponseMatrix {
+	matrix := &irt.ResponseMatrix{}
+	columns := make(map[uint]int)
+	rows := make(map[uint]int)
+
+	for _, response := range responses {
+		if response.Question.Pretest {
+			continue
+		}
+		if _, ok := columns[response.QuestionID]; !ok {
+			columns[response.QuestionID] = len(matrix.QuestionIDs)
+			matrix.QuestionIDs = append(matrix.QuestionIDs, response.QuestionID)
+		}
+		if _, ok := rows[response.ExamRecordID]; !ok {
+			rows[response.ExamRecordID] = len(rows)
+			matrix.RowIDs = append(matrix.RowIDs, response.ExamRecordID)
+		}
+	}
+
+	matrix.Responses = make([][]int, len(rows))
+	for i := range matrix.Responses {
+		row := make([]int, len(matrix.QuestionIDs))
+		for j := range row {
+			row[j] = irt.Missing
+		}
+		matrix.Responses[i] = row
+	}
+
+	// 同一场考试重复作答时以最后一次为准
+	for _, response := range responses {
+		if response.Question.Pretest {
+			continue
+		}
+		value := 0
+		if response.IsCorrect {
+			value = 1
+		}
+		matrix.Responses[rows[response.ExamRecordID]][columns[response.QuestionID]] = value
+	}
+	return matrix
+}
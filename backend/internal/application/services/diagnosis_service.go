@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+// DiagnosisService 认知诊断服务接口
+type DiagnosisService interface {
+	// Diagnose 在科目的知识点 Q 矩阵上拟合 DINA/DINO 模型，
+	// 返回题目失误、猜测参数和每场考试的知识点掌握概率；pointIDs 为空时使用科目全部知识点
+	Diagnose(ctx context.Context, subjectID uint, model analysis.DiagnosisModel, pointIDs []uint) (*DiagnosisReport, error)
+	// KnowledgeProgress 合并考生在科目内的全部作答，给出各知识点的掌握进度
+	KnowledgeProgress(ctx context.Context, userID, subjectID uint, model analysis.DiagnosisModel, pointIDs []uint) ([]*KnowledgePointProgress, error)
+}
+
+// DiagnosisReport 科目认知诊断报告
+type DiagnosisReport struct {
+	SubjectID       uint                       `json:"subject_id"`
+	Model           analysis.DiagnosisModel    `json:"model"`
+	KnowledgePoints []*DiagnosisKnowledgePoint `json:"knowledge_points"`
+	Items           []*DiagnosisItemReport     `json:"items"`
+	Examinees       []*DiagnosisExamineeReport `json:"examinees"`
+	LogLikelihood   float64                    `json:"log_likelihood"`
+	Iterations      int                        `json:"iterations"`
+	Converged       bool                       `json:"converged"`
+}
+
+// DiagnosisKnowledgePoint 参与诊断的知识点及其总体掌握比例
+type DiagnosisKnowledgePoint struct {
+	KnowledgePointID uint    `json:"knowledge_point_id"`
+	Name             string  `json:"name"`
+	Prevalence       float64 `json:"prevalence"`
+}
+
+// DiagnosisItemReport 单题失误与猜测参数
+type DiagnosisItemReport struct {
+	QuestionID        uint    `json:"question_id"`
+	KnowledgePointIDs []uint  `json:"knowledge_point_ids"`
+	Slip              float64 `json:"slip"`
+	Guess             float64 `json:"guess"`
+	SlipSE            float64 `json:"slip_se"`
+	GuessSE           float64 `json:"guess_se"`
+}
+
+// DiagnosisExamineeReport 单场考试的知识点掌握情况，顺序同 KnowledgePoints
+type DiagnosisExamineeReport struct {
+	ExamRecordID uint      `json:"exam_record_id"`
+	UserID       uint      `json:"user_id"`
+	Mastery      []float64 `json:"mastery"`
+	Mastered     []bool    `json:"mastered"`
+	Profile      []bool    `json:"profile"`
+}
+
+// NewDiagnosisService creates a new cognitive diagnosis service instance
+func NewDiagnosisService(abilityRepo repositories.AbilityRepository, questionRepo repositories.QuestionRepository, knowledgeRepo repositories.KnowledgePointRepository) DiagnosisService {
+	return &diagnosisService{
+		abilityRepo:   abilityRepo,
+		questionRepo:  questionRepo,
+		knowledgeRepo: knowledgeRepo,
+	}
+}
+
+type diagnosisService struct {
+	abilityRepo   repositories.AbilityRepository
+	questionRepo  repositories.QuestionRepository
+	knowledgeRepo repositories.KnowledgePointRepository
+}
+
+// diagnosisData 拟合结果及其对应的作答数据
+type diagnosisData struct {
+	result    *analysis.DiagnosisResult
+	matrix    *irt.ResponseMatrix
+	points    []*models.KnowledgePoint
+	responses []*models.ExamResponse
+}
+
+// Diagnose implements DiagnosisService
+func (s *diagnosisService) Diagnose(ctx context.Context, subjectID uint, model analysis.DiagnosisModel, pointIDs []uint) (*DiagnosisReport, error) {
+	data, err := s.fit(ctx, subjectID, model, pointIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := data.result
+
+	report := &DiagnosisReport{
+		SubjectID:       subjectID,
+		Model:           model,
+		KnowledgePoints: make([]*DiagnosisKnowledgePoint, len(data.points)),
+		Items:           make([]*DiagnosisItemReport, 0, len(result.Items)),
+		Examinees:       make([]*DiagnosisExamineeReport, len(result.Examinees)),
+		LogLikelihood:   result.LogLikelihood,
+		Iterations:      result.Iterations,
+		Converged:       result.Converged,
+	}
+	for k, point := range data.points {
+		report.KnowledgePoints[k] = &DiagnosisKnowledgePoint{
+			KnowledgePointID: point.ID,
+			Name:             point.Name,
+			Prevalence:       result.Prevalence[k],
+		}
+	}
+	for _, item := range result.Items {
+		if item.Skipped {
+			continue
+		}
+		report.Items = append(report.Items, &DiagnosisItemReport{
+			QuestionID:        item.QuestionID,
+			KnowledgePointIDs: item.AttributeIDs,
+			Slip:              item.Slip,
+			Guess:             item.Guess,
+			SlipSE:            item.SlipSE,
+			GuessSE:           item.GuessSE,
+		})
+	}
+
+	users := make(map[uint]uint, len(data.matrix.RowIDs))
+	for _, response := range data.responses {
+		users[response.ExamRecordID] = response.ExamRecord.UserID
+	}
+	for i, examinee := range result.Examinees {
+		recordID := data.matrix.RowIDs[examinee.Row]
+		report.Examinees[i] = &DiagnosisExamineeReport{
+			ExamRecordID: recordID,
+			UserID:       users[recordID],
+			Mastery:      examinee.Mastery,
+			Mastered:     examinee.Mastered,
+			Profile:      examinee.Profile,
+		}
+	}
+	return report, nil
+}
+
+// KnowledgeProgress implements DiagnosisService
+func (s *diagnosisService) KnowledgeProgress(ctx context.Context, userID, subjectID uint, model analysis.DiagnosisModel, pointIDs []uint) ([]*KnowledgePointProgress, error) {
+	data, err := s.fit(ctx, subjectID, model, pointIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[uint]int, len(data.matrix.QuestionIDs))
+	for j, id := range data.matrix.QuestionIDs {
+		columns[id] = j
+	}
+	// 多场考试重复作答同一题时以最后一次为准
+	row := make([]int, len(data.matrix.QuestionIDs))
+	for j := range row {
+		row[j] = irt.Missing
+	}
+	timeSpent := make([]int64, len(row))
+	for _, response := range data.responses {
+		if response.ExamRecord.UserID != userID || response.Question.Pretest {
+			continue
+		}
+		j := columns[response.QuestionID]
+		row[j] = 0
+		if response.IsCorrect {
+			row[j] = 1
+		}
+		timeSpent[j] = response.ResponseTime
+	}
+
+	examinee, err := data.result.Diagnose(row)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]*KnowledgePointProgress, len(data.points))
+	for k, point := range data.points {
+		p := &KnowledgePointProgress{
+			KnowledgePointID:  point.ID,
+			Name:              point.Name,
+			MasteryLevel:      examinee.Mastery[k],
+			RecommendedReview: !examinee.Mastered[k],
+		}
+		var totalTime int64
+		for j, item := range data.result.Items {
+			if row[j] == irt.Missing || !containsUint(item.AttributeIDs, point.ID) {
+				continue
+			}
+			p.QuestionCount++
+			p.CorrectCount += row[j]
+			totalTime += timeSpent[j]
+		}
+		if p.QuestionCount > 0 {
+			p.AverageTimeSpent = float64(totalTime) / float64(p.QuestionCount)
+		}
+		progress[k] = p
+	}
+	return progress, nil
+}
+
+// fit 读取科目作答与 Q 矩阵并拟合认知诊断模型
+func (s *diagnosisService) fit(ctx context.Context, subjectID uint, model analysis.DiagnosisModel, pointIDs []uint) (*diagnosisData, error) {
+	responses, err := s.abilityRepo.ListSubjectResponses(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	matrix := buildResponseMatrix(responses)
+
+	points, err := s.knowledgeRepo.ListBySubject(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.questionRepo.ListKnowledgePointLinks(ctx, matrix.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 只保留有题目关联的知识点作为属性，按ID排序使结果稳定
+	linked := make(map[uint]bool, len(links))
+	for _, link := range links {
+		linked[link.KnowledgePointID] = true
+	}
+	attributes := make([]*models.KnowledgePoint, 0, len(points))
+	for _, point := range points {
+		if linked[point.ID] && (len(pointIDs) == 0 || containsUint(pointIDs, point.ID)) {
+			attributes = append(attributes, point)
+		}
+	}
+	sort.Slice(attributes, func(a, b int) bool { return attributes[a].ID < attributes[b].ID })
+
+	q := analysis.QMatrix{
+		AttributeIDs: make([]uint, len(attributes)),
+		Required:     make([][]bool, len(matrix.QuestionIDs)),
+	}
+	index := make(map[uint]int, len(attributes))
+	for k, point := range attributes {
+		q.AttributeIDs[k] = point.ID
+		index[point.ID] = k
+	}
+	columns := make(map[uint]int, len(matrix.QuestionIDs))
+	for j, id := range matrix.QuestionIDs {
+		columns[id] = j
+		q.Required[j] = make([]bool, len(attributes))
+	}
+	for _, link := range links {
+		if k, ok := index[link.KnowledgePointID]; ok {
+			q.Required[columns[link.QuestionID]][k] = true
+		}
+	}
+
+	cfg := analysis.DefaultDiagnosisConfig()
+	cfg.Model = model
+	result, err := analysis.FitDiagnosis(matrix, q, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &diagnosisData{result: result, matrix: matrix, points: attributes, responses: responses}, nil
+}
+
+func containsUint(ids []uint, id uint) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
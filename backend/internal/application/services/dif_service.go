@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+// DIF 分组属性
+const (
+	GroupAttributeClass  = "class"
+	GroupAttributeRegion = "region"
+	GroupAttributeGender = "gender"
+)
+
+var ErrUnknownGroupAttribute = errors.New("unknown group attribute")
+
+// DIFService 题目功能差异分析服务接口
+type DIFService interface {
+	// Analyze 对科目内每道题做参照组与焦点组的 DIF 分析并保存结果
+	Analyze(ctx context.Context, subjectID uint, attribute, reference, focal string) ([]*models.DIFResult, error)
+	// GetReport 返回已保存的 DIF 结果，标记 C 级题目
+	GetReport(ctx context.Context, subjectID uint, attribute string) (*DIFReport, error)
+}
+
+// DIFReport 科目 DIF 报告
+type DIFReport struct {
+	SubjectID      uint             `json:"subject_id"`
+	GroupAttribute string           `json:"group_attribute,omitempty"`
+	Total          int              `json:"total"`
+	FlaggedCount   int              `json:"flagged_count"`
+	Items          []*DIFItemReport `json:"items"`
+}
+
+// DIFItemReport 单题 DIF 结果
+type DIFItemReport struct {
+	QuestionID     uint      `json:"question_id"`
+	GroupAttribute string    `json:"group_attribute"`
+	ReferenceGroup string    `json:"reference_group"`
+	FocalGroup     string    `json:"focal_group"`
+	ReferenceCount int       `json:"reference_count"`
+	FocalCount     int       `json:"focal_count"`
+	MHDelta        float64   `json:"mh_delta"`
+	MHPValue       float64   `json:"mh_p_value"`
+	ETSClass       string    `json:"ets_class"`
+	LRDeltaR2      float64   `json:"lr_delta_r2"`
+	LRPValue       float64   `json:"lr_p_value"`
+	LRClass        string    `json:"lr_class"`
+	Favors         string    `json:"favors,omitempty"` // 题目有利的组别：reference 或 focal
+	Flagged        bool      `json:"flagged"`
+	Skipped        bool      `json:"skipped"`
+	AnalyzedAt     time.Time `json:"analyzed_at"`
+}
+
+// NewDIFService creates a new DIF service instance
+func NewDIFService(abilityRepo repositories.AbilityRepository, difRepo repositories.DIFRepository, subjectRepo repositories.SubjectRepository) DIFService {
+	return &difService{
+		abilityRepo: abilityRepo,
+		difRepo:     difRepo,
+		subjectRepo: subjectRepo,
+	}
+}
+
+type difService struct {
+	abilityRepo repositories.AbilityRepository
+	difRepo     repositories.DIFRepository
+	subjectRepo repositories.SubjectRepository
+}
+
+// Analyze implements DIFService
+func (s *difService) Analyze(ctx context.Context, subjectID uint, attribute, reference, focal string) ([]*models.DIFResult, error) {
+	if _, err := groupValue(&models.User{}, attribute); err != nil {
+		return nil, err
+	}
+
+	data, err := loadFitData(ctx, s.abilityRepo, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.groupLabels(ctx, data, attribute, reference, focal)
+	if err != nil {
+		return nil, err
+	}
+
+	difs, err := analysis.DIF(data.matrix, groups, data.thetas, analysis.DefaultDIFConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	results := make([]*models.DIFResult, len(difs))
+	for i, d := range difs {
+		results[i] = &models.DIFResult{
+			SubjectID:      subjectID,
+			QuestionID:     d.QuestionID,
+			GroupAttribute: attribute,
+			ReferenceGroup: reference,
+			FocalGroup:     focal,
+			ReferenceCount: d.ReferenceCount,
+			FocalCount:     d.FocalCount,
+			Skipped:        d.Skipped,
+			MHOddsRatio:    d.MHOddsRatio,
+			MHDelta:        d.MHDelta,
+			MHDeltaSE:      d.MHDeltaSE,
+			MHChiSquare:    d.MHChiSquare,
+			MHPValue:       d.MHPValue,
+			ETSClass:       string(d.ETSClass),
+			LRUniform:      d.LRUniform,
+			LRNonUniform:   d.LRNonUniform,
+			LRPValue:       d.LRPValue,
+			LRDeltaR2:      d.LRDeltaR2,
+			LRClass:        string(d.LRClass),
+			AnalyzedAt:     now,
+		}
+		if d.Skipped {
+			results[i].MHPValue = 1
+			results[i].LRPValue = 1
+		}
+	}
+
+	if err := s.difRepo.ReplaceResults(ctx, subjectID, attribute, reference, focal, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetReport implements DIFService
+func (s *difService) GetReport(ctx context.Context, subjectID uint, attribute string) (*DIFReport, error) {
+	results, err := s.difRepo.ListResults(ctx, subjectID, attribute)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DIFReport{SubjectID: subjectID, GroupAttribute: attribute, Total: len(results)}
+	for _, r := range results {
+		item := &DIFItemReport{
+			QuestionID:     r.QuestionID,
+			GroupAttribute: r.GroupAttribute,
+			ReferenceGroup: r.ReferenceGroup,
+			FocalGroup:     r.FocalGroup,
+			ReferenceCount: r.ReferenceCount,
+			FocalCount:     r.FocalCount,
+			MHDelta:        r.MHDelta,
+			MHPValue:       r.MHPValue,
+			ETSClass:       r.ETSClass,
+			LRDeltaR2:      r.LRDeltaR2,
+			LRPValue:       r.LRPValue,
+			LRClass:        r.LRClass,
+			Flagged:        r.ETSClass == string(analysis.ClassC) || r.LRClass == string(analysis.ClassC),
+			Skipped:        r.Skipped,
+			AnalyzedAt:     r.AnalyzedAt,
+		}
+		// MH D-DIF 为负表示焦点组在同等能力下更难答对
+		if item.ETSClass != string(analysis.ClassA) {
+			if r.MHDelta < 0 {
+				item.Favors = "reference"
+			} else {
+				item.Favors = "focal"
+			}
+		}
+		if item.Flagged {
+			report.FlaggedCount++
+		}
+		report.Items = append(report.Items, item)
+	}
+	return report, nil
+}
+
+// groupLabels 按考生的分组属性为作答矩阵每一行标记参照组或焦点组
+func (s *difService) groupLabels(ctx context.Context, data *fitData, attribute, reference, focal string) ([]int, error) {
+	userByRecord := make(map[uint]uint, len(data.matrix.RowIDs))
+	for _, response := range data.responses {
+		userByRecord[response.ExamRecordID] = response.ExamRecord.UserID
+	}
+	userIDs := make([]uint, 0, len(userByRecord))
+	seen := make(map[uint]bool, len(userByRecord))
+	for _, id := range userByRecord {
+		if !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	users, err := s.difRepo.ListUsers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[uint]string, len(users))
+	for _, u := range users {
+		value, err := groupValue(u, attribute)
+		if err != nil {
+			return nil, err
+		}
+		values[u.ID] = value
+	}
+
+	groups := make([]int, len(data.matrix.RowIDs))
+	for i, recordID := range data.matrix.RowIDs {
+		switch values[userByRecord[recordID]] {
+		case reference:
+			groups[i] = analysis.GroupReference
+		case focal:
+			groups[i] = analysis.GroupFocal
+		default:
+			groups[i] = analysis.GroupNone
+		}
+	}
+	return groups, nil
+}
+
+// groupValue 返回用户在指定分组属性上的取值
+func groupValue(user *models.User, attribute string) (string, error) {
+	switch attribute {
+	case GroupAttributeClass:
+		return user.ClassName, nil
+	case GroupAttributeRegion:
+		return user.Region, nil
+	case GroupAttributeGender:
+		return user.Gender, nil
+	default:
+		return "", ErrUnknownGroupAttribute
+	}
+}
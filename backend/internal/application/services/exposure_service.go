@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/domain/repositories"
+)
+
+// ExposureService 题目曝光控制服务接口
+type ExposureService interface {
+	GetSetting(ctx context.Context, subjectID uint) (*models.ExposureSetting, error)
+	UpdateSetting(ctx context.Context, setting *models.ExposureSetting) error
+	ComputeSympsonHetter(ctx context.Context, subjectID uint) (*irt.SympsonHetterResult, error)
+	GetExposureReport(ctx context.Context, subjectID uint) (*ExposureReport, error)
+}
+
+// ExposureReport 科目题目曝光率报告
+type ExposureReport struct {
+	SubjectID       uint                  `json:"subject_id"`
+	TotalSessions   int64                 `json:"total_sessions"`
+	MaxExposureRate float64               `json:"max_exposure_rate"`
+	Items           []*ItemExposureReport `json:"items"`
+}
+
+// ItemExposureReport 单题曝光率
+type ItemExposureReport struct {
+	QuestionID        uint    `json:"question_id"`
+	AdministeredCount int64   `json:"administered_count"`
+	ExposureRate      float64 `json:"exposure_rate"`
+	ExposureParameter float64 `json:"exposure_parameter"`
+	Overexposed       bool    `json:"overexposed"`
+}
+
+// NewExposureService creates a new exposure service instance
+func NewExposureService(exposureRepo repositories.ExposureRepository, questionRepo repositories.QuestionRepository, subjectRepo repositories.SubjectRepository) ExposureService {
+	return &exposureService{
+		exposureRepo: exposureRepo,
+		questionRepo: questionRepo,
+		subjectRepo:  subjectRepo,
+	}
+}
+
+type exposureService struct {
+	exposureRepo repositories.ExposureRepository
+	questionRepo repositories.QuestionRepository
+	subjectRepo  repositories.SubjectRepository
+}
+
+// GetSetting implements ExposureService
+func (s *exposureService) GetSetting(ctx context.Context, subjectID uint) (*models.ExposureSetting, error) {
+	setting, err := s.exposureRepo.FindSetting(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		setting = defaultExposureSetting(subjectID)
+	}
+	return setting, nil
+}
+
+// UpdateSetting implements ExposureService
+func (s *exposureService) UpdateSetting(ctx context.Context, setting *models.ExposureSetting) error {
+	existing, err := s.exposureRepo.FindSetting(ctx, setting.SubjectID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		setting.ID = existing.ID
+		setting.CreatedAt = existing.CreatedAt
+	}
+	return s.exposureRepo.SaveSetting(ctx, setting)
+}
+
+// ComputeSympsonHetter implements ExposureService
+// 以科目题库模拟自适应测验，求出各题曝光参数并保存
+func (s *exposureService) ComputeSympsonHetter(ctx context.Context, subjectID uint) (*irt.SympsonHetterResult, error) {
+	setting, err := s.GetSetting(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := s.questionRepo.ListCandidates(ctx, subjectID, nil)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+	}
+	params, err := itemParameters(ctx, s.questionRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+	// 与在线选题一致，未标定的题目不参与模拟
+	bank := make([]irt.Candidate, 0, len(questions))
+	for _, q := range questions {
+		p, ok := params[q.ID]
+		if !ok {
+			continue
+		}
+		bank = append(bank, irt.Candidate{
+			QuestionID:     q.ID,
+			Difficulty:     p.Difficulty,
+			Discrimination: p.Discrimination,
+			Guessing:       p.Guessing,
+			UpperAsymptote: p.UpperAsymptote,
+		})
+	}
+
+	cfg := irt.DefaultSympsonHetterConfig()
+	cfg.MaxExposureRate = setting.MaxExposureRate
+	cfg.Model, err = subjectModel(ctx, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := irt.ComputeSympsonHetter(bank, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.exposureRepo.SaveExposureParameters(ctx, subjectID, result.Parameters); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetExposureReport implements ExposureService
+func (s *exposureService) GetExposureReport(ctx context.Context, subjectID uint) (*ExposureReport, error) {
+	setting, err := s.GetSetting(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.exposureRepo.CountSubjectSessions(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := s.questionRepo.ListCandidates(ctx, subjectID, nil)
+	if err != nil {
+		return nil, err
+	}
+	exposures, err := s.exposureRepo.ListSubjectExposures(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	exposureByQuestion := make(map[uint]*models.ItemExposure, len(exposures))
+	for _, e := range exposures {
+		exposureByQuestion[e.QuestionID] = e
+	}
+
+	report := &ExposureReport{
+		SubjectID:       subjectID,
+		TotalSessions:   total,
+		MaxExposureRate: setting.MaxExposureRate,
+	}
+	for _, q := range questions {
+		item := &ItemExposureReport{QuestionID: q.ID, ExposureParameter: 1}
+		if e, ok := exposureByQuestion[q.ID]; ok {
+			item.AdministeredCount = e.AdministeredCount
+			item.ExposureParameter = e.ExposureParameter
+		}
+		if total > 0 {
+			item.ExposureRate = float64(item.AdministeredCount) / float64(total)
+		}
+		item.Overexposed = item.ExposureRate > setting.MaxExposureRate
+		report.Items = append(report.Items, item)
+	}
+
+	sort.Slice(report.Items, func(i, j int) bool {
+		return report.Items[i].ExposureRate > report.Items[j].ExposureRate
+	})
+	return report, nil
+}
+
+func defaultExposureSetting(subjectID uint) *models.ExposureSetting {
+	return &models.ExposureSetting{
+		SubjectID:       subjectID,
+		Method:          string(irt.ExposureNone),
+		RandomesqueSize: 5,
+		MaxExposureRate: 0.25,
+	}
+}
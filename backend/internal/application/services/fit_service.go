@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+// FitService 模型拟合检验服务接口
+type FitService interface {
+	// ItemFit 计算科目内每道题的拟合统计量
+	ItemFit(ctx context.Context, subjectID uint) ([]*analysis.ItemFit, error)
+	// PersonFit 计算科目内每场考试的考生拟合统计量
+	PersonFit(ctx context.Context, subjectID uint) ([]*PersonFitReport, error)
+}
+
+// PersonFitReport 单场考试的考生拟合结果
+type PersonFitReport struct {
+	ExamRecordID uint    `json:"exam_record_id"`
+	UserID       uint    `json:"user_id"`
+	Theta        float64 `json:"theta"`
+	Items        int     `json:"items"`
+	LzStar       float64 `json:"lz_star"`
+	Misfit       bool    `json:"misfit"`
+}
+
+// NewFitService creates a new fit service instance
+func NewFitService(abilityRepo repositories.AbilityRepository, subjectRepo repositories.SubjectRepository) FitService {
+	return &fitService{
+		abilityRepo: abilityRepo,
+		subjectRepo: subjectRepo,
+	}
+}
+
+type fitService struct {
+	abilityRepo repositories.AbilityRepository
+	subjectRepo repositories.SubjectRepository
+}
+
+// fitData 拟合分析所需的作答矩阵、题目参数与能力估计
+type fitData struct {
+	model     irt.Model
+	matrix    *irt.ResponseMatrix
+	items     []analysis.Item
+	thetas    []float64
+	responses []*models.ExamResponse
+}
+
+// ItemFit implements FitService
+func (s *fitService) ItemFit(ctx context.Context, subjectID uint) ([]*analysis.ItemFit, error) {
+	data, err := loadFitData(ctx, s.abilityRepo, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	cfg := analysis.DefaultFitConfig()
+	cfg.Model = data.model
+	return analysis.ItemFitStatistics(data.matrix, data.items, data.thetas, cfg)
+}
+
+// PersonFit implements FitService
+func (s *fitService) PersonFit(ctx context.Context, subjectID uint) ([]*PersonFitReport, error) {
+	data, err := loadFitData(ctx, s.abilityRepo, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	fits, err := analysis.PersonFitStatistics(data.matrix, data.items, data.thetas, data.model)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[uint]uint, len(data.matrix.RowIDs))
+	for _, response := range data.responses {
+		users[response.ExamRecordID] = response.ExamRecord.UserID
+	}
+
+	reports := make([]*PersonFitReport, 0, len(fits))
+	for _, fit := range fits {
+		recordID := data.matrix.RowIDs[fit.Row]
+		reports = append(reports, &PersonFitReport{
+			ExamRecordID: recordID,
+			UserID:       users[recordID],
+			Theta:        fit.Theta,
+			Items:        fit.Items,
+			LzStar:       fit.LzStar,
+			Misfit:       fit.Misfit,
+		})
+	}
+	return reports, nil
+}
+
+// loadFitData 读取科目作答数据并估计每场考试的能力值
+func loadFitData(ctx context.Context, abilityRepo repositories.AbilityRepository, subjectRepo repositories.SubjectRepository, subjectID uint) (*fitData, error) {
+	model, err := subjectModel(ctx, subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := abilityRepo.ListSubjectResponses(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	matrix := buildResponseMatrix(responses)
+
+	items, err := resolveItems(ctx, abilityRepo, matrix.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	thetas, err := analysis.EstimateAbilities(matrix, items, model)
+	if err != nil {
+		return nil, err
+	}
+	return &fitData{model: model, matrix: matrix, items: items, thetas: thetas, responses: responses}, nil
+}
+
+// resolveItems 按题目顺序取出已标定参数，存在未标定的题目时返回 ErrUncalibratedItem
+func resolveItems(ctx context.Context, abilityRepo repositories.AbilityRepository, questionIDs []uint) ([]analysis.Item, error) {
+	params, err := abilityRepo.ListQuestionParameters(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	paramsByQuestion := make(map[uint]*models.QuestionParameter, len(params))
+	for _, p := range params {
+		paramsByQuestion[p.QuestionID] = p
+	}
+	items := make([]analysis.Item, len(questionIDs))
+	for j, id := range questionIDs {
+		p, ok := paramsByQuestion[id]
+		if !ok || p.Discrimination <= 0 {
+			return nil, fmt.Errorf("%w: question %d", ErrUncalibratedItem, id)
+		}
+		items[j] = analysis.Item{
+			QuestionID:     id,
+			Difficulty:     p.Difficulty,
+			Discrimination: p.Discrimination,
+			Guessing:       p.Guessing,
+			UpperAsymptote: p.UpperAsymptote,
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+// ForensicsService 作答异常与作弊检测服务接口
+type ForensicsService interface {
+	// Report 检测试卷全部考试记录的异常作答，给出可疑考生对、异常考生与异常快速答对的作答，供监考人员复核
+	Report(ctx context.Context, paperID uint) (*ForensicsReport, error)
+}
+
+// ForensicsReport 试卷作答异常与作弊检测报告
+type ForensicsReport struct {
+	ExamPaperID     uint                     `json:"exam_paper_id"`
+	Examinees       int                      `json:"examinees"`
+	PairsTested     int                      `json:"pairs_tested"`
+	CriticalPValue  float64                  `json:"critical_p_value"` // 按考生对数校正后的显著性水平
+	SuspiciousPairs []*SuspiciousPairReport  `json:"suspicious_pairs"`
+	Persons         []*PersonForensicsReport `json:"persons"` // 只包含被标记的考生
+	FastCorrect     []*FastCorrectReport     `json:"fast_correct"`
+}
+
+// SuspiciousPairReport 答案相似性显著的一对考试记录
+type SuspiciousPairReport struct {
+	ExamRecordA        uint    `json:"exam_record_a"`
+	UserA              uint    `json:"user_a"`
+	ExamRecordB        uint    `json:"exam_record_b"`
+	UserB              uint    `json:"user_b"`
+	CommonItems        int     `json:"common_items"`
+	Matches            int     `json:"matches"`
+	IdenticalIncorrect int     `json:"identical_incorrect"`
+	Expected           float64 `json:"expected"`
+	GBTPValue          float64 `json:"gbt_p_value"`
+	Omega              float64 `json:"omega"`
+	OmegaPValue        float64 `json:"omega_p_value"`
+}
+
+// PersonForensicsReport 单场考试的异常作答指标
+type PersonForensicsReport struct {
+	ExamRecordID     uint    `json:"exam_record_id"`
+	UserID           uint    `json:"user_id"`
+	Theta            float64 `json:"theta"`
+	LzStar           float64 `json:"lz_star"`
+	Misfit           bool    `json:"misfit"`
+	Speed            float64 `json:"speed"`
+	FastCorrect      int     `json:"fast_correct"`
+	FastPValue       float64 `json:"fast_p_value"`
+	FastFlagged      bool    `json:"fast_flagged"`
+	Changes          int     `json:"changes"`
+	WrongToRight     int     `json:"wrong_to_right"`
+	RightToWrong     int     `json:"right_to_wrong"`
+	WrongToWrong     int     `json:"wrong_to_wrong"`
+	WrongToRightZ    float64 `json:"wrong_to_right_z"`
+	ExcessiveChanges bool    `json:"excessive_changes"`
+	SuspiciousPairs  int     `json:"suspicious_pairs"`
+}
+
+// FastCorrectReport 一次异常快速的答对作答
+type FastCorrectReport struct {
+	ExamRecordID uint    `json:"exam_record_id"`
+	UserID       uint    `json:"user_id"`
+	QuestionID   uint    `json:"question_id"`
+	Seconds      float64 `json:"seconds"`
+	Residual     float64 `json:"residual"`
+	Probability  float64 `json:"probability"`
+}
+
+// NewForensicsService creates a new forensics service instance
+func NewForensicsService(forensicsRepo repositories.ForensicsRepository, abilityRepo repositories.AbilityRepository, responseTimeRepo repositories.ResponseTimeRepository, subjectRepo repositories.SubjectRepository) ForensicsService {
+	return &forensicsService{
+		forensicsRepo:    forensicsRepo,
+		abilityRepo:      abilityRepo,
+		responseTimeRepo: responseTimeRepo,
+		subjectRepo:      subjectRepo,
+	}
+}
+
+type forensicsService struct {
+	forensicsRepo    repositories.ForensicsRepository
+	abilityRepo      repositories.AbilityRepository
+	responseTimeRepo repositories.ResponseTimeRepository
+	subjectRepo      repositories.SubjectRepository
+}
+
+// Report implements ForensicsService
+func (s *forensicsService) Report(ctx context.Context, paperID uint) (*ForensicsReport, error) {
+	paper, err := s.forensicsRepo.FindPaper(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+	if paper == nil {
+		return nil, ErrPaperNotFound
+	}
+	model, err := subjectModel(ctx, s.subjectRepo, paper.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := s.forensicsRepo.ListPaperSubmissions(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 试测题不参与检测
+	scored := make([]*models.ExamResponse, 0, len(submissions))
+	for _, submission := range submissions {
+		if !submission.Question.Pretest {
+			scored = append(scored, submission)
+		}
+	}
+	matrix := buildResponseMatrix(scored)
+	report := &ForensicsReport{
+		ExamPaperID:     paperID,
+		Examinees:       len(matrix.RowIDs),
+		SuspiciousPairs: make([]*SuspiciousPairReport, 0),
+		Persons:         make([]*PersonForensicsReport, 0),
+		FastCorrect:     make([]*FastCorrectReport, 0),
+	}
+	if len(matrix.RowIDs) == 0 {
+		return report, nil
+	}
+
+	fitItems, err := resolveItems(ctx, s.abilityRepo, matrix.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	timeParams, err := s.responseTimeRepo.ListParameters(ctx, matrix.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	times := make(map[uint]irt.TimeParams, len(timeParams))
+	for _, p := range timeParams {
+		times[p.QuestionID] = irt.TimeParams{Intensity: p.Intensity, Discrimination: p.Discrimination}
+	}
+
+	columns := make(map[uint]int, len(matrix.QuestionIDs))
+	for j, id := range matrix.QuestionIDs {
+		columns[id] = j
+	}
+	categories := optionCategories(matrix.QuestionIDs, scored)
+	items := make([]analysis.ForensicItem, len(matrix.QuestionIDs))
+	for j, id := range matrix.QuestionIDs {
+		items[j] = analysis.ForensicItem{
+			Item:    fitItems[j],
+			Key:     categories[j].key,
+			Options: len(categories[j].labels),
+			Time:    times[id],
+		}
+	}
+
+	// 每场考试按提交顺序重放：最后一次提交为最终作答，前后提交的答案不同记为一次改答
+	rows := make(map[uint]int, len(matrix.RowIDs))
+	users := make(map[uint]uint, len(matrix.RowIDs))
+	examinees := make([]analysis.ForensicExaminee, len(matrix.RowIDs))
+	for i, recordID := range matrix.RowIDs {
+		rows[recordID] = i
+		e := analysis.ForensicExaminee{
+			Options: make([]int, len(items)),
+			Seconds: make([]float64, len(items)),
+		}
+		for j := range e.Options {
+			e.Options[j] = irt.Missing
+		}
+		examinees[i] = e
+	}
+	for _, submission := range scored {
+		i := rows[submission.ExamRecordID]
+		j := columns[submission.QuestionID]
+		users[submission.ExamRecordID] = submission.ExamRecord.UserID
+		e := &examinees[i]
+		e.Seconds[j] += float64(submission.ResponseTime)
+
+		option := categories[j].index(submission.UserAnswer)
+		if option == irt.Missing {
+			continue
+		}
+		if previous := e.Options[j]; previous != irt.Missing && previous != option {
+			e.Changes = append(e.Changes, analysis.AnswerChange{Item: j, From: previous, To: option})
+		}
+		e.Options[j] = option
+	}
+
+	cfg := analysis.DefaultForensicsConfig()
+	cfg.Model = model
+	population, err := s.responseTimeRepo.FindPopulation(ctx, paper.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	if population != nil {
+		cfg.SpeedMean, cfg.SpeedSD = population.MeanSpeed, population.SDSpeed
+	}
+	result, err := analysis.Forensics(items, examinees, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	report.PairsTested = result.PairsTested
+	report.CriticalPValue = result.CriticalPValue
+	pairCounts := make(map[int]int)
+	for _, pair := range result.Pairs {
+		recordA, recordB := matrix.RowIDs[pair.RowA], matrix.RowIDs[pair.RowB]
+		pairCounts[pair.RowA]++
+		pairCounts[pair.RowB]++
+		report.SuspiciousPairs = append(report.SuspiciousPairs, &SuspiciousPairReport{
+			ExamRecordA:        recordA,
+			UserA:              users[recordA],
+			ExamRecordB:        recordB,
+			UserB:              users[recordB],
+			CommonItems:        pair.CommonItems,
+			Matches:            pair.Matches,
+			IdenticalIncorrect: pair.IdenticalIncorrect,
+			Expected:           pair.Expected,
+			GBTPValue:          pair.GBTPValue,
+			Omega:              pair.Omega,
+			OmegaPValue:        pair.OmegaPValue,
+		})
+	}
+	for _, person := range result.Persons {
+		if !person.Flagged {
+			continue
+		}
+		recordID := matrix.RowIDs[person.Row]
+		report.Persons = append(report.Persons, &PersonForensicsReport{
+			ExamRecordID:     recordID,
+			UserID:           users[recordID],
+			Theta:            person.Theta,
+			LzStar:           person.LzStar,
+			Misfit:           person.Misfit,
+			Speed:            person.Speed,
+			FastCorrect:      person.FastCorrect,
+			FastPValue:       person.FastPValue,
+			FastFlagged:      person.FastFlagged,
+			Changes:          person.Changes,
+			WrongToRight:     person.WrongToRight,
+			RightToWrong:     person.RightToWrong,
+			WrongToWrong:     person.WrongToWrong,
+			WrongToRightZ:    person.WrongToRightZ,
+			ExcessiveChanges: person.ExcessiveChanges,
+			SuspiciousPairs:  pairCounts[person.Row],
+		})
+	}
+	for _, flag := range result.FastCorrect {
+		recordID := matrix.RowIDs[flag.Row]
+		report.FastCorrect = append(report.FastCorrect, &FastCorrectReport{
+			ExamRecordID: recordID,
+			UserID:       users[recordID],
+			QuestionID:   matrix.QuestionIDs[flag.Item],
+			Seconds:      flag.Seconds,
+			Residual:     flag.Residual,
+			Probability:  flag.Probability,
+		})
+	}
+	return report, nil
+}
+
+// answerCategories 一道题的作答类别：题目选项标签在前，选项之外出现过的答案依次追加
+type answerCategories struct {
+	labels []string
+	key    int
+}
+
+// index 答案对应的类别，空答案视为未作答
+func (c *answerCategories) index(answer string) int {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return irt.Missing
+	}
+	for k, label := range c.labels {
+		if label == answer {
+			return k
+		}
+	}
+	return irt.Missing
+}
+
+// optionCategories 按题目顺序整理作答类别，标准答案不在选项标签中时（如填空题）作为第一个类别
+func optionCategories(questionIDs []uint, responses []*models.ExamResponse) []*answerCategories {
+	questions := make(map[uint]*models.Question, len(questionIDs))
+	observed := make(map[uint][]string, len(questionIDs))
+	for _, response := range responses {
+		questions[response.QuestionID] = &response.Question
+		if answer := strings.TrimSpace(response.UserAnswer); answer != "" {
+			observed[response.QuestionID] = append(observed[response.QuestionID], answer)
+		}
+	}
+
+	categories := make([]*answerCategories, len(questionIDs))
+	for j, id := range questionIDs {
+		question := questions[id]
+		options := append([]models.QuestionOption(nil), question.Options...)
+		sort.Slice(options, func(a, b int) bool { return options[a].Order < options[b].Order })
+
+		c := &answerCategories{labels: make([]string, 0, len(options)+1)}
+		add := func(label string) {
+			if label = strings.TrimSpace(label); label != "" && c.index(label) == irt.Missing {
+				c.labels = append(c.labels, label)
+			}
+		}
+		for _, option := range options {
+			add(option.Label)
+		}
+		c.key = c.index(question.Answer)
+		if c.key == irt.Missing {
+			c.labels = append([]string{strings.TrimSpace(question.Answer)}, c.labels...)
+			c.key = 0
+		}
+		for _, answer := range observed[id] {
+			add(answer)
+		}
+		categories[j] = c
+	}
+	return categories
+}
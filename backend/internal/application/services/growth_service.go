@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+var ErrNoEstimations = errors.New("user has no ability estimations in this subject")
+
+// growthBandZ 成长曲线置信带的正态分位数（95%）
+const growthBandZ = 1.96
+
+// GrowthService 能力成长追踪服务接口
+type GrowthService interface {
+	// TrackGrowth 对考生在科目内的历次能力估计做状态空间平滑，给出当前能力、成长速度、
+	// 最近一次考试 horizonDays 天后的能力预测，并标记显著下降
+	TrackGrowth(ctx context.Context, userID, subjectID uint, horizonDays int) (*GrowthReport, error)
+	// GrowthCurve 供图表使用的平滑成长曲线及其置信带，预测段按 stepDays 天一个点延伸 horizonDays 天
+	GrowthCurve(ctx context.Context, userID, subjectID uint, horizonDays, stepDays int) (*GrowthCurve, error)
+}
+
+// GrowthReport 考生在科目内的能力成长摘要，成长速度以每30天的能力变化计
+type GrowthReport struct {
+	UserID         uint                `json:"user_id"`
+	SubjectID      uint                `json:"subject_id"`
+	Observations   int                 `json:"observations"`
+	FirstExamAt    time.Time           `json:"first_exam_at"`
+	LastExamAt     time.Time           `json:"last_exam_at"`
+	CurrentAbility float64             `json:"current_ability"`
+	CurrentSE      float64             `json:"current_se"`
+	GrowthRate     float64             `json:"growth_rate"`
+	GrowthRateSE   float64             `json:"growth_rate_se"`
+	Declining      bool                `json:"declining"` // 成长速度显著为负
+	Forecast       *GrowthForecast     `json:"forecast"`
+	Declines       []*GrowthCurvePoint `json:"declines"` // 显著低于预期的考试
+}
+
+// GrowthCurve 平滑成长曲线
+type GrowthCurve struct {
+	UserID    uint                `json:"user_id"`
+	SubjectID uint                `json:"subject_id"`
+	Points    []*GrowthCurvePoint `json:"points"`
+	Forecast  []*GrowthForecast   `json:"forecast"`
+}
+
+// GrowthCurvePoint 成长曲线上对应一次考试的点，Lower、Upper 为平滑能力的95%置信带
+type GrowthCurvePoint struct {
+	ExamRecordID  uint      `json:"exam_record_id,omitempty"`
+	ExamSessionID uint      `json:"exam_session_id,omitempty"` // 自适应考试会话结束时的估计
+	Time          time.Time `json:"time"`
+	Observed      float64   `json:"observed"`
+	ObservedSE    float64   `json:"observed_se"`
+	Smoothed      float64   `json:"smoothed"`
+	SmoothedSE    float64   `json:"smoothed_se"`
+	Lower         float64   `json:"lower"`
+	Upper         float64   `json:"upper"`
+	GrowthRate    float64   `json:"growth_rate"`
+	Innovation    float64   `json:"innovation"` // 观测相对一步预测的标准化偏离
+	Decline       bool      `json:"decline"`
+}
+
+// GrowthForecast 未来某一时刻的能力预测及其95%置信带
+type GrowthForecast struct {
+	Time          time.Time `json:"time"`
+	Ability       float64   `json:"ability"`
+	StandardError float64   `json:"standard_error"`
+	Lower         float64   `json:"lower"`
+	Upper         float64   `json:"upper"`
+}
+
+// NewGrowthService creates a new growth tracking service instance
+func NewGrowthService(abilityRepo repositories.AbilityRepository) GrowthService {
+	return &growthService{
+		abilityRepo: abilityRepo,
+	}
+}
+
+type growthService struct {
+	abilityRepo repositories.AbilityRepository
+}
+
+// TrackGrowth implements GrowthService
+func (s *growthService) TrackGrowth(ctx context.Context, userID, subjectID uint, horizonDays int) (*GrowthReport, error) {
+	trajectory, estimations, err := s.trajectory(ctx, userID, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	points := growthCurvePoints(trajectory, estimations)
+	last := trajectory.Points[len(trajectory.Points)-1].Time
+	report := &GrowthReport{
+		UserID:         userID,
+		SubjectID:      subjectID,
+		Observations:   len(points),
+		FirstExamAt:    trajectory.Points[0].Time,
+		LastExamAt:     last,
+		CurrentAbility: trajectory.Current,
+		CurrentSE:      trajectory.CurrentSE,
+		GrowthRate:     trajectory.GrowthRate,
+		GrowthRateSE:   trajectory.GrowthRateSE,
+		Declining:      trajectory.Declining,
+		Forecast:       growthForecast(trajectory.Forecast(last.AddDate(0, 0, horizonDays))),
+		Declines:       make([]*GrowthCurvePoint, 0),
+	}
+	for _, p := range points {
+		if p.Decline {
+			report.Declines = append(report.Declines, p)
+		}
+	}
+	return report, nil
+}
+
+// GrowthCurve implements GrowthService
+func (s *growthService) GrowthCurve(ctx context.Context, userID, subjectID uint, horizonDays, stepDays int) (*GrowthCurve, error) {
+	trajectory, estimations, err := s.trajectory(ctx, userID, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	curve := &GrowthCurve{
+		UserID:    userID,
+		SubjectID: subjectID,
+		Points:    growthCurvePoints(trajectory, estimations),
+		Forecast:  make([]*GrowthForecast, 0),
+	}
+	if stepDays <= 0 {
+		stepDays = horizonDays
+	}
+	last := trajectory.Points[len(trajectory.Points)-1].Time
+	for day := stepDays; day <= horizonDays && stepDays > 0; day += stepDays {
+		curve.Forecast = append(curve.Forecast, growthForecast(trajectory.Forecast(last.AddDate(0, 0, day))))
+	}
+	return curve, nil
+}
+
+// trajectory 按估计时间平滑考生在科目内的历次能力估计
+func (s *growthService) trajectory(ctx context.Context, userID, subjectID uint) (*analysis.GrowthTrajectory, []*models.AbilityEstimation, error) {
+	estimations, err := s.abilityRepo.ListUserSubjectEstimations(ctx, userID, subjectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(estimations) == 0 {
+		return nil, nil, ErrNoEstimations
+	}
+
+	observations := make([]analysis.GrowthObservation, len(estimations))
+	for i, e := range estimations {
+		observations[i] = analysis.GrowthObservation{
+			Time:          e.CreatedAt,
+			Theta:         e.Ability,
+			StandardError: e.StandardError,
+		}
+	}
+	trajectory, err := analysis.TrackGrowth(observations, analysis.DefaultGrowthConfig())
+	if err != nil {
+		return nil, nil, err
+	}
+	return trajectory, estimations, nil
+}
+
+// growthCurvePoints 将平滑结果与考试记录对应，估计记录已按时间升序排列
+func growthCurvePoints(trajectory *analysis.GrowthTrajectory, estimations []*models.AbilityEstimation) []*GrowthCurvePoint {
+	points := make([]*GrowthCurvePoint, len(trajectory.Points))
+	for i, p := range trajectory.Points {
+		points[i] = &GrowthCurvePoint{
+			ExamRecordID:  estimations[i].ExamRecordID,
+			ExamSessionID: estimations[i].ExamSessionID,
+			Time:          p.Time,
+			Observed:      p.Observed,
+			ObservedSE:    p.ObservedSE,
+			Smoothed:      p.Smoothed,
+			SmoothedSE:    p.SmoothedSE,
+			Lower:         p.Smoothed - growthBandZ*p.SmoothedSE,
+			Upper:         p.Smoothed + growthBandZ*p.SmoothedSE,
+			GrowthRate:    p.Slope,
+			Innovation:    p.Innovation,
+			Decline:       p.Decline,
+		}
+	}
+	return points
+}
+
+func growthForecast(f analysis.GrowthForecast) *GrowthForecast {
+	return &GrowthForecast{
+		Time:          f.Time,
+		Ability:       f.Theta,
+		StandardError: f.StandardError,
+		Lower:         f.Theta - growthBandZ*f.StandardError,
+		Upper:         f.Theta + growthBandZ*f.StandardError,
+	}
+}
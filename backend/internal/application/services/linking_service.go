@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+var (
+	ErrPaperNotFound        = errors.New("exam paper not found")
+	ErrPaperSubjectMismatch = errors.New("exam paper does not belong to the subject")
+	ErrSamePaper            = errors.New("papers to link or equate must differ")
+	ErrNoPaperResponses     = errors.New("exam paper has no responses to calibrate")
+)
+
+// LinkingService 量尺连接与原始分等值服务接口
+type LinkingService interface {
+	// LinkPapers 单独标定新试卷，以两卷共同的锚题求出到基准试卷量尺的转换常数，
+	// Apply 为 true 时将新试卷题目参数与考生能力值转换到基准量尺
+	LinkPapers(ctx context.Context, req *LinkingRequest) (*LinkingResult, error)
+	// ListTransformations 科目的量尺转换历史，最新的在前
+	ListTransformations(ctx context.Context, subjectID uint) ([]*models.ScaleTransformation, error)
+	// Equate 将试卷 X 的原始分等值到试卷 Y，两卷题目参数须已在同一量尺上
+	Equate(ctx context.Context, req *EquatingRequest) (*EquatingResult, error)
+}
+
+// LinkingRequest 量尺连接请求
+type LinkingRequest struct {
+	SubjectID   uint
+	NewPaperID  uint
+	BasePaperID uint
+	Method      irt.LinkingMethod
+	Apply       bool
+}
+
+// LinkingResult 量尺连接结果
+type LinkingResult struct {
+	Transformation *models.ScaleTransformation
+	Anchors        []AnchorReport
+}
+
+// AnchorReport 锚题在两个量尺上的参数及转换后的新量尺参数
+type AnchorReport struct {
+	QuestionID           uint    `json:"question_id"`
+	NewDifficulty        float64 `json:"new_difficulty"`
+	NewDiscrimination    float64 `json:"new_discrimination"`
+	BaseDifficulty       float64 `json:"base_difficulty"`
+	BaseDiscrimination   float64 `json:"base_discrimination"`
+	LinkedDifficulty     float64 `json:"linked_difficulty"`
+	LinkedDiscrimination float64 `json:"linked_discrimination"`
+}
+
+// EquatingRequest 原始分等值请求，Scores 为空时等值试卷 X 的全部整数分
+type EquatingRequest struct {
+	SubjectID   uint
+	FromPaperID uint
+	ToPaperID   uint
+	Method      irt.EquatingMethod
+	Scores      []float64
+}
+
+// EquatingResult 原始分等值结果
+type EquatingResult struct {
+	FromPaperID uint
+	ToPaperID   uint
+	Method      irt.EquatingMethod
+	Scores      []irt.EquatedScore
+}
+
+// NewLinkingService creates a new linking service instance
+func NewLinkingService(linkingRepo repositories.LinkingRepository, abilityRepo repositories.AbilityRepository, subjectRepo repositories.SubjectRepository) LinkingService {
+	return &linkingService{
+		linkingRepo: linkingRepo,
+		abilityRepo: abilityRepo,
+		subjectRepo: subjectRepo,
+	}
+}
+
+type linkingService struct {
+	linkingRepo repositories.LinkingRepository
+	abilityRepo repositories.AbilityRepository
+	subjectRepo repositories.SubjectRepository
+}
+
+// LinkPapers implements LinkingService
+func (s *linkingService) LinkPapers(ctx context.Context, req *LinkingRequest) (*LinkingResult, error) {
+	if err := s.checkPapers(ctx, req.SubjectID, req.NewPaperID, req.BasePaperID); err != nil {
+		return nil, err
+	}
+	model, err := subjectModel(ctx, s.subjectRepo, req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 新试卷的作答单独标定，得到新量尺上的题目参数
+	responses, err := s.linkingRepo.ListPaperResponses(ctx, req.NewPaperID)
+	if err != nil {
+		return nil, err
+	}
+	if len(responses) == 0 {
+		return nil, ErrNoPaperResponses
+	}
+	matrix := buildResponseMatrix(responses)
+	calibration, err := irt.NewCalibrator(model).Calibrate(matrix)
+	if err != nil {
+		return nil, err
+	}
+	calibrated := make(map[uint]irt.ItemCalibration, len(calibration.Items))
+	for _, item := range calibration.Items {
+		if !item.Skipped {
+			calibrated[item.QuestionID] = item
+		}
+	}
+
+	// 两卷共同且在新试卷上完成标定的题目作为锚题，基准量尺参数取题目参数表
+	baseQuestions, err := s.linkingRepo.ListPaperQuestions(ctx, req.BasePaperID)
+	if err != nil {
+		return nil, err
+	}
+	inBase := make(map[uint]bool, len(baseQuestions))
+	anchorIDs := make([]uint, 0, len(baseQuestions))
+	for _, q := range baseQuestions {
+		inBase[q.QuestionID] = true
+		if _, ok := calibrated[q.QuestionID]; ok {
+			anchorIDs = append(anchorIDs, q.QuestionID)
+		}
+	}
+	baseParams, err := s.abilityRepo.ListQuestionParameters(ctx, anchorIDs)
+	if err != nil {
+		return nil, err
+	}
+	anchors := make([]irt.AnchorItem, 0, len(baseParams))
+	for _, p := range baseParams {
+		if irt.ItemModel(p.ItemModel).Polytomous() {
+			continue
+		}
+		anchors = append(anchors, irt.AnchorItem{
+			QuestionID: p.QuestionID,
+			New:        calibratedParams(calibrated[p.QuestionID]),
+			Base:       parameterParams(p),
+		})
+	}
+
+	t, err := model.Link(anchors, req.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.ScaleTransformation{
+		SubjectID:   req.SubjectID,
+		NewPaperID:  req.NewPaperID,
+		BasePaperID: req.BasePaperID,
+		Method:      string(req.Method),
+		Slope:       t.A,
+		Intercept:   t.B,
+		AnchorCount: len(anchors),
+	}
+	result := &LinkingResult{Transformation: record, Anchors: make([]AnchorReport, len(anchors))}
+	for i, anchor := range anchors {
+		linked := t.Item(anchor.New)
+		result.Anchors[i] = AnchorReport{
+			QuestionID:           anchor.QuestionID,
+			NewDifficulty:        anchor.New.Difficulty,
+			NewDiscrimination:    anchor.New.Discrimination,
+			BaseDifficulty:       anchor.Base.Difficulty,
+			BaseDiscrimination:   anchor.Base.Discrimination,
+			LinkedDifficulty:     linked.Difficulty,
+			LinkedDiscrimination: linked.Discrimination,
+		}
+	}
+
+	if !req.Apply {
+		if err := s.linkingRepo.CreateTransformation(ctx, record); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	// 锚题保持基准量尺参数，其余题目写入转换后的参数
+	params, err := s.rescaledParameters(ctx, calibrated, inBase, t, calibration.Model)
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[uint]bool)
+	userIDs := make([]uint, 0)
+	for _, response := range responses {
+		if id := response.ExamRecord.UserID; !users[id] {
+			users[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	now := time.Now()
+	record.Applied = true
+	record.AppliedAt = &now
+	record.ItemsRescaled = len(params)
+	record.AbilitiesRescaled = len(userIDs)
+	if err := s.linkingRepo.ApplyTransformation(ctx, record, params, matrix.RowIDs, userIDs); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// rescaledParameters 将新试卷非锚题的标定结果转换到基准量尺，多级计分题不参与
+func (s *linkingService) rescaledParameters(ctx context.Context, calibrated map[uint]irt.ItemCalibration, anchors map[uint]bool, t irt.Transformation, family irt.ModelFamily) ([]*models.QuestionParameter, error) {
+	ids := make([]uint, 0, len(calibrated))
+	for id := range calibrated {
+		if !anchors[id] {
+			ids = append(ids, id)
+		}
+	}
+	existing, err := s.abilityRepo.ListQuestionParameters(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	paramsByQuestion := make(map[uint]*models.QuestionParameter, len(existing))
+	for _, p := range existing {
+		paramsByQuestion[p.QuestionID] = p
+	}
+
+	now := time.Now()
+	params := make([]*models.QuestionParameter, 0, len(ids))
+	for _, id := range ids {
+		p, ok := paramsByQuestion[id]
+		if !ok {
+			p = &models.QuestionParameter{QuestionID: id}
+		}
+		if irt.ItemModel(p.ItemModel).Polytomous() {
+			continue
+		}
+		item := calibrated[id]
+		linked := t.Item(calibratedParams(item))
+		p.Difficulty = linked.Difficulty
+		p.Discrimination = linked.Discrimination
+		p.Guessing = linked.Guessing
+		p.UpperAsymptote = linked.UpperAsymptote
+		p.DifficultySE = t.StandardError(item.DifficultySE)
+		p.DiscriminationSE = item.DiscriminationSE / t.A
+		p.GuessingSE = item.GuessingSE
+		p.UpperAsymptoteSE = item.UpperAsymptoteSE
+		p.CalibrationModel = string(family)
+		p.CalibratedAt = &now
+		params = append(params, p)
+	}
+	return params, nil
+}
+
+// ListTransformations implements LinkingService
+func (s *linkingService) ListTransformations(ctx context.Context, subjectID uint) ([]*models.ScaleTransformation, error) {
+	return s.linkingRepo.ListTransformations(ctx, subjectID)
+}
+
+// Equate implements LinkingService
+func (s *linkingService) Equate(ctx context.Context, req *EquatingRequest) (*EquatingResult, error) {
+	if err := s.checkPapers(ctx, req.SubjectID, req.FromPaperID, req.ToPaperID); err != nil {
+		return nil, err
+	}
+	model, err := subjectModel(ctx, s.subjectRepo, req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	formX, err := s.testItems(ctx, req.FromPaperID)
+	if err != nil {
+		return nil, err
+	}
+	formY, err := s.testItems(ctx, req.ToPaperID)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := req.Scores
+	if len(scores) == 0 {
+		var max float64
+		for _, item := range formX {
+			max += math.Max(1, item.Score)
+		}
+		for x := 0.0; x <= max; x++ {
+			scores = append(scores, x)
+		}
+	}
+
+	equated, err := model.Equate(req.Method, formX, formY, scores)
+	if err != nil {
+		return nil, err
+	}
+	return &EquatingResult{
+		FromPaperID: req.FromPaperID,
+		ToPaperID:   req.ToPaperID,
+		Method:      req.Method,
+		Scores:      equated,
+	}, nil
+}
+
+// checkPapers 校验两份试卷存在、互不相同且属于同一科目
+func (s *linkingService) checkPapers(ctx context.Context, subjectID uint, paperIDs ...uint) error {
+	if paperIDs[0] == paperIDs[1] {
+		return ErrSamePaper
+	}
+	for _, id := range paperIDs {
+		paper, err := s.linkingRepo.FindPaper(ctx, id)
+		if err != nil {
+			return err
+		}
+		if paper == nil {
+			return ErrPaperNotFound
+		}
+		if paper.SubjectID != subjectID {
+			return ErrPaperSubjectMismatch
+		}
+	}
+	return nil
+}
+
+// testItems 试卷题目及其分值，未标定的题目退回到题目自带的IRT参数
+func (s *linkingService) testItems(ctx context.Context, paperID uint) ([]irt.TestItem, error) {
+	questions, err := s.linkingRepo.ListPaperQuestions(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+	return paperTestItems(ctx, s.abilityRepo, questions)
+}
+
+// paperTestItems 将试卷题目关联转换为测验函数使用的题目参数，存在未标定的题目时返回 ErrUncalibratedItem
+func paperTestItems(ctx context.Context, abilityRepo repositories.AbilityRepository, questions []*models.ExamPaperQuestion) ([]irt.TestItem, error) {
+	ids := make([]uint, len(questions))
+	for i, q := range questions {
+		ids[i] = q.QuestionID
+	}
+	params, err := abilityRepo.ListQuestionParameters(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	paramsByQuestion := make(map[uint]*models.QuestionParameter, len(params))
+	for _, p := range params {
+		paramsByQuestion[p.QuestionID] = p
+	}
+
+	items := make([]irt.TestItem, len(questions))
+	for i, q := range questions {
+		p, ok := paramsByQuestion[q.QuestionID]
+		if !ok || p.Discrimination <= 0 {
+			return nil, fmt.Errorf("%w: question %d", ErrUncalibratedItem, q.QuestionID)
+		}
+		items[i] = irt.TestItem{
+			QuestionID:     q.QuestionID,
+			Difficulty:     p.Difficulty,
+			Discrimination: p.Discrimination,
+			Guessing:       p.Guessing,
+			UpperAsymptote: p.UpperAsymptote,
+			Score:          q.Score,
+		}
+	}
+	return items, nil
+}
+
+// calibratedParams 标定结果中的题目参数
+func calibratedParams(item irt.ItemCalibration) irt.ItemParams {
+	return irt.ItemParams{
+		Difficulty:     item.Difficulty,
+		Discrimination: item.Discrimination,
+		Guessing:       item.Guessing,
+		UpperAsymptote: item.UpperAsymptote,
+	}
+}
+
+// parameterParams 题目参数表中的二级计分参数
+func parameterParams(p *models.QuestionParameter) irt.ItemParams {
+	return irt.ItemParams{
+		Difficulty:     p.Difficulty,
+		Discrimination: p.Discrimination,
+		Guessing:       p.Guessing,
+		UpperAsymptote: p.UpperAsymptote,
+	}
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+var (
+	ErrTooFewDimensions        = errors.New("multidimensional calibration needs at least two top-level knowledge points with linked questions")
+	ErrMIRTCalibrationNotFound = errors.New("multidimensional calibration not found")
+	ErrAbilityProfileNotFound  = errors.New("ability profile not found")
+)
+
+// MIRTService 多维项目反应理论服务接口
+type MIRTService interface {
+	// Calibrate 以科目顶层知识点为维度标定补偿型多维模型，题目只在其知识点所属的顶层知识点上有区分度；
+	// 科目为2PL及以下时标定 M2PL，否则标定 M3PL，dryRun 为 true 时不保存
+	Calibrate(ctx context.Context, subjectID uint, dryRun bool) (*models.MIRTCalibration, error)
+	GetCalibration(ctx context.Context, calibrationID uint) (*models.MIRTCalibration, error)
+	ListCalibrations(ctx context.Context, subjectID uint) ([]*models.MIRTCalibration, error)
+	// EstimateProfile 合并考生在科目内的全部作答，按当前多维标定估计能力向量及其协方差并保存为能力剖面
+	EstimateProfile(ctx context.Context, userID, subjectID uint) (*models.UserAbilityProfile, error)
+	GetProfile(ctx context.Context, userID, subjectID uint) (*models.UserAbilityProfile, error)
+}
+
+// NewMIRTService creates a new multidimensional IRT service instance
+func NewMIRTService(
+	mirtRepo repositories.MIRTRepository,
+	abilityRepo repositories.AbilityRepository,
+	questionRepo repositories.QuestionRepository,
+	knowledgeRepo repositories.KnowledgePointRepository,
+	subjectRepo repositories.SubjectRepository,
+) MIRTService {
+	return &mirtService{
+		mirtRepo:      mirtRepo,
+		abilityRepo:   abilityRepo,
+		questionRepo:  questionRepo,
+		knowledgeRepo: knowledgeRepo,
+		subjectRepo:   subjectRepo,
+	}
+}
+
+type mirtService struct {
+	mirtRepo      repositories.MIRTRepository
+	abilityRepo   repositories.AbilityRepository
+	questionRepo  repositories.QuestionRepository
+	knowledgeRepo repositories.KnowledgePointRepository
+	subjectRepo   repositories.SubjectRepository
+}
+
+// Calibrate implements MIRTService
+func (s *mirtService) Calibrate(ctx context.Context, subjectID uint, dryRun bool) (*models.MIRTCalibration, error) {
+	model, err := subjectModel(ctx, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := s.abilityRepo.ListSubjectResponses(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	matrix := buildResponseMatrix(responses)
+
+	dimensions, pattern, err := s.loadingPattern(ctx, subjectID, matrix.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(dimensions) < 2 {
+		return nil, ErrTooFewDimensions
+	}
+
+	result, err := irt.NewMultiCalibrator(model).Calibrate(matrix, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	calibration := &models.MIRTCalibration{
+		SubjectID:     subjectID,
+		Family:        result.Model,
+		D:             result.D,
+		Examinees:     result.Examinees,
+		Iterations:    result.Iterations,
+		Converged:     result.Converged,
+		LogLikelihood: result.LogLikelihood,
+	}
+	for k, point := range dimensions {
+		calibration.Dimensions = append(calibration.Dimensions, models.MIRTDimension{
+			Position:         k,
+			KnowledgePointID: point.ID,
+			Name:             point.Name,
+		})
+		for l := k + 1; l < len(dimensions); l++ {
+			calibration.Correlations = append(calibration.Correlations, models.MIRTCorrelation{
+				Row:   k,
+				Col:   l,
+				Value: result.Correlations[k][l],
+			})
+		}
+	}
+	// 作答人数不足的题目不保存多维参数，D 最优选题时不可选
+	for i, item := range result.Items {
+		if item.Skipped {
+			continue
+		}
+		param := models.MIRTItemParameter{
+			QuestionID:    item.QuestionID,
+			Intercept:     item.Intercept,
+			Guessing:      item.Guessing,
+			InterceptSE:   item.InterceptSE,
+			GuessingSE:    item.GuessingSE,
+			ResponseCount: item.ResponseCount,
+		}
+		for k, loaded := range pattern[i] {
+			if loaded {
+				param.Loadings = append(param.Loadings, models.MIRTLoading{
+					Position:         k,
+					Discrimination:   item.Discrimination[k],
+					DiscriminationSE: item.DiscriminationSE[k],
+				})
+			}
+		}
+		calibration.Items = append(calibration.Items, param)
+	}
+
+	if dryRun {
+		return calibration, nil
+	}
+	if err := s.mirtRepo.CreateCalibration(ctx, calibration); err != nil {
+		return nil, err
+	}
+	return calibration, nil
+}
+
+// GetCalibration implements MIRTService
+func (s *mirtService) GetCalibration(ctx context.Context, calibrationID uint) (*models.MIRTCalibration, error) {
+	calibration, err := s.mirtRepo.FindCalibration(ctx, calibrationID)
+	if err != nil {
+		return nil, err
+	}
+	if calibration == nil {
+		return nil, ErrMIRTCalibrationNotFound
+	}
+	return calibration, nil
+}
+
+// ListCalibrations implements MIRTService
+func (s *mirtService) ListCalibrations(ctx context.Context, subjectID uint) ([]*models.MIRTCalibration, error) {
+	return s.mirtRepo.ListCalibrations(ctx, subjectID)
+}
+
+// EstimateProfile implements MIRTService
+func (s *mirtService) EstimateProfile(ctx context.Context, userID, subjectID uint) (*models.UserAbilityProfile, error) {
+	calibration, err := s.mirtRepo.FindActiveCalibration(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if calibration == nil {
+		return nil, ErrMIRTCalibrationNotFound
+	}
+
+	responses, err := s.abilityRepo.ListSubjectResponses(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	// 多场考试重复作答同一题时以最后一次为准
+	correct := make(map[uint]bool)
+	var order []uint
+	for _, response := range responses {
+		if response.ExamRecord.UserID != userID || response.Question.Pretest {
+			continue
+		}
+		if _, ok := correct[response.QuestionID]; !ok {
+			order = append(order, response.QuestionID)
+		}
+		correct[response.QuestionID] = response.IsCorrect
+	}
+
+	items := calibration.MultiItems()
+	answered := make([]irt.MultiResponse, 0, len(order))
+	for _, id := range order {
+		if item, ok := items[id]; ok {
+			answered = append(answered, irt.MultiResponse{Item: item, Correct: correct[id]})
+		}
+	}
+	if len(answered) == 0 {
+		return nil, irt.ErrNoResponses
+	}
+
+	estimate, err := calibration.Estimator().Estimate(answered)
+	if err != nil {
+		return nil, err
+	}
+	profile := calibration.Profile(userID, estimate, len(answered))
+	if err := s.mirtRepo.SaveProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// GetProfile implements MIRTService
+func (s *mirtService) GetProfile(ctx context.Context, userID, subjectID uint) (*models.UserAbilityProfile, error) {
+	profile, err := s.mirtRepo.FindProfile(ctx, userID, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, ErrAbilityProfileNotFound
+	}
+	return profile, nil
+}
+
+// loadingPattern 以有题目关联的顶层知识点为维度（按ID排序），题目在其关联知识点所属的顶层知识点上加载
+func (s *mirtService) loadingPattern(ctx context.Context, subjectID uint, questionIDs []uint) ([]*models.KnowledgePoint, irt.LoadingPattern, error) {
+	points, err := s.knowledgeRepo.ListBySubject(ctx, subjectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[uint]*models.KnowledgePoint, len(points))
+	for _, point := range points {
+		byID[point.ID] = point
+	}
+	rootOf := func(id uint) (uint, bool) {
+		point, ok := byID[id]
+		// 层级深度不超过知识点总数，防止父子关系成环
+		for depth := 0; ok && point.ParentID != nil && depth < len(points); depth++ {
+			parent, found := byID[*point.ParentID]
+			if !found {
+				break
+			}
+			point = parent
+		}
+		if !ok {
+			return 0, false
+		}
+		return point.ID, true
+	}
+
+	links, err := s.questionRepo.ListKnowledgePointLinks(ctx, questionIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	roots := make(map[uint]map[uint]bool, len(questionIDs))
+	used := make(map[uint]bool)
+	for _, link := range links {
+		root, ok := rootOf(link.KnowledgePointID)
+		if !ok {
+			continue
+		}
+		if roots[link.QuestionID] == nil {
+			roots[link.QuestionID] = make(map[uint]bool)
+		}
+		roots[link.QuestionID][root] = true
+		used[root] = true
+	}
+
+	dimensions := make([]*models.KnowledgePoint, 0, len(used))
+	for id := range used {
+		dimensions = append(dimensions, byID[id])
+	}
+	sort.Slice(dimensions, func(a, b int) bool { return dimensions[a].ID < dimensions[b].ID })
+
+	pattern := make(irt.LoadingPattern, len(questionIDs))
+	for i, id := range questionIDs {
+		pattern[i] = make([]bool, len(dimensions))
+		for k, point := range dimensions {
+			pattern[i][k] = roots[id][point.ID]
+		}
+	}
+	return dimensions, pattern, nil
+}
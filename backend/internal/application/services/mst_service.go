@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/domain/repositories"
+)
+
+var (
+	ErrPanelNotFound        = errors.New("mst panel not found")
+	ErrInvalidPanelDesign   = errors.New("panel needs at least two stages with a single routing module in the first stage")
+	ErrDuplicateModule      = errors.New("module labels must be unique within a panel")
+	ErrEmptyPanelModule     = errors.New("every module needs at least one question")
+	ErrDuplicatePanelItem   = errors.New("a question can appear in only one module of a panel")
+	ErrPretestModuleItem    = errors.New("pretest questions cannot be placed in mst modules")
+	ErrMissingRoutingRule   = errors.New("every module before the last stage needs exactly one routing rule")
+	ErrUnknownRoutingModule = errors.New("routing rule refers to an unknown or last-stage module")
+)
+
+// MSTService 多阶段测验板服务接口
+type MSTService interface {
+	// CreatePanel 保存测验板并按规则推导路由切点，模块按阶段给出、阶段内由易到难排列
+	CreatePanel(ctx context.Context, panel *models.MSTPanel, rules []RoutingRuleSpec) (*models.MSTPanel, error)
+	GetPanel(ctx context.Context, panelID uint) (*models.MSTPanel, error)
+	ListPanels(ctx context.Context, examPaperID uint) ([]*models.MSTPanel, error)
+	// SetPanelActive 启用或停用测验板，已开考的会话继续使用原测验板
+	SetPanelActive(ctx context.Context, panelID uint, active bool) (*models.MSTPanel, error)
+}
+
+// RoutingRuleSpec 一个模块完成后的路由规则：人工切点直接使用 Cuts，
+// 近似最大信息与既定群体切点由模块题目参数推导
+type RoutingRuleSpec struct {
+	Module         string // 来源模块标签
+	Method         irt.RoutingMethod
+	CutMethod      irt.RoutingCutMethod
+	Cuts           []float64
+	Proportions    []float64 // 既定群体路由中进入各后续模块的目标比例，为空时均分
+	PopulationMean float64
+	PopulationSD   float64
+}
+
+// NewMSTService creates a new multistage testing service instance
+func NewMSTService(
+	mstRepo repositories.MSTRepository,
+	examRepo repositories.ExamRepository,
+	questionRepo repositories.QuestionRepository,
+	subjectRepo repositories.SubjectRepository,
+) MSTService {
+	return &mstService{
+		mstRepo:      mstRepo,
+		examRepo:     examRepo,
+		questionRepo: questionRepo,
+		subjectRepo:  subjectRepo,
+	}
+}
+
+type mstService struct {
+	mstRepo      repositories.MSTRepository
+	examRepo     repositories.ExamRepository
+	questionRepo repositories.QuestionRepository
+	subjectRepo  repositories.SubjectRepository
+}
+
+// CreatePanel implements MSTService
+func (s *mstService) CreatePanel(ctx context.Context, panel *models.MSTPanel, rules []RoutingRuleSpec) (*models.MSTPanel, error) {
+	paper, err := s.examRepo.FindPaperByID(ctx, panel.ExamPaperID)
+	if err != nil {
+		return nil, err
+	}
+	if paper == nil {
+		return nil, ErrPaperNotFound
+	}
+	stages, err := validatePanelDesign(panel)
+	if err != nil {
+		return nil, err
+	}
+
+	// 模块题目参数，分值缺省取题目分值
+	var ids []uint
+	for _, module := range panel.Modules {
+		for _, q := range module.Questions {
+			ids = append(ids, q.QuestionID)
+		}
+	}
+	params, err := itemParameters(ctx, s.questionRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[*models.MSTModule][]irt.TestItem, len(panel.Modules))
+	for _, modules := range stages {
+		for _, module := range modules {
+			for i := range module.Questions {
+				q := &module.Questions[i]
+				question, err := s.questionRepo.FindByID(ctx, q.QuestionID)
+				if err != nil {
+					return nil, err
+				}
+				if question.Pretest {
+					return nil, ErrPretestModuleItem
+				}
+				p, ok := params[question.ID]
+				if !ok {
+					return nil, fmt.Errorf("%w: question %d", ErrUncalibratedItem, question.ID)
+				}
+				if q.Score <= 0 {
+					q.Score = question.Score
+				}
+				items[module] = append(items[module], irt.TestItem{
+					QuestionID:     question.ID,
+					Difficulty:     p.Difficulty,
+					Discrimination: p.Discrimination,
+					Guessing:       p.Guessing,
+					UpperAsymptote: p.UpperAsymptote,
+					Score:          q.Score,
+				})
+			}
+		}
+	}
+
+	model, err := subjectModel(ctx, s.subjectRepo, paper.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	byLabel := make(map[string]*models.MSTModule, len(panel.Modules))
+	for _, modules := range stages[:len(stages)-1] {
+		for _, module := range modules {
+			byLabel[module.Label] = module
+		}
+	}
+	panel.Rules = nil
+	for _, spec := range rules {
+		module, ok := byLabel[spec.Module]
+		if !ok {
+			return nil, ErrUnknownRoutingModule
+		}
+		delete(byLabel, spec.Module)
+
+		next := stages[module.Stage]
+		cuts := spec.Cuts
+		if spec.CutMethod == irt.RoutingCutManual {
+			switch spec.Method {
+			case irt.RoutingNumberCorrect, irt.RoutingTheta:
+			default:
+				return nil, irt.ErrUnknownRoutingMethod
+			}
+			if err := irt.ValidateRoutingCuts(cuts, len(next)); err != nil {
+				return nil, err
+			}
+		} else {
+			cfg := irt.DefaultRoutingConfig()
+			cfg.Model = model
+			cfg.Method = spec.Method
+			cfg.CutMethod = spec.CutMethod
+			cfg.Proportions = spec.Proportions
+			cfg.PopulationMean = spec.PopulationMean
+			if spec.PopulationSD > 0 {
+				cfg.PopulationSD = spec.PopulationSD
+			}
+			nextItems := make([][]irt.TestItem, len(next))
+			for k, m := range next {
+				nextItems[k] = items[m]
+			}
+			cuts, err = irt.RoutingCuts(items[module], nextItems, cfg)
+			if err != nil {
+				return nil, fmt.Errorf("module %s: %w", module.Label, err)
+			}
+		}
+
+		rule := models.MSTRoutingRule{
+			Stage:     module.Stage,
+			Position:  module.Position,
+			Method:    string(spec.Method),
+			CutMethod: string(spec.CutMethod),
+		}
+		for k, cut := range cuts {
+			rule.Cuts = append(rule.Cuts, models.MSTRoutingCut{Position: k, Value: cut})
+		}
+		panel.Rules = append(panel.Rules, rule)
+	}
+	if len(byLabel) > 0 {
+		return nil, ErrMissingRoutingRule
+	}
+
+	panel.Active = true
+	if err := s.mstRepo.CreatePanel(ctx, panel); err != nil {
+		return nil, err
+	}
+	return panel, nil
+}
+
+// GetPanel implements MSTService
+func (s *mstService) GetPanel(ctx context.Context, panelID uint) (*models.MSTPanel, error) {
+	panel, err := s.mstRepo.FindPanel(ctx, panelID)
+	if err != nil {
+		return nil, err
+	}
+	if panel == nil {
+		return nil, ErrPanelNotFound
+	}
+	return panel, nil
+}
+
+// ListPanels implements MSTService
+func (s *mstService) ListPanels(ctx context.Context, examPaperID uint) ([]*models.MSTPanel, error) {
+	return s.mstRepo.ListPanels(ctx, examPaperID)
+}
+
+// SetPanelActive implements MSTService
+func (s *mstService) SetPanelActive(ctx context.Context, panelID uint, active bool) (*models.MSTPanel, error) {
+	panel, err := s.GetPanel(ctx, panelID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mstRepo.SetPanelActive(ctx, panelID, active); err != nil {
+		return nil, err
+	}
+	panel.Active = active
+	return panel, nil
+}
+
+// validatePanelDesign 检查阶段连续、第一阶段只有一个路由模块、模块标签唯一且题目不重复
+func validatePanelDesign(panel *models.MSTPanel) ([][]*models.MSTModule, error) {
+	for _, module := range panel.Modules {
+		if module.Stage < 1 {
+			return nil, ErrInvalidPanelDesign
+		}
+	}
+	stages := panel.Stages()
+	if len(stages) < 2 || len(stages[0]) != 1 {
+		return nil, ErrInvalidPanelDesign
+	}
+	labels := make(map[string]bool, len(panel.Modules))
+	questions := make(map[uint]bool)
+	for _, modules := range stages {
+		if len(modules) == 0 {
+			return nil, ErrInvalidPanelDesign
+		}
+		for _, module := range modules {
+			if labels[module.Label] {
+				return nil, ErrDuplicateModule
+			}
+			labels[module.Label] = true
+			if len(module.Questions) == 0 {
+				return nil, ErrEmptyPanelModule
+			}
+			for _, q := range module.Questions {
+				if questions[q.QuestionID] {
+					return nil, ErrDuplicatePanelItem
+				}
+				questions[q.QuestionID] = true
+			}
+		}
+	}
+	return stages, nil
+}
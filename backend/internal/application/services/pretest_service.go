@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+var ErrNoQuestionsFlagged = errors.New("no questions of the subject matched")
+
+// PretestService 试测题嵌入配置与在线标定服务接口
+type PretestService interface {
+	GetSetting(ctx context.Context, subjectID uint) (*models.PretestSetting, error)
+	UpdateSetting(ctx context.Context, setting *models.PretestSetting) error
+	// FlagQuestions 标记（或取消标记）科目中的试测题，返回更新的题目数
+	FlagQuestions(ctx context.Context, subjectID uint, questionIDs []uint, pretest bool) (int64, error)
+	// CalibrateOnline 固定计分题参数，对科目全部试测题做在线标定，满足转正条件的题目转为正式题；
+	// dryRun 为 true 时只返回结果，不写回数据库
+	CalibrateOnline(ctx context.Context, subjectID uint, dryRun bool) (*OnlineCalibrationResult, error)
+	ListCalibrations(ctx context.Context, subjectID uint) ([]*models.PretestCalibration, error)
+}
+
+// OnlineCalibrationResult 一次在线标定的结果
+type OnlineCalibrationResult struct {
+	SubjectID uint
+	Method    irt.OnlineMethod
+	Examinees int // 作答过试测题且有计分题作答的考生（考试记录或会话）数
+	Items     []PretestItemResult
+}
+
+// PretestItemResult 单道试测题的在线标定结果
+type PretestItemResult struct {
+	irt.ItemCalibration
+	Ready    bool // 满足转正条件
+	Promoted bool // 已转为正式题，dryRun 时始终为 false
+}
+
+// NewPretestService creates a new pretest service instance
+func NewPretestService(pretestRepo repositories.PretestRepository, abilityRepo repositories.AbilityRepository, subjectRepo repositories.SubjectRepository) PretestService {
+	return &pretestService{
+		pretestRepo: pretestRepo,
+		abilityRepo: abilityRepo,
+		subjectRepo: subjectRepo,
+	}
+}
+
+type pretestService struct {
+	pretestRepo repositories.PretestRepository
+	abilityRepo repositories.AbilityRepository
+	subjectRepo repositories.SubjectRepository
+}
+
+// GetSetting implements PretestService
+func (s *pretestService) GetSetting(ctx context.Context, subjectID uint) (*models.PretestSetting, error) {
+	setting, err := s.pretestRepo.FindSetting(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		setting = defaultPretestSetting(subjectID)
+	}
+	return setting, nil
+}
+
+// UpdateSetting implements PretestService
+func (s *pretestService) UpdateSetting(ctx context.Context, setting *models.PretestSetting) error {
+	existing, err := s.pretestRepo.FindSetting(ctx, setting.SubjectID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		setting.ID = existing.ID
+		setting.CreatedAt = existing.CreatedAt
+	}
+	return s.pretestRepo.SaveSetting(ctx, setting)
+}
+
+// FlagQuestions implements PretestService
+func (s *pretestService) FlagQuestions(ctx context.Context, subjectID uint, questionIDs []uint, pretest bool) (int64, error) {
+	updated, err := s.pretestRepo.SetPretest(ctx, subjectID, questionIDs, pretest)
+	if err != nil {
+		return 0, err
+	}
+	if updated == 0 {
+		return 0, ErrNoQuestionsFlagged
+	}
+	return updated, nil
+}
+
+// ListCalibrations implements PretestService
+func (s *pretestService) ListCalibrations(ctx context.Context, subjectID uint) ([]*models.PretestCalibration, error) {
+	return s.pretestRepo.ListCalibrations(ctx, subjectID)
+}
+
+// pretestExaminee 一名考生（考试记录或自适应会话）的计分题作答与试测题作答
+type pretestExaminee struct {
+	operational []*models.PretestResponse
+	pretest     []*models.PretestResponse
+}
+
+// CalibrateOnline implements PretestService
+func (s *pretestService) CalibrateOnline(ctx context.Context, subjectID uint, dryRun bool) (*OnlineCalibrationResult, error) {
+	setting, err := s.GetSetting(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	model, err := subjectModel(ctx, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	method := irt.OnlineMethod(setting.Method)
+
+	rows, err := s.pretestRepo.ListResponses(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	examinees := make(map[string]*pretestExaminee)
+	order := make([]string, 0)
+	questionIDs := make([]uint, 0)
+	seen := make(map[uint]bool)
+	pretestIDs := make([]uint, 0)
+	for _, row := range rows {
+		e, ok := examinees[row.Examinee]
+		if !ok {
+			e = &pretestExaminee{}
+			examinees[row.Examinee] = e
+			order = append(order, row.Examinee)
+		}
+		if row.Pretest {
+			e.pretest = append(e.pretest, row)
+		} else {
+			e.operational = append(e.operational, row)
+		}
+		if !seen[row.QuestionID] {
+			seen[row.QuestionID] = true
+			questionIDs = append(questionIDs, row.QuestionID)
+			if row.Pretest {
+				pretestIDs = append(pretestIDs, row.QuestionID)
+			}
+		}
+	}
+
+	params, err := s.abilityRepo.ListQuestionParameters(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	paramsByQuestion := make(map[uint]*models.QuestionParameter, len(params))
+	for _, p := range params {
+		paramsByQuestion[p.QuestionID] = p
+	}
+
+	// 以计分题固定量尺，按试测题归集各考生的作答
+	estimator := irt.NewEstimator(irt.MethodEAP)
+	estimator.Model = model
+	observations := make(map[uint][]irt.PretestObservation, len(pretestIDs))
+	result := &OnlineCalibrationResult{SubjectID: subjectID, Method: method}
+	for _, key := range order {
+		e := examinees[key]
+		if len(e.pretest) == 0 {
+			continue
+		}
+		operational := operationalResponses(e.operational, paramsByQuestion)
+		if len(operational) == 0 {
+			continue
+		}
+		estimate, err := estimator.Estimate(operational)
+		if err != nil {
+			return nil, err
+		}
+		result.Examinees++
+		for _, row := range e.pretest {
+			observations[row.QuestionID] = append(observations[row.QuestionID], irt.PretestObservation{
+				Theta:       estimate.Theta,
+				Operational: operational,
+				Correct:     row.IsCorrect,
+			})
+		}
+	}
+
+	calibrator := irt.NewCalibrator(model)
+	criteria := irt.PromotionCriteria{
+		MinResponses:        setting.MinResponses,
+		MaxDifficultySE:     setting.MaxDifficultySE,
+		MaxDiscriminationSE: setting.MaxDiscriminationSE,
+		MaxGuessingSE:       setting.MaxGuessingSE,
+		MaxUpperAsymptoteSE: setting.MaxUpperAsymptoteSE,
+	}
+	start := pretestStart(model, params)
+	calibrations := make([]*models.PretestCalibration, 0, len(pretestIDs))
+	ready := make([]*models.PretestCalibration, 0)
+	for _, id := range pretestIDs {
+		item, err := calibrator.CalibrateOnline(id, method, start, observations[id])
+		if err != nil {
+			return nil, err
+		}
+		itemResult := PretestItemResult{ItemCalibration: item, Ready: criteria.Ready(model, item)}
+		if !item.Skipped {
+			calibration := &models.PretestCalibration{
+				QuestionID:       id,
+				SubjectID:        subjectID,
+				Method:           string(method),
+				Difficulty:       item.Difficulty,
+				Discrimination:   item.Discrimination,
+				Guessing:         item.Guessing,
+				UpperAsymptote:   item.UpperAsymptote,
+				DifficultySE:     item.DifficultySE,
+				DiscriminationSE: item.DiscriminationSE,
+				GuessingSE:       item.GuessingSE,
+				UpperAsymptoteSE: item.UpperAsymptoteSE,
+				ResponseCount:    item.ResponseCount,
+			}
+			calibrations = append(calibrations, calibration)
+			if itemResult.Ready {
+				ready = append(ready, calibration)
+			}
+		}
+		result.Items = append(result.Items, itemResult)
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := s.pretestRepo.SaveCalibrations(ctx, calibrations); err != nil {
+		return nil, err
+	}
+	if err := s.pretestRepo.Promote(ctx, ready, promotedParameters(ready, paramsByQuestion, model.Family)); err != nil {
+		return nil, err
+	}
+	for i := range result.Items {
+		result.Items[i].Promoted = result.Items[i].Ready
+	}
+	return result, nil
+}
+
+// operationalResponses 考生计分题作答的IRT作答模式，未标定或多级计分的计分题不参与
+func operationalResponses(rows []*models.PretestResponse, params map[uint]*models.QuestionParameter) []irt.ItemResponse {
+	items := make([]irt.ItemResponse, 0, len(rows))
+	for _, row := range rows {
+		p, ok := params[row.QuestionID]
+		if !ok || irt.ItemModel(p.ItemModel).Polytomous() {
+			continue
+		}
+		items = append(items, irt.ItemResponse{
+			QuestionID:     row.QuestionID,
+			Difficulty:     p.Difficulty,
+			Discrimination: p.Discrimination,
+			Guessing:       p.Guessing,
+			UpperAsymptote: p.UpperAsymptote,
+			Correct:        row.IsCorrect,
+		})
+	}
+	return items
+}
+
+// pretestStart 试测题参数初值；1PL 下区分度取计分题的共同区分度
+func pretestStart(model irt.Model, params []*models.QuestionParameter) irt.ItemParams {
+	start := irt.ItemParams{Discrimination: 1, UpperAsymptote: 1}
+	switch model.Family {
+	case irt.Model3PL:
+		start.Guessing = 0.2
+	case irt.Model4PL:
+		start.Guessing = 0.2
+		start.UpperAsymptote = 0.95
+	case irt.Model1PL:
+		var sum float64
+		var n int
+		for _, p := range params {
+			if p.CalibratedAt != nil && p.Discrimination > 0 {
+				sum += p.Discrimination
+				n++
+			}
+		}
+		if n > 0 {
+			start.Discrimination = sum / float64(n)
+		}
+	}
+	return start
+}
+
+// promotedParameters 转正题目写入题目参数表的参数
+func promotedParameters(calibrations []*models.PretestCalibration, existing map[uint]*models.QuestionParameter, family irt.ModelFamily) []*models.QuestionParameter {
+	now := time.Now()
+	params := make([]*models.QuestionParameter, len(calibrations))
+	for i, c := range calibrations {
+		p, ok := existing[c.QuestionID]
+		if !ok {
+			p = &models.QuestionParameter{QuestionID: c.QuestionID}
+		}
+		p.Difficulty = c.Difficulty
+		p.Discrimination = c.Discrimination
+		p.Guessing = c.Guessing
+		p.UpperAsymptote = c.UpperAsymptote
+		p.DifficultySE = c.DifficultySE
+		p.DiscriminationSE = c.DiscriminationSE
+		p.GuessingSE = c.GuessingSE
+		p.UpperAsymptoteSE = c.UpperAsymptoteSE
+		p.CalibrationModel = string(family)
+		p.CalibratedAt = &now
+		params[i] = p
+	}
+	return params
+}
+
+func defaultPretestSetting(subjectID uint) *models.PretestSetting {
+	return &models.PretestSetting{
+		SubjectID:           subjectID,
+		MaxItems:            2,
+		StartAfter:          3,
+		Method:              string(irt.OnlineMEM),
+		MinResponses:        200,
+		MaxDifficultySE:     0.3,
+		MaxDiscriminationSE: 0.3,
+		MaxGuessingSE:       0.1,
+		MaxUpperAsymptoteSE: 0.1,
+	}
+}
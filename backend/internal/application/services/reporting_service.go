@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+var (
+	ErrScaleNotFound     = errors.New("reporting scale not found")
+	ErrNoReportingScale  = errors.New("subject has no active reporting scale")
+	ErrNormTableNotFound = errors.New("norm table not found")
+)
+
+// ReportingService 报告分数量表、常模与表现水平报告服务接口
+type ReportingService interface {
+	// 量表
+	// CreateScale 为科目新建一个量表版本并启用，此后的报告分数均按新版本换算
+	CreateScale(ctx context.Context, scale *models.ReportingScale) (*models.ReportingScale, error)
+	ListScales(ctx context.Context, subjectID uint) ([]*models.ReportingScale, error)
+	// ConversionTable 量表在能力值网格上的 θ–报告分数转换表
+	ConversionTable(ctx context.Context, scaleID uint, grid []float64) (*ConversionTable, error)
+
+	// 常模
+	// BuildNormTable 以科目当前量表换算参照群体的能力值，建立新版本的百分位常模表并启用
+	BuildNormTable(ctx context.Context, subjectID uint, cohort NormCohort) (*models.NormTable, error)
+	ListNormTables(ctx context.Context, scaleID uint) ([]*models.NormTable, error)
+	GetNormTable(ctx context.Context, tableID uint) (*models.NormTable, error)
+
+	// 分数报告
+	// ScoreReport 按科目当前量表、常模与表现水平报告能力值，科目均未配置时返回 nil
+	ScoreReport(ctx context.Context, subjectID uint, theta, standardError float64) (*ScoreReport, error)
+	// ReportAbilities 逐条报告考生科目能力值，结果与输入一一对应
+	ReportAbilities(ctx context.Context, abilities []*models.UserAbility) ([]*ScoreReport, error)
+	// ReportEstimations 逐条报告能力估计历史，结果与输入一一对应
+	ReportEstimations(ctx context.Context, estimations []*models.AbilityEstimation) ([]*ScoreReport, error)
+}
+
+// NormCohort 参照群体：科目下能力值更新时间在 [From, To) 内的考生
+type NormCohort struct {
+	Name string
+	From *time.Time
+	To   *time.Time
+}
+
+// ScoreReport 能力值的报告分数与表现水平，未配置量表时不含报告分数，未建立常模时不含百分位等级
+type ScoreReport struct {
+	ScaleID          uint     `json:"scale_id,omitempty"`
+	ScaleVersion     int      `json:"scale_version,omitempty"`
+	ScaledScore      *float64 `json:"scaled_score,omitempty"`
+	ScaledSEM        *float64 `json:"scaled_sem,omitempty"` // 报告分数上的条件标准误
+	NormTableID      uint     `json:"norm_table_id,omitempty"`
+	NormVersion      int      `json:"norm_version,omitempty"`
+	PercentileRank   *float64 `json:"percentile_rank,omitempty"`
+	PerformanceLevel string   `json:"performance_level,omitempty"`
+	LevelSetID       uint     `json:"level_set_id,omitempty"`
+	LevelSetVersion  int      `json:"level_set_version,omitempty"`
+}
+
+// ConversionTable θ–报告分数转换表
+type ConversionTable struct {
+	ScaleID      uint               `json:"scale_id"`
+	ScaleVersion int                `json:"scale_version"`
+	Rows         []*ConversionEntry `json:"rows"`
+}
+
+// ConversionEntry 转换表中的一行
+type ConversionEntry struct {
+	Theta       float64 `json:"theta"`
+	Unrounded   float64 `json:"unrounded"`
+	ScaledScore float64 `json:"scaled_score"`
+}
+
+// NewReportingService creates a new score reporting service instance
+func NewReportingService(
+	reportingRepo repositories.ReportingRepository,
+	performanceLevelRepo repositories.PerformanceLevelRepository,
+	subjectRepo repositories.SubjectRepository,
+) ReportingService {
+	return &reportingService{
+		reportingRepo:        reportingRepo,
+		performanceLevelRepo: performanceLevelRepo,
+		subjectRepo:          subjectRepo,
+	}
+}
+
+type reportingService struct {
+	reportingRepo        repositories.ReportingRepository
+	performanceLevelRepo repositories.PerformanceLevelRepository
+	subjectRepo          repositories.SubjectRepository
+}
+
+// CreateScale implements ReportingService
+func (s *reportingService) CreateScale(ctx context.Context, scale *models.ReportingScale) (*models.ReportingScale, error) {
+	if err := scale.IRTScale().Validate(); err != nil {
+		return nil, err
+	}
+	subject, err := s.subjectRepo.FindByID(ctx, scale.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	if subject == nil {
+		return nil, ErrSubjectNotFound
+	}
+	if scale.Transform == string(irt.ScaleLinear) {
+		scale.Points = nil
+	}
+	if err := s.reportingRepo.CreateScale(ctx, scale); err != nil {
+		return nil, err
+	}
+	return scale, nil
+}
+
+// ListScales implements ReportingService
+func (s *reportingService) ListScales(ctx context.Context, subjectID uint) ([]*models.ReportingScale, error) {
+	return s.reportingRepo.ListScales(ctx, subjectID)
+}
+
+// ConversionTable implements ReportingService
+func (s *reportingService) ConversionTable(ctx context.Context, scaleID uint, grid []float64) (*ConversionTable, error) {
+	scale, err := s.reportingRepo.FindScale(ctx, scaleID)
+	if err != nil {
+		return nil, err
+	}
+	if scale == nil {
+		return nil, ErrScaleNotFound
+	}
+	conversion := scale.IRTScale()
+	table := &ConversionTable{
+		ScaleID:      scale.ID,
+		ScaleVersion: scale.Version,
+		Rows:         make([]*ConversionEntry, len(grid)),
+	}
+	for i, theta := range grid {
+		table.Rows[i] = &ConversionEntry{
+			Theta:       theta,
+			Unrounded:   conversion.Unrounded(theta),
+			ScaledScore: conversion.Score(theta),
+		}
+	}
+	return table, nil
+}
+
+// BuildNormTable implements ReportingService
+func (s *reportingService) BuildNormTable(ctx context.Context, subjectID uint, cohort NormCohort) (*models.NormTable, error) {
+	scale, err := s.reportingRepo.FindActiveScale(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if scale == nil {
+		return nil, ErrNoReportingScale
+	}
+	abilities, err := s.reportingRepo.ListCohortAbilities(ctx, subjectID, cohort.From, cohort.To)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := scale.IRTScale()
+	scores := make([]float64, len(abilities))
+	for i, a := range abilities {
+		scores[i] = conversion.Score(a.Ability)
+	}
+	norms, err := analysis.BuildNorms(scores)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &models.NormTable{
+		ScaleID:    scale.ID,
+		SubjectID:  subjectID,
+		Cohort:     cohort.Name,
+		CohortFrom: cohort.From,
+		CohortTo:   cohort.To,
+		SampleSize: norms.SampleSize,
+		MeanScore:  norms.Mean,
+		SDScore:    norms.SD,
+		Entries:    make([]models.NormTableEntry, len(norms.Entries)),
+	}
+	for i, e := range norms.Entries {
+		table.Entries[i] = models.NormTableEntry{
+			ScaledScore:       e.Score,
+			Frequency:         e.Frequency,
+			PercentileRank:    e.PercentileRank,
+			CumulativePercent: e.CumulativePercent,
+		}
+	}
+	if err := s.reportingRepo.CreateNormTable(ctx, table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// ListNormTables implements ReportingService
+func (s *reportingService) ListNormTables(ctx context.Context, scaleID uint) ([]*models.NormTable, error) {
+	return s.reportingRepo.ListNormTables(ctx, scaleID)
+}
+
+// GetNormTable implements ReportingService
+func (s *reportingService) GetNormTable(ctx context.Context, tableID uint) (*models.NormTable, error) {
+	table, err := s.reportingRepo.FindNormTable(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if table == nil {
+		return nil, ErrNormTableNotFound
+	}
+	return table, nil
+}
+
+// ScoreReport implements ReportingService
+func (s *reportingService) ScoreReport(ctx context.Context, subjectID uint, theta, standardError float64) (*ScoreReport, error) {
+	reporter, err := s.subjectReporter(ctx, subjectID)
+	if err != nil || reporter == nil {
+		return nil, err
+	}
+	return reporter.report(theta, standardError), nil
+}
+
+// ReportAbilities implements ReportingService
+func (s *reportingService) ReportAbilities(ctx context.Context, abilities []*models.UserAbility) ([]*ScoreReport, error) {
+	reporters := make(map[uint]*scoreReporter)
+	reports := make([]*ScoreReport, len(abilities))
+	for i, a := range abilities {
+		reporter, err := s.cachedReporter(ctx, reporters, a.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+		if reporter != nil {
+			reports[i] = reporter.report(a.Ability, a.StandardError)
+		}
+	}
+	return reports, nil
+}
+
+// ReportEstimations implements ReportingService
+func (s *reportingService) ReportEstimations(ctx context.Context, estimations []*models.AbilityEstimation) ([]*ScoreReport, error) {
+	reporters := make(map[uint]*scoreReporter)
+	reports := make([]*ScoreReport, len(estimations))
+	for i, e := range estimations {
+		reporter, err := s.cachedReporter(ctx, reporters, e.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+		if reporter != nil {
+			reports[i] = reporter.report(e.Ability, e.StandardError)
+		}
+	}
+	return reports, nil
+}
+
+// scoreReporter 科目当前量表、常模与表现水平，量表与表现水平至少有一项
+type scoreReporter struct {
+	scale      *models.ReportingScale
+	conversion irt.ReportingScale
+	norms      *models.NormTable
+	entries    []analysis.NormEntry
+	levels     *models.PerformanceLevelSet
+}
+
+// report 换算报告分数、条件标准误与百分位等级，并划分表现水平
+func (r *scoreReporter) report(theta, standardError float64) *ScoreReport {
+	report := &ScoreReport{}
+	if r.scale != nil {
+		score := r.conversion.Score(theta)
+		sem := r.conversion.StandardError(theta, standardError)
+		report.ScaleID = r.scale.ID
+		report.ScaleVersion = r.scale.Version
+		report.ScaledScore = &score
+		report.ScaledSEM = &sem
+		if r.norms != nil {
+			rank := analysis.PercentileRank(r.entries, score)
+			report.NormTableID = r.norms.ID
+			report.NormVersion = r.norms.Version
+			report.PercentileRank = &rank
+		}
+	}
+	if r.levels != nil {
+		if level := r.levels.Classify(theta); level != nil {
+			report.PerformanceLevel = level.Name
+		}
+		report.LevelSetID = r.levels.ID
+		report.LevelSetVersion = r.levels.Version
+	}
+	return report
+}
+
+// subjectReporter 读取科目当前量表、常模与表现水平，均未配置时返回 nil
+func (s *reportingService) subjectReporter(ctx context.Context, subjectID uint) (*scoreReporter, error) {
+	scale, err := s.reportingRepo.FindActiveScale(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	levels, err := s.performanceLevelRepo.FindActiveSet(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if scale == nil && levels == nil {
+		return nil, nil
+	}
+	reporter := &scoreReporter{scale: scale, levels: levels}
+	if scale == nil {
+		return reporter, nil
+	}
+	norms, err := s.reportingRepo.FindActiveNormTable(ctx, scale.ID)
+	if err != nil {
+		return nil, err
+	}
+	reporter.conversion = scale.IRTScale()
+	reporter.norms = norms
+	if norms != nil {
+		reporter.entries = norms.NormEntries()
+	}
+	return reporter, nil
+}
+
+// cachedReporter 批量报告时每个科目只读取一次量表与常模
+func (s *reportingService) cachedReporter(ctx context.Context, reporters map[uint]*scoreReporter, subjectID uint) (*scoreReporter, error) {
+	if reporter, ok := reporters[subjectID]; ok {
+		return reporter, nil
+	}
+	reporter, err := s.subjectReporter(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	reporters[subjectID] = reporter
+	return reporter, nil
+}
@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+var ErrResponseTimesNotCalibrated = errors.New("response time parameters have not been calibrated")
+
+// ResponseTimeService 作答时间建模服务接口
+type ResponseTimeService interface {
+	// Calibrate 标定科目题目的对数正态作答时间参数，并估计考生能力与速度的联合分布；
+	// dryRun 为 true 时只返回结果，不写回数据库
+	Calibrate(ctx context.Context, subjectID uint, dryRun bool) (*ResponseTimeCalibration, error)
+	// Speededness 用已标定的时间参数检测快速猜测和赶时间的考生与测验段
+	Speededness(ctx context.Context, subjectID uint) (*SpeedednessReport, error)
+}
+
+// ResponseTimeCalibration 一次作答时间标定的结果
+type ResponseTimeCalibration struct {
+	SubjectID  uint               `json:"subject_id"`
+	Items      []ResponseTimeItem `json:"items"`
+	Population PopulationReport   `json:"population"`
+	Examinees  []*ExamineeSpeed   `json:"examinees"`
+	Iterations int                `json:"iterations"`
+	Converged  bool               `json:"converged"`
+}
+
+// ResponseTimeItem 单题时间参数
+type ResponseTimeItem struct {
+	QuestionID       uint    `json:"question_id"`
+	Intensity        float64 `json:"intensity"`
+	Discrimination   float64 `json:"discrimination"`
+	IntensitySE      float64 `json:"intensity_se"`
+	DiscriminationSE float64 `json:"discrimination_se"`
+	ExpectedSeconds  float64 `json:"expected_seconds"` // 平均速度考生的期望用时
+	ResponseCount    int     `json:"response_count"`
+	Skipped          bool    `json:"skipped"`
+}
+
+// PopulationReport 考生能力与速度的二元正态总体分布
+type PopulationReport struct {
+	MeanTheta   float64 `json:"mean_theta"`
+	SDTheta     float64 `json:"sd_theta"`
+	MeanSpeed   float64 `json:"mean_speed"`
+	SDSpeed     float64 `json:"sd_speed"`
+	Correlation float64 `json:"correlation"`
+}
+
+// ExamineeSpeed 单个考生（考试记录或自适应会话）的能力与速度估计，
+// JointTheta 以速度为附属信息，先验取给定速度时能力的条件分布
+type ExamineeSpeed struct {
+	Examinee     string  `json:"examinee"`
+	Theta        float64 `json:"theta"`
+	ThetaSE      float64 `json:"theta_se"`
+	Speed        float64 `json:"speed"`
+	SpeedSE      float64 `json:"speed_se"`
+	JointTheta   float64 `json:"joint_theta"`
+	JointThetaSE float64 `json:"joint_theta_se"`
+}
+
+// SpeedednessReport 科目快速猜测与赶时间检测报告
+type SpeedednessReport struct {
+	SubjectID    uint                       `json:"subject_id"`
+	RapidGuesses []RapidGuessReport         `json:"rapid_guesses"`
+	Persons      []PersonSpeedednessReport  `json:"persons"`
+	Sections     []SectionSpeedednessReport `json:"sections"`
+}
+
+// SectionSpeedednessReport 按施测位置均分的测验段的赶时间情况
+type SectionSpeedednessReport struct {
+	Section      int     `json:"section"`
+	Responses    int     `json:"responses"`
+	RapidGuesses int     `json:"rapid_guesses"`
+	RapidRate    float64 `json:"rapid_rate"`
+	MeanResidual float64 `json:"mean_residual"`
+	Speeded      bool    `json:"speeded"`
+}
+
+// RapidGuessReport 一次快速猜测作答
+type RapidGuessReport struct {
+	Examinee   string  `json:"examinee"`
+	Position   int     `json:"position"`
+	QuestionID uint    `json:"question_id"`
+	Seconds    float64 `json:"seconds"`
+	Threshold  float64 `json:"threshold"`
+}
+
+// PersonSpeedednessReport 单个考生的作答努力度与末段加速情况
+type PersonSpeedednessReport struct {
+	Examinee     string  `json:"examinee"`
+	Speed        float64 `json:"speed"`
+	Responses    int     `json:"responses"`
+	RapidGuesses int     `json:"rapid_guesses"`
+	Effort       float64 `json:"effort"`
+	SpeedShift   float64 `json:"speed_shift"`
+	PValue       float64 `json:"p_value"`
+	LowEffort    bool    `json:"low_effort"`
+	Speeded      bool    `json:"speeded"`
+}
+
+// NewResponseTimeService creates a new response time service instance
+func NewResponseTimeService(responseTimeRepo repositories.ResponseTimeRepository, abilityRepo repositories.AbilityRepository, subjectRepo repositories.SubjectRepository) ResponseTimeService {
+	return &responseTimeService{
+		responseTimeRepo: responseTimeRepo,
+		abilityRepo:      abilityRepo,
+		subjectRepo:      subjectRepo,
+	}
+}
+
+type responseTimeService struct {
+	responseTimeRepo repositories.ResponseTimeRepository
+	abilityRepo      repositories.AbilityRepository
+	subjectRepo      repositories.SubjectRepository
+}
+
+// timedExaminee 一名考生按施测顺序排列的带用时作答
+type timedExaminee struct {
+	key       string
+	responses []*models.TimedResponse
+}
+
+// Calibrate implements ResponseTimeService
+func (s *responseTimeService) Calibrate(ctx context.Context, subjectID uint, dryRun bool) (*ResponseTimeCalibration, error) {
+	model, err := subjectModel(ctx, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.responseTimeRepo.ListTimedResponses(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	examinees, questionIDs := groupTimedResponses(rows)
+
+	// 同一考生重复作答同一题时以最后一次为准
+	columns := make(map[uint]int, len(questionIDs))
+	for j, id := range questionIDs {
+		columns[id] = j
+	}
+	matrix := &irt.TimeMatrix{QuestionIDs: questionIDs, Seconds: make([][]float64, len(examinees))}
+	for i, e := range examinees {
+		row := make([]float64, len(questionIDs))
+		for j := range row {
+			row[j] = math.NaN()
+		}
+		for _, r := range e.responses {
+			row[columns[r.QuestionID]] = r.Seconds
+		}
+		matrix.Seconds[i] = row
+	}
+	calibration, err := irt.CalibrateResponseTimes(matrix, irt.DefaultTimeCalibrationConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	// 计分题能力估计，与速度一起估计考生层的二元正态分布
+	params, err := s.abilityRepo.ListQuestionParameters(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	paramsByQuestion := make(map[uint]*models.QuestionParameter, len(params))
+	for _, p := range params {
+		paramsByQuestion[p.QuestionID] = p
+	}
+	estimator := irt.NewEstimator(irt.MethodEAP)
+	estimator.Model = model
+
+	rowsUsed := make([]int, 0, len(examinees))
+	operational := make([][]irt.ItemResponse, 0, len(examinees))
+	abilities := make([]*irt.AbilityEstimate, 0, len(examinees))
+	speeds := make([]irt.SpeedEstimate, 0, len(examinees))
+	for i, e := range examinees {
+		scored := make([]*models.PretestResponse, 0, len(e.responses))
+		for _, r := range e.responses {
+			if !r.Pretest {
+				scored = append(scored, &r.PretestResponse)
+			}
+		}
+		items := operationalResponses(scored, paramsByQuestion)
+		if len(items) == 0 {
+			continue
+		}
+		estimate, err := estimator.Estimate(items)
+		if err != nil {
+			return nil, err
+		}
+		rowsUsed = append(rowsUsed, i)
+		operational = append(operational, items)
+		abilities = append(abilities, estimate)
+		speeds = append(speeds, calibration.Speeds[i])
+	}
+	population := irt.EstimatePopulation(abilities, speeds)
+
+	result := &ResponseTimeCalibration{
+		SubjectID: subjectID,
+		Items:     make([]ResponseTimeItem, len(calibration.Items)),
+		Population: PopulationReport{
+			MeanTheta:   population.MeanTheta,
+			SDTheta:     population.SDTheta,
+			MeanSpeed:   population.MeanSpeed,
+			SDSpeed:     population.SDSpeed,
+			Correlation: population.Correlation,
+		},
+		Examinees:  make([]*ExamineeSpeed, len(rowsUsed)),
+		Iterations: calibration.Iterations,
+		Converged:  calibration.Converged,
+	}
+	for k, i := range rowsUsed {
+		joint, err := population.JointEstimator(irt.MethodEAP, model, speeds[k]).Estimate(operational[k])
+		if err != nil {
+			return nil, err
+		}
+		result.Examinees[k] = &ExamineeSpeed{
+			Examinee:     examinees[i].key,
+			Theta:        abilities[k].Theta,
+			ThetaSE:      abilities[k].StandardError,
+			Speed:        speeds[k].Tau,
+			SpeedSE:      speeds[k].StandardError,
+			JointTheta:   joint.Theta,
+			JointThetaSE: joint.StandardError,
+		}
+	}
+
+	now := time.Now()
+	saved := make([]*models.ResponseTimeParameter, 0, len(calibration.Items))
+	for j, item := range calibration.Items {
+		result.Items[j] = ResponseTimeItem{
+			QuestionID:       item.QuestionID,
+			Intensity:        item.Intensity,
+			Discrimination:   item.Discrimination,
+			IntensitySE:      item.IntensitySE,
+			DiscriminationSE: item.DiscriminationSE,
+			ResponseCount:    item.ResponseCount,
+			Skipped:          item.Skipped,
+		}
+		if item.Skipped {
+			continue
+		}
+		result.Items[j].ExpectedSeconds = item.Params().ExpectedSeconds(0)
+		saved = append(saved, &models.ResponseTimeParameter{
+			QuestionID:       item.QuestionID,
+			SubjectID:        subjectID,
+			Intensity:        item.Intensity,
+			Discrimination:   item.Discrimination,
+			IntensitySE:      item.IntensitySE,
+			DiscriminationSE: item.DiscriminationSE,
+			ResponseCount:    item.ResponseCount,
+			CalibratedAt:     &now,
+		})
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	err = s.responseTimeRepo.SaveCalibration(ctx, saved, &models.SpeedPopulation{
+		SubjectID:    subjectID,
+		MeanTheta:    population.MeanTheta,
+		SDTheta:      population.SDTheta,
+		MeanSpeed:    population.MeanSpeed,
+		SDSpeed:      population.SDSpeed,
+		Correlation:  population.Correlation,
+		Examinees:    len(rowsUsed),
+		CalibratedAt: &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Speededness implements ResponseTimeService
+func (s *responseTimeService) Speededness(ctx context.Context, subjectID uint) (*SpeedednessReport, error) {
+	rows, err := s.responseTimeRepo.ListTimedResponses(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	examinees, questionIDs := groupTimedResponses(rows)
+
+	params, err := s.responseTimeRepo.ListParameters(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(params) == 0 {
+		return nil, ErrResponseTimesNotCalibrated
+	}
+	timeParams := make(map[uint]irt.TimeParams, len(params))
+	for _, p := range params {
+		timeParams[p.QuestionID] = irt.TimeParams{Intensity: p.Intensity, Discrimination: p.Discrimination}
+	}
+	population, err := s.responseTimeRepo.FindPopulation(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	priorMean, priorSD := 0.0, 1.0
+	if population != nil {
+		priorMean, priorSD = population.MeanSpeed, population.SDSpeed
+	}
+
+	sequences := make([]analysis.TimedSequence, len(examinees))
+	for i, e := range examinees {
+		responses := make([]irt.TimedResponse, len(e.responses))
+		for k, r := range e.responses {
+			responses[k] = irt.TimedResponse{QuestionID: r.QuestionID, Item: timeParams[r.QuestionID], Seconds: r.Seconds}
+		}
+		sequences[i] = analysis.TimedSequence{
+			Speed:     irt.EstimateSpeed(responses, priorMean, priorSD).Tau,
+			Responses: responses,
+		}
+	}
+	detected := analysis.Speededness(sequences, analysis.DefaultSpeedednessConfig())
+
+	report := &SpeedednessReport{
+		SubjectID:    subjectID,
+		RapidGuesses: make([]RapidGuessReport, len(detected.RapidGuesses)),
+		Persons:      make([]PersonSpeedednessReport, len(detected.Persons)),
+		Sections:     make([]SectionSpeedednessReport, len(detected.Sections)),
+	}
+	for i, flag := range detected.RapidGuesses {
+		report.RapidGuesses[i] = RapidGuessReport{
+			Examinee:   examinees[flag.Row].key,
+			Position:   flag.Position,
+			QuestionID: flag.QuestionID,
+			Seconds:    flag.Seconds,
+			Threshold:  flag.Threshold,
+		}
+	}
+	for i, section := range detected.Sections {
+		report.Sections[i] = SectionSpeedednessReport{
+			Section:      section.Section,
+			Responses:    section.Responses,
+			RapidGuesses: section.RapidGuesses,
+			RapidRate:    section.RapidRate,
+			MeanResidual: section.MeanResidual,
+			Speeded:      section.Speeded,
+		}
+	}
+	for i, person := range detected.Persons {
+		report.Persons[i] = PersonSpeedednessReport{
+			Examinee:     examinees[person.Row].key,
+			Speed:        sequences[person.Row].Speed,
+			Responses:    person.Responses,
+			RapidGuesses: person.RapidGuesses,
+			Effort:       person.Effort,
+			SpeedShift:   person.SpeedShift,
+			PValue:       person.PValue,
+			LowEffort:    person.LowEffort,
+			Speeded:      person.Speeded,
+		}
+	}
+	return report, nil
+}
+
+// groupTimedResponses 按考生归并作答并列出出现过的题目
+func groupTimedResponses(rows []*models.TimedResponse) ([]*timedExaminee, []uint) {
+	examinees := make([]*timedExaminee, 0)
+	index := make(map[string]int)
+	questionIDs := make([]uint, 0)
+	seen := make(map[uint]bool)
+	for _, row := range rows {
+		i, ok := index[row.Examinee]
+		if !ok {
+			i = len(examinees)
+			index[row.Examinee] = i
+			examinees = append(examinees, &timedExaminee{key: row.Examinee})
+		}
+		examinees[i].responses = append(examinees[i].responses, row)
+		if !seen[row.QuestionID] {
+			seen[row.QuestionID] = true
+			questionIDs = append(questionIDs, row.QuestionID)
+		}
+	}
+	return examinees, questionIDs
+}
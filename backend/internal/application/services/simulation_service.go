@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+)
+
+var ErrNoCalibratedItems = errors.New("subject has no calibrated operational questions")
+
+// SimulationService 自适应测验模拟服务接口
+type SimulationService interface {
+	// SimulateCAT 以科目已标定的题目参数为题库模拟自适应测验，曝光控制与能力估计与线上一致；
+	// paperID 非0时选题策略、终止规则与内容蓝图取自该试卷的自适应配置，否则使用 cfg 中的设置
+	SimulateCAT(ctx context.Context, subjectID, paperID uint, cfg irt.CATSimulationConfig) (*irt.CATSimulationResult, error)
+}
+
+// NewSimulationService creates a new CAT simulation service instance
+func NewSimulationService(
+	abilityRepo repositories.AbilityRepository,
+	questionRepo repositories.QuestionRepository,
+	examRepo repositories.ExamRepository,
+	blueprintRepo repositories.BlueprintRepository,
+	exposureRepo repositories.ExposureRepository,
+	subjectRepo repositories.SubjectRepository,
+) SimulationService {
+	return &simulationService{
+		abilityRepo:   abilityRepo,
+		questionRepo:  questionRepo,
+		examRepo:      examRepo,
+		blueprintRepo: blueprintRepo,
+		exposureRepo:  exposureRepo,
+		subjectRepo:   subjectRepo,
+	}
+}
+
+type simulationService struct {
+	abilityRepo   repositories.AbilityRepository
+	questionRepo  repositories.QuestionRepository
+	examRepo      repositories.ExamRepository
+	blueprintRepo repositories.BlueprintRepository
+	exposureRepo  repositories.ExposureRepository
+	subjectRepo   repositories.SubjectRepository
+}
+
+// SimulateCAT implements SimulationService
+func (s *simulationService) SimulateCAT(ctx context.Context, subjectID, paperID uint, cfg irt.CATSimulationConfig) (*irt.CATSimulationResult, error) {
+	model, err := subjectModel(ctx, s.subjectRepo, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Model = model
+
+	bank, err := s.calibratedBank(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bank) == 0 {
+		return nil, ErrNoCalibratedItems
+	}
+
+	if paperID != 0 {
+		paper, err := s.examRepo.FindPaperByID(ctx, paperID)
+		if err != nil {
+			return nil, err
+		}
+		if paper == nil {
+			return nil, ErrPaperNotFound
+		}
+		if paper.SubjectID != subjectID {
+			return nil, ErrPaperSubjectMismatch
+		}
+		cfg.Strategy = irt.SelectionStrategy(paper.SelectionStrategy)
+		cfg.Stopping = paper.StoppingRule(model)
+		blueprint, err := s.blueprintRepo.FindByPaperID(ctx, paperID)
+		if err != nil {
+			return nil, err
+		}
+		if blueprint != nil {
+			cfg.Blueprint = blueprint.IRTBlueprint()
+		}
+	}
+
+	// 曝光控制与线上选题一致，取科目配置
+	setting, err := s.exposureRepo.FindSetting(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if setting != nil {
+		cfg.ExposureMethod = irt.ExposureMethod(setting.Method)
+		cfg.RandomesqueSize = setting.RandomesqueSize
+		if cfg.ExposureMethod == irt.ExposureSympsonHetter {
+			exposures, err := s.exposureRepo.ListSubjectExposures(ctx, subjectID)
+			if err != nil {
+				return nil, err
+			}
+			cfg.ExposureParameters = make(map[uint]float64, len(exposures))
+			for _, e := range exposures {
+				cfg.ExposureParameters[e.QuestionID] = e.ExposureParameter
+			}
+		}
+	}
+	return irt.SimulateCAT(bank, cfg)
+}
+
+// calibratedBank 科目计分题中已有标定参数的题目，连同知识点与题型属性转换为选题候选
+func (s *simulationService) calibratedBank(ctx context.Context, subjectID uint) ([]irt.Candidate, error) {
+	questions, err := s.questionRepo.ListCandidates(ctx, subjectID, nil)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+	}
+	params, err := s.abilityRepo.ListQuestionParameters(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.questionRepo.ListKnowledgePointLinks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	pointsByQuestion := make(map[uint][]uint, len(questions))
+	for _, link := range links {
+		pointsByQuestion[link.QuestionID] = append(pointsByQuestion[link.QuestionID], link.KnowledgePointID)
+	}
+	types := make(map[uint]string, len(questions))
+	for _, q := range questions {
+		types[q.ID] = q.Type
+	}
+
+	bank := make([]irt.Candidate, 0, len(params))
+	for _, p := range params {
+		candidate := irt.Candidate{
+			QuestionID:        p.QuestionID,
+			Difficulty:        p.Difficulty,
+			Discrimination:    p.Discrimination,
+			Guessing:          p.Guessing,
+			UpperAsymptote:    p.UpperAsymptote,
+			KnowledgePointIDs: pointsByQuestion[p.QuestionID],
+			QuestionType:      types[p.QuestionID],
+		}
+		if model := irt.ItemModel(p.ItemModel); model.Polytomous() {
+			candidate.Model = model
+			for _, c := range p.Categories {
+				candidate.Thresholds = append(candidate.Thresholds, c.Threshold)
+			}
+		}
+		bank = append(bank, candidate)
+	}
+	return bank, nil
+}
@@ -0,0 +1,454 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+var (
+	ErrSessionNotFound       = errors.New("standard setting session not found")
+	ErrSessionClosed         = errors.New("standard setting session is closed")
+	ErrTooFewLevels          = errors.New("at least two performance levels are required")
+	ErrInvalidBoundary       = errors.New("boundary must be between 1 and the number of levels minus one")
+	ErrQuestionNotOnPaper    = errors.New("question is not an operational question of the session paper")
+	ErrDisorderedCutScores   = errors.New("cut scores must increase with the performance levels")
+	ErrInvalidBookmarkPage   = errors.New("bookmark page must be a page of the ordered item booklet")
+	ErrInvalidAngoffRating   = errors.New("angoff rating must be a probability between 0 and 1")
+	ErrNoOperationalQuestion = errors.New("exam paper has no operational questions")
+)
+
+// StandardSettingService 标准设定与表现水平服务接口
+type StandardSettingService interface {
+	// 会议
+	// CreateSession 针对试卷新建标准设定会议，科目取自试卷
+	CreateSession(ctx context.Context, session *models.StandardSettingSession) (*models.StandardSettingSession, error)
+	GetSession(ctx context.Context, sessionID uint) (*models.StandardSettingSession, error)
+	ListSessions(ctx context.Context, subjectID uint) ([]*models.StandardSettingSession, error)
+	// Booklet 按 RP 位置由易到难排列的试卷题册，供书签法评委使用
+	Booklet(ctx context.Context, sessionID uint) ([]*BookletPageReport, error)
+	// SubmitRatings 评委提交当前轮次的评定，重复提交覆盖原值
+	SubmitRatings(ctx context.Context, sessionID, panelistID uint, ratings []SubmittedRating) error
+	// NextRound 结束当前轮次的讨论，进入下一轮评定
+	NextRound(ctx context.Context, sessionID uint) (*models.StandardSettingSession, error)
+	// Results 计算指定轮次（0为当前轮次）的划界分数与影响分析
+	Results(ctx context.Context, sessionID uint, round int) (*StandardSettingReport, error)
+	// Adopt 将会议当前轮次的划界分数保存为科目新版本的表现水平并结束会议
+	Adopt(ctx context.Context, sessionID uint, name string) (*models.PerformanceLevelSet, error)
+
+	// 表现水平
+	// CreateLevelSet 手工设定科目新版本的表现水平
+	CreateLevelSet(ctx context.Context, set *models.PerformanceLevelSet) (*models.PerformanceLevelSet, error)
+	ListLevelSets(ctx context.Context, subjectID uint) ([]*models.PerformanceLevelSet, error)
+}
+
+// SubmittedRating 评委提交的一条评定：Angoff 法给出 QuestionID 与答对概率，书签法给出页码
+type SubmittedRating struct {
+	Boundary   int
+	QuestionID uint
+	Value      float64
+}
+
+// BookletPageReport 排序题册中的一页
+type BookletPageReport struct {
+	Page       int     `json:"page"`
+	QuestionID uint    `json:"question_id"`
+	Location   float64 `json:"location"`
+}
+
+// StandardSettingReport 标准设定结果与影响分析
+type StandardSettingReport struct {
+	SessionID uint                 `json:"session_id"`
+	Method    string               `json:"method"`
+	Round     int                  `json:"round"`
+	Ordered   bool                 `json:"ordered"` // 划界分数是否随水平严格递增，递增时才能采用
+	Cuts      []*CutScoreReport    `json:"cuts"`
+	Panelists []*PanelistCutReport `json:"panelists"`
+	Impact    []*LevelImpactReport `json:"impact"` // 科目全部考生按划界分数的水平分布
+}
+
+// CutScoreReport 一个分界点的划界分数
+type CutScoreReport struct {
+	Boundary  int     `json:"boundary"`
+	Level     string  `json:"level"` // 分界点之上的水平
+	Panelists int     `json:"panelists"`
+	Theta     float64 `json:"theta"`
+	RawScore  float64 `json:"raw_score"`
+	ThetaSD   float64 `json:"theta_sd"`
+	ThetaSE   float64 `json:"theta_se"`
+	MinTheta  float64 `json:"min_theta"`
+	MaxTheta  float64 `json:"max_theta"`
+}
+
+// PanelistCutReport 单个评委的划界分数，供下一轮讨论反馈
+type PanelistCutReport struct {
+	PanelistID uint    `json:"panelist_id"`
+	Boundary   int     `json:"boundary"`
+	RawScore   float64 `json:"raw_score"`
+	Theta      float64 `json:"theta"`
+}
+
+// LevelImpactReport 一个表现水平的影响分析
+type LevelImpactReport struct {
+	Level   string  `json:"level"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// NewStandardSettingService creates a new standard setting service instance
+func NewStandardSettingService(
+	standardSettingRepo repositories.StandardSettingRepository,
+	performanceLevelRepo repositories.PerformanceLevelRepository,
+	abilityRepo repositories.AbilityRepository,
+	subjectRepo repositories.SubjectRepository,
+) StandardSettingService {
+	return &standardSettingService{
+		standardSettingRepo:  standardSettingRepo,
+		performanceLevelRepo: performanceLevelRepo,
+		abilityRepo:          abilityRepo,
+		subjectRepo:          subjectRepo,
+	}
+}
+
+type standardSettingService struct {
+	standardSettingRepo  repositories.StandardSettingRepository
+	performanceLevelRepo repositories.PerformanceLevelRepository
+	abilityRepo          repositories.AbilityRepository
+	subjectRepo          repositories.SubjectRepository
+}
+
+// CreateSession implements StandardSettingService
+func (s *standardSettingService) CreateSession(ctx context.Context, session *models.StandardSettingSession) (*models.StandardSettingSession, error) {
+	switch analysis.StandardSettingMethod(session.Method) {
+	case analysis.StandardSettingAngoff, analysis.StandardSettingBookmark:
+	default:
+		return nil, analysis.ErrUnknownStandardSettingMethod
+	}
+	if len(session.Levels) < 2 {
+		return nil, ErrTooFewLevels
+	}
+	paper, err := s.standardSettingRepo.FindPaper(ctx, session.ExamPaperID)
+	if err != nil {
+		return nil, err
+	}
+	if paper == nil {
+		return nil, ErrPaperNotFound
+	}
+	session.SubjectID = paper.SubjectID
+	session.Round = 1
+	session.Status = "open"
+	if session.ResponseProbability <= 0 || session.ResponseProbability >= 1 {
+		session.ResponseProbability = analysis.DefaultStandardSettingConfig().ResponseProbability
+	}
+	for i := range session.Levels {
+		session.Levels[i].Order = i
+	}
+	if err := s.standardSettingRepo.CreateSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSession implements StandardSettingService
+func (s *standardSettingService) GetSession(ctx context.Context, sessionID uint) (*models.StandardSettingSession, error) {
+	session, err := s.standardSettingRepo.FindSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+// ListSessions implements StandardSettingService
+func (s *standardSettingService) ListSessions(ctx context.Context, subjectID uint) ([]*models.StandardSettingSession, error) {
+	return s.standardSettingRepo.ListSessions(ctx, subjectID)
+}
+
+// Booklet implements StandardSettingService
+func (s *standardSettingService) Booklet(ctx context.Context, sessionID uint) ([]*BookletPageReport, error) {
+	session, err := s.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	items, cfg, err := s.sessionItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	pages := analysis.OrderedItemBooklet(cfg.Model, items, cfg.ResponseProbability, cfg.MinTheta, cfg.MaxTheta)
+	booklet := make([]*BookletPageReport, len(pages))
+	for k, page := range pages {
+		booklet[k] = &BookletPageReport{
+			Page:       page.Page,
+			QuestionID: items[page.Item].QuestionID,
+			Location:   page.Location,
+		}
+	}
+	return booklet, nil
+}
+
+// SubmitRatings implements StandardSettingService
+func (s *standardSettingService) SubmitRatings(ctx context.Context, sessionID, panelistID uint, ratings []SubmittedRating) error {
+	session, err := s.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.Status != "open" {
+		return ErrSessionClosed
+	}
+	items, _, err := s.sessionItems(ctx, session)
+	if err != nil {
+		return err
+	}
+	onPaper := make(map[uint]bool, len(items))
+	for _, item := range items {
+		onPaper[item.QuestionID] = true
+	}
+
+	records := make([]*models.StandardSettingRating, 0, len(ratings))
+	for _, r := range ratings {
+		if r.Boundary < 1 || r.Boundary >= len(session.Levels) {
+			return ErrInvalidBoundary
+		}
+		record := &models.StandardSettingRating{
+			SessionID:  sessionID,
+			PanelistID: panelistID,
+			Round:      session.Round,
+			Boundary:   r.Boundary,
+			Value:      r.Value,
+		}
+		if analysis.StandardSettingMethod(session.Method) == analysis.StandardSettingBookmark {
+			if r.Value != math.Trunc(r.Value) || r.Value < 1 || int(r.Value) > len(items) {
+				return ErrInvalidBookmarkPage
+			}
+		} else {
+			if !onPaper[r.QuestionID] {
+				return ErrQuestionNotOnPaper
+			}
+			if r.Value < 0 || r.Value > 1 {
+				return ErrInvalidAngoffRating
+			}
+			record.QuestionID = r.QuestionID
+		}
+		records = append(records, record)
+	}
+	return s.standardSettingRepo.SaveRatings(ctx, records)
+}
+
+// NextRound implements StandardSettingService
+func (s *standardSettingService) NextRound(ctx context.Context, sessionID uint) (*models.StandardSettingSession, error) {
+	session, err := s.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != "open" {
+		return nil, ErrSessionClosed
+	}
+	session.Round++
+	if err := s.standardSettingRepo.UpdateSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Results implements StandardSettingService
+func (s *standardSettingService) Results(ctx context.Context, sessionID uint, round int) (*StandardSettingReport, error) {
+	session, err := s.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if round <= 0 {
+		round = session.Round
+	}
+	items, cfg, err := s.sessionItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := s.standardSettingRepo.ListRatings(ctx, sessionID, round)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[uint]int, len(items))
+	for j, item := range items {
+		columns[item.QuestionID] = j
+	}
+	panel := make([]analysis.PanelRating, 0, len(ratings))
+	for _, r := range ratings {
+		rating := analysis.PanelRating{Panelist: r.PanelistID, Boundary: r.Boundary - 1, Value: r.Value}
+		if cfg.Method == analysis.StandardSettingAngoff {
+			j, ok := columns[r.QuestionID]
+			if !ok {
+				// 评定后题目被移出试卷
+				continue
+			}
+			rating.Item = j
+		}
+		panel = append(panel, rating)
+	}
+	result, err := analysis.SetStandards(items, panel, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := session.OrderedLevels()
+	report := &StandardSettingReport{
+		SessionID: session.ID,
+		Method:    session.Method,
+		Round:     round,
+		Ordered:   result.Ordered,
+		Cuts:      make([]*CutScoreReport, len(result.Cuts)),
+		Panelists: make([]*PanelistCutReport, len(result.Panelists)),
+	}
+	cuts := make([]float64, len(result.Cuts))
+	for b, cut := range result.Cuts {
+		cuts[b] = cut.Theta
+		report.Cuts[b] = &CutScoreReport{
+			Boundary:  cut.Boundary + 1,
+			Level:     levels[cut.Boundary+1],
+			Panelists: cut.Panelists,
+			Theta:     cut.Theta,
+			RawScore:  cut.RawScore,
+			ThetaSD:   cut.ThetaSD,
+			ThetaSE:   cut.ThetaSE,
+			MinTheta:  cut.MinTheta,
+			MaxTheta:  cut.MaxTheta,
+		}
+	}
+	for i, p := range result.Panelists {
+		report.Panelists[i] = &PanelistCutReport{
+			PanelistID: p.Panelist,
+			Boundary:   p.Boundary + 1,
+			RawScore:   p.RawScore,
+			Theta:      p.Theta,
+		}
+	}
+
+	thetas, err := s.standardSettingRepo.ListSubjectThetas(ctx, session.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, impact := range analysis.ImpactAnalysis(thetas, cuts) {
+		report.Impact = append(report.Impact, &LevelImpactReport{
+			Level:   levels[impact.Level],
+			Count:   impact.Count,
+			Percent: impact.Percent,
+		})
+	}
+	return report, nil
+}
+
+// Adopt implements StandardSettingService
+func (s *standardSettingService) Adopt(ctx context.Context, sessionID uint, name string) (*models.PerformanceLevelSet, error) {
+	report, err := s.Results(ctx, sessionID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !report.Ordered {
+		return nil, ErrDisorderedCutScores
+	}
+	session, err := s.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != "open" {
+		return nil, ErrSessionClosed
+	}
+
+	set := &models.PerformanceLevelSet{
+		SubjectID: session.SubjectID,
+		Name:      name,
+		SessionID: &session.ID,
+	}
+	for k, level := range session.OrderedLevels() {
+		performanceLevel := models.PerformanceLevel{Order: k, Name: level}
+		if k > 0 {
+			cut := report.Cuts[k-1].Theta
+			performanceLevel.CutScore = &cut
+		}
+		set.Levels = append(set.Levels, performanceLevel)
+	}
+	if err := s.performanceLevelRepo.CreateSet(ctx, set); err != nil {
+		return nil, err
+	}
+
+	session.Status = "closed"
+	if err := s.standardSettingRepo.UpdateSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// CreateLevelSet implements StandardSettingService
+func (s *standardSettingService) CreateLevelSet(ctx context.Context, set *models.PerformanceLevelSet) (*models.PerformanceLevelSet, error) {
+	if len(set.Levels) < 2 {
+		return nil, ErrTooFewLevels
+	}
+	subject, err := s.subjectRepo.FindByID(ctx, set.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	if subject == nil {
+		return nil, ErrSubjectNotFound
+	}
+	// 水平按给出的顺序由低到高，最低水平没有划界分数
+	for k := range set.Levels {
+		set.Levels[k].Order = k
+		if k == 0 {
+			set.Levels[k].CutScore = nil
+			continue
+		}
+		cut := set.Levels[k].CutScore
+		if cut == nil || (k > 1 && *cut <= *set.Levels[k-1].CutScore) {
+			return nil, ErrDisorderedCutScores
+		}
+	}
+	set.SessionID = nil
+	if err := s.performanceLevelRepo.CreateSet(ctx, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// ListLevelSets implements StandardSettingService
+func (s *standardSettingService) ListLevelSets(ctx context.Context, subjectID uint) ([]*models.PerformanceLevelSet, error) {
+	return s.performanceLevelRepo.ListSets(ctx, subjectID)
+}
+
+// sessionItems 会议试卷的计分题参数与标准设定配置
+func (s *standardSettingService) sessionItems(ctx context.Context, session *models.StandardSettingSession) ([]irt.TestItem, analysis.StandardSettingConfig, error) {
+	cfg := analysis.DefaultStandardSettingConfig()
+	model, err := subjectModel(ctx, s.subjectRepo, session.SubjectID)
+	if err != nil {
+		return nil, cfg, err
+	}
+	cfg.Model = model
+	cfg.Method = analysis.StandardSettingMethod(session.Method)
+	cfg.Boundaries = len(session.Levels) - 1
+	cfg.ResponseProbability = session.ResponseProbability
+
+	questions, err := s.standardSettingRepo.ListPaperQuestions(ctx, session.ExamPaperID)
+	if err != nil {
+		return nil, cfg, err
+	}
+	operational := make([]*models.ExamPaperQuestion, 0, len(questions))
+	for _, q := range questions {
+		if !q.Pretest && !q.Question.Pretest {
+			operational = append(operational, q)
+		}
+	}
+	if len(operational) == 0 {
+		return nil, cfg, ErrNoOperationalQuestion
+	}
+	items, err := paperTestItems(ctx, s.abilityRepo, operational)
+	if err != nil {
+		return nil, cfg, err
+	}
+	return items, cfg, nil
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+)
+
+// StatisticsService 试卷经典测量理论统计服务接口
+type StatisticsService interface {
+	// PaperStatistics 由试卷已交卷考试记录的作答计算题目难度、区分度与测验信度，试测题不参与统计
+	PaperStatistics(ctx context.Context, paperID uint) (*PaperStatistics, error)
+	// PaperDistractors 统计试卷选择类题目各选项在不同能力分位组的选择比例，标记无效干扰项与疑似答案标错的题目
+	PaperDistractors(ctx context.Context, paperID uint, cfg analysis.DistractorConfig) (*DistractorReport, error)
+}
+
+// PaperStatistics 试卷题目与测验的经典统计量，总分为答对题数
+type PaperStatistics struct {
+	ExamPaperID  uint                    `json:"exam_paper_id"`
+	Title        string                  `json:"title"`
+	Examinees    int                     `json:"examinees"`
+	ItemCount    int                     `json:"item_count"`
+	Mean         float64                 `json:"mean"`
+	SD           float64                 `json:"sd"`
+	Min          int                     `json:"min"`
+	Max          int                     `json:"max"`
+	Alpha        float64                 `json:"alpha"` // KR-20
+	SEM          float64                 `json:"sem"`
+	Items        []*ItemStatistics       `json:"items"`
+	Distribution []*ScoreFrequencyReport `json:"score_distribution"`
+}
+
+// ItemStatistics 单题经典统计量
+type ItemStatistics struct {
+	Order          int64   `json:"order"`
+	QuestionID     uint    `json:"question_id"`
+	QuestionType   string  `json:"question_type"`
+	Answered       int     `json:"answered"`
+	PValue         float64 `json:"p_value"`
+	PointBiserial  float64 `json:"point_biserial"`
+	Biserial       float64 `json:"biserial"`
+	ItemRest       float64 `json:"item_rest"`
+	AlphaIfDeleted float64 `json:"alpha_if_deleted"`
+}
+
+// ScoreFrequencyReport 总分分布中的一个分数
+type ScoreFrequencyReport struct {
+	Score             int     `json:"score"`
+	Count             int     `json:"count"`
+	Percent           float64 `json:"percent"`
+	CumulativePercent float64 `json:"cumulative_percent"`
+}
+
+// DistractorReport 试卷干扰项分析报告，能力以计分题答对题数衡量
+type DistractorReport struct {
+	ExamPaperID      uint                        `json:"exam_paper_id"`
+	Examinees        int                         `json:"examinees"`
+	MinSelectionRate float64                     `json:"min_selection_rate"`
+	Groups           []*AbilityGroupReport       `json:"groups"`
+	Questions        []*QuestionDistractorReport `json:"questions"`
+}
+
+// AbilityGroupReport 能力分位组，由低到高编号
+type AbilityGroupReport struct {
+	Group     int `json:"group"`
+	Examinees int `json:"examinees"`
+	MinScore  int `json:"min_score"`
+	MaxScore  int `json:"max_score"`
+}
+
+// QuestionDistractorReport 单题干扰项分析
+type QuestionDistractorReport struct {
+	Order          int64                     `json:"order"`
+	QuestionID     uint                      `json:"question_id"`
+	QuestionType   string                    `json:"question_type"`
+	Answered       int                       `json:"answered"`
+	NonFunctioning int                       `json:"non_functioning"`
+	LikelyMiskey   bool                      `json:"likely_miskey"`
+	Options        []*OptionDistractorReport `json:"options"`
+}
+
+// OptionDistractorReport 单个选项的选择情况，group_rates 与 groups 一一对应
+type OptionDistractorReport struct {
+	Label          string    `json:"label"`
+	IsCorrect      bool      `json:"is_correct"`
+	Count          int       `json:"count"`
+	SelectionRate  float64   `json:"selection_rate"`
+	GroupRates     []float64 `json:"group_rates"`
+	PointBiserial  float64   `json:"point_biserial"`
+	NonFunctioning bool      `json:"non_functioning"`
+	LikelyMiskey   bool      `json:"likely_miskey"`
+}
+
+// NewStatisticsService creates a new classical statistics service instance
+func NewStatisticsService(statisticsRepo repositories.StatisticsRepository) StatisticsService {
+	return &statisticsService{
+		statisticsRepo: statisticsRepo,
+	}
+}
+
+type statisticsService struct {
+	statisticsRepo repositories.StatisticsRepository
+}
+
+// PaperStatistics implements StatisticsService
+func (s *statisticsService) PaperStatistics(ctx context.Context, paperID uint) (*PaperStatistics, error) {
+	paper, submissions, err := s.loadSubmissions(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := &irt.ResponseMatrix{RowIDs: submissions.recordIDs}
+	items := make([]*ItemStatistics, len(submissions.questions))
+	for j, pq := range submissions.questions {
+		matrix.QuestionIDs = append(matrix.QuestionIDs, pq.QuestionID)
+		items[j] = &ItemStatistics{
+			Order:        pq.Order,
+			QuestionID:   pq.QuestionID,
+			QuestionType: pq.Question.Type,
+		}
+	}
+	matrix.Responses = make([][]int, len(submissions.final))
+	for i, row := range submissions.final {
+		matrix.Responses[i] = make([]int, len(row))
+		for j, response := range row {
+			switch {
+			case response == nil:
+				matrix.Responses[i][j] = irt.Missing
+			case response.IsCorrect:
+				matrix.Responses[i][j] = 1
+			}
+		}
+	}
+
+	test, err := analysis.ClassicalStatistics(matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PaperStatistics{
+		ExamPaperID:  paperID,
+		Title:        paper.Title,
+		Examinees:    test.Examinees,
+		ItemCount:    len(items),
+		Mean:         test.Mean,
+		SD:           test.SD,
+		Min:          test.Min,
+		Max:          test.Max,
+		Alpha:        test.Alpha,
+		SEM:          test.SEM,
+		Items:        items,
+		Distribution: make([]*ScoreFrequencyReport, len(test.Distribution)),
+	}
+	for j, item := range test.Items {
+		items[j].Answered = item.Answered
+		items[j].PValue = item.PValue
+		items[j].PointBiserial = item.PointBiserial
+		items[j].Biserial = item.Biserial
+		items[j].ItemRest = item.ItemRest
+		items[j].AlphaIfDeleted = item.AlphaIfDeleted
+	}
+	for k, f := range test.Distribution {
+		result.Distribution[k] = &ScoreFrequencyReport{
+			Score:             f.Score,
+			Count:             f.Count,
+			Percent:           f.Percent,
+			CumulativePercent: f.CumulativePercent,
+		}
+	}
+	return result, nil
+}
+
+// PaperDistractors implements StatisticsService
+func (s *statisticsService) PaperDistractors(ctx context.Context, paperID uint, cfg analysis.DistractorConfig) (*DistractorReport, error) {
+	_, submissions, err := s.loadSubmissions(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 选项按顺序排列，没有选项的题目（如填空题）只计入总分
+	items := make([]analysis.DistractorItem, len(submissions.questions))
+	for j, pq := range submissions.questions {
+		options := append([]models.QuestionOption(nil), pq.Question.Options...)
+		sort.Slice(options, func(a, b int) bool { return options[a].Order < options[b].Order })
+		for _, option := range options {
+			items[j].Options = append(items[j].Options, strings.TrimSpace(option.Label))
+			items[j].Correct = append(items[j].Correct, option.IsCorrect)
+		}
+	}
+	responses := make([][]analysis.DistractorResponse, len(submissions.final))
+	for i, row := range submissions.final {
+		responses[i] = make([]analysis.DistractorResponse, len(row))
+		for j, response := range row {
+			if response == nil || strings.TrimSpace(response.UserAnswer) == "" {
+				continue
+			}
+			responses[i][j] = analysis.DistractorResponse{
+				Answered: true,
+				Correct:  response.IsCorrect,
+				Selected: selectedOptions(response.UserAnswer, items[j].Options),
+			}
+		}
+	}
+
+	result, err := analysis.Distractors(items, responses, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DistractorReport{
+		ExamPaperID:      paperID,
+		Examinees:        result.Examinees,
+		MinSelectionRate: cfg.MinSelectionRate,
+		Groups:           make([]*AbilityGroupReport, len(result.Groups)),
+		Questions:        make([]*QuestionDistractorReport, len(result.Items)),
+	}
+	for g, group := range result.Groups {
+		report.Groups[g] = &AbilityGroupReport{
+			Group:     g + 1,
+			Examinees: group.Examinees,
+			MinScore:  group.MinScore,
+			MaxScore:  group.MaxScore,
+		}
+	}
+	for k, item := range result.Items {
+		pq := submissions.questions[item.Item]
+		question := &QuestionDistractorReport{
+			Order:          pq.Order,
+			QuestionID:     pq.QuestionID,
+			QuestionType:   pq.Question.Type,
+			Answered:       item.Answered,
+			NonFunctioning: item.NonFunctioning,
+			LikelyMiskey:   item.LikelyMiskey,
+			Options:        make([]*OptionDistractorReport, len(item.Options)),
+		}
+		for o, option := range item.Options {
+			question.Options[o] = &OptionDistractorReport{
+				Label:          option.Label,
+				IsCorrect:      option.IsCorrect,
+				Count:          option.Count,
+				SelectionRate:  option.SelectionRate,
+				GroupRates:     option.GroupRates,
+				PointBiserial:  option.PointBiserial,
+				NonFunctioning: option.NonFunctioning,
+				LikelyMiskey:   option.LikelyMiskey,
+			}
+		}
+		report.Questions[k] = question
+	}
+	return report, nil
+}
+
+// selectedOptions 解析作答选中的选项：答案与某个标签完全相同时只选中该项，
+// 否则按逗号、空格等分隔，无分隔符时逐字符匹配（如多选题答案 "AC"）
+func selectedOptions(answer string, labels []string) []bool {
+	selected := make([]bool, len(labels))
+	answer = strings.TrimSpace(answer)
+	for k, label := range labels {
+		if label == answer {
+			selected[k] = true
+			return selected
+		}
+	}
+	tokens := strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || r == '，' || r == ';' || r == '、' || unicode.IsSpace(r)
+	})
+	if len(tokens) == 1 {
+		tokens = tokens[:0]
+		for _, r := range answer {
+			tokens = append(tokens, string(r))
+		}
+	}
+	for _, token := range tokens {
+		for k, label := range labels {
+			if label == token {
+				selected[k] = true
+			}
+		}
+	}
+	return selected
+}
+
+// paperSubmissions 试卷计分题与每场已交卷考试的最终作答
+type paperSubmissions struct {
+	questions []*models.ExamPaperQuestion // 计分题，按题目顺序
+	recordIDs []uint
+	final     [][]*models.ExamResponse // 行为考试记录、列为题目，未作答为 nil
+}
+
+// loadSubmissions 读取试卷计分题及已交卷考试的作答，同一场考试重复作答时以最后一次为准
+func (s *statisticsService) loadSubmissions(ctx context.Context, paperID uint) (*models.ExamPaper, *paperSubmissions, error) {
+	paper, err := s.statisticsRepo.FindPaper(ctx, paperID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if paper == nil {
+		return nil, nil, ErrPaperNotFound
+	}
+	paperQuestions, err := s.statisticsRepo.ListPaperQuestions(ctx, paperID)
+	if err != nil {
+		return nil, nil, err
+	}
+	responses, err := s.statisticsRepo.ListCompletedResponses(ctx, paperID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	submissions := &paperSubmissions{}
+	columns := make(map[uint]int, len(paperQuestions))
+	for _, pq := range paperQuestions {
+		if pq.Pretest || pq.Question.Pretest {
+			continue
+		}
+		if _, ok := columns[pq.QuestionID]; ok {
+			continue
+		}
+		columns[pq.QuestionID] = len(submissions.questions)
+		submissions.questions = append(submissions.questions, pq)
+	}
+	rows := make(map[uint]int)
+	for _, response := range responses {
+		j, ok := columns[response.QuestionID]
+		if !ok {
+			continue
+		}
+		i, ok := rows[response.ExamRecordID]
+		if !ok {
+			i = len(submissions.recordIDs)
+			rows[response.ExamRecordID] = i
+			submissions.recordIDs = append(submissions.recordIDs, response.ExamRecordID)
+			submissions.final = append(submissions.final, make([]*models.ExamResponse, len(submissions.questions)))
+		}
+		submissions.final[i][j] = response
+	}
+	return paper, submissions, nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/repositories"
+)
+
+var ErrSubjectNotFound = errors.New("subject not found")
+
+// SubjectService 科目项目反应模型配置服务接口
+type SubjectService interface {
+	// GetIRTModel 科目使用的项目反应模型，未配置时为 D=1.7 的3PL
+	GetIRTModel(ctx context.Context, subjectID uint) (irt.Model, error)
+	// UpdateIRTModel 设置科目的模型族与量尺因子，所有概率、信息量与估计计算随之切换
+	UpdateIRTModel(ctx context.Context, subjectID uint, model irt.Model) (irt.Model, error)
+}
+
+// NewSubjectService creates a new subject service instance
+func NewSubjectService(subjectRepo repositories.SubjectRepository) SubjectService {
+	return &subjectService{
+		subjectRepo: subjectRepo,
+	}
+}
+
+type subjectService struct {
+	subjectRepo repositories.SubjectRepository
+}
+
+// GetIRTModel implements SubjectService
+func (s *subjectService) GetIRTModel(ctx context.Context, subjectID uint) (irt.Model, error) {
+	subject, err := s.subjectRepo.FindByID(ctx, subjectID)
+	if err != nil {
+		return irt.Model{}, err
+	}
+	if subject == nil {
+		return irt.Model{}, ErrSubjectNotFound
+	}
+	return subject.ResponseModel(), nil
+}
+
+// UpdateIRTModel implements SubjectService
+func (s *subjectService) UpdateIRTModel(ctx context.Context, subjectID uint, model irt.Model) (irt.Model, error) {
+	if err := model.Validate(); err != nil {
+		return irt.Model{}, err
+	}
+	subject, err := s.subjectRepo.FindByID(ctx, subjectID)
+	if err != nil {
+		return irt.Model{}, err
+	}
+	if subject == nil {
+		return irt.Model{}, ErrSubjectNotFound
+	}
+	if err := s.subjectRepo.UpdateIRTModel(ctx, subjectID, string(model.Family), model.D); err != nil {
+		return irt.Model{}, err
+	}
+	return model, nil
+}
+
+// subjectModel 查询科目的项目反应模型，科目不存在时使用默认模型
+func subjectModel(ctx context.Context, subjectRepo repositories.SubjectRepository, subjectID uint) (irt.Model, error) {
+	subject, err := subjectRepo.FindByID(ctx, subjectID)
+	if err != nil {
+		return irt.Model{}, err
+	}
+	return subject.ResponseModel(), nil
+}
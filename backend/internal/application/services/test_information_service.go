@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/domain/repositories"
+)
+
+// TestInformationService 试卷测验信息与测验特征曲线服务接口
+type TestInformationService interface {
+	// GetTestCurves 在能力值网格上计算试卷的测验信息函数、条件测量标准误与测验特征曲线，
+	// thetas 为需要单独给出期望得分的能力值，rawScores 为需要反解能力值的原始分
+	GetTestCurves(ctx context.Context, paperID uint, grid, thetas, rawScores []float64) (*TestCurves, error)
+}
+
+// TestCurves 试卷的测验函数
+type TestCurves struct {
+	ExamPaperID     uint
+	ItemCount       int
+	MaxScore        float64
+	CutScore        float64 // 试卷在能力量尺上的划界分数
+	CutPoint        irt.CurvePoint
+	PeakTheta       float64 // 测验信息最大处
+	PeakInformation float64
+	Curve           []irt.CurvePoint
+	Expected        []irt.CurvePoint // 指定能力值处的期望得分
+	ScoreThetas     []ScoreTheta     // 原始分对应的能力值
+}
+
+// ScoreTheta 原始分与测验特征曲线反解出的能力值
+type ScoreTheta struct {
+	RawScore float64
+	Theta    float64
+}
+
+// NewTestInformationService creates a new test information service instance
+func NewTestInformationService(examRepo repositories.ExamRepository, questionRepo repositories.QuestionRepository, subjectRepo repositories.SubjectRepository) TestInformationService {
+	return &testInformationService{
+		examRepo:     examRepo,
+		questionRepo: questionRepo,
+		subjectRepo:  subjectRepo,
+	}
+}
+
+type testInformationService struct {
+	examRepo     repositories.ExamRepository
+	questionRepo repositories.QuestionRepository
+	subjectRepo  repositories.SubjectRepository
+}
+
+// GetTestCurves implements TestInformationService
+func (s *testInformationService) GetTestCurves(ctx context.Context, paperID uint, grid, thetas, rawScores []float64) (*TestCurves, error) {
+	paper, err := s.examRepo.FindPaperByID(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+	if paper == nil {
+		return nil, errors.New("exam paper not found")
+	}
+
+	questions, err := s.questionRepo.ListByExamPaper(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, errors.New("exam paper has no questions")
+	}
+	model, err := subjectModel(ctx, s.subjectRepo, paper.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+	}
+	params, err := itemParameters(ctx, s.questionRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]irt.TestItem, len(questions))
+	result := &TestCurves{ExamPaperID: paperID, ItemCount: len(questions), CutScore: paper.CutScore}
+	for i, q := range questions {
+		p, ok := params[q.ID]
+		if !ok {
+			return nil, fmt.Errorf("%w: question %d", ErrUncalibratedItem, q.ID)
+		}
+		items[i] = irt.TestItem{
+			QuestionID:     q.ID,
+			Difficulty:     p.Difficulty,
+			Discrimination: p.Discrimination,
+			Guessing:       p.Guessing,
+			UpperAsymptote: p.UpperAsymptote,
+			Score:          q.Score,
+		}
+		if q.Score > 0 {
+			result.MaxScore += q.Score
+		} else {
+			result.MaxScore++
+		}
+	}
+
+	result.Curve = model.TestCurves(items, grid)
+	for _, point := range result.Curve {
+		if point.Information > result.PeakInformation {
+			result.PeakInformation = point.Information
+			result.PeakTheta = point.Theta
+		}
+	}
+	result.CutPoint = model.TestCurves(items, []float64{paper.CutScore})[0]
+	result.Expected = model.TestCurves(items, thetas)
+
+	minTheta, maxTheta := -4.0, 4.0
+	if len(grid) > 0 {
+		minTheta, maxTheta = grid[0], grid[len(grid)-1]
+	}
+	for _, score := range rawScores {
+		result.ScoreThetas = append(result.ScoreThetas, ScoreTheta{
+			RawScore: score,
+			Theta:    model.ThetaForScore(items, score, minTheta, maxTheta),
+		})
+	}
+	return result, nil
+}
+
+// itemParameters 批量查询题目已标定的IRT参数，区分度不为正的记录视为未标定；
+// 题目参数表是选题、组卷与测验函数共用的参数来源
+func itemParameters(ctx context.Context, questionRepo repositories.QuestionRepository, questionIDs []uint) (map[uint]*models.ItemParameter, error) {
+	params, err := questionRepo.ListItemParameters(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	calibrated := make(map[uint]*models.ItemParameter, len(params))
+	for _, p := range params {
+		if p.Discrimination > 0 {
+			calibrated[p.QuestionID] = p
+		}
+	}
+	return calibrated, nil
+}
@@ -0,0 +1,198 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+var ErrNoExaminees = errors.New("response matrix has no examinees")
+
+// ClassicalItem 单题经典测量理论统计量
+type ClassicalItem struct {
+	QuestionID     uint
+	Answered       int     // 作答人数，未作答按答错计入其余统计量
+	PValue         float64 // 难度：答对比例
+	PointBiserial  float64 // 与总分的点二列相关
+	Biserial       float64 // 与总分的二列相关
+	ItemRest       float64 // 与去掉本题后总分的相关
+	AlphaIfDeleted float64 // 删除本题后的 α
+}
+
+// ScoreFrequency 总分分布中的一个分数
+type ScoreFrequency struct {
+	Score             int
+	Count             int
+	Percent           float64
+	CumulativePercent float64
+}
+
+// ClassicalTest 测验层面的经典测量理论统计量，总分为答对题数
+type ClassicalTest struct {
+	Examinees    int
+	Items        []ClassicalItem
+	Mean         float64
+	SD           float64
+	Min          int
+	Max          int
+	Alpha        float64 // 二级计分下的 Cronbach α 即 KR-20
+	SEM          float64 // 测量标准误 SD·√(1−α)
+	Distribution []ScoreFrequency
+}
+
+// ClassicalStatistics 计算作答矩阵的题目与测验统计量，未作答按答错处理；方差均取 n−1 为除数
+func ClassicalStatistics(matrix *irt.ResponseMatrix) (*ClassicalTest, error) {
+	n := len(matrix.Responses)
+	if n == 0 {
+		return nil, ErrNoExaminees
+	}
+	k := len(matrix.QuestionIDs)
+
+	scores := make([][]float64, n)
+	totals := make([]float64, n)
+	for i, row := range matrix.Responses {
+		scores[i] = make([]float64, k)
+		for j, x := range row {
+			if x == 1 {
+				scores[i][j] = 1
+				totals[i]++
+			}
+		}
+	}
+
+	result := &ClassicalTest{
+		Examinees: n,
+		Items:     make([]ClassicalItem, k),
+		Min:       k,
+	}
+	totalMean, totalVar := meanVariance(totals)
+	result.Mean = totalMean
+	result.SD = math.Sqrt(totalVar)
+
+	itemVars := make([]float64, k)
+	var sumItemVar float64
+	column := make([]float64, n)
+	rest := make([]float64, n)
+	for j := 0; j < k; j++ {
+		item := ClassicalItem{QuestionID: matrix.QuestionIDs[j]}
+		for i := range matrix.Responses {
+			if matrix.Responses[i][j] != irt.Missing {
+				item.Answered++
+			}
+			column[i] = scores[i][j]
+			rest[i] = totals[i] - scores[i][j]
+		}
+		p, variance := meanVariance(column)
+		itemVars[j] = variance
+		sumItemVar += variance
+
+		item.PValue = p
+		item.PointBiserial = correlation(column, totals)
+		item.ItemRest = correlation(column, rest)
+		if p > 0 && p < 1 {
+			item.Biserial = item.PointBiserial * math.Sqrt(p*(1-p)) / NormalDensity(NormalQuantile(p))
+		}
+		result.Items[j] = item
+	}
+	result.Alpha = cronbachAlpha(k, sumItemVar, totalVar)
+	if result.Alpha > 0 {
+		result.SEM = result.SD * math.Sqrt(1-result.Alpha)
+	} else {
+		result.SEM = result.SD
+	}
+
+	// 删除第 j 题后总分方差 = Var(X) − 2Cov(x_j, X) + Var(x_j)
+	for j := 0; j < k; j++ {
+		for i := range matrix.Responses {
+			column[i] = scores[i][j]
+		}
+		cov := covariance(column, totals)
+		restVar := totalVar - 2*cov + itemVars[j]
+		result.Items[j].AlphaIfDeleted = cronbachAlpha(k-1, sumItemVar-itemVars[j], restVar)
+	}
+
+	counts := make([]int, k+1)
+	for _, total := range totals {
+		score := int(total)
+		counts[score]++
+		if score < result.Min {
+			result.Min = score
+		}
+		if score > result.Max {
+			result.Max = score
+		}
+	}
+	var cumulative int
+	for score, count := range counts {
+		cumulative += count
+		if count == 0 {
+			continue
+		}
+		result.Distribution = append(result.Distribution, ScoreFrequency{
+			Score:             score,
+			Count:             count,
+			Percent:           float64(count) / float64(n),
+			CumulativePercent: float64(cumulative) / float64(n),
+		})
+	}
+	return result, nil
+}
+
+// cronbachAlpha α = k/(k−1)·(1 − Σσ²_j / σ²_X)，题数不足或总分无变异时为0
+func cronbachAlpha(k int, sumItemVar, totalVar float64) float64 {
+	if k < 2 || totalVar <= 0 {
+		return 0
+	}
+	return float64(k) / float64(k-1) * (1 - sumItemVar/totalVar)
+}
+
+// meanVariance 均值与 n−1 为除数的方差
+func meanVariance(values []float64) (float64, float64) {
+	n := float64(len(values))
+	if n == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / n
+	if n < 2 {
+		return mean, 0
+	}
+	var ss float64
+	for _, v := range values {
+		ss += (v - mean) * (v - mean)
+	}
+	return mean, ss / (n - 1)
+}
+
+// covariance n−1 为除数的协方差
+func covariance(x, y []float64) float64 {
+	n := float64(len(x))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY float64
+	for i := range x {
+		sumX += x[i]
+		sumY += y[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+	var s float64
+	for i := range x {
+		s += (x[i] - meanX) * (y[i] - meanY)
+	}
+	return s / (n - 1)
+}
+
+// correlation 皮尔逊相关，任一变量无变异时为0
+func correlation(x, y []float64) float64 {
+	_, varX := meanVariance(x)
+	_, varY := meanVariance(y)
+	if varX <= 0 || varY <= 0 {
+		return 0
+	}
+	return covariance(x, y) / math.Sqrt(varX*varY)
+}
@@ -0,0 +1,57 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassicalStatisticsHandComputed(t *testing.T) {
+	// 5人3题，总分 3、2、1、0、3
+	matrix := &irt.ResponseMatrix{
+		QuestionIDs: []uint{1, 2, 3},
+		Responses: [][]int{
+			{1, 1, 1},
+			{1, 1, 0},
+			{1, 0, irt.Missing},
+			{0, 0, 0},
+			{1, 1, 1},
+		},
+	}
+
+	result, err := ClassicalStatistics(matrix)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Examinees)
+	assert.InDelta(t, 1.8, result.Mean, 1e-12)
+	assert.InDelta(t, math.Sqrt(1.7), result.SD, 1e-12)
+	assert.Equal(t, 0, result.Min)
+	assert.Equal(t, 3, result.Max)
+
+	// 题目方差 0.2、0.3、0.3，总分方差 1.7：α = 3/2·(1 − 0.8/1.7) = 27/34
+	assert.InDelta(t, 27.0/34, result.Alpha, 1e-12)
+	assert.InDelta(t, math.Sqrt(1.7*7/34), result.SEM, 1e-12)
+
+	wantP := []float64{0.8, 0.6, 0.4}
+	wantAlphaIfDeleted := []float64{0.8, 4.0 / 7, 0.75}
+	for j, item := range result.Items {
+		assert.InDelta(t, wantP[j], item.PValue, 1e-12)
+		assert.InDelta(t, wantAlphaIfDeleted[j], item.AlphaIfDeleted, 1e-12)
+	}
+	assert.Equal(t, 4, result.Items[2].Answered)
+
+	counts := map[int]int{}
+	for _, f := range result.Distribution {
+		counts[f.Score] = f.Count
+	}
+	assert.Equal(t, map[int]int{0: 1, 1: 1, 2: 1, 3: 2}, counts)
+	assert.InDelta(t, 1.0, result.Distribution[len(result.Distribution)-1].CumulativePercent, 1e-12)
+}
+
+func TestClassicalStatisticsNoExaminees(t *testing.T) {
+	_, err := ClassicalStatistics(&irt.ResponseMatrix{QuestionIDs: []uint{1}})
+	assert.ErrorIs(t, err, ErrNoExaminees)
+}
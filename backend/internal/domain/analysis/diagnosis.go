@@ -0,0 +1,345 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+// DiagnosisModel 认知诊断模型
+type DiagnosisModel string
+
+const (
+	DINA DiagnosisModel = "dina" // 须掌握题目考查的全部知识点才能答对
+	DINO DiagnosisModel = "dino" // 掌握题目考查的任一知识点即可答对
+)
+
+var (
+	ErrUnknownDiagnosisModel = errors.New("unknown cognitive diagnosis model")
+	ErrQMatrixMismatch       = errors.New("q-matrix rows do not match response matrix columns")
+	ErrEmptyQMatrix          = errors.New("q-matrix has no attributes")
+	ErrTooManyAttributes     = errors.New("q-matrix has too many attributes")
+)
+
+// minProbability 失误、猜测参数和知识状态先验概率的下界，避免取对数溢出
+const minProbability = 1e-4
+
+// QMatrix 题目×知识点的考查矩阵，Required 的行与作答矩阵的列一一对应
+type QMatrix struct {
+	AttributeIDs []uint
+	Required     [][]bool
+}
+
+// DiagnosisConfig 认知诊断模型拟合配置
+type DiagnosisConfig struct {
+	Model            DiagnosisModel
+	MaxAttributes    int     // 知识点数上限，潜在类别数为 2^K
+	MaxIterations    int     // EM 最大迭代次数
+	Tolerance        float64 // 失误、猜测参数的最大变化量小于该值时收敛
+	MaxSlip          float64 // 失误参数上界
+	MaxGuess         float64 // 猜测参数上界，与 MaxSlip 一起保证 1-s > g
+	MasteryThreshold float64 // 后验掌握概率不低于该值视为已掌握
+}
+
+// DefaultDiagnosisConfig 返回常用的认知诊断拟合配置
+func DefaultDiagnosisConfig() DiagnosisConfig {
+	return DiagnosisConfig{
+		Model:            DINA,
+		MaxAttributes:    10,
+		MaxIterations:    500,
+		Tolerance:        1e-4,
+		MaxSlip:          0.5,
+		MaxGuess:         0.5,
+		MasteryThreshold: 0.5,
+	}
+}
+
+// ItemDiagnosis 单题失误与猜测参数
+type ItemDiagnosis struct {
+	QuestionID   uint
+	AttributeIDs []uint // 题目考查的知识点
+	Slip         float64
+	Guess        float64
+	SlipSE       float64
+	GuessSE      float64
+	Skipped      bool // Q 矩阵中未关联任何知识点
+}
+
+// ExamineeDiagnosis 单个考生（作答矩阵一行）的诊断结果
+type ExamineeDiagnosis struct {
+	Row      int
+	Mastery  []float64 // 各知识点的后验掌握概率，顺序同 AttributeIDs
+	Mastered []bool    // 按 MasteryThreshold 判定的掌握情况
+	Profile  []bool    // 后验概率最大的知识状态
+}
+
+// DiagnosisResult 认知诊断模型拟合结果
+type DiagnosisResult struct {
+	Model         DiagnosisModel
+	AttributeIDs  []uint
+	Items         []*ItemDiagnosis
+	Examinees     []*ExamineeDiagnosis
+	Prevalence    []float64 // 各知识点在总体中的掌握比例
+	ClassPrior    []float64 // 2^K 个知识状态的先验概率，第 k 位为1表示掌握第 k 个知识点
+	LogLikelihood float64
+	Iterations    int
+	Converged     bool
+
+	eta       [][]bool // 各题在各知识状态下的理想作答
+	threshold float64
+}
+
+// FitDiagnosis 用 EM 算法在 Q 矩阵上拟合 DINA 或 DINO 模型，
+// 估计每题的失误、猜测参数和知识状态分布，并给出每个考生的后验掌握概率
+func FitDiagnosis(matrix *irt.ResponseMatrix, q QMatrix, cfg DiagnosisConfig) (*DiagnosisResult, error) {
+	if cfg.Model != DINA && cfg.Model != DINO {
+		return nil, ErrUnknownDiagnosisModel
+	}
+	if len(q.Required) != len(matrix.QuestionIDs) {
+		return nil, ErrQMatrixMismatch
+	}
+	attributes := len(q.AttributeIDs)
+	if attributes == 0 {
+		return nil, ErrEmptyQMatrix
+	}
+	if attributes > cfg.MaxAttributes {
+		return nil, ErrTooManyAttributes
+	}
+	for _, row := range q.Required {
+		if len(row) != attributes {
+			return nil, ErrQMatrixMismatch
+		}
+	}
+
+	classes := 1 << attributes
+	result := &DiagnosisResult{
+		Model:        cfg.Model,
+		AttributeIDs: q.AttributeIDs,
+		Items:        make([]*ItemDiagnosis, len(matrix.QuestionIDs)),
+		ClassPrior:   make([]float64, classes),
+		eta:          make([][]bool, len(matrix.QuestionIDs)),
+		threshold:    cfg.MasteryThreshold,
+	}
+	for j, id := range matrix.QuestionIDs {
+		item := &ItemDiagnosis{QuestionID: id, Slip: 0.2, Guess: 0.2}
+		for k, required := range q.Required[j] {
+			if required {
+				item.AttributeIDs = append(item.AttributeIDs, q.AttributeIDs[k])
+			}
+		}
+		item.Skipped = len(item.AttributeIDs) == 0
+		result.Items[j] = item
+		if !item.Skipped {
+			result.eta[j] = idealResponses(cfg.Model, q.Required[j], classes)
+		}
+	}
+	for c := range result.ClassPrior {
+		result.ClassPrior[c] = 1 / float64(classes)
+	}
+
+	posteriors := make([][]float64, len(matrix.Responses))
+	for i := range posteriors {
+		posteriors[i] = make([]float64, classes)
+	}
+
+	for result.Iterations < cfg.MaxIterations {
+		result.Iterations++
+
+		// E 步：各考生的知识状态后验及各题的期望人数
+		result.LogLikelihood = 0
+		classCounts := make([]float64, classes)
+		masteredN := make([]float64, len(result.Items))
+		masteredR := make([]float64, len(result.Items))
+		lackingN := make([]float64, len(result.Items))
+		lackingR := make([]float64, len(result.Items))
+		for i, row := range matrix.Responses {
+			result.LogLikelihood += result.posterior(row, posteriors[i])
+			for c, p := range posteriors[i] {
+				classCounts[c] += p
+			}
+			for j, x := range row {
+				if x == irt.Missing || result.Items[j].Skipped {
+					continue
+				}
+				var mastered float64
+				for c, p := range posteriors[i] {
+					if result.eta[j][c] {
+						mastered += p
+					}
+				}
+				masteredN[j] += mastered
+				lackingN[j] += 1 - mastered
+				if x == 1 {
+					masteredR[j] += mastered
+					lackingR[j] += 1 - mastered
+				}
+			}
+		}
+
+		// M 步：失误、猜测参数与知识状态分布
+		change := 0.0
+		for j, item := range result.Items {
+			if item.Skipped {
+				continue
+			}
+			slip, guess := item.Slip, item.Guess
+			if masteredN[j] > 0 {
+				slip = clamp(1-masteredR[j]/masteredN[j], minProbability, cfg.MaxSlip)
+			}
+			if lackingN[j] > 0 {
+				guess = clamp(lackingR[j]/lackingN[j], minProbability, cfg.MaxGuess)
+			}
+			change = math.Max(change, math.Max(math.Abs(slip-item.Slip), math.Abs(guess-item.Guess)))
+			item.Slip, item.Guess = slip, guess
+			item.SlipSE = binomialSE(slip, masteredN[j])
+			item.GuessSE = binomialSE(guess, lackingN[j])
+		}
+		total := float64(len(matrix.Responses))
+		if total > 0 {
+			var sum float64
+			for c := range result.ClassPrior {
+				result.ClassPrior[c] = math.Max(classCounts[c]/total, minProbability)
+				sum += result.ClassPrior[c]
+			}
+			for c := range result.ClassPrior {
+				result.ClassPrior[c] /= sum
+			}
+		}
+
+		if change < cfg.Tolerance {
+			result.Converged = true
+			break
+		}
+	}
+
+	result.Prevalence = make([]float64, attributes)
+	for c, p := range result.ClassPrior {
+		for k := 0; k < attributes; k++ {
+			if c&(1<<k) != 0 {
+				result.Prevalence[k] += p
+			}
+		}
+	}
+	result.Examinees = make([]*ExamineeDiagnosis, len(matrix.Responses))
+	for i, row := range matrix.Responses {
+		result.Examinees[i] = result.examinee(i, row)
+	}
+	return result, nil
+}
+
+// Diagnose 用已拟合的题目参数和知识状态分布诊断一组作答，
+// responses 与拟合时作答矩阵的列一一对应，未作答为 irt.Missing
+func (r *DiagnosisResult) Diagnose(responses []int) (*ExamineeDiagnosis, error) {
+	if len(responses) != len(r.Items) {
+		return nil, ErrQMatrixMismatch
+	}
+	return r.examinee(-1, responses), nil
+}
+
+// examinee 计算一行作答的边际掌握概率与最大后验知识状态
+func (r *DiagnosisResult) examinee(row int, responses []int) *ExamineeDiagnosis {
+	attributes := len(r.AttributeIDs)
+	post := make([]float64, len(r.ClassPrior))
+	r.posterior(responses, post)
+
+	result := &ExamineeDiagnosis{
+		Row:      row,
+		Mastery:  make([]float64, attributes),
+		Mastered: make([]bool, attributes),
+		Profile:  make([]bool, attributes),
+	}
+	best := 0
+	for c, p := range post {
+		if p > post[best] {
+			best = c
+		}
+		for k := 0; k < attributes; k++ {
+			if c&(1<<k) != 0 {
+				result.Mastery[k] += p
+			}
+		}
+	}
+	for k := 0; k < attributes; k++ {
+		result.Mastered[k] = result.Mastery[k] >= r.threshold
+		result.Profile[k] = best&(1<<k) != 0
+	}
+	return result
+}
+
+// posterior 将一行作答的知识状态后验写入 post，返回该行的对数边际似然
+func (r *DiagnosisResult) posterior(responses []int, post []float64) float64 {
+	for c, prior := range r.ClassPrior {
+		post[c] = math.Log(prior)
+	}
+	for j, x := range responses {
+		item := r.Items[j]
+		if x == irt.Missing || item.Skipped {
+			continue
+		}
+		// 理想作答为1时答对概率 1-s，否则为 g
+		var hit, miss float64
+		if x == 1 {
+			hit, miss = math.Log(1-item.Slip), math.Log(item.Guess)
+		} else {
+			hit, miss = math.Log(item.Slip), math.Log(1-item.Guess)
+		}
+		for c := range post {
+			if r.eta[j][c] {
+				post[c] += hit
+			} else {
+				post[c] += miss
+			}
+		}
+	}
+
+	maxLog := math.Inf(-1)
+	for _, v := range post {
+		maxLog = math.Max(maxLog, v)
+	}
+	var sum float64
+	for c, v := range post {
+		post[c] = math.Exp(v - maxLog)
+		sum += post[c]
+	}
+	for c := range post {
+		post[c] /= sum
+	}
+	return maxLog + math.Log(sum)
+}
+
+// idealResponses 计算题目在每个知识状态下的理想作答
+func idealResponses(model DiagnosisModel, required []bool, classes int) []bool {
+	eta := make([]bool, classes)
+	for c := range eta {
+		all, some := true, false
+		for k, r := range required {
+			if !r {
+				continue
+			}
+			if c&(1<<k) != 0 {
+				some = true
+			} else {
+				all = false
+			}
+		}
+		if model == DINA {
+			eta[c] = all
+		} else {
+			eta[c] = some
+		}
+	}
+	return eta
+}
+
+// binomialSE 以期望人数 n 近似比例参数 p 的标准误，无人时返回0
+func binomialSE(p, n float64) float64 {
+	if n <= 0 {
+		return 0
+	}
+	return math.Sqrt(p * (1 - p) / n)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,150 @@
+package analysis
+
+import (
+	"math/rand"
+	"testing"
+
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// diagnosisQ 3个知识点、12道题：每个知识点单独考查2题，每两个知识点组合考查2题
+func diagnosisQ() QMatrix {
+	patterns := [][]bool{
+		{true, false, false}, {false, true, false}, {false, false, true},
+		{true, true, false}, {true, false, true}, {false, true, true},
+	}
+	q := QMatrix{AttributeIDs: []uint{11, 12, 13}}
+	for _, p := range append(patterns, patterns...) {
+		q.Required = append(q.Required, p)
+	}
+	return q
+}
+
+// diagnosisData 按给定的掌握比例独立抽取知识状态，再按失误、猜测参数生成作答
+func diagnosisData(rng *rand.Rand, model DiagnosisModel, q QMatrix, prevalence []float64, slip, guess float64, examinees int) (*irt.ResponseMatrix, [][]bool) {
+	matrix := &irt.ResponseMatrix{QuestionIDs: make([]uint, len(q.Required)), Responses: make([][]int, examinees)}
+	for j := range matrix.QuestionIDs {
+		matrix.QuestionIDs[j] = uint(j + 1)
+	}
+	profiles := make([][]bool, examinees)
+	for i := range profiles {
+		profile := make([]bool, len(prevalence))
+		class := 0
+		for k, p := range prevalence {
+			profile[k] = rng.Float64() < p
+			if profile[k] {
+				class |= 1 << k
+			}
+		}
+		row := make([]int, len(q.Required))
+		for j, required := range q.Required {
+			p := guess
+			if idealResponses(model, required, 1<<len(prevalence))[class] {
+				p = 1 - slip
+			}
+			if rng.Float64() < p {
+				row[j] = 1
+			}
+		}
+		profiles[i] = profile
+		matrix.Responses[i] = row
+	}
+	return matrix, profiles
+}
+
+func TestIdealResponses(t *testing.T) {
+	// 题目考查第1、3个知识点；知识状态的第 k 位表示掌握第 k 个知识点
+	required := []bool{true, false, true}
+	assert.Equal(t, []bool{false, false, false, false, false, true, false, true}, idealResponses(DINA, required, 8))
+	assert.Equal(t, []bool{false, true, false, true, true, true, true, true}, idealResponses(DINO, required, 8))
+}
+
+func TestFitDiagnosisRecoversParameters(t *testing.T) {
+	prevalence := []float64{0.3, 0.5, 0.7}
+	for _, model := range []DiagnosisModel{DINA, DINO} {
+		t.Run(string(model), func(t *testing.T) {
+			q := diagnosisQ()
+			matrix, profiles := diagnosisData(rand.New(rand.NewSource(31)), model, q, prevalence, 0.1, 0.2, 3000)
+
+			cfg := DefaultDiagnosisConfig()
+			cfg.Model = model
+			result, err := FitDiagnosis(matrix, q, cfg)
+			require.NoError(t, err)
+			assert.True(t, result.Converged)
+
+			for _, item := range result.Items {
+				assert.InDelta(t, 0.1, item.Slip, 0.05, "question %d", item.QuestionID)
+				assert.InDelta(t, 0.2, item.Guess, 0.05, "question %d", item.QuestionID)
+				assert.Greater(t, item.SlipSE, 0.0)
+			}
+			assert.Equal(t, []uint{11, 13}, result.Items[4].AttributeIDs)
+			assert.InDeltaSlice(t, prevalence, result.Prevalence, 0.05)
+
+			var sum float64
+			for _, p := range result.ClassPrior {
+				sum += p
+			}
+			assert.InDelta(t, 1.0, sum, 1e-9)
+
+			// 各知识点的掌握判定与真实知识状态大体一致
+			correct := 0
+			for i, examinee := range result.Examinees {
+				for k := range prevalence {
+					if examinee.Mastered[k] == profiles[i][k] {
+						correct++
+					}
+				}
+			}
+			assert.Greater(t, float64(correct)/float64(3*len(profiles)), 0.85)
+		})
+	}
+}
+
+func TestDiagnoseNewResponses(t *testing.T) {
+	q := diagnosisQ()
+	matrix, _ := diagnosisData(rand.New(rand.NewSource(37)), DINA, q, []float64{0.5, 0.5, 0.5}, 0.1, 0.2, 1500)
+	result, err := FitDiagnosis(matrix, q, DefaultDiagnosisConfig())
+	require.NoError(t, err)
+
+	// 只答对考查第1个知识点的单知识点题，其余答错；第12题未作答
+	responses := []int{1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, irt.Missing}
+	examinee, err := result.Diagnose(responses)
+	require.NoError(t, err)
+	assert.Equal(t, -1, examinee.Row)
+	assert.Equal(t, []bool{true, false, false}, examinee.Profile)
+	assert.Equal(t, []bool{true, false, false}, examinee.Mastered)
+
+	_, err = result.Diagnose(responses[:5])
+	assert.ErrorIs(t, err, ErrQMatrixMismatch)
+}
+
+func TestFitDiagnosisSkipsUnmappedItems(t *testing.T) {
+	q := diagnosisQ()
+	matrix, _ := diagnosisData(rand.New(rand.NewSource(41)), DINA, q, []float64{0.5, 0.5, 0.5}, 0.1, 0.2, 500)
+	q.Required[0] = []bool{false, false, false}
+
+	result, err := FitDiagnosis(matrix, q, DefaultDiagnosisConfig())
+	require.NoError(t, err)
+	assert.True(t, result.Items[0].Skipped)
+	assert.Empty(t, result.Items[0].AttributeIDs)
+	assert.False(t, result.Items[1].Skipped)
+}
+
+func TestFitDiagnosisErrors(t *testing.T) {
+	q := diagnosisQ()
+	matrix, _ := diagnosisData(rand.New(rand.NewSource(1)), DINA, q, []float64{0.5, 0.5, 0.5}, 0.1, 0.2, 10)
+	cfg := DefaultDiagnosisConfig()
+
+	_, err := FitDiagnosis(matrix, q, DiagnosisConfig{Model: "rum"})
+	assert.ErrorIs(t, err, ErrUnknownDiagnosisModel)
+	_, err = FitDiagnosis(matrix, QMatrix{AttributeIDs: q.AttributeIDs, Required: q.Required[:3]}, cfg)
+	assert.ErrorIs(t, err, ErrQMatrixMismatch)
+	_, err = FitDiagnosis(matrix, QMatrix{Required: q.Required}, cfg)
+	assert.ErrorIs(t, err, ErrEmptyQMatrix)
+	cfg.MaxAttributes = 2
+	_, err = FitDiagnosis(matrix, q, cfg)
+	assert.ErrorIs(t, err, ErrTooManyAttributes)
+}
@@ -0,0 +1,337 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+// ETSClass ETS 的 DIF 等级
+type ETSClass string
+
+const (
+	ClassA ETSClass = "A" // 可忽略
+	ClassB ETSClass = "B" // 轻度到中度
+	ClassC ETSClass = "C" // 中度到严重
+)
+
+// 考生分组
+const (
+	GroupNone      = 0
+	GroupReference = 1 // 参照组
+	GroupFocal     = 2 // 焦点组
+)
+
+var ErrGroupMismatch = errors.New("group labels do not match response matrix rows")
+
+// DIFConfig DIF 分析配置
+type DIFConfig struct {
+	Strata       int     // Mantel–Haenszel 能力分层数
+	MinGroupSize int     // 每组最少作答人数，不足时不做分析
+	Alpha        float64 // 显著性水平
+}
+
+// DefaultDIFConfig 返回常用的 DIF 分析配置
+func DefaultDIFConfig() DIFConfig {
+	return DIFConfig{
+		Strata:       10,
+		MinGroupSize: 30,
+		Alpha:        0.05,
+	}
+}
+
+// ItemDIF 单题 DIF 分析结果
+type ItemDIF struct {
+	QuestionID     uint
+	ReferenceCount int
+	FocalCount     int
+	Skipped        bool // 任一组人数不足
+
+	// Mantel–Haenszel
+	MHOddsRatio float64 // 共同优势比 α_MH，大于1表示有利于参照组
+	MHDelta     float64 // MH D-DIF = -2.35 ln α_MH
+	MHDeltaSE   float64
+	MHChiSquare float64 // 含连续性校正
+	MHPValue    float64
+	ETSClass    ETSClass
+
+	// logistic 回归
+	LRUniform    float64 // 一致性 DIF 卡方（组别主效应）
+	LRNonUniform float64 // 非一致性 DIF 卡方（能力×组别交互）
+	LRPValue     float64 // 两自由度总体检验
+	LRDeltaR2    float64 // Nagelkerke R² 增量
+	LRClass      ETSClass
+	LRConverged  bool
+	LRIterations int
+
+	ReferenceRate float64 // 参照组答对率
+	FocalRate     float64 // 焦点组答对率
+}
+
+// DIF 对作答矩阵每道题做 Mantel–Haenszel 与 logistic 回归 DIF 分析，
+// groups 为每行的分组标记，thetas 为匹配变量（能力估计）
+func DIF(matrix *irt.ResponseMatrix, groups []int, thetas []float64, cfg DIFConfig) ([]*ItemDIF, error) {
+	if len(groups) != len(matrix.Responses) || len(thetas) != len(matrix.Responses) {
+		return nil, ErrGroupMismatch
+	}
+	strata := abilityStrata(groups, thetas, cfg.Strata)
+
+	results := make([]*ItemDIF, len(matrix.QuestionIDs))
+	for j, id := range matrix.QuestionIDs {
+		result := &ItemDIF{QuestionID: id, ETSClass: ClassA, LRClass: ClassA}
+		var x [][3]float64
+		var y []float64
+		var refCorrect, focalCorrect float64
+		for i, row := range matrix.Responses {
+			if groups[i] == GroupNone || row[j] == irt.Missing {
+				continue
+			}
+			g := 0.0
+			if groups[i] == GroupFocal {
+				g = 1
+				result.FocalCount++
+				focalCorrect += float64(row[j])
+			} else {
+				result.ReferenceCount++
+				refCorrect += float64(row[j])
+			}
+			x = append(x, [3]float64{thetas[i], g, thetas[i] * g})
+			y = append(y, float64(row[j]))
+		}
+		if result.ReferenceCount < cfg.MinGroupSize || result.FocalCount < cfg.MinGroupSize {
+			result.Skipped = true
+			results[j] = result
+			continue
+		}
+		result.ReferenceRate = refCorrect / float64(result.ReferenceCount)
+		result.FocalRate = focalCorrect / float64(result.FocalCount)
+
+		mantelHaenszel(result, matrix.Responses, j, groups, strata, cfg.Alpha)
+		logisticDIF(result, x, y, cfg.Alpha)
+		results[j] = result
+	}
+	return results, nil
+}
+
+// abilityStrata 按全体考生能力估计的分位数划分层，未分组的考生为 -1
+func abilityStrata(groups []int, thetas []float64, strata int) []int {
+	rows := make([]int, 0, len(groups))
+	for i, g := range groups {
+		if g != GroupNone {
+			rows = append(rows, i)
+		}
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		return thetas[rows[a]] < thetas[rows[b]]
+	})
+
+	result := make([]int, len(groups))
+	for i := range result {
+		result[i] = -1
+	}
+	if strata < 1 {
+		strata = 1
+	}
+	for rank, i := range rows {
+		result[i] = rank * strata / len(rows)
+	}
+	return result
+}
+
+// mantelHaenszel 计算 MH 优势比、D-DIF 及其标准误（Robins–Breslow–Greenland），并给出 ETS 等级
+func mantelHaenszel(result *ItemDIF, matrix [][]int, column int, groups, strata []int, alpha float64) {
+	type table struct{ a, b, c, d float64 } // a/b 参照组对/错，c/d 焦点组对/错
+	tables := make(map[int]*table)
+	for i, row := range matrix {
+		if strata[i] < 0 || row[column] == irt.Missing {
+			continue
+		}
+		t, ok := tables[strata[i]]
+		if !ok {
+			t = &table{}
+			tables[strata[i]] = t
+		}
+		correct := row[column] == 1
+		switch {
+		case groups[i] == GroupReference && correct:
+			t.a++
+		case groups[i] == GroupReference:
+			t.b++
+		case correct:
+			t.c++
+		default:
+			t.d++
+		}
+	}
+
+	var sumR, sumS, sumPR, sumPSQR, sumQS float64
+	var sumA, sumEA, sumVarA float64
+	for _, t := range tables {
+		n := t.a + t.b + t.c + t.d
+		if n < 2 {
+			continue
+		}
+		r := t.a * t.d / n
+		s := t.b * t.c / n
+		p := (t.a + t.d) / n
+		q := (t.b + t.c) / n
+		sumR += r
+		sumS += s
+		sumPR += p * r
+		sumPSQR += p*s + q*r
+		sumQS += q * s
+
+		nRef, nFocal := t.a+t.b, t.c+t.d
+		m1, m0 := t.a+t.c, t.b+t.d
+		sumA += t.a
+		sumEA += nRef * m1 / n
+		sumVarA += nRef * nFocal * m1 * m0 / (n * n * (n - 1))
+	}
+	if sumR == 0 || sumS == 0 || sumVarA == 0 {
+		return
+	}
+
+	result.MHOddsRatio = sumR / sumS
+	result.MHDelta = -2.35 * math.Log(result.MHOddsRatio)
+	variance := sumPR/(2*sumR*sumR) + sumPSQR/(2*sumR*sumS) + sumQS/(2*sumS*sumS)
+	result.MHDeltaSE = 2.35 * math.Sqrt(variance)
+
+	diff := math.Max(math.Abs(sumA-sumEA)-0.5, 0)
+	result.MHChiSquare = diff * diff / sumVarA
+	result.MHPValue = ChiSquareSurvival(result.MHChiSquare, 1)
+
+	// ETS 规则：C 要求 |D| ≥ 1.5 且显著大于 1；B 要求 |D| ≥ 1 且显著不为 0
+	absDelta := math.Abs(result.MHDelta)
+	switch {
+	case absDelta >= 1.5 && result.MHDeltaSE > 0 && NormalSurvival((absDelta-1)/result.MHDeltaSE) < alpha:
+		result.ETSClass = ClassC
+	case absDelta >= 1 && result.MHPValue < alpha:
+		result.ETSClass = ClassB
+	default:
+		result.ETSClass = ClassA
+	}
+}
+
+// logisticDIF 依次拟合 θ、θ+组别、θ+组别+交互 三个模型（Swaminathan & Rogers, 1990），
+// 以 Nagelkerke R² 增量按 Jodoin & Gierl (2001) 标准分级
+func logisticDIF(result *ItemDIF, x [][3]float64, y []float64, alpha float64) {
+	n := float64(len(y))
+	var mean float64
+	for _, v := range y {
+		mean += v
+	}
+	mean /= n
+	if mean <= 0 || mean >= 1 {
+		return
+	}
+	nullLL := n * (mean*math.Log(mean) + (1-mean)*math.Log(1-mean))
+
+	ll1, _, _ := fitLogistic(x, y, 1)
+	ll2, _, _ := fitLogistic(x, y, 2)
+	ll3, iterations, converged := fitLogistic(x, y, 3)
+	result.LRIterations = iterations
+	result.LRConverged = converged
+
+	result.LRUniform = math.Max(2*(ll2-ll1), 0)
+	result.LRNonUniform = math.Max(2*(ll3-ll2), 0)
+	result.LRPValue = ChiSquareSurvival(2*math.Max(ll3-ll1, 0), 2)
+	result.LRDeltaR2 = nagelkerke(ll3, nullLL, n) - nagelkerke(ll1, nullLL, n)
+
+	switch {
+	case result.LRPValue >= alpha || result.LRDeltaR2 < 0.035:
+		result.LRClass = ClassA
+	case result.LRDeltaR2 < 0.070:
+		result.LRClass = ClassB
+	default:
+		result.LRClass = ClassC
+	}
+}
+
+// nagelkerke 计算 Nagelkerke 伪 R²
+func nagelkerke(ll, nullLL, n float64) float64 {
+	coxSnell := 1 - math.Exp(2*(nullLL-ll)/n)
+	maximum := 1 - math.Exp(2*nullLL/n)
+	if maximum <= 0 {
+		return 0
+	}
+	return coxSnell / maximum
+}
+
+// fitLogistic 用牛顿法拟合截距加前 k 个协变量的 logistic 回归，返回对数似然
+func fitLogistic(x [][3]float64, y []float64, k int) (float64, int, bool) {
+	const (
+		maxIterations = 50
+		tolerance     = 1e-8
+	)
+	dim := k + 1
+	beta := make([]float64, dim)
+	features := func(row [3]float64) []float64 {
+		f := make([]float64, dim)
+		f[0] = 1
+		copy(f[1:], row[:k])
+		return f
+	}
+
+	logLik := func(beta []float64) float64 {
+		var ll float64
+		for i, row := range x {
+			eta := dot(beta, features(row))
+			// log(1 + e^η) 的数值稳定写法
+			ll += y[i]*eta - math.Max(eta, 0) - math.Log1p(math.Exp(-math.Abs(eta)))
+		}
+		return ll
+	}
+
+	current := logLik(beta)
+	for iter := 1; iter <= maxIterations; iter++ {
+		gradient := make([]float64, dim)
+		hessian := make([][]float64, dim)
+		for a := range hessian {
+			hessian[a] = make([]float64, dim)
+		}
+		for i, row := range x {
+			f := features(row)
+			p := 1 / (1 + math.Exp(-dot(beta, f)))
+			w := p * (1 - p)
+			for a := 0; a < dim; a++ {
+				gradient[a] += (y[i] - p) * f[a]
+				for b := 0; b < dim; b++ {
+					hessian[a][b] += w * f[a] * f[b]
+				}
+			}
+		}
+
+		step, ok := irt.SolveLinear(hessian, gradient)
+		if !ok {
+			return current, iter, false
+		}
+		// 步长减半直到似然不再下降，防止完全分离时发散
+		scale := 1.0
+		for half := 0; half < 20; half++ {
+			candidate := make([]float64, dim)
+			for a := range beta {
+				candidate[a] = beta[a] + scale*step[a]
+			}
+			if ll := logLik(candidate); ll >= current-tolerance {
+				change := ll - current
+				beta, current = candidate, ll
+				if math.Abs(change) < tolerance {
+					return current, iter, true
+				}
+				break
+			}
+			scale /= 2
+		}
+	}
+	return current, maxIterations, false
+}
+
+func dot(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
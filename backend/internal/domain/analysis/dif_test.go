@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mhMatrix 两个能力层各80人（参照组、焦点组各40人）。
+// 第1题：低能力层参照组答对30、焦点组答对20；高能力层参照组答对20、焦点组答对10。
+// 第2题：两层中两组都答对30
+func mhMatrix() (*irt.ResponseMatrix, []int, []float64) {
+	matrix := &irt.ResponseMatrix{QuestionIDs: []uint{1, 2}}
+	var groups []int
+	var thetas []float64
+	for _, stratum := range []struct {
+		theta                  float64
+		refCorrect, focCorrect int
+	}{{-1, 30, 20}, {1, 20, 10}} {
+		for _, group := range []int{GroupReference, GroupFocal} {
+			correct := stratum.refCorrect
+			if group == GroupFocal {
+				correct = stratum.focCorrect
+			}
+			for i := 0; i < 40; i++ {
+				row := []int{0, 0}
+				if i < correct {
+					row[0] = 1
+				}
+				if i < 30 {
+					row[1] = 1
+				}
+				matrix.Responses = append(matrix.Responses, row)
+				groups = append(groups, group)
+				thetas = append(thetas, stratum.theta)
+			}
+		}
+	}
+	return matrix, groups, thetas
+}
+
+func TestMantelHaenszelTextbookValues(t *testing.T) {
+	matrix, groups, thetas := mhMatrix()
+	cfg := DefaultDIFConfig()
+	cfg.Strata = 2
+
+	results, err := DIF(matrix, groups, thetas, cfg)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// 每层 A·D/N = 7.5、B·C/N = 2.5，α_MH = 15/5 = 3
+	item := results[0]
+	assert.Equal(t, 80, item.ReferenceCount)
+	assert.Equal(t, 80, item.FocalCount)
+	assert.InDelta(t, 3.0, item.MHOddsRatio, 1e-9)
+	assert.InDelta(t, -2.35*math.Log(3), item.MHDelta, 1e-9)
+	// RBG 方差：9.375/450 + 8.75/150 + 1.875/50 = 0.116667
+	assert.InDelta(t, 2.35*math.Sqrt(0.35/3), item.MHDeltaSE, 1e-9)
+	// 连续性校正后 (|50 − 40| − 0.5)² / ΣVar(A)，ΣVar(A) = 2·(40·40·50·30)/(80²·79)
+	assert.InDelta(t, 9.5*9.5*80*80*79/(2*40*40*50*30), item.MHChiSquare, 1e-9)
+	assert.Less(t, item.MHPValue, 0.01)
+	// |D| = 2.58 ≥ 1.5 且显著大于 1
+	assert.Equal(t, ClassC, item.ETSClass)
+	assert.InDelta(t, 0.625, item.ReferenceRate, 1e-9)
+	assert.InDelta(t, 0.375, item.FocalRate, 1e-9)
+
+	// 两组答对率在各层相同，没有 DIF
+	item = results[1]
+	assert.InDelta(t, 1.0, item.MHOddsRatio, 1e-9)
+	assert.InDelta(t, 0.0, item.MHDelta, 1e-9)
+	assert.Equal(t, ClassA, item.ETSClass)
+}
+
+func TestDIFSkipsSmallGroups(t *testing.T) {
+	matrix, groups, thetas := mhMatrix()
+	cfg := DefaultDIFConfig()
+	cfg.MinGroupSize = 100
+
+	results, err := DIF(matrix, groups, thetas, cfg)
+	require.NoError(t, err)
+	for _, item := range results {
+		assert.True(t, item.Skipped)
+		assert.Zero(t, item.MHOddsRatio)
+	}
+
+	_, err = DIF(matrix, groups[1:], thetas, cfg)
+	assert.ErrorIs(t, err, ErrGroupMismatch)
+}
@@ -0,0 +1,184 @@
+package analysis
+
+import (
+	"errors"
+	"sort"
+)
+
+var ErrInvalidGroups = errors.New("ability groups must be at least 2")
+
+// DistractorConfig 干扰项分析配置
+type DistractorConfig struct {
+	Groups           int     // 按总分划分的能力分位组数
+	MinSelectionRate float64 // 干扰项选择率低于该值视为无效干扰项
+}
+
+// DefaultDistractorConfig 返回常用的五分位、5% 配置
+func DefaultDistractorConfig() DistractorConfig {
+	return DistractorConfig{
+		Groups:           5,
+		MinSelectionRate: 0.05,
+	}
+}
+
+// DistractorItem 参与分析的题目选项，Correct 与选项一一对应；无选项的题目只计入总分
+type DistractorItem struct {
+	Options []string
+	Correct []bool
+}
+
+// DistractorResponse 一名考生对一道题的作答
+type DistractorResponse struct {
+	Answered bool
+	Correct  bool   // 计分结果，计入总分
+	Selected []bool // 选中的选项，多选题可选中多个
+}
+
+// AbilityGroup 按总分排序后等分的一个能力分位组，同分考生可能分入相邻两组
+type AbilityGroup struct {
+	Examinees int
+	MinScore  int
+	MaxScore  int
+}
+
+// OptionAnalysis 单个选项的分析结果
+type OptionAnalysis struct {
+	Label          string
+	IsCorrect      bool
+	Count          int
+	SelectionRate  float64   // 占作答人数的比例
+	GroupRates     []float64 // 各能力分位组内作答者的选择比例，由低到高
+	PointBiserial  float64   // 是否选择该选项与去掉本题后总分的相关
+	NonFunctioning bool      // 干扰项选择率低于 MinSelectionRate
+	LikelyMiskey   bool      // 正确选项的区分度为负
+}
+
+// ItemDistractors 单题干扰项分析结果
+type ItemDistractors struct {
+	Item           int
+	Answered       int
+	Options        []OptionAnalysis
+	NonFunctioning int  // 无效干扰项个数
+	LikelyMiskey   bool // 任一正确选项疑似答案标错
+}
+
+// DistractorResult 一份试卷的干扰项分析结果
+type DistractorResult struct {
+	Examinees int
+	Groups    []AbilityGroup
+	Items     []ItemDistractors // 只包含有选项的题目，按题目下标排列
+}
+
+// Distractors 按总分将考生分为等人数的能力分位组，统计每个选项在各组的选择比例与选项点二列相关，
+// 标记选择率过低的干扰项与区分度为负的正确选项；未作答按答错计入总分，但不计入选项统计
+func Distractors(items []DistractorItem, responses [][]DistractorResponse, cfg DistractorConfig) (*DistractorResult, error) {
+	n := len(responses)
+	if n == 0 {
+		return nil, ErrNoExaminees
+	}
+	if cfg.Groups < 2 {
+		return nil, ErrInvalidGroups
+	}
+	groups := cfg.Groups
+	if groups > n {
+		groups = n
+	}
+
+	totals := make([]int, n)
+	for i, row := range responses {
+		for _, r := range row {
+			if r.Correct {
+				totals[i]++
+			}
+		}
+	}
+
+	// 按总分升序等分为能力分位组
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return totals[order[a]] < totals[order[b]] })
+	groupOf := make([]int, n)
+	result := &DistractorResult{Examinees: n, Groups: make([]AbilityGroup, groups)}
+	for rank, i := range order {
+		g := rank * groups / n
+		groupOf[i] = g
+		group := &result.Groups[g]
+		if group.Examinees == 0 || totals[i] < group.MinScore {
+			group.MinScore = totals[i]
+		}
+		if totals[i] > group.MaxScore {
+			group.MaxScore = totals[i]
+		}
+		group.Examinees++
+	}
+
+	for j, item := range items {
+		if len(item.Options) == 0 {
+			continue
+		}
+		analysis := ItemDistractors{Item: j, Options: make([]OptionAnalysis, len(item.Options))}
+		answeredInGroup := make([]int, groups)
+		rest := make([]float64, 0, n)
+		for i, row := range responses {
+			r := row[j]
+			if !r.Answered {
+				continue
+			}
+			analysis.Answered++
+			answeredInGroup[groupOf[i]]++
+			score := totals[i]
+			if r.Correct {
+				score--
+			}
+			rest = append(rest, float64(score))
+		}
+
+		indicator := make([]float64, 0, analysis.Answered)
+		for k, label := range item.Options {
+			option := OptionAnalysis{
+				Label:      label,
+				IsCorrect:  k < len(item.Correct) && item.Correct[k],
+				GroupRates: make([]float64, groups),
+			}
+			indicator = indicator[:0]
+			for i, row := range responses {
+				r := row[j]
+				if !r.Answered {
+					continue
+				}
+				if k < len(r.Selected) && r.Selected[k] {
+					option.Count++
+					option.GroupRates[groupOf[i]]++
+					indicator = append(indicator, 1)
+				} else {
+					indicator = append(indicator, 0)
+				}
+			}
+			for g := range option.GroupRates {
+				if answeredInGroup[g] > 0 {
+					option.GroupRates[g] /= float64(answeredInGroup[g])
+				}
+			}
+			if analysis.Answered > 0 {
+				option.SelectionRate = float64(option.Count) / float64(analysis.Answered)
+				option.PointBiserial = correlation(indicator, rest)
+				if option.IsCorrect {
+					option.LikelyMiskey = option.PointBiserial < 0
+				} else {
+					option.NonFunctioning = option.SelectionRate < cfg.MinSelectionRate
+				}
+			}
+			if option.NonFunctioning {
+				analysis.NonFunctioning++
+			}
+			if option.LikelyMiskey {
+				analysis.LikelyMiskey = true
+			}
+			analysis.Options[k] = option
+		}
+		result.Items = append(result.Items, analysis)
+	}
+	return result, nil
+}
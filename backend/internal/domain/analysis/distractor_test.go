@@ -0,0 +1,137 @@
+package analysis
+
+import (
+	"math/rand"
+	"testing"
+
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// choose 选中第 k 个选项的作答，correct 为计分结果
+func choose(options, k int, correct bool) DistractorResponse {
+	selected := make([]bool, options)
+	selected[k] = true
+	return DistractorResponse{Answered: true, Correct: correct, Selected: selected}
+}
+
+func TestDistractorsHandComputed(t *testing.T) {
+	// 第1题无选项只计分；第2题前四名考生依次选 B、B、A、A，第5人未作答，正确答案为 A
+	items := []DistractorItem{
+		{},
+		{Options: []string{"A", "B", "C"}, Correct: []bool{true, false, false}},
+	}
+	responses := [][]DistractorResponse{
+		{{Answered: true}, choose(3, 1, false)},
+		{{Answered: true, Correct: true}, choose(3, 1, false)},
+		{{Answered: true}, choose(3, 0, true)},
+		{{Answered: true, Correct: true}, choose(3, 0, true)},
+		{{Answered: true, Correct: true}, {}},
+	}
+	result, err := Distractors(items, responses, DistractorConfig{Groups: 2, MinSelectionRate: 0.05})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Examinees)
+
+	// 总分 0、1、1、2、1，按 rank·2/5 分组：前三名为低分组，同分考生分入两组
+	assert.Equal(t, []AbilityGroup{{Examinees: 3, MinScore: 0, MaxScore: 1}, {Examinees: 2, MinScore: 1, MaxScore: 2}}, result.Groups)
+
+	require.Len(t, result.Items, 1)
+	item := result.Items[0]
+	assert.Equal(t, 1, item.Item)
+	assert.Equal(t, 4, item.Answered)
+	a, b, c := item.Options[0], item.Options[1], item.Options[2]
+	assert.True(t, a.IsCorrect)
+	assert.Equal(t, 2, a.Count)
+	assert.InDelta(t, 0.5, a.SelectionRate, 1e-12)
+	// 低分组作答者为第1、2、3人，高分组作答者只有第4人
+	assert.InDeltaSlice(t, []float64{1.0 / 3, 1}, a.GroupRates, 1e-12)
+	assert.InDeltaSlice(t, []float64{2.0 / 3, 0}, b.GroupRates, 1e-12)
+	// 去掉本题后的总分为 0、1、0、1，选 A 与之不相关
+	assert.InDelta(t, 0, a.PointBiserial, 1e-12)
+	assert.False(t, a.LikelyMiskey)
+	assert.True(t, c.NonFunctioning)
+	assert.Equal(t, 1, item.NonFunctioning)
+}
+
+// distractorData 按能力生成12道计分题和两道四选一题目的作答；
+// 第2道选择题的答案标错：能力高的考生选 B，按标注答案 A 计分
+func distractorData(rng *rand.Rand, examinees int) ([]DistractorItem, [][]DistractorResponse) {
+	model := irt.Model{Family: irt.Model2PL, D: irt.ScalingNormal}
+	items := make([]DistractorItem, 14)
+	labels := []string{"A", "B", "C", "D"}
+	items[12] = DistractorItem{Options: labels, Correct: []bool{true, false, false, false}}
+	items[13] = DistractorItem{Options: labels, Correct: []bool{true, false, false, false}}
+
+	responses := make([][]DistractorResponse, examinees)
+	for i := range responses {
+		theta := rng.NormFloat64()
+		row := make([]DistractorResponse, len(items))
+		for j := 0; j < 12; j++ {
+			p := model.Probability(theta, irt.ItemParams{Difficulty: -1.5 + float64(j)*3/11, Discrimination: 1.2})
+			row[j] = DistractorResponse{Answered: true, Correct: rng.Float64() < p}
+		}
+		// 第1道选择题：答对选 A，答错时在 B、C 中选择，D 无人选
+		if rng.Float64() < model.Probability(theta, irt.ItemParams{Discrimination: 1.2}) {
+			row[12] = choose(4, 0, true)
+		} else {
+			row[12] = choose(4, 1+rng.Intn(2), false)
+		}
+		if rng.Float64() < model.Probability(theta, irt.ItemParams{Discrimination: 1.2}) {
+			row[13] = choose(4, 1, false)
+		} else {
+			option := rng.Intn(3)
+			if option > 0 {
+				option++
+			}
+			row[13] = choose(4, option, option == 0)
+		}
+		responses[i] = row
+	}
+	return items, responses
+}
+
+func TestDistractorsFlagsMiskeyAndNonFunctioning(t *testing.T) {
+	items, responses := distractorData(rand.New(rand.NewSource(59)), 1000)
+	result, err := Distractors(items, responses, DefaultDistractorConfig())
+	require.NoError(t, err)
+	require.Len(t, result.Groups, 5)
+	for _, group := range result.Groups {
+		assert.Equal(t, 200, group.Examinees)
+	}
+	require.Len(t, result.Items, 2)
+
+	// 正常题目：正确选项选择比例随能力分位组递增，干扰项相关为负，D 为无效干扰项
+	good := result.Items[0]
+	assert.Equal(t, 12, good.Item)
+	assert.False(t, good.LikelyMiskey)
+	key := good.Options[0]
+	for g := 1; g < len(key.GroupRates); g++ {
+		assert.Greater(t, key.GroupRates[g], key.GroupRates[g-1])
+	}
+	assert.Greater(t, key.PointBiserial, 0.2)
+	assert.Less(t, good.Options[1].PointBiserial, 0.0)
+	assert.Less(t, good.Options[2].PointBiserial, 0.0)
+	assert.Zero(t, good.Options[3].Count)
+	assert.True(t, good.Options[3].NonFunctioning)
+	assert.Equal(t, 1, good.NonFunctioning)
+
+	// 答案标错的题目：标注的正确选项区分度为负，实际答案 B 区分度为正
+	miskeyed := result.Items[1]
+	assert.True(t, miskeyed.LikelyMiskey)
+	assert.True(t, miskeyed.Options[0].LikelyMiskey)
+	assert.Greater(t, miskeyed.Options[1].PointBiserial, 0.2)
+}
+
+func TestDistractorsErrors(t *testing.T) {
+	_, err := Distractors(nil, nil, DefaultDistractorConfig())
+	assert.ErrorIs(t, err, ErrNoExaminees)
+	_, err = Distractors(nil, [][]DistractorResponse{{}}, DistractorConfig{Groups: 1})
+	assert.ErrorIs(t, err, ErrInvalidGroups)
+
+	// 分组数超过考生数时每人一组
+	result, err := Distractors(nil, [][]DistractorResponse{{}, {}}, DefaultDistractorConfig())
+	require.NoError(t, err)
+	assert.Len(t, result.Groups, 2)
+}
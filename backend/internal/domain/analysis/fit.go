@@ -0,0 +1,404 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+var ErrItemMismatch = errors.New("item parameters do not match response matrix columns")
+
+// 拟合判定阈值
+const (
+	MaxMeanSquare     = 1.3    // infit/outfit 均方上限，过拟合（均方偏小）不视为不拟合
+	ItemFitAlpha      = 0.01   // S-X² 显著性水平
+	PersonFitCritical = -1.645 // lz* 单侧5%临界值，低于该值视为异常作答模式
+)
+
+// Item 参与拟合分析的题目二级计分参数
+type Item struct {
+	QuestionID     uint
+	Difficulty     float64
+	Discrimination float64
+	Guessing       float64
+	UpperAsymptote float64 // 4PL 上渐近线，0表示1
+}
+
+// ResidualPoint 残差图上的一个能力分组
+type ResidualPoint struct {
+	Theta                float64 // 组内平均能力估计
+	Count                int
+	Observed             float64 // 组内实际答对比例
+	Expected             float64 // 模型期望答对比例
+	StandardizedResidual float64
+}
+
+// ItemFit 单题拟合统计量
+type ItemFit struct {
+	QuestionID uint
+	Count      int
+	Infit      float64 // 信息加权均方
+	Outfit     float64 // 未加权均方
+	Residuals  []ResidualPoint
+
+	// Orlando–Thissen S-X²，只使用作答了全部题目的考生
+	SX2          float64
+	SX2DF        int
+	SX2PValue    float64
+	SX2Available bool
+
+	Misfit bool
+}
+
+// PersonFit 考生拟合统计量
+type PersonFit struct {
+	Row    int // 作答矩阵中的行号
+	Theta  float64
+	Items  int
+	LzStar float64 // Snijders (2001) 修正的标准化对数似然
+	Misfit bool
+}
+
+// FitConfig 拟合分析配置
+type FitConfig struct {
+	ResidualGroups   int // 残差图能力分组数
+	QuadraturePoints int // S-X² 积分节点数
+	MinSX2Examinees  int // 计算 S-X² 所需的最少完整作答人数
+	MinExpected      float64
+	Model            irt.Model // 科目项目反应模型
+}
+
+// DefaultFitConfig 返回常用的拟合分析配置
+func DefaultFitConfig() FitConfig {
+	return FitConfig{
+		ResidualGroups:   10,
+		QuadraturePoints: 41,
+		MinSX2Examinees:  50,
+		MinExpected:      1,
+	}
+}
+
+// EstimateAbilities 使用 MAP 估计每一行考生的能力值，无作答的行为0
+func EstimateAbilities(matrix *irt.ResponseMatrix, items []Item, model irt.Model) ([]float64, error) {
+	if len(items) != len(matrix.QuestionIDs) {
+		return nil, ErrItemMismatch
+	}
+	estimator := irt.NewEstimator(irt.MethodMAP)
+	estimator.Model = model
+	thetas := make([]float64, len(matrix.Responses))
+	for i, row := range matrix.Responses {
+		responses := rowResponses(row, items)
+		if len(responses) == 0 {
+			continue
+		}
+		estimate, err := estimator.Estimate(responses)
+		if err != nil {
+			return nil, err
+		}
+		thetas[i] = estimate.Theta
+	}
+	return thetas, nil
+}
+
+// ItemFitStatistics 计算每道题的 infit/outfit、S-X² 与残差图数据
+func ItemFitStatistics(matrix *irt.ResponseMatrix, items []Item, thetas []float64, cfg FitConfig) ([]*ItemFit, error) {
+	if len(items) != len(matrix.QuestionIDs) {
+		return nil, ErrItemMismatch
+	}
+
+	fits := make([]*ItemFit, len(items))
+	for j, item := range items {
+		fit := &ItemFit{QuestionID: item.QuestionID}
+		var sumSquared, sumVariance, sumStandardized float64
+		for i, row := range matrix.Responses {
+			if row[j] == irt.Missing {
+				continue
+			}
+			p := probability(cfg.Model, thetas[i], item)
+			variance := p * (1 - p)
+			residual := float64(row[j]) - p
+			sumSquared += residual * residual
+			sumVariance += variance
+			sumStandardized += residual * residual / variance
+			fit.Count++
+		}
+		if fit.Count > 0 {
+			fit.Infit = sumSquared / sumVariance
+			fit.Outfit = sumStandardized / float64(fit.Count)
+		}
+		fit.Residuals = residualPoints(cfg.Model, matrix, j, item, thetas, cfg.ResidualGroups)
+		fits[j] = fit
+	}
+
+	sumScoreFit(matrix, items, fits, cfg)
+
+	for _, fit := range fits {
+		if fit.Count == 0 {
+			continue
+		}
+		fit.Misfit = fit.Infit > MaxMeanSquare || fit.Outfit > MaxMeanSquare ||
+			(fit.SX2Available && fit.SX2PValue < ItemFitAlpha)
+	}
+	return fits, nil
+}
+
+// residualPoints 按能力估计排序后等人数分组，比较实际与期望答对比例
+func residualPoints(model irt.Model, matrix *irt.ResponseMatrix, column int, item Item, thetas []float64, groups int) []ResidualPoint {
+	rows := make([]int, 0, len(matrix.Responses))
+	for i, row := range matrix.Responses {
+		if row[column] != irt.Missing {
+			rows = append(rows, i)
+		}
+	}
+	if len(rows) == 0 || groups <= 0 {
+		return nil
+	}
+	if groups > len(rows) {
+		groups = len(rows)
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		return thetas[rows[a]] < thetas[rows[b]]
+	})
+
+	points := make([]ResidualPoint, 0, groups)
+	for g := 0; g < groups; g++ {
+		start := g * len(rows) / groups
+		end := (g + 1) * len(rows) / groups
+		var point ResidualPoint
+		for _, i := range rows[start:end] {
+			point.Theta += thetas[i]
+			point.Observed += float64(matrix.Responses[i][column])
+			point.Expected += probability(model, thetas[i], item)
+		}
+		point.Count = end - start
+		n := float64(point.Count)
+		point.Theta /= n
+		point.Observed /= n
+		point.Expected /= n
+		point.StandardizedResidual = (point.Observed - point.Expected) / math.Sqrt(point.Expected*(1-point.Expected)/n)
+		points = append(points, point)
+	}
+	return points
+}
+
+// sumScoreFit 计算 Orlando–Thissen (2000) S-X²：
+// 按总分分组比较实际与期望答对比例，期望值由 Lord–Wingersky 递推得到
+func sumScoreFit(matrix *irt.ResponseMatrix, items []Item, fits []*ItemFit, cfg FitConfig) {
+	n := len(items)
+	if n < 3 {
+		return
+	}
+
+	var complete [][]int
+	for _, row := range matrix.Responses {
+		full := true
+		for _, x := range row {
+			if x == irt.Missing {
+				full = false
+				break
+			}
+		}
+		if full {
+			complete = append(complete, row)
+		}
+	}
+	if len(complete) < cfg.MinSX2Examinees {
+		return
+	}
+
+	// 各总分组的人数与各题答对人数
+	groupSize := make([]float64, n+1)
+	groupCorrect := make([][]float64, n)
+	for j := range groupCorrect {
+		groupCorrect[j] = make([]float64, n+1)
+	}
+	for _, row := range complete {
+		score := 0
+		for _, x := range row {
+			score += x
+		}
+		groupSize[score]++
+		for j, x := range row {
+			groupCorrect[j][score] += float64(x)
+		}
+	}
+
+	nodes, weights := irt.NormalQuadrature(cfg.QuadraturePoints)
+	probs := make([][]float64, len(nodes))
+	full := make([]float64, n+1) // 总分的边际分布
+	for q, theta := range nodes {
+		probs[q] = make([]float64, n)
+		for j, item := range items {
+			probs[q][j] = probability(cfg.Model, theta, item)
+		}
+		dist := scoreDistribution(probs[q], -1)
+		for k := range full {
+			full[k] += weights[q] * dist[k]
+		}
+	}
+
+	for j, item := range items {
+		// 去掉第 j 题后的总分分布
+		expected := make([]float64, n+1)
+		for q := range nodes {
+			rest := scoreDistribution(probs[q], j)
+			for k := 1; k < n; k++ {
+				expected[k] += weights[q] * probs[q][j] * rest[k-1]
+			}
+		}
+
+		// 合并期望人数过少的相邻总分组，不使用满分与零分组
+		var chi2 float64
+		groups := 0
+		var size, observed, expectedCount float64
+		for k := 1; k < n; k++ {
+			if full[k] <= 0 {
+				continue
+			}
+			size += groupSize[k]
+			observed += groupCorrect[j][k]
+			expectedCount += groupSize[k] * expected[k] / full[k]
+			if k < n-1 && (expectedCount < cfg.MinExpected || size-expectedCount < cfg.MinExpected) {
+				continue
+			}
+			if size > 0 {
+				e := expectedCount / size
+				o := observed / size
+				chi2 += size * (o - e) * (o - e) / (e * (1 - e))
+				groups++
+			}
+			size, observed, expectedCount = 0, 0, 0
+		}
+
+		df := groups - freeParameters(cfg.Model, item)
+		if df < 1 {
+			continue
+		}
+		fits[j].SX2 = chi2
+		fits[j].SX2DF = df
+		fits[j].SX2PValue = ChiSquareSurvival(chi2, df)
+		fits[j].SX2Available = true
+	}
+}
+
+// scoreDistribution 用 Lord–Wingersky 递推求给定能力下的总分分布，skip 为排除的题目下标
+func scoreDistribution(probs []float64, skip int) []float64 {
+	dist := make([]float64, len(probs)+1)
+	dist[0] = 1
+	count := 0
+	for j, p := range probs {
+		if j == skip {
+			continue
+		}
+		count++
+		for k := count; k > 0; k-- {
+			dist[k] = dist[k]*(1-p) + dist[k-1]*p
+		}
+		dist[0] *= 1 - p
+	}
+	return dist
+}
+
+// freeParameters 题目的自由参数个数，用于确定 S-X² 自由度
+func freeParameters(model irt.Model, item Item) int {
+	switch model.Family {
+	case irt.ModelRasch, irt.Model1PL:
+		return 1
+	case irt.Model2PL:
+		return 2
+	case irt.Model4PL:
+		return 4
+	}
+	if item.Guessing > 0 {
+		return 3
+	}
+	return 2
+}
+
+// PersonFitStatistics 计算每位考生的 lz*，能力值须为 MAP 估计（标准正态先验）
+func PersonFitStatistics(matrix *irt.ResponseMatrix, items []Item, thetas []float64, model irt.Model) ([]*PersonFit, error) {
+	if len(items) != len(matrix.QuestionIDs) {
+		return nil, ErrItemMismatch
+	}
+
+	fits := make([]*PersonFit, 0, len(matrix.Responses))
+	for i, row := range matrix.Responses {
+		theta := thetas[i]
+		fit := &PersonFit{Row: i, Theta: theta}
+
+		// W = Σ (x - P) w，w = ln(P/Q)；r = P'/(PQ) 为估计方程权重
+		var w, sumPW, sumPR, variance float64
+		type term struct{ p, weight, r float64 }
+		terms := make([]term, 0, len(row))
+		for j, x := range row {
+			if x == irt.Missing {
+				continue
+			}
+			item := items[j]
+			p := probability(model, theta, item)
+			derivative := model.Derivative(theta, item.params())
+			weight := math.Log(p / (1 - p))
+			r := derivative / (p * (1 - p))
+			w += (float64(x) - p) * weight
+			sumPW += derivative * weight
+			sumPR += derivative * r
+			terms = append(terms, term{p: p, weight: weight, r: r})
+		}
+		fit.Items = len(terms)
+		if fit.Items == 0 || sumPR == 0 {
+			fits = append(fits, fit)
+			continue
+		}
+
+		c := sumPW / sumPR
+		for _, t := range terms {
+			adjusted := t.weight - c*t.r
+			variance += adjusted * adjusted * t.p * (1 - t.p)
+		}
+		// MAP 估计方程中的先验项 r0 = -θ
+		r0 := -theta
+		if variance > 0 {
+			fit.LzStar = (w + c*r0) / math.Sqrt(variance)
+		}
+		fit.Misfit = fit.LzStar < PersonFitCritical
+		fits = append(fits, fit)
+	}
+	return fits, nil
+}
+
+// rowResponses 将作答矩阵的一行转换为能力估计所需的作答模式
+func rowResponses(row []int, items []Item) []irt.ItemResponse {
+	responses := make([]irt.ItemResponse, 0, len(row))
+	for j, x := range row {
+		if x == irt.Missing {
+			continue
+		}
+		responses = append(responses, irt.ItemResponse{
+			QuestionID:     items[j].QuestionID,
+			Difficulty:     items[j].Difficulty,
+			Discrimination: items[j].Discrimination,
+			Guessing:       items[j].Guessing,
+			UpperAsymptote: items[j].UpperAsymptote,
+			Correct:        x == 1,
+		})
+	}
+	return responses
+}
+
+// params 题目的二级计分参数
+func (item Item) params() irt.ItemParams {
+	return irt.ItemParams{
+		Difficulty:     item.Difficulty,
+		Discrimination: item.Discrimination,
+		Guessing:       item.Guessing,
+		UpperAsymptote: item.UpperAsymptote,
+	}
+}
+
+// probability 答对概率，截断在 (0, 1) 内以避免对数与除零溢出
+func probability(model irt.Model, theta float64, item Item) float64 {
+	p := model.Probability(theta, item.params())
+	return math.Min(math.Max(p, 1e-6), 1-1e-6)
+}
@@ -0,0 +1,157 @@
+package analysis
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeanSquareHandComputed(t *testing.T) {
+	// Rasch 题目难度为0：θ = 0 时 P = 1/2，θ = ln 3 时 P = 3/4
+	matrix := &irt.ResponseMatrix{QuestionIDs: []uint{1}, Responses: [][]int{{1}, {0}}}
+	items := []Item{{QuestionID: 1, Discrimination: 1}}
+	thetas := []float64{0, math.Log(3)}
+
+	cfg := DefaultFitConfig()
+	cfg.Model = irt.Model{Family: irt.ModelRasch, D: irt.ScalingLogistic}
+	fits, err := ItemFitStatistics(matrix, items, thetas, cfg)
+	require.NoError(t, err)
+
+	// infit = Σ(x−P)²/ΣPQ = (1/4 + 9/16)/(1/4 + 3/16)，outfit = mean((x−P)²/PQ) = (1 + 3)/2
+	fit := fits[0]
+	assert.Equal(t, 2, fit.Count)
+	assert.InDelta(t, 13.0/7, fit.Infit, 1e-9)
+	assert.InDelta(t, 2.0, fit.Outfit, 1e-9)
+	assert.False(t, fit.SX2Available)
+	assert.True(t, fit.Misfit)
+}
+
+// fitData 按2PL抽取作答，misfitColumn 列的作答与能力无关
+func fitData(rng *rand.Rand, examinees int, items []Item, misfitColumn int) (*irt.ResponseMatrix, []float64) {
+	model := irt.Model{Family: irt.Model2PL, D: irt.ScalingNormal}
+	matrix := &irt.ResponseMatrix{QuestionIDs: make([]uint, len(items)), Responses: make([][]int, examinees)}
+	for j, item := range items {
+		matrix.QuestionIDs[j] = item.QuestionID
+	}
+	thetas := make([]float64, examinees)
+	for i := range matrix.Responses {
+		thetas[i] = rng.NormFloat64()
+		row := make([]int, len(items))
+		for j, item := range items {
+			p := model.Probability(thetas[i], item.params())
+			if j == misfitColumn {
+				p = 0.5
+			}
+			if rng.Float64() < p {
+				row[j] = 1
+			}
+		}
+		matrix.Responses[i] = row
+	}
+	return matrix, thetas
+}
+
+// fitItems 12道2PL题目，难度从 -1.5 均匀增加到 1.5
+func fitItems() []Item {
+	items := make([]Item, 12)
+	for j := range items {
+		items[j] = Item{QuestionID: uint(j + 1), Difficulty: -1.5 + 3*float64(j)/11, Discrimination: 0.8 + 0.1*float64(j%5)}
+	}
+	return items
+}
+
+func TestItemFitModelConsistentData(t *testing.T) {
+	items := fitItems()
+	matrix, thetas := fitData(rand.New(rand.NewSource(11)), 2000, items, -1)
+
+	cfg := DefaultFitConfig()
+	cfg.Model = irt.Model{Family: irt.Model2PL, D: irt.ScalingNormal}
+	fits, err := ItemFitStatistics(matrix, items, thetas, cfg)
+	require.NoError(t, err)
+
+	// 按模型生成的作答均方接近1，S-X² 在1%水平下偶有拒绝
+	flagged := 0
+	for _, fit := range fits {
+		assert.InDelta(t, 1.0, fit.Infit, 0.1, "question %d", fit.QuestionID)
+		assert.Less(t, fit.Outfit, MaxMeanSquare, "question %d", fit.QuestionID)
+		require.True(t, fit.SX2Available)
+		assert.Greater(t, fit.SX2DF, 0)
+		assert.Len(t, fit.Residuals, cfg.ResidualGroups)
+		if fit.Misfit {
+			flagged++
+		}
+	}
+	assert.LessOrEqual(t, flagged, 1)
+
+	// 残差图的期望比例随能力分组递增
+	residuals := fits[5].Residuals
+	for g := 1; g < len(residuals); g++ {
+		assert.Greater(t, residuals[g].Expected, residuals[g-1].Expected)
+	}
+}
+
+func TestItemFitFlagsMisfittingItem(t *testing.T) {
+	// 第1题声称高区分度，实际作答与能力无关
+	items := fitItems()
+	items[0].Discrimination = 2
+	items[0].Difficulty = 0
+	matrix, thetas := fitData(rand.New(rand.NewSource(11)), 2000, items, 0)
+
+	cfg := DefaultFitConfig()
+	cfg.Model = irt.Model{Family: irt.Model2PL, D: irt.ScalingNormal}
+	fits, err := ItemFitStatistics(matrix, items, thetas, cfg)
+	require.NoError(t, err)
+
+	assert.True(t, fits[0].Misfit)
+	assert.Greater(t, fits[0].Infit, MaxMeanSquare)
+	assert.Greater(t, fits[0].Outfit, MaxMeanSquare)
+	require.True(t, fits[0].SX2Available)
+	assert.Less(t, fits[0].SX2PValue, ItemFitAlpha)
+}
+
+func TestPersonFitFlagsAberrantPattern(t *testing.T) {
+	items := make([]Item, 30)
+	for j := range items {
+		items[j] = Item{QuestionID: uint(j + 1), Difficulty: -2 + 4*float64(j)/29, Discrimination: 1.2}
+	}
+	// 按难度排序：正常考生答对容易的一半，异常考生答对困难的一半
+	typical := make([]int, len(items))
+	aberrant := make([]int, len(items))
+	for j := range items {
+		if j < 15 {
+			typical[j] = 1
+		} else {
+			aberrant[j] = 1
+		}
+	}
+	matrix := &irt.ResponseMatrix{QuestionIDs: make([]uint, len(items)), Responses: [][]int{typical, aberrant}}
+	model := irt.Model{Family: irt.Model2PL, D: irt.ScalingNormal}
+
+	thetas, err := EstimateAbilities(matrix, items, model)
+	require.NoError(t, err)
+	fits, err := PersonFitStatistics(matrix, items, thetas, model)
+	require.NoError(t, err)
+
+	assert.Equal(t, 30, fits[0].Items)
+	assert.False(t, fits[0].Misfit)
+	assert.Greater(t, fits[0].LzStar, 0.0)
+	assert.True(t, fits[1].Misfit)
+	assert.Less(t, fits[1].LzStar, PersonFitCritical)
+}
+
+func TestFitItemMismatch(t *testing.T) {
+	matrix := &irt.ResponseMatrix{QuestionIDs: []uint{1, 2}, Responses: [][]int{{1, 0}}}
+	items := []Item{{QuestionID: 1, Discrimination: 1}}
+
+	_, err := EstimateAbilities(matrix, items, irt.Model{})
+	assert.ErrorIs(t, err, ErrItemMismatch)
+	_, err = ItemFitStatistics(matrix, items, []float64{0}, DefaultFitConfig())
+	assert.ErrorIs(t, err, ErrItemMismatch)
+	_, err = PersonFitStatistics(matrix, items, []float64{0}, irt.Model{})
+	assert.ErrorIs(t, err, ErrItemMismatch)
+}
@@ -0,0 +1,411 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+var ErrInvalidKey = errors.New("item key must be one of its options")
+
+// ForensicsConfig 作答异常与作弊检测配置
+type ForensicsConfig struct {
+	Model irt.Model // 科目项目反应模型
+
+	// 答案相似性：GBT 与 ω 的 p 值按考生对数做 Bonferroni 校正后与 PairAlpha 比较
+	PairAlpha      float64
+	MinCommonItems int // 两人共同作答题数低于该值时不检验
+
+	// 异常快速答对：标准化用时残差不高于 -FastResidual 的答对作答
+	FastResidual float64
+	FastAlpha    float64 // 考生异常快速答对题数的二项检验显著性水平
+	SpeedMean    float64 // 考生速度先验
+	SpeedSD      float64
+
+	// 类擦除改答：错改对次数相对同卷考生的 z 分数
+	ChangeZ         float64
+	MinWrongToRight int // 错改对次数低于该值时不标记
+}
+
+// DefaultForensicsConfig 返回常用的检测配置
+func DefaultForensicsConfig() ForensicsConfig {
+	return ForensicsConfig{
+		PairAlpha:       0.05,
+		MinCommonItems:  10,
+		FastResidual:    1.96,
+		FastAlpha:       0.001,
+		SpeedSD:         1,
+		ChangeZ:         3,
+		MinWrongToRight: 2,
+	}
+}
+
+// ForensicItem 参与检测的题目，选项按 0..Options-1 编号
+type ForensicItem struct {
+	Item
+	Key     int            // 正确选项
+	Options int            // 选项总数（含正确选项）
+	Time    irt.TimeParams // 作答时间参数，未标定时区分度为0
+}
+
+// AnswerChange 一次改答：考试过程中同一题前后提交了不同选项
+type AnswerChange struct {
+	Item int // 题目下标
+	From int
+	To   int
+}
+
+// ForensicExaminee 单个考生在一份试卷上的作答
+type ForensicExaminee struct {
+	Options []int     // 每题最终选项，irt.Missing 表示未作答
+	Seconds []float64 // 每题用时，0表示未记录
+	Changes []AnswerChange
+}
+
+// PairSimilarity 一对考生的答案相似性
+type PairSimilarity struct {
+	RowA               int
+	RowB               int
+	CommonItems        int
+	Matches            int     // 选项相同的题数
+	IdenticalIncorrect int     // 选择相同错误选项的题数
+	Expected           float64 // 两人独立作答时的期望相同题数
+	GBTPValue          float64 // 广义二项检验 P(M ≥ Matches)
+	Omega              float64 // 两个抄袭方向中较大的 ω
+	OmegaPValue        float64
+	Suspicious         bool
+}
+
+// FastCorrectFlag 一次异常快速的答对作答
+type FastCorrectFlag struct {
+	Row         int
+	Item        int
+	Seconds     float64
+	Residual    float64 // 标准化用时残差
+	Probability float64 // 模型答对概率
+}
+
+// PersonForensics 单个考生的异常作答指标
+type PersonForensics struct {
+	Row    int
+	Theta  float64
+	Items  int
+	LzStar float64
+	Misfit bool
+
+	Speed       float64
+	FastCorrect int
+	FastPValue  float64 // 模型成立时答对作答中异常快速者的个数服从二项分布
+	FastFlagged bool
+
+	Changes          int
+	WrongToRight     int
+	RightToWrong     int
+	WrongToWrong     int
+	WrongToRightZ    float64 // 错改对次数相对同卷考生的 z 分数
+	ExcessiveChanges bool
+
+	Flagged bool
+}
+
+// ForensicsResult 一份试卷的作答异常与作弊检测结果
+type ForensicsResult struct {
+	Persons        []PersonForensics
+	Pairs          []PairSimilarity // 只包含可疑考生对，按 GBT p 值升序
+	PairsTested    int
+	CriticalPValue float64 // 校正后的考生对显著性水平
+	FastCorrect    []FastCorrectFlag
+}
+
+// Forensics 检测一份试卷上的异常作答：
+// 考生拟合 lz*、考生对的答案相似性（GBT 与 ω）、异常快速答对和类擦除的错改对。
+// 选项概率由答对概率与同题答错考生的干扰项选择比例组合而成
+func Forensics(items []ForensicItem, examinees []ForensicExaminee, cfg ForensicsConfig) (*ForensicsResult, error) {
+	for _, item := range items {
+		if item.Key < 0 || item.Key >= item.Options {
+			return nil, ErrInvalidKey
+		}
+	}
+
+	// 二级计分矩阵与 MAP 能力估计
+	matrix := &irt.ResponseMatrix{
+		QuestionIDs: make([]uint, len(items)),
+		Responses:   make([][]int, len(examinees)),
+	}
+	fitItems := make([]Item, len(items))
+	for j, item := range items {
+		matrix.QuestionIDs[j] = item.QuestionID
+		fitItems[j] = item.Item
+	}
+	for i, e := range examinees {
+		row := make([]int, len(items))
+		for j, option := range e.Options {
+			row[j] = irt.Missing
+			if option != irt.Missing {
+				row[j] = 0
+				if option == items[j].Key {
+					row[j] = 1
+				}
+			}
+		}
+		matrix.Responses[i] = row
+	}
+	thetas, err := EstimateAbilities(matrix, fitItems, cfg.Model)
+	if err != nil {
+		return nil, err
+	}
+	fits, err := PersonFitStatistics(matrix, fitItems, thetas, cfg.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ForensicsResult{Persons: make([]PersonForensics, len(examinees))}
+	for i, fit := range fits {
+		result.Persons[i] = PersonForensics{
+			Row:    i,
+			Theta:  fit.Theta,
+			Items:  fit.Items,
+			LzStar: fit.LzStar,
+			Misfit: fit.Misfit,
+		}
+	}
+
+	// 每个考生在每道题上选择各选项的概率
+	distractors := distractorWeights(items, examinees)
+	probs := make([][][]float64, len(examinees))
+	for i := range examinees {
+		probs[i] = make([][]float64, len(items))
+		for j, item := range items {
+			p := probability(cfg.Model, thetas[i], item.Item)
+			options := make([]float64, item.Options)
+			for k := range options {
+				if k == item.Key {
+					options[k] = p
+				} else {
+					options[k] = (1 - p) * distractors[j][k]
+				}
+			}
+			probs[i][j] = options
+		}
+	}
+
+	fastCorrect(items, examinees, probs, result, cfg)
+	answerChanges(items, examinees, result, cfg)
+	pairSimilarity(items, examinees, probs, result, cfg)
+
+	for i := range result.Persons {
+		person := &result.Persons[i]
+		person.Flagged = person.Misfit || person.FastFlagged || person.ExcessiveChanges
+	}
+	for _, pair := range result.Pairs {
+		result.Persons[pair.RowA].Flagged = true
+		result.Persons[pair.RowB].Flagged = true
+	}
+	return result, nil
+}
+
+// distractorWeights 答错考生在各干扰项上的选择比例，加0.5平滑使未被选过的干扰项概率不为0
+func distractorWeights(items []ForensicItem, examinees []ForensicExaminee) [][]float64 {
+	weights := make([][]float64, len(items))
+	for j, item := range items {
+		counts := make([]float64, item.Options)
+		var total float64
+		for k := range counts {
+			if k != item.Key {
+				counts[k] = 0.5
+				total += 0.5
+			}
+		}
+		for _, e := range examinees {
+			option := e.Options[j]
+			if option != irt.Missing && option != item.Key && option < item.Options {
+				counts[option]++
+				total++
+			}
+		}
+		for k := range counts {
+			if total > 0 {
+				counts[k] /= total
+			}
+		}
+		weights[j] = counts
+	}
+	return weights
+}
+
+// fastCorrect 按对数正态时间模型标记异常快速的答对作答，速度用考生全部有用时的作答估计
+func fastCorrect(items []ForensicItem, examinees []ForensicExaminee, probs [][][]float64, result *ForensicsResult, cfg ForensicsConfig) {
+	for i, e := range examinees {
+		timed := make([]irt.TimedResponse, 0, len(items))
+		for j, seconds := range e.Seconds {
+			if seconds > 0 && items[j].Time.Calibrated() {
+				timed = append(timed, irt.TimedResponse{QuestionID: items[j].QuestionID, Item: items[j].Time, Seconds: seconds})
+			}
+		}
+		if len(timed) == 0 {
+			continue
+		}
+		person := &result.Persons[i]
+		person.Speed = irt.EstimateSpeed(timed, cfg.SpeedMean, cfg.SpeedSD).Tau
+
+		var correct int
+		for j, seconds := range e.Seconds {
+			item := items[j]
+			if seconds <= 0 || !item.Time.Calibrated() || e.Options[j] != item.Key {
+				continue
+			}
+			correct++
+			residual := item.Time.Residual(seconds, person.Speed)
+			if residual > -cfg.FastResidual {
+				continue
+			}
+			person.FastCorrect++
+			result.FastCorrect = append(result.FastCorrect, FastCorrectFlag{
+				Row:         i,
+				Item:        j,
+				Seconds:     seconds,
+				Residual:    residual,
+				Probability: probs[i][j][item.Key],
+			})
+		}
+		rates := make([]float64, correct)
+		for k := range rates {
+			rates[k] = NormalSurvival(cfg.FastResidual)
+		}
+		person.FastPValue = upperTail(rates, person.FastCorrect)
+		person.FastFlagged = person.FastCorrect > 0 && person.FastPValue < cfg.FastAlpha
+	}
+}
+
+// answerChanges 统计改答类型，错改对次数与同卷考生比较
+func answerChanges(items []ForensicItem, examinees []ForensicExaminee, result *ForensicsResult, cfg ForensicsConfig) {
+	var sum, sumSq float64
+	for i, e := range examinees {
+		person := &result.Persons[i]
+		for _, change := range e.Changes {
+			key := items[change.Item].Key
+			person.Changes++
+			switch {
+			case change.From != key && change.To == key:
+				person.WrongToRight++
+			case change.From == key && change.To != key:
+				person.RightToWrong++
+			default:
+				person.WrongToWrong++
+			}
+		}
+		sum += float64(person.WrongToRight)
+		sumSq += float64(person.WrongToRight * person.WrongToRight)
+	}
+	n := float64(len(examinees))
+	if n < 2 {
+		return
+	}
+	mean := sum / n
+	sd := math.Sqrt(math.Max((sumSq-n*mean*mean)/(n-1), 0))
+	if sd == 0 {
+		return
+	}
+	for i := range result.Persons {
+		person := &result.Persons[i]
+		person.WrongToRightZ = (float64(person.WrongToRight) - mean) / sd
+		person.ExcessiveChanges = person.WrongToRight >= cfg.MinWrongToRight && person.WrongToRightZ >= cfg.ChangeZ
+	}
+}
+
+// pairSimilarity 对每对考生计算共同作答题上的选项相同数：
+// GBT 以两人独立作答时各题选项相同的概率 Σ_k P_ak·P_bk 求相同数的精确分布；
+// ω 以一方的作答为已知，比较另一方选到相同选项的次数与期望，取两个方向中较大者
+func pairSimilarity(items []ForensicItem, examinees []ForensicExaminee, probs [][][]float64, result *ForensicsResult, cfg ForensicsConfig) {
+	type candidate struct {
+		pair      PairSimilarity
+		minPValue float64
+	}
+	candidates := make([]candidate, 0)
+	matchProbs := make([]float64, 0, len(items))
+
+	for a := 0; a < len(examinees); a++ {
+		for b := a + 1; b < len(examinees); b++ {
+			pair := PairSimilarity{RowA: a, RowB: b}
+			matchProbs = matchProbs[:0]
+			var expectedA, varianceA, expectedB, varianceB float64
+			for j := range items {
+				optionA, optionB := examinees[a].Options[j], examinees[b].Options[j]
+				if optionA == irt.Missing || optionB == irt.Missing || optionA >= items[j].Options || optionB >= items[j].Options {
+					continue
+				}
+				pair.CommonItems++
+				if optionA == optionB {
+					pair.Matches++
+					if optionA != items[j].Key {
+						pair.IdenticalIncorrect++
+					}
+				}
+				var p float64
+				for k := range probs[a][j] {
+					p += probs[a][j][k] * probs[b][j][k]
+				}
+				matchProbs = append(matchProbs, p)
+				pair.Expected += p
+
+				// b 抄 a：b 选到 a 所选选项的概率
+				pb := probs[b][j][optionA]
+				expectedB += pb
+				varianceB += pb * (1 - pb)
+				pa := probs[a][j][optionB]
+				expectedA += pa
+				varianceA += pa * (1 - pa)
+			}
+			if pair.CommonItems == 0 || pair.CommonItems < cfg.MinCommonItems {
+				continue
+			}
+			result.PairsTested++
+
+			pair.GBTPValue = upperTail(matchProbs, pair.Matches)
+			pair.Omega = math.Inf(-1)
+			for _, direction := range [][2]float64{{expectedA, varianceA}, {expectedB, varianceB}} {
+				if direction[1] > 0 {
+					pair.Omega = math.Max(pair.Omega, (float64(pair.Matches)-direction[0])/math.Sqrt(direction[1]))
+				}
+			}
+			if math.IsInf(pair.Omega, -1) {
+				pair.Omega = 0
+			}
+			pair.OmegaPValue = NormalSurvival(pair.Omega)
+			candidates = append(candidates, candidate{pair: pair, minPValue: math.Min(pair.GBTPValue, pair.OmegaPValue)})
+		}
+	}
+
+	if result.PairsTested == 0 {
+		return
+	}
+	result.CriticalPValue = cfg.PairAlpha / float64(result.PairsTested)
+	for _, c := range candidates {
+		if c.minPValue < result.CriticalPValue {
+			c.pair.Suspicious = true
+			result.Pairs = append(result.Pairs, c.pair)
+		}
+	}
+	sort.Slice(result.Pairs, func(x, y int) bool {
+		return result.Pairs[x].GBTPValue < result.Pairs[y].GBTPValue
+	})
+}
+
+// upperTail 独立伯努利变量之和（泊松二项分布）不小于 m 的概率
+func upperTail(probs []float64, m int) float64 {
+	dist := make([]float64, len(probs)+1)
+	dist[0] = 1
+	for n, p := range probs {
+		for k := n + 1; k > 0; k-- {
+			dist[k] = dist[k]*(1-p) + dist[k-1]*p
+		}
+		dist[0] *= 1 - p
+	}
+	var tail float64
+	for k := m; k < len(dist); k++ {
+		tail += dist[k]
+	}
+	return math.Min(tail, 1)
+}
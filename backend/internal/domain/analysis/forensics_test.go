@@ -0,0 +1,145 @@
+package analysis
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpperTailHandComputed(t *testing.T) {
+	// 两枚均匀硬币：P(M ≥ 1) = 3/4，P(M ≥ 2) = 1/4
+	assert.InDelta(t, 1.0, upperTail([]float64{0.5, 0.5}, 0), 1e-12)
+	assert.InDelta(t, 0.75, upperTail([]float64{0.5, 0.5}, 1), 1e-12)
+	assert.InDelta(t, 0.25, upperTail([]float64{0.5, 0.5}, 2), 1e-12)
+	assert.Zero(t, upperTail([]float64{0.5, 0.5}, 3))
+
+	// 概率不同时：P(M = 2) = 0.2·0.6，P(M ≥ 1) = 1 − 0.8·0.4
+	assert.InDelta(t, 0.12, upperTail([]float64{0.2, 0.6}, 2), 1e-12)
+	assert.InDelta(t, 0.68, upperTail([]float64{0.2, 0.6}, 1), 1e-12)
+}
+
+// forensicData 60名考生作答40道四选一题目，答错时在干扰项中均匀选择，每题用时服从对数正态模型
+func forensicData(rng *rand.Rand) ([]ForensicItem, []ForensicExaminee) {
+	model := irt.Model{Family: irt.Model2PL, D: irt.ScalingNormal}
+	items := make([]ForensicItem, 40)
+	for j := range items {
+		items[j] = ForensicItem{
+			Item:    Item{QuestionID: uint(j + 1), Difficulty: -1.5 + 3*float64(j)/39, Discrimination: 0.8 + 0.6*rng.Float64()},
+			Key:     j % 4,
+			Options: 4,
+			Time:    irt.TimeParams{Intensity: math.Log(40), Discrimination: 2},
+		}
+	}
+	examinees := make([]ForensicExaminee, 60)
+	for i := range examinees {
+		theta := rng.NormFloat64()
+		e := ForensicExaminee{Options: make([]int, len(items)), Seconds: make([]float64, len(items))}
+		for j, item := range items {
+			if rng.Float64() < model.Probability(theta, item.params()) {
+				e.Options[j] = item.Key
+			} else {
+				e.Options[j] = (item.Key + 1 + rng.Intn(3)) % 4
+			}
+			e.Seconds[j] = math.Exp(item.Time.Intensity + rng.NormFloat64()/item.Time.Discrimination)
+		}
+		examinees[i] = e
+	}
+	return items, examinees
+}
+
+func TestForensicsFlagsCopiedPair(t *testing.T) {
+	items, examinees := forensicData(rand.New(rand.NewSource(47)))
+	// 得分最低的考生（错误选项最多）的前30题答案被另一名考生照抄
+	source, lowest := 0, len(items)
+	for i, e := range examinees {
+		correct := 0
+		for j, option := range e.Options {
+			if option == items[j].Key {
+				correct++
+			}
+		}
+		if correct < lowest {
+			source, lowest = i, correct
+		}
+	}
+	copier := (source + 1) % len(examinees)
+	copy(examinees[copier].Options[:30], examinees[source].Options[:30])
+
+	cfg := DefaultForensicsConfig()
+	cfg.Model = irt.Model{Family: irt.Model2PL, D: irt.ScalingNormal}
+	result, err := Forensics(items, examinees, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 60*59/2, result.PairsTested)
+	assert.InDelta(t, 0.05/1770, result.CriticalPValue, 1e-15)
+
+	require.NotEmpty(t, result.Pairs)
+	pair := result.Pairs[0]
+	assert.ElementsMatch(t, []int{source, copier}, []int{pair.RowA, pair.RowB})
+	assert.True(t, pair.Suspicious)
+	assert.Equal(t, 40, pair.CommonItems)
+	assert.GreaterOrEqual(t, pair.Matches, 30)
+	assert.Greater(t, pair.IdenticalIncorrect, 10)
+	assert.Greater(t, float64(pair.Matches), pair.Expected)
+	assert.Less(t, pair.GBTPValue, result.CriticalPValue)
+	assert.Greater(t, pair.Omega, 3.0)
+	assert.Len(t, result.Pairs, 1)
+	assert.True(t, result.Persons[source].Flagged)
+	assert.True(t, result.Persons[copier].Flagged)
+}
+
+func TestForensicsFlagsFastCorrectAndChanges(t *testing.T) {
+	items, examinees := forensicData(rand.New(rand.NewSource(53)))
+	// 第3名考生前15题全部在2秒内答对
+	for j := 0; j < 15; j++ {
+		examinees[2].Options[j] = items[j].Key
+		examinees[2].Seconds[j] = 2
+	}
+	// 第4名考生有6次错改对，其他考生偶尔改答
+	for j := 0; j < 6; j++ {
+		examinees[3].Options[j] = items[j].Key
+		examinees[3].Changes = append(examinees[3].Changes, AnswerChange{Item: j, From: (items[j].Key + 1) % 4, To: items[j].Key})
+	}
+	for i := 10; i < 20; i++ {
+		examinees[i].Changes = []AnswerChange{{Item: 20, From: items[20].Key, To: (items[20].Key + 1) % 4}}
+	}
+	examinees[20].Changes = []AnswerChange{{Item: 21, From: (items[21].Key + 1) % 4, To: items[21].Key}}
+
+	cfg := DefaultForensicsConfig()
+	cfg.Model = irt.Model{Family: irt.Model2PL, D: irt.ScalingNormal}
+	result, err := Forensics(items, examinees, cfg)
+	require.NoError(t, err)
+
+	fast := result.Persons[2]
+	assert.GreaterOrEqual(t, fast.FastCorrect, 15)
+	assert.Less(t, fast.FastPValue, cfg.FastAlpha)
+	assert.True(t, fast.FastFlagged)
+	assert.True(t, fast.Flagged)
+	for _, flag := range result.FastCorrect {
+		assert.LessOrEqual(t, flag.Residual, -cfg.FastResidual)
+	}
+
+	changes := result.Persons[3]
+	assert.Equal(t, 6, changes.Changes)
+	assert.Equal(t, 6, changes.WrongToRight)
+	assert.Greater(t, changes.WrongToRightZ, cfg.ChangeZ)
+	assert.True(t, changes.ExcessiveChanges)
+	assert.Equal(t, 1, result.Persons[10].RightToWrong)
+	assert.False(t, result.Persons[20].ExcessiveChanges)
+
+	for i, person := range result.Persons {
+		if i != 2 {
+			assert.False(t, person.FastFlagged, "row %d", i)
+		}
+	}
+}
+
+func TestForensicsInvalidKey(t *testing.T) {
+	items := []ForensicItem{{Item: Item{QuestionID: 1, Discrimination: 1}, Key: 4, Options: 4}}
+	_, err := Forensics(items, nil, DefaultForensicsConfig())
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
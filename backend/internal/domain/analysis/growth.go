@@ -0,0 +1,225 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+// 能力成长追踪：局部线性趋势状态空间模型，状态为能力水平 θ 与成长速度 v，
+// θ_t = θ_{t-1} + v_{t-1}·Δt + η，v_t = v_{t-1} + ζ，观测为各次考试的能力估计，
+// 观测误差方差取该次估计标准误的平方；前向 Kalman 滤波后以 RTS 平滑得到成长曲线
+
+var ErrNoObservations = errors.New("no ability observations to track")
+
+// GrowthConfig 成长追踪配置，速度与噪声均以 TimeUnit 为时间单位
+type GrowthConfig struct {
+	TimeUnit         time.Duration // 成长速度的时间单位，缺省为30天
+	LevelNoise       float64       // 能力水平每单位时间的过程噪声方差
+	SlopeNoise       float64       // 成长速度每单位时间的过程噪声方差
+	InitialSlopeSD   float64       // 成长速度先验标准差，先验均值为0
+	MinStandardError float64       // 标准误缺失或过小的观测按该值处理
+	DeclineZ         float64       // 标准化新息不高于 -DeclineZ 时标记为显著下降
+	TrendZ           float64       // 当前成长速度的 z 值不高于 -TrendZ 时判定为持续下降
+}
+
+// DefaultGrowthConfig 返回常用的成长追踪配置
+func DefaultGrowthConfig() GrowthConfig {
+	return GrowthConfig{
+		TimeUnit:         30 * 24 * time.Hour,
+		LevelNoise:       0.01,
+		SlopeNoise:       0.0025,
+		InitialSlopeSD:   0.2,
+		MinStandardError: 0.1,
+		DeclineZ:         1.96,
+		TrendZ:           1.96,
+	}
+}
+
+// GrowthObservation 一次能力估计
+type GrowthObservation struct {
+	Time          time.Time
+	Theta         float64
+	StandardError float64
+}
+
+// GrowthPoint 成长曲线上对应一次观测的点
+type GrowthPoint struct {
+	Time          time.Time
+	Observed      float64
+	ObservedSE    float64
+	Filtered      float64 // 只用此前及本次观测的滤波估计
+	FilteredSE    float64
+	Smoothed      float64 // 使用全部观测的平滑估计
+	SmoothedSE    float64
+	Slope         float64 // 平滑后的成长速度
+	SlopeSE       float64
+	Innovation    float64 // 标准化新息：观测相对一步预测的偏离
+	Decline       bool    // 观测显著低于一步预测
+	predictedMean [2]float64
+	predictedCov  [2][2]float64
+	filteredMean  [2]float64
+	filteredCov   [2][2]float64
+}
+
+// GrowthTrajectory 成长追踪结果，Points 按时间升序
+type GrowthTrajectory struct {
+	Points        []GrowthPoint
+	Current       float64 // 最近一次观测时的能力水平
+	CurrentSE     float64
+	GrowthRate    float64 // 当前成长速度，每个时间单位的能力变化
+	GrowthRateSE  float64
+	Declining     bool // 成长速度显著为负
+	LogLikelihood float64
+	config        GrowthConfig
+}
+
+// GrowthForecast 未来某一时刻的能力预测
+type GrowthForecast struct {
+	Time          time.Time
+	Theta         float64
+	StandardError float64
+}
+
+// TrackGrowth 对按时间排列的能力估计做 Kalman 滤波与 RTS 平滑
+func TrackGrowth(observations []GrowthObservation, cfg GrowthConfig) (*GrowthTrajectory, error) {
+	if len(observations) == 0 {
+		return nil, ErrNoObservations
+	}
+	if cfg.TimeUnit <= 0 {
+		cfg.TimeUnit = DefaultGrowthConfig().TimeUnit
+	}
+	obs := append([]GrowthObservation(nil), observations...)
+	sort.SliceStable(obs, func(a, b int) bool { return obs[a].Time.Before(obs[b].Time) })
+
+	traj := &GrowthTrajectory{Points: make([]GrowthPoint, len(obs)), config: cfg}
+	// 首次观测前的状态：水平近似无信息，速度取先验
+	mean := [2]float64{obs[0].Theta, 0}
+	cov := [2][2]float64{{1e4, 0}, {0, cfg.InitialSlopeSD * cfg.InitialSlopeSD}}
+	previous := obs[0].Time
+	for t, o := range obs {
+		se := math.Max(o.StandardError, cfg.MinStandardError)
+		mean, cov = cfg.predict(mean, cov, o.Time.Sub(previous))
+		previous = o.Time
+
+		point := &traj.Points[t]
+		point.Time = o.Time
+		point.Observed = o.Theta
+		point.ObservedSE = se
+		point.predictedMean, point.predictedCov = mean, cov
+
+		// 更新：观测只含能力水平
+		s := cov[0][0] + se*se
+		residual := o.Theta - mean[0]
+		if t > 0 {
+			point.Innovation = residual / math.Sqrt(s)
+			point.Decline = point.Innovation <= -cfg.DeclineZ
+			traj.LogLikelihood += -0.5 * (math.Log(2*math.Pi*s) + residual*residual/s)
+		}
+		gain := [2]float64{cov[0][0] / s, cov[1][0] / s}
+		mean = [2]float64{mean[0] + gain[0]*residual, mean[1] + gain[1]*residual}
+		cov = [2][2]float64{
+			{cov[0][0] - gain[0]*cov[0][0], cov[0][1] - gain[0]*cov[0][1]},
+			{cov[1][0] - gain[1]*cov[0][0], cov[1][1] - gain[1]*cov[0][1]},
+		}
+		point.filteredMean, point.filteredCov = mean, cov
+		point.Filtered = mean[0]
+		point.FilteredSE = math.Sqrt(math.Max(0, cov[0][0]))
+	}
+
+	// RTS 平滑：自后向前用后一时刻的平滑结果修正滤波估计
+	last := len(obs) - 1
+	smoothedMean, smoothedCov := traj.Points[last].filteredMean, traj.Points[last].filteredCov
+	traj.Points[last].setSmoothed(smoothedMean, smoothedCov)
+	for t := last - 1; t >= 0; t-- {
+		current, next := &traj.Points[t], &traj.Points[t+1]
+		dt := cfg.units(next.Time.Sub(current.Time))
+		// J = P_t|t·Fᵀ·P_{t+1|t}⁻¹
+		pf := current.filteredCov
+		pfFt := [2][2]float64{
+			{pf[0][0] + pf[0][1]*dt, pf[0][1]},
+			{pf[1][0] + pf[1][1]*dt, pf[1][1]},
+		}
+		inv, ok := irt.InvertMatrix(matrix2(next.predictedCov))
+		if !ok {
+			current.setSmoothed(current.filteredMean, current.filteredCov)
+			smoothedMean, smoothedCov = current.filteredMean, current.filteredCov
+			continue
+		}
+		j := irt.MultiplyMatrix(matrix2(pfFt), inv)
+		diffMean := [2]float64{smoothedMean[0] - next.predictedMean[0], smoothedMean[1] - next.predictedMean[1]}
+		var diffCov [2][2]float64
+		for a := 0; a < 2; a++ {
+			for b := 0; b < 2; b++ {
+				diffCov[a][b] = smoothedCov[a][b] - next.predictedCov[a][b]
+			}
+		}
+		mean := current.filteredMean
+		for a := 0; a < 2; a++ {
+			mean[a] += j[a][0]*diffMean[0] + j[a][1]*diffMean[1]
+		}
+		cov := current.filteredCov
+		correction := irt.MultiplyMatrix(irt.MultiplyMatrix(j, matrix2(diffCov)), irt.TransposeMatrix(j))
+		for a := 0; a < 2; a++ {
+			for b := 0; b < 2; b++ {
+				cov[a][b] += correction[a][b]
+			}
+		}
+		current.setSmoothed(mean, cov)
+		smoothedMean, smoothedCov = mean, cov
+	}
+
+	final := traj.Points[last]
+	traj.Current, traj.CurrentSE = final.Smoothed, final.SmoothedSE
+	traj.GrowthRate, traj.GrowthRateSE = final.Slope, final.SlopeSE
+	if traj.GrowthRateSE > 0 {
+		traj.Declining = traj.GrowthRate/traj.GrowthRateSE <= -cfg.TrendZ
+	}
+	return traj, nil
+}
+
+// Forecast 按当前能力水平与成长速度外推到 at 时刻，标准误包含期间的过程噪声
+func (t *GrowthTrajectory) Forecast(at time.Time) GrowthForecast {
+	final := t.Points[len(t.Points)-1]
+	horizon := at.Sub(final.Time)
+	if horizon < 0 {
+		horizon = 0
+	}
+	mean, cov := t.config.predict(final.filteredMean, final.filteredCov, horizon)
+	return GrowthForecast{Time: at, Theta: mean[0], StandardError: math.Sqrt(math.Max(0, cov[0][0]))}
+}
+
+// predict 状态转移 Δt 时间：F = [[1, Δt], [0, 1]]，过程噪声随间隔线性增长
+func (cfg GrowthConfig) predict(mean [2]float64, cov [2][2]float64, elapsed time.Duration) ([2]float64, [2][2]float64) {
+	dt := cfg.units(elapsed)
+	next := [2]float64{mean[0] + mean[1]*dt, mean[1]}
+	// F·P·Fᵀ
+	p := [2][2]float64{
+		{cov[0][0] + dt*(cov[0][1]+cov[1][0]) + dt*dt*cov[1][1], cov[0][1] + dt*cov[1][1]},
+		{cov[1][0] + dt*cov[1][1], cov[1][1]},
+	}
+	p[0][0] += cfg.LevelNoise * dt
+	p[1][1] += cfg.SlopeNoise * dt
+	return next, p
+}
+
+// units 以 TimeUnit 计的时间间隔
+func (cfg GrowthConfig) units(elapsed time.Duration) float64 {
+	return float64(elapsed) / float64(cfg.TimeUnit)
+}
+
+// setSmoothed 记录平滑后的能力水平与成长速度
+func (p *GrowthPoint) setSmoothed(mean [2]float64, cov [2][2]float64) {
+	p.Smoothed = mean[0]
+	p.SmoothedSE = math.Sqrt(math.Max(0, cov[0][0]))
+	p.Slope = mean[1]
+	p.SlopeSE = math.Sqrt(math.Max(0, cov[1][1]))
+}
+
+// matrix2 将 2×2 状态协方差转为 irt 矩阵运算使用的行切片
+func matrix2(a [2][2]float64) [][]float64 {
+	return [][]float64{{a[0][0], a[0][1]}, {a[1][0], a[1][1]}}
+}
@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// levelOnlyConfig 成长速度几乎固定为0的配置，模型退化为标量随机游走 θ_t = θ_{t-1} + η
+func levelOnlyConfig(levelNoise float64) GrowthConfig {
+	cfg := DefaultGrowthConfig()
+	cfg.LevelNoise = levelNoise
+	cfg.SlopeNoise = 0
+	cfg.InitialSlopeSD = 1e-4
+	cfg.MinStandardError = 1e-3
+	return cfg
+}
+
+func TestTrackGrowthConstantLevel(t *testing.T) {
+	// 无过程噪声时平滑值为全部观测的精度加权均值，滤波值为此前观测的精度加权均值
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	thetas := []float64{0.2, -0.1, 0.5, 0.3}
+	ses := []float64{0.5, 0.3, 0.4, 0.2}
+	observations := make([]GrowthObservation, len(thetas))
+	for i := range thetas {
+		observations[i] = GrowthObservation{Time: base.AddDate(0, 0, 30*i), Theta: thetas[i], StandardError: ses[i]}
+	}
+
+	trajectory, err := TrackGrowth(observations, levelOnlyConfig(0))
+	require.NoError(t, err)
+	require.Len(t, trajectory.Points, len(thetas))
+
+	var weighted, precision float64
+	for i, p := range trajectory.Points {
+		w := 1 / (ses[i] * ses[i])
+		weighted += w * thetas[i]
+		precision += w
+		assert.InDelta(t, weighted/precision, p.Filtered, 1e-4)
+		assert.InDelta(t, 1/math.Sqrt(precision), p.FilteredSE, 1e-4)
+	}
+	for _, p := range trajectory.Points {
+		assert.InDelta(t, weighted/precision, p.Smoothed, 1e-4)
+		assert.InDelta(t, 1/math.Sqrt(precision), p.SmoothedSE, 1e-4)
+		assert.InDelta(t, 0, p.Slope, 1e-3)
+	}
+	assert.InDelta(t, weighted/precision, trajectory.Current, 1e-4)
+}
+
+func TestTrackGrowthRandomWalk(t *testing.T) {
+	// 两次观测间隔一个时间单位，过程噪声方差 q：
+	// 滤波 θ̂_2 = (θ_1/(r_1+q) + θ_2/r_2) / (1/(r_1+q) + 1/r_2)，
+	// 平滑 θ̂_1 = (θ_1/r_1 + θ_2/(r_2+q)) / (1/r_1 + 1/(r_2+q))
+	const q, r1, r2 = 0.09, 0.16, 0.04
+	const theta1, theta2 = -0.2, 0.6
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	cfg := levelOnlyConfig(q)
+	observations := []GrowthObservation{
+		{Time: base, Theta: theta1, StandardError: math.Sqrt(r1)},
+		{Time: base.Add(cfg.TimeUnit), Theta: theta2, StandardError: math.Sqrt(r2)},
+	}
+
+	trajectory, err := TrackGrowth(observations, cfg)
+	require.NoError(t, err)
+
+	first, second := trajectory.Points[0], trajectory.Points[1]
+	filteredPrecision := 1/(r1+q) + 1/r2
+	assert.InDelta(t, (theta1/(r1+q)+theta2/r2)/filteredPrecision, second.Filtered, 1e-4)
+	assert.InDelta(t, 1/math.Sqrt(filteredPrecision), second.FilteredSE, 1e-4)
+	assert.InDelta(t, second.Filtered, second.Smoothed, 1e-9)
+
+	smoothedPrecision := 1/r1 + 1/(r2+q)
+	assert.InDelta(t, (theta1/r1+theta2/(r2+q))/smoothedPrecision, first.Smoothed, 1e-4)
+	assert.InDelta(t, 1/math.Sqrt(smoothedPrecision), first.SmoothedSE, 1e-4)
+
+	// 标准化新息 (θ_2 − θ_1)/√(r_1 + q + r_2)
+	assert.InDelta(t, (theta2-theta1)/math.Sqrt(r1+q+r2), second.Innovation, 1e-4)
+
+	// 预测 h 个时间单位后：均值不变，方差增加 q·h
+	forecast := trajectory.Forecast(second.Time.Add(3 * cfg.TimeUnit))
+	assert.InDelta(t, second.Filtered, forecast.Theta, 1e-4)
+	assert.InDelta(t, math.Sqrt(1/filteredPrecision+3*q), forecast.StandardError, 1e-4)
+}
+
+func TestTrackGrowthFlagsDecline(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	var observations []GrowthObservation
+	for i, theta := range []float64{0.5, 0.6, 0.55, 0.65, -1.2} {
+		observations = append(observations, GrowthObservation{Time: base.AddDate(0, 0, 30*i), Theta: theta, StandardError: 0.2})
+	}
+
+	trajectory, err := TrackGrowth(observations, DefaultGrowthConfig())
+	require.NoError(t, err)
+	for _, p := range trajectory.Points[:4] {
+		assert.False(t, p.Decline)
+	}
+	assert.True(t, trajectory.Points[4].Decline)
+	assert.Less(t, trajectory.Points[4].Innovation, -DefaultGrowthConfig().DeclineZ)
+
+	_, err = TrackGrowth(nil, DefaultGrowthConfig())
+	assert.ErrorIs(t, err, ErrNoObservations)
+}
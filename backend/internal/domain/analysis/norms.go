@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+)
+
+// NormEntry 常模表中的一个报告分数
+type NormEntry struct {
+	Score             float64
+	Frequency         int
+	PercentileRank    float64 // 低于该分数的百分比加上该分数人数的一半
+	CumulativePercent float64 // 不高于该分数的百分比
+}
+
+// Norms 参照群体的报告分数常模
+type Norms struct {
+	SampleSize int
+	Mean       float64
+	SD         float64
+	Entries    []NormEntry // 按分数升序，只包含出现过的分数
+}
+
+// BuildNorms 由参照群体的报告分数建立百分位常模表
+func BuildNorms(scores []float64) (*Norms, error) {
+	n := len(scores)
+	if n == 0 {
+		return nil, ErrNoExaminees
+	}
+	sorted := append([]float64(nil), scores...)
+	sort.Float64s(sorted)
+
+	norms := &Norms{SampleSize: n}
+	norms.Mean, norms.SD = meanVariance(sorted)
+	norms.SD = math.Sqrt(norms.SD)
+
+	below := 0
+	for i := 0; i < n; {
+		j := i
+		for j < n && sorted[j] == sorted[i] {
+			j++
+		}
+		frequency := j - i
+		norms.Entries = append(norms.Entries, NormEntry{
+			Score:             sorted[i],
+			Frequency:         frequency,
+			PercentileRank:    100 * (float64(below) + float64(frequency)/2) / float64(n),
+			CumulativePercent: 100 * float64(below+frequency) / float64(n),
+		})
+		below += frequency
+		i = j
+	}
+	return norms, nil
+}
+
+// PercentileRank 查表得到报告分数的百分位等级；参照群体中未出现的分数取低于它的人数百分比。
+// 在出现过的分数上与等值使用的连续化百分位（irt 包 percentileRank）取值相同，
+// 但报告分数是间距不定的实数量尺，无法按整数分 ±0.5 连续化，分数之间不做插值
+func PercentileRank(entries []NormEntry, score float64) float64 {
+	k := sort.Search(len(entries), func(i int) bool { return entries[i].Score >= score })
+	if k < len(entries) && entries[k].Score == score {
+		return entries[k].PercentileRank
+	}
+	if k == 0 {
+		return 0
+	}
+	return entries[k-1].CumulativePercent
+}
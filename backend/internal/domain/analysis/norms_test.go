@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildNormsHandComputed(t *testing.T) {
+	// 10人：分数 40 一人、50 三人、60 四人、70 两人
+	scores := []float64{60, 50, 70, 60, 40, 50, 60, 70, 50, 60}
+	norms, err := BuildNorms(scores)
+	require.NoError(t, err)
+	assert.Equal(t, 10, norms.SampleSize)
+	assert.InDelta(t, 57, norms.Mean, 1e-12)
+	assert.InDelta(t, math.Sqrt(90), norms.SD, 1e-12)
+
+	// 百分位等级 = 低于该分数的百分比 + 该分数人数百分比的一半
+	assert.Equal(t, []NormEntry{
+		{Score: 40, Frequency: 1, PercentileRank: 5, CumulativePercent: 10},
+		{Score: 50, Frequency: 3, PercentileRank: 25, CumulativePercent: 40},
+		{Score: 60, Frequency: 4, PercentileRank: 60, CumulativePercent: 80},
+		{Score: 70, Frequency: 2, PercentileRank: 90, CumulativePercent: 100},
+	}, norms.Entries)
+
+	// 未出现的分数取低于它的人数百分比
+	assert.Equal(t, 60.0, PercentileRank(norms.Entries, 60))
+	assert.Equal(t, 40.0, PercentileRank(norms.Entries, 55))
+	assert.Equal(t, 0.0, PercentileRank(norms.Entries, 30))
+	assert.Equal(t, 100.0, PercentileRank(norms.Entries, 99))
+
+	_, err = BuildNorms(nil)
+	assert.ErrorIs(t, err, ErrNoExaminees)
+}
@@ -0,0 +1,168 @@
+package analysis
+
+import (
+	"math"
+
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+// RapidGuessConfig 快速猜测判定配置：用时低于题目常模用时的一定比例（NT10）且不超过上限时视为快速猜测
+type RapidGuessConfig struct {
+	Fraction   float64 // 常模用时的比例
+	MaxSeconds float64 // 阈值上限（秒）
+}
+
+// RapidGuessThreshold 题目的快速猜测用时阈值，常模用时取平均速度考生的期望用时
+func RapidGuessThreshold(item irt.TimeParams, cfg RapidGuessConfig) float64 {
+	threshold := cfg.Fraction * item.ExpectedSeconds(0)
+	if cfg.MaxSeconds > 0 {
+		threshold = math.Min(threshold, cfg.MaxSeconds)
+	}
+	return threshold
+}
+
+// SpeedednessConfig 快速猜测与赶时间检测配置
+type SpeedednessConfig struct {
+	RapidGuess    RapidGuessConfig
+	Sections      int     // 按施测位置把测验均分为若干段
+	RapidRate     float64 // 段内快速猜测比例不低于该值时视为赶时间
+	ResidualShift float64 // 段内平均标准化用时残差不高于 -ResidualShift 时视为赶时间
+	MinEffort     float64 // 考生作答努力度（非快速猜测作答比例）低于该值时标记
+	Alpha         float64 // 考生末段加速检验的显著性水平
+}
+
+// DefaultSpeedednessConfig 返回常用的检测配置
+func DefaultSpeedednessConfig() SpeedednessConfig {
+	return SpeedednessConfig{
+		RapidGuess:    RapidGuessConfig{Fraction: 0.1, MaxSeconds: 10},
+		Sections:      4,
+		RapidRate:     0.1,
+		ResidualShift: 0.5,
+		MinEffort:     0.9,
+		Alpha:         0.05,
+	}
+}
+
+// TimedSequence 单个考生按施测顺序排列的作答用时，Speed 为其速度估计
+type TimedSequence struct {
+	Speed     float64
+	Responses []irt.TimedResponse
+}
+
+// RapidGuessFlag 被判定为快速猜测的一次作答
+type RapidGuessFlag struct {
+	Row        int
+	Position   int // 施测位置，从0开始
+	QuestionID uint
+	Seconds    float64
+	Threshold  float64
+	Residual   float64
+}
+
+// PersonSpeededness 单个考生的作答努力度与末段加速检验
+type PersonSpeededness struct {
+	Row          int
+	Responses    int
+	RapidGuesses int
+	Effort       float64 // 作答努力度 RTE：非快速猜测作答的比例
+	SpeedShift   float64 // 末段平均残差减去前段平均残差，负值表示末段加速
+	PValue       float64 // 末段加速的单侧检验
+	LowEffort    bool
+	Speeded      bool
+}
+
+// SectionSpeededness 测验各段的赶时间情况
+type SectionSpeededness struct {
+	Section      int // 从1开始
+	Responses    int
+	RapidGuesses int
+	RapidRate    float64
+	MeanResidual float64
+	Speeded      bool
+}
+
+// SpeedednessReport 快速猜测与赶时间检测结果
+type SpeedednessReport struct {
+	RapidGuesses []RapidGuessFlag
+	Persons      []PersonSpeededness
+	Sections     []SectionSpeededness
+}
+
+// Speededness 基于对数正态作答时间模型检测快速猜测与赶时间：
+// 按 NT10 阈值标记快速猜测，按施测位置分段汇总快速猜测比例和标准化用时残差，
+// 并对每个考生检验末段残差是否显著低于前段
+func Speededness(sequences []TimedSequence, cfg SpeedednessConfig) *SpeedednessReport {
+	sections := cfg.Sections
+	if sections < 1 {
+		sections = 1
+	}
+	report := &SpeedednessReport{
+		Persons:  make([]PersonSpeededness, len(sequences)),
+		Sections: make([]SectionSpeededness, sections),
+	}
+	residualSums := make([]float64, sections)
+	residualCounts := make([]int, sections)
+
+	for row, seq := range sequences {
+		person := PersonSpeededness{Row: row, PValue: 1}
+		var lastSum, restSum float64
+		var lastCount, restCount int
+		for pos, r := range seq.Responses {
+			if !r.Item.Calibrated() {
+				continue
+			}
+			section := pos * sections / len(seq.Responses)
+			residual := r.Item.Residual(r.Seconds, seq.Speed)
+			threshold := RapidGuessThreshold(r.Item, cfg.RapidGuess)
+
+			person.Responses++
+			report.Sections[section].Responses++
+			residualSums[section] += residual
+			residualCounts[section]++
+			if r.Seconds < threshold {
+				person.RapidGuesses++
+				report.Sections[section].RapidGuesses++
+				report.RapidGuesses = append(report.RapidGuesses, RapidGuessFlag{
+					Row:        row,
+					Position:   pos,
+					QuestionID: r.QuestionID,
+					Seconds:    r.Seconds,
+					Threshold:  threshold,
+					Residual:   residual,
+				})
+			}
+			if section == sections-1 {
+				lastSum += residual
+				lastCount++
+			} else {
+				restSum += residual
+				restCount++
+			}
+		}
+
+		if person.Responses > 0 {
+			person.Effort = 1 - float64(person.RapidGuesses)/float64(person.Responses)
+			person.LowEffort = person.Effort < cfg.MinEffort
+		}
+		// 模型成立时标准化残差独立服从标准正态
+		if lastCount > 0 && restCount > 0 {
+			person.SpeedShift = lastSum/float64(lastCount) - restSum/float64(restCount)
+			z := person.SpeedShift / math.Sqrt(1/float64(lastCount)+1/float64(restCount))
+			person.PValue = NormalSurvival(-z)
+			person.Speeded = person.PValue < cfg.Alpha
+		}
+		report.Persons[row] = person
+	}
+
+	for k := range report.Sections {
+		section := &report.Sections[k]
+		section.Section = k + 1
+		if section.Responses == 0 {
+			continue
+		}
+		section.RapidRate = float64(section.RapidGuesses) / float64(section.Responses)
+		section.MeanResidual = residualSums[k] / float64(residualCounts[k])
+		section.Speeded = section.RapidRate >= cfg.RapidRate || section.MeanResidual <= -cfg.ResidualShift
+	}
+	return report
+}
@@ -0,0 +1,292 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+// StandardSettingMethod 标准设定方法
+type StandardSettingMethod string
+
+const (
+	// StandardSettingAngoff 修正 Angoff 法：评委估计临界考生答对每道题的概率，期望得分之和经测验特征曲线反解为 θ 划界分数
+	StandardSettingAngoff StandardSettingMethod = "angoff"
+	// StandardSettingBookmark 书签法：评委在按 RP 位置排序的题册中标出临界考生最后一道能掌握的题，该题的 RP 位置即 θ 划界分数
+	StandardSettingBookmark StandardSettingMethod = "bookmark"
+)
+
+var (
+	ErrUnknownStandardSettingMethod = errors.New("unknown standard setting method")
+	ErrNoRatings                    = errors.New("no complete panelist ratings")
+	ErrInvalidRating                = errors.New("rating out of range")
+)
+
+// StandardSettingConfig 标准设定计算配置
+type StandardSettingConfig struct {
+	Model               irt.Model
+	Method              StandardSettingMethod
+	Boundaries          int     // 分界点个数，即表现水平数减一
+	ResponseProbability float64 // 书签法的掌握标准，常用 0.67
+	MinTheta            float64 // 划界分数的搜索范围
+	MaxTheta            float64
+}
+
+// DefaultStandardSettingConfig 返回常用的标准设定配置
+func DefaultStandardSettingConfig() StandardSettingConfig {
+	return StandardSettingConfig{
+		Model:               irt.DefaultModel(),
+		Method:              StandardSettingAngoff,
+		Boundaries:          1,
+		ResponseProbability: 0.67,
+		MinTheta:            -4,
+		MaxTheta:            4,
+	}
+}
+
+// PanelRating 一位评委在一个分界点上的一条评定；
+// Angoff 法 Item 为题目下标、Value 为答对概率，书签法 Item 不使用、Value 为书签页码（从1开始）
+type PanelRating struct {
+	Panelist uint
+	Boundary int // 分界点下标，从0开始
+	Item     int
+	Value    float64
+}
+
+// BookletPage 排序题册中的一页
+type BookletPage struct {
+	Page     int
+	Item     int     // 题目下标
+	Location float64 // 答对概率达到掌握标准的能力值
+}
+
+// PanelistCut 单个评委在一个分界点上的划界分数
+type PanelistCut struct {
+	Panelist uint
+	Boundary int
+	RawScore float64 // 临界考生的期望得分
+	Theta    float64
+}
+
+// BoundaryCut 一个分界点的评委组划界分数
+type BoundaryCut struct {
+	Boundary  int
+	Panelists int
+	Theta     float64 // Angoff 法由评委平均期望得分反解，书签法取评委 θ 的中位数
+	RawScore  float64 // 划界分数对应的期望得分
+	ThetaSD   float64 // 评委间 θ 划界分数的标准差
+	ThetaSE   float64 // 评委组划界分数的标准误 SD/√n
+	MinTheta  float64
+	MaxTheta  float64
+}
+
+// StandardSettingResult 标准设定结果
+type StandardSettingResult struct {
+	Cuts      []BoundaryCut // 按分界点顺序
+	Panelists []PanelistCut
+	Ordered   bool // 划界分数是否随分界点严格递增
+}
+
+// LevelImpact 表现水平的影响分析：参照群体落入该水平的人数与比例
+type LevelImpact struct {
+	Level   int
+	Count   int
+	Percent float64
+}
+
+// OrderedItemBooklet 按题目的 RP 位置由易到难排列的题册
+func OrderedItemBooklet(model irt.Model, items []irt.TestItem, rp, minTheta, maxTheta float64) []BookletPage {
+	pages := make([]BookletPage, len(items))
+	for j, item := range items {
+		pages[j] = BookletPage{Item: j, Location: responseProbabilityLocation(model, item, rp, minTheta, maxTheta)}
+	}
+	sort.SliceStable(pages, func(a, b int) bool { return pages[a].Location < pages[b].Location })
+	for k := range pages {
+		pages[k].Page = k + 1
+	}
+	return pages
+}
+
+// responseProbabilityLocation 二分求答对概率等于 rp 的能力值，超出范围时返回端点
+func responseProbabilityLocation(model irt.Model, item irt.TestItem, rp, minTheta, maxTheta float64) float64 {
+	params := irt.ItemParams{
+		Difficulty:     item.Difficulty,
+		Discrimination: item.Discrimination,
+		Guessing:       item.Guessing,
+		UpperAsymptote: item.UpperAsymptote,
+	}
+	lo, hi := minTheta, maxTheta
+	if model.Probability(lo, params) >= rp {
+		return lo
+	}
+	if model.Probability(hi, params) <= rp {
+		return hi
+	}
+	for i := 0; i < 60; i++ {
+		mid := (lo + hi) / 2
+		if model.Probability(mid, params) < rp {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// SetStandards 由评委评定计算各分界点的划界分数；Angoff 法只采用评完全部题目的评委
+func SetStandards(items []irt.TestItem, ratings []PanelRating, cfg StandardSettingConfig) (*StandardSettingResult, error) {
+	switch cfg.Method {
+	case StandardSettingAngoff, StandardSettingBookmark:
+	default:
+		return nil, ErrUnknownStandardSettingMethod
+	}
+	var booklet []BookletPage
+	if cfg.Method == StandardSettingBookmark {
+		booklet = OrderedItemBooklet(cfg.Model, items, cfg.ResponseProbability, cfg.MinTheta, cfg.MaxTheta)
+	}
+
+	// 按分界点与评委归并评定
+	type key struct {
+		boundary int
+		panelist uint
+	}
+	grouped := make(map[key][]PanelRating)
+	var keys []key
+	for _, r := range ratings {
+		if r.Boundary < 0 || r.Boundary >= cfg.Boundaries {
+			return nil, ErrInvalidRating
+		}
+		k := key{r.Boundary, r.Panelist}
+		if _, ok := grouped[k]; !ok {
+			keys = append(keys, k)
+		}
+		grouped[k] = append(grouped[k], r)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		if keys[a].boundary != keys[b].boundary {
+			return keys[a].boundary < keys[b].boundary
+		}
+		return keys[a].panelist < keys[b].panelist
+	})
+
+	result := &StandardSettingResult{Cuts: make([]BoundaryCut, cfg.Boundaries), Ordered: true}
+	panelistsByBoundary := make([][]PanelistCut, cfg.Boundaries)
+	for _, k := range keys {
+		cut := PanelistCut{Panelist: k.panelist, Boundary: k.boundary}
+		if cfg.Method == StandardSettingAngoff {
+			values := make([]float64, len(items))
+			rated := make([]bool, len(items))
+			count := 0
+			for _, r := range grouped[k] {
+				if r.Item < 0 || r.Item >= len(items) || r.Value < 0 || r.Value > 1 {
+					return nil, ErrInvalidRating
+				}
+				if !rated[r.Item] {
+					count++
+				}
+				rated[r.Item] = true
+				values[r.Item] = r.Value
+			}
+			if count < len(items) {
+				continue
+			}
+			for j, item := range items {
+				cut.RawScore += itemWeight(item) * values[j]
+			}
+			cut.Theta = cfg.Model.ThetaForScore(items, cut.RawScore, cfg.MinTheta, cfg.MaxTheta)
+		} else {
+			// 同一分界点有多条书签时以最后一条为准
+			page := int(grouped[k][len(grouped[k])-1].Value)
+			if page < 1 || page > len(booklet) {
+				return nil, ErrInvalidRating
+			}
+			cut.Theta = booklet[page-1].Location
+			cut.RawScore = cfg.Model.ExpectedScore(items, cut.Theta)
+		}
+		result.Panelists = append(result.Panelists, cut)
+		panelistsByBoundary[k.boundary] = append(panelistsByBoundary[k.boundary], cut)
+	}
+
+	for b := range result.Cuts {
+		cuts := panelistsByBoundary[b]
+		if len(cuts) == 0 {
+			return nil, ErrNoRatings
+		}
+		thetas := make([]float64, len(cuts))
+		var sumRaw float64
+		for i, c := range cuts {
+			thetas[i] = c.Theta
+			sumRaw += c.RawScore
+		}
+		_, variance := meanVariance(thetas)
+		sorted := append([]float64(nil), thetas...)
+		sort.Float64s(sorted)
+		boundary := BoundaryCut{
+			Boundary:  b,
+			Panelists: len(cuts),
+			ThetaSD:   math.Sqrt(variance),
+			MinTheta:  sorted[0],
+			MaxTheta:  sorted[len(sorted)-1],
+		}
+		boundary.ThetaSE = boundary.ThetaSD / math.Sqrt(float64(len(cuts)))
+		if cfg.Method == StandardSettingAngoff {
+			boundary.RawScore = sumRaw / float64(len(cuts))
+			boundary.Theta = cfg.Model.ThetaForScore(items, boundary.RawScore, cfg.MinTheta, cfg.MaxTheta)
+		} else {
+			boundary.Theta = median(sorted)
+			boundary.RawScore = cfg.Model.ExpectedScore(items, boundary.Theta)
+		}
+		if b > 0 && boundary.Theta <= result.Cuts[b-1].Theta {
+			result.Ordered = false
+		}
+		result.Cuts[b] = boundary
+	}
+	return result, nil
+}
+
+// ClassifyLevel 能力值所属的表现水平下标：不低于的划界分数个数，cuts 须按升序排列
+func ClassifyLevel(cuts []float64, theta float64) int {
+	level := 0
+	for _, cut := range cuts {
+		if theta >= cut {
+			level++
+		}
+	}
+	return level
+}
+
+// ImpactAnalysis 按划界分数统计参照群体在各表现水平的人数与比例
+func ImpactAnalysis(thetas []float64, cuts []float64) []LevelImpact {
+	impact := make([]LevelImpact, len(cuts)+1)
+	for k := range impact {
+		impact[k].Level = k
+	}
+	for _, theta := range thetas {
+		impact[ClassifyLevel(cuts, theta)].Count++
+	}
+	if len(thetas) > 0 {
+		for k := range impact {
+			impact[k].Percent = float64(impact[k].Count) / float64(len(thetas))
+		}
+	}
+	return impact
+}
+
+// median 已排序数据的中位数
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// itemWeight 题目分值，0按1分计
+func itemWeight(item irt.TestItem) float64 {
+	if item.Score <= 0 {
+		return 1
+	}
+	return item.Score
+}
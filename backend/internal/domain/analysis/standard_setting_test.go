@@ -0,0 +1,136 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// angoffRatings 评委对每道题给出相同的答对概率
+func angoffRatings(panelist uint, boundary, items int, value float64) []PanelRating {
+	ratings := make([]PanelRating, items)
+	for j := range ratings {
+		ratings[j] = PanelRating{Panelist: panelist, Boundary: boundary, Item: j, Value: value}
+	}
+	return ratings
+}
+
+func TestAngoffHandComputed(t *testing.T) {
+	// Rasch、D=1，4道难度为0的题目：期望得分 s 对应 θ = ln(s/(4−s))
+	items := make([]irt.TestItem, 4)
+	for j := range items {
+		items[j] = irt.TestItem{QuestionID: uint(j + 1), Discrimination: 1}
+	}
+	cfg := DefaultStandardSettingConfig()
+	cfg.Model = irt.Model{Family: irt.ModelRasch, D: irt.ScalingLogistic}
+
+	ratings := append(angoffRatings(1, 0, 4, 0.5), angoffRatings(2, 0, 4, 0.75)...)
+	// 第3位评委只评了3道题，不参与计算
+	ratings = append(ratings, angoffRatings(3, 0, 3, 0.9)...)
+	result, err := SetStandards(items, ratings, cfg)
+	require.NoError(t, err)
+
+	require.Len(t, result.Panelists, 2)
+	assert.InDelta(t, 2, result.Panelists[0].RawScore, 1e-12)
+	assert.InDelta(t, 0, result.Panelists[0].Theta, 1e-9)
+	assert.InDelta(t, 3, result.Panelists[1].RawScore, 1e-12)
+	assert.InDelta(t, math.Log(3), result.Panelists[1].Theta, 1e-9)
+
+	// 评委组划界分数由平均期望得分 2.5 反解，而不是评委 θ 的平均
+	cut := result.Cuts[0]
+	assert.Equal(t, 2, cut.Panelists)
+	assert.InDelta(t, 2.5, cut.RawScore, 1e-12)
+	assert.InDelta(t, math.Log(2.5/1.5), cut.Theta, 1e-9)
+	assert.InDelta(t, math.Log(3)/math.Sqrt2, cut.ThetaSD, 1e-9)
+	assert.InDelta(t, math.Log(3)/2, cut.ThetaSE, 1e-9)
+	assert.InDelta(t, 0, cut.MinTheta, 1e-9)
+	assert.InDelta(t, math.Log(3), cut.MaxTheta, 1e-9)
+}
+
+func TestBookmarkHandComputed(t *testing.T) {
+	items := []irt.TestItem{
+		{QuestionID: 1, Difficulty: -1, Discrimination: 1},
+		{QuestionID: 2, Difficulty: 0.5, Discrimination: 1},
+		{QuestionID: 3, Difficulty: 0, Discrimination: 1},
+		{QuestionID: 4, Difficulty: 2, Discrimination: 1},
+	}
+	model := irt.Model{Family: irt.ModelRasch, D: irt.ScalingLogistic}
+
+	// RP = 0.5 时 RP 位置即难度；题册按位置由易到难排列
+	booklet := OrderedItemBooklet(model, items, 0.5, -4, 4)
+	assert.Equal(t, []int{0, 2, 1, 3}, []int{booklet[0].Item, booklet[1].Item, booklet[2].Item, booklet[3].Item})
+	assert.InDelta(t, 0.5, booklet[2].Location, 1e-9)
+	assert.Equal(t, 3, booklet[2].Page)
+	// 超出搜索范围时取端点
+	assert.Equal(t, 1.0, OrderedItemBooklet(model, items[3:], 0.5, -1, 1)[0].Location)
+
+	// RP = 0.67 时位置为 b + ln(0.67/0.33)
+	cfg := DefaultStandardSettingConfig()
+	cfg.Model = model
+	cfg.Method = StandardSettingBookmark
+	cfg.Boundaries = 2
+	shift := math.Log(0.67 / 0.33)
+	ratings := []PanelRating{
+		{Panelist: 1, Boundary: 0, Value: 2},
+		{Panelist: 2, Boundary: 0, Value: 3},
+		{Panelist: 3, Boundary: 0, Value: 1},
+		{Panelist: 3, Boundary: 0, Value: 3}, // 以最后一条书签为准
+		{Panelist: 1, Boundary: 1, Value: 4},
+		{Panelist: 2, Boundary: 1, Value: 4},
+	}
+	result, err := SetStandards(items, ratings, cfg)
+	require.NoError(t, err)
+	assert.True(t, result.Ordered)
+
+	// 第一个分界点的评委 θ 为第2、3、3页的位置，取中位数
+	first := result.Cuts[0]
+	assert.Equal(t, 3, first.Panelists)
+	assert.InDelta(t, 0.5+shift, first.Theta, 1e-9)
+	assert.InDelta(t, shift, first.MinTheta, 1e-9)
+	assert.InDelta(t, model.ExpectedScore(items, first.Theta), first.RawScore, 1e-12)
+	assert.InDelta(t, 2+shift, result.Cuts[1].Theta, 1e-9)
+	assert.Zero(t, result.Cuts[1].ThetaSD)
+
+	// 划界分数未随分界点递增时标记为无序
+	ratings[4].Value, ratings[5].Value = 1, 1
+	result, err = SetStandards(items, ratings, cfg)
+	require.NoError(t, err)
+	assert.False(t, result.Ordered)
+}
+
+func TestSetStandardsErrors(t *testing.T) {
+	items := []irt.TestItem{{QuestionID: 1, Discrimination: 1}, {QuestionID: 2, Discrimination: 1}}
+	cfg := DefaultStandardSettingConfig()
+
+	_, err := SetStandards(items, nil, StandardSettingConfig{Method: "contrasting_groups"})
+	assert.ErrorIs(t, err, ErrUnknownStandardSettingMethod)
+	_, err = SetStandards(items, angoffRatings(1, 1, 2, 0.5), cfg)
+	assert.ErrorIs(t, err, ErrInvalidRating)
+	_, err = SetStandards(items, angoffRatings(1, 0, 2, 1.2), cfg)
+	assert.ErrorIs(t, err, ErrInvalidRating)
+	_, err = SetStandards(items, angoffRatings(1, 0, 1, 0.5), cfg)
+	assert.ErrorIs(t, err, ErrNoRatings)
+
+	cfg.Method = StandardSettingBookmark
+	_, err = SetStandards(items, []PanelRating{{Panelist: 1, Value: 3}}, cfg)
+	assert.ErrorIs(t, err, ErrInvalidRating)
+}
+
+func TestClassifyLevelAndImpact(t *testing.T) {
+	cuts := []float64{-0.5, 1}
+	assert.Equal(t, 0, ClassifyLevel(cuts, -1))
+	assert.Equal(t, 1, ClassifyLevel(cuts, -0.5))
+	assert.Equal(t, 2, ClassifyLevel(cuts, 1.2))
+
+	impact := ImpactAnalysis([]float64{-2, -0.7, 0, 0.4, 1, 3}, cuts)
+	assert.Equal(t, []LevelImpact{
+		{Level: 0, Count: 2, Percent: 2.0 / 6},
+		{Level: 1, Count: 2, Percent: 2.0 / 6},
+		{Level: 2, Count: 2, Percent: 2.0 / 6},
+	}, impact)
+	assert.Equal(t, []LevelImpact{{Level: 0}, {Level: 1}}, ImpactAnalysis(nil, []float64{0}))
+}
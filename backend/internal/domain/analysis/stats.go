@@ -0,0 +1,77 @@
+package analysis
+
+import "math"
+
+// ChiSquareSurvival 返回自由度为 df 的卡方分布上尾概率 P(X > x)
+func ChiSquareSurvival(x float64, df int) float64 {
+	if df <= 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 1
+	}
+	return 1 - regularizedGammaP(float64(df)/2, x/2)
+}
+
+// NormalSurvival 返回标准正态分布上尾概率 P(Z > z)
+func NormalSurvival(z float64) float64 {
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// NormalQuantile 标准正态分布的分位数 Φ⁻¹(p)
+func NormalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// NormalDensity 标准正态分布的密度 φ(z)
+func NormalDensity(z float64) float64 {
+	return math.Exp(-z*z/2) / math.Sqrt(2*math.Pi)
+}
+
+// regularizedGammaP 正则化下不完全伽马函数 P(s, x)，
+// x < s+1 时用级数展开，否则用连分式（Numerical Recipes 6.2）
+func regularizedGammaP(s, x float64) float64 {
+	const (
+		maxIterations = 500
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+	lgamma, _ := math.Lgamma(s)
+
+	if x < s+1 {
+		sum := 1 / s
+		term := sum
+		for n := 1; n < maxIterations; n++ {
+			term *= x / (s + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return sum * math.Exp(-x+s*math.Log(x)-lgamma)
+	}
+
+	b := x + 1 - s
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < maxIterations; n++ {
+		an := -float64(n) * (float64(n) - s)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return 1 - math.Exp(-x+s*math.Log(x)-lgamma)*h
+}
@@ -0,0 +1,401 @@
+package irt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	ErrInvalidAssemblySpec = errors.New("invalid test assembly specification")
+	ErrPoolTooSmall        = errors.New("item pool too small for requested forms")
+)
+
+// AssemblyItem 组卷题库中的一道题
+type AssemblyItem struct {
+	QuestionID        uint
+	Difficulty        float64
+	Discrimination    float64
+	Guessing          float64
+	UpperAsymptote    float64 // 4PL 上渐近线，0表示1
+	Score             float64 // 分值
+	Seconds           float64 // 预计作答时间（秒）
+	KnowledgePointIDs []uint
+	QuestionType      string
+}
+
+// TargetPoint 目标测验信息函数上的一个点
+type TargetPoint struct {
+	Theta       float64
+	Information float64
+}
+
+// AssemblySpec 自动组卷规格，每份平行卷的题目互不重复
+type AssemblySpec struct {
+	Forms          int // 平行卷数量
+	Length         int // 每份试卷题数
+	Targets        []TargetPoint
+	Constraints    []ContentConstraint // 知识点与题型约束，目标比例按 Length 换算
+	TotalScore     float64             // 目标总分，0表示不限
+	ScoreTolerance float64
+	MaxSeconds     float64   // 预计总用时上限，0表示不限
+	EnemyPairs     [][2]uint // 互斥题对，不能出现在同一份试卷中
+	SwapIterations int       // 贪心组卷后的交换优化轮数
+	Model          Model     // 科目项目反应模型
+}
+
+// AssembledForm 组卷结果
+type AssembledForm struct {
+	QuestionIDs []uint
+	Information []float64 // 各目标点处的测验信息量
+	Deviation   float64   // 与目标信息函数的相对偏差 Σ|T-I|/T
+	TotalScore  float64
+	Seconds     float64
+	Violations  []string // 未能满足的约束
+}
+
+// assemblyForm 组卷过程中的试卷状态
+type assemblyForm struct {
+	items   []int
+	info    []float64
+	counts  []int
+	score   float64
+	seconds float64
+}
+
+type assembler struct {
+	pool     []AssemblyItem
+	spec     AssemblySpec
+	infoAt   [][]float64 // 各题在目标点处的信息量
+	matches  [][]bool    // 各题是否属于各约束
+	enemies  map[uint]map[uint]bool
+	used     []bool
+	lower    []int
+	upper    []int
+	minScore []float64 // 任选 r 道题的最小总分
+	maxScore []float64 // 任选 r 道题的最大总分
+}
+
+// Assemble 以归一化加权绝对偏差启发式（Luecht, 1998）依次为各平行卷选题，
+// 再通过题目交换缩小与目标信息函数的偏差
+func Assemble(pool []AssemblyItem, spec AssemblySpec) ([]AssembledForm, error) {
+	if spec.Forms < 1 || spec.Length < 1 || len(spec.Targets) == 0 {
+		return nil, ErrInvalidAssemblySpec
+	}
+	for _, t := range spec.Targets {
+		if t.Information <= 0 {
+			return nil, ErrInvalidAssemblySpec
+		}
+	}
+	if len(pool) < spec.Forms*spec.Length {
+		return nil, ErrPoolTooSmall
+	}
+
+	a := newAssembler(pool, spec)
+	forms := make([]*assemblyForm, spec.Forms)
+	for f := range forms {
+		forms[f] = &assemblyForm{
+			info:   make([]float64, len(spec.Targets)),
+			counts: make([]int, len(spec.Constraints)),
+		}
+	}
+
+	// 蛇形顺序轮流选题，避免先组的试卷占据最优题目
+	for step := 0; step < spec.Length; step++ {
+		for k := range forms {
+			f := k
+			if step%2 == 1 {
+				f = len(forms) - 1 - k
+			}
+			idx := a.pick(forms[f], true)
+			if idx < 0 {
+				idx = a.pick(forms[f], false)
+			}
+			if idx < 0 {
+				continue
+			}
+			a.add(forms[f], idx)
+		}
+	}
+
+	for _, form := range forms {
+		a.improve(form)
+	}
+
+	results := make([]AssembledForm, len(forms))
+	for f, form := range forms {
+		results[f] = a.result(form)
+	}
+	return results, nil
+}
+
+func newAssembler(pool []AssemblyItem, spec AssemblySpec) *assembler {
+	a := &assembler{
+		pool:    pool,
+		spec:    spec,
+		infoAt:  make([][]float64, len(pool)),
+		matches: make([][]bool, len(pool)),
+		enemies: make(map[uint]map[uint]bool),
+		used:    make([]bool, len(pool)),
+		lower:   make([]int, len(spec.Constraints)),
+		upper:   make([]int, len(spec.Constraints)),
+	}
+	for i, item := range pool {
+		a.infoAt[i] = make([]float64, len(spec.Targets))
+		for k, t := range spec.Targets {
+			a.infoAt[i][k] = spec.Model.Information(t.Theta, ItemParams{
+				Difficulty:     item.Difficulty,
+				Discrimination: item.Discrimination,
+				Guessing:       item.Guessing,
+				UpperAsymptote: item.UpperAsymptote,
+			})
+		}
+		a.matches[i] = make([]bool, len(spec.Constraints))
+		candidate := Candidate{KnowledgePointIDs: item.KnowledgePointIDs, QuestionType: item.QuestionType}
+		for c, constraint := range spec.Constraints {
+			a.matches[i][c] = constraint.Matches(candidate)
+		}
+	}
+	for c, constraint := range spec.Constraints {
+		a.lower[c], a.upper[c] = constraint.bounds(spec.Length)
+	}
+	for _, pair := range spec.EnemyPairs {
+		for _, ids := range [][2]uint{pair, {pair[1], pair[0]}} {
+			if a.enemies[ids[0]] == nil {
+				a.enemies[ids[0]] = make(map[uint]bool)
+			}
+			a.enemies[ids[0]][ids[1]] = true
+		}
+	}
+
+	scores := make([]float64, len(pool))
+	for i, item := range pool {
+		scores[i] = item.Score
+	}
+	sort.Float64s(scores)
+	a.minScore = make([]float64, spec.Length+1)
+	a.maxScore = make([]float64, spec.Length+1)
+	for r := 1; r <= spec.Length; r++ {
+		a.minScore[r] = a.minScore[r-1] + scores[r-1]
+		a.maxScore[r] = a.maxScore[r-1] + scores[len(scores)-r]
+	}
+	return a
+}
+
+// pick 为试卷选出下一道题，strict 为 false 时只保证题目不重复且不互斥
+func (a *assembler) pick(form *assemblyForm, strict bool) int {
+	remaining := a.spec.Length - len(form.items)
+	if remaining <= 0 {
+		return -1
+	}
+
+	// 剩余题量仅够满足下限时只能选择补足下限的题目
+	required := 0
+	for c := range a.spec.Constraints {
+		if form.counts[c] < a.lower[c] {
+			required += a.lower[c] - form.counts[c]
+		}
+	}
+
+	best, bestValue := -1, math.Inf(-1)
+	for i := range a.pool {
+		if a.used[i] || a.conflicts(form, i, -1) {
+			continue
+		}
+		fillsLower := false
+		if strict {
+			if !a.withinBounds(form, i, -1) || !a.withinResources(form, i, -1, remaining-1) {
+				continue
+			}
+			for c := range a.spec.Constraints {
+				if a.matches[i][c] && form.counts[c] < a.lower[c] {
+					fillsLower = true
+				}
+			}
+			if required >= remaining && !fillsLower {
+				continue
+			}
+		}
+
+		// 使每个目标点上该题的信息量尽量接近剩余缺口的平均值
+		var deviation float64
+		for k, t := range a.spec.Targets {
+			need := math.Max(t.Information-form.info[k], 0) / float64(remaining)
+			deviation += math.Abs(need-a.infoAt[i][k]) / t.Information
+		}
+		value := -deviation
+		if fillsLower {
+			value += 0.1
+		}
+		if value > bestValue {
+			best, bestValue = i, value
+		}
+	}
+	return best
+}
+
+// conflicts 判断题目 i 是否与试卷中的题目互斥，skip 为交换时将被替换的题目
+func (a *assembler) conflicts(form *assemblyForm, i, skip int) bool {
+	enemies := a.enemies[a.pool[i].QuestionID]
+	if len(enemies) == 0 {
+		return false
+	}
+	for _, j := range form.items {
+		if j != skip && enemies[a.pool[j].QuestionID] {
+			return true
+		}
+	}
+	return false
+}
+
+// withinBounds 判断加入题目 i（并移除 skip）后是否仍不超过各约束上限
+func (a *assembler) withinBounds(form *assemblyForm, i, skip int) bool {
+	for c := range a.spec.Constraints {
+		count := form.counts[c]
+		if a.matches[i][c] {
+			count++
+		}
+		if skip >= 0 && a.matches[skip][c] {
+			count--
+		}
+		if count > a.upper[c] {
+			return false
+		}
+	}
+	return true
+}
+
+// withinResources 判断加入题目 i 后剩余 remaining 道题能否凑足总分且不超时
+func (a *assembler) withinResources(form *assemblyForm, i, skip, remaining int) bool {
+	score := form.score + a.pool[i].Score
+	seconds := form.seconds + a.pool[i].Seconds
+	if skip >= 0 {
+		score -= a.pool[skip].Score
+		seconds -= a.pool[skip].Seconds
+	}
+	if a.spec.MaxSeconds > 0 && seconds > a.spec.MaxSeconds {
+		return false
+	}
+	if a.spec.TotalScore > 0 {
+		left := a.spec.TotalScore - score
+		tolerance := a.spec.ScoreTolerance
+		if left < a.minScore[remaining]-tolerance || left > a.maxScore[remaining]+tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *assembler) add(form *assemblyForm, i int) {
+	a.used[i] = true
+	form.items = append(form.items, i)
+	a.apply(form, i, 1)
+}
+
+// apply 将题目 i 的信息量、约束计数、分值与时间计入（sign=1）或移出（sign=-1）试卷
+func (a *assembler) apply(form *assemblyForm, i int, sign float64) {
+	for k := range form.info {
+		form.info[k] += sign * a.infoAt[i][k]
+	}
+	for c := range form.counts {
+		if a.matches[i][c] {
+			form.counts[c] += int(sign)
+		}
+	}
+	form.score += sign * a.pool[i].Score
+	form.seconds += sign * a.pool[i].Seconds
+}
+
+// deviation 试卷与目标信息函数的相对偏差
+func (a *assembler) deviation(info []float64) float64 {
+	var total float64
+	for k, t := range a.spec.Targets {
+		total += math.Abs(t.Information-info[k]) / t.Information
+	}
+	return total
+}
+
+// improve 逐题尝试与未使用的题目交换，接受使偏差减小且不破坏约束的交换
+func (a *assembler) improve(form *assemblyForm) {
+	info := make([]float64, len(form.info))
+	for iter := 0; iter < a.spec.SwapIterations; iter++ {
+		improved := false
+		for pos, out := range form.items {
+			current := a.deviation(form.info)
+			bestIn, bestDeviation := -1, current
+			for in := range a.pool {
+				if a.used[in] || a.conflicts(form, in, out) || !a.withinBounds(form, in, out) || !a.keepsLower(form, in, out) {
+					continue
+				}
+				if !a.withinResources(form, in, out, 0) {
+					continue
+				}
+				for k := range info {
+					info[k] = form.info[k] - a.infoAt[out][k] + a.infoAt[in][k]
+				}
+				if d := a.deviation(info); d < bestDeviation-1e-9 {
+					bestIn, bestDeviation = in, d
+				}
+			}
+			if bestIn < 0 {
+				continue
+			}
+			a.apply(form, out, -1)
+			a.used[out] = false
+			a.apply(form, bestIn, 1)
+			a.used[bestIn] = true
+			form.items[pos] = bestIn
+			improved = true
+		}
+		if !improved {
+			return
+		}
+	}
+}
+
+// keepsLower 判断用题目 in 替换 out 后已满足的下限不被破坏
+func (a *assembler) keepsLower(form *assemblyForm, in, out int) bool {
+	for c := range a.spec.Constraints {
+		if a.matches[out][c] && !a.matches[in][c] && form.counts[c] <= a.lower[c] {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *assembler) result(form *assemblyForm) AssembledForm {
+	result := AssembledForm{
+		QuestionIDs: make([]uint, len(form.items)),
+		Information: append([]float64(nil), form.info...),
+		Deviation:   a.deviation(form.info),
+		TotalScore:  form.score,
+		Seconds:     form.seconds,
+	}
+	for p, i := range form.items {
+		result.QuestionIDs[p] = a.pool[i].QuestionID
+	}
+
+	if len(form.items) < a.spec.Length {
+		result.Violations = append(result.Violations, fmt.Sprintf("length %d below %d", len(form.items), a.spec.Length))
+	}
+	for c, constraint := range a.spec.Constraints {
+		if form.counts[c] < a.lower[c] || form.counts[c] > a.upper[c] {
+			result.Violations = append(result.Violations, fmt.Sprintf("%s count %d outside [%d, %d]", constraintLabel(constraint), form.counts[c], a.lower[c], a.upper[c]))
+		}
+	}
+	if a.spec.TotalScore > 0 && math.Abs(form.score-a.spec.TotalScore) > a.spec.ScoreTolerance {
+		result.Violations = append(result.Violations, fmt.Sprintf("total score %.1f differs from %.1f", form.score, a.spec.TotalScore))
+	}
+	if a.spec.MaxSeconds > 0 && form.seconds > a.spec.MaxSeconds {
+		result.Violations = append(result.Violations, fmt.Sprintf("expected time %.0fs exceeds %.0fs", form.seconds, a.spec.MaxSeconds))
+	}
+	return result
+}
+
+func constraintLabel(c ContentConstraint) string {
+	if c.KnowledgePointID != 0 {
+		return fmt.Sprintf("knowledge point %d", c.KnowledgePointID)
+	}
+	return fmt.Sprintf("question type %s", c.QuestionType)
+}
@@ -0,0 +1,147 @@
+package irt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assemblyPool 随机生成的组卷题库：奇数题属于知识点1，每三题一道填空题，分值1或2分
+func assemblyPool(size int, seed int64) []AssemblyItem {
+	rng := rand.New(rand.NewSource(seed))
+	pool := make([]AssemblyItem, size)
+	for i := range pool {
+		item := AssemblyItem{
+			QuestionID:     uint(i + 1),
+			Difficulty:     rng.NormFloat64(),
+			Discrimination: 0.6 + rng.Float64(),
+			Guessing:       0.15,
+			Score:          float64(1 + i%2),
+			Seconds:        60,
+			QuestionType:   "single_choice",
+		}
+		if i%2 == 0 {
+			item.KnowledgePointIDs = []uint{1}
+		} else {
+			item.KnowledgePointIDs = []uint{2}
+		}
+		if i%3 == 0 {
+			item.QuestionType = "fill_blank"
+		}
+		pool[i] = item
+	}
+	return pool
+}
+
+func assemblySpec() AssemblySpec {
+	return AssemblySpec{
+		Forms:  2,
+		Length: 10,
+		Targets: []TargetPoint{
+			{Theta: -1, Information: 2.5},
+			{Theta: 0, Information: 3.5},
+			{Theta: 1, Information: 2.5},
+		},
+		Constraints: []ContentConstraint{
+			{KnowledgePointID: 1, MinCount: 6},
+			{QuestionType: "fill_blank", MaxCount: 2},
+		},
+		TotalScore:     14,
+		MaxSeconds:     600,
+		EnemyPairs:     [][2]uint{{1, 3}, {5, 7}},
+		SwapIterations: 5,
+	}
+}
+
+func TestAssembleParallelFormsMeetConstraints(t *testing.T) {
+	pool := assemblyPool(80, 5)
+	spec := assemblySpec()
+	forms, err := Assemble(pool, spec)
+	require.NoError(t, err)
+	require.Len(t, forms, 2)
+
+	byID := make(map[uint]AssemblyItem)
+	for _, item := range pool {
+		byID[item.QuestionID] = item
+	}
+	seen := make(map[uint]bool)
+	for _, form := range forms {
+		assert.Empty(t, form.Violations)
+		require.Len(t, form.QuestionIDs, spec.Length)
+
+		inForm := make(map[uint]bool)
+		var knowledgePoint, fillBlank int
+		var score float64
+		for _, id := range form.QuestionIDs {
+			// 平行卷之间题目不重复
+			assert.False(t, seen[id], "question %d", id)
+			seen[id] = true
+			inForm[id] = true
+
+			item := byID[id]
+			if item.KnowledgePointIDs[0] == 1 {
+				knowledgePoint++
+			}
+			if item.QuestionType == "fill_blank" {
+				fillBlank++
+			}
+			score += item.Score
+		}
+		assert.GreaterOrEqual(t, knowledgePoint, 6)
+		assert.LessOrEqual(t, fillBlank, 2)
+		assert.Equal(t, spec.TotalScore, score)
+		assert.Equal(t, score, form.TotalScore)
+		assert.Equal(t, 600.0, form.Seconds)
+		for _, pair := range spec.EnemyPairs {
+			assert.False(t, inForm[pair[0]] && inForm[pair[1]], "enemy pair %v", pair)
+		}
+		assert.Len(t, form.Information, len(spec.Targets))
+	}
+}
+
+func TestAssembleSwapReducesDeviation(t *testing.T) {
+	pool := assemblyPool(80, 5)
+	spec := assemblySpec()
+
+	spec.SwapIterations = 0
+	greedy, err := Assemble(pool, spec)
+	require.NoError(t, err)
+	spec.SwapIterations = 10
+	improved, err := Assemble(pool, spec)
+	require.NoError(t, err)
+
+	// 交换只接受使偏差减小的替换
+	for f := range greedy {
+		assert.LessOrEqual(t, improved[f].Deviation, greedy[f].Deviation+1e-9)
+		assert.Empty(t, improved[f].Violations)
+	}
+}
+
+func TestAssembleReportsViolations(t *testing.T) {
+	// 题库中没有满足总分的组合时仍然组卷，并报告未满足的约束
+	pool := assemblyPool(20, 7)
+	spec := assemblySpec()
+	spec.Forms = 1
+	spec.TotalScore = 40
+	forms, err := Assemble(pool, spec)
+	require.NoError(t, err)
+	assert.Len(t, forms[0].QuestionIDs, spec.Length)
+	assert.NotEmpty(t, forms[0].Violations)
+}
+
+func TestAssembleErrors(t *testing.T) {
+	pool := assemblyPool(15, 1)
+	spec := assemblySpec()
+
+	_, err := Assemble(pool, spec)
+	assert.ErrorIs(t, err, ErrPoolTooSmall)
+
+	spec.Targets = []TargetPoint{{Theta: 0}}
+	_, err = Assemble(pool, spec)
+	assert.ErrorIs(t, err, ErrInvalidAssemblySpec)
+
+	_, err = Assemble(pool, AssemblySpec{Forms: 1, Length: 5})
+	assert.ErrorIs(t, err, ErrInvalidAssemblySpec)
+}
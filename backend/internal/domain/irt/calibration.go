@@ -0,0 +1,492 @@
+package irt
+
+import (
+	"errors"
+	"math"
+)
+
+// Missing 作答矩阵中的未作答标记
+const Missing = -1
+
+var ErrEmptyMatrix = errors.New("response matrix is empty")
+
+// ResponseMatrix 考生×题目作答矩阵，取值为 1（答对）、0（答错）或 Missing
+type ResponseMatrix struct {
+	QuestionIDs []uint
+	Responses   [][]int
+	RowIDs      []uint // 各行对应的考试记录ID，可为空
+}
+
+// ItemCalibration 单题标定结果
+type ItemCalibration struct {
+	QuestionID       uint
+	Difficulty       float64
+	Discrimination   float64
+	Guessing         float64
+	UpperAsymptote   float64
+	DifficultySE     float64
+	DiscriminationSE float64
+	GuessingSE       float64
+	UpperAsymptoteSE float64
+	ResponseCount    int
+	Skipped          bool // 作答人数不足，未参与标定
+}
+
+// CalibrationResult 标定运行结果
+type CalibrationResult struct {
+	Model         ModelFamily
+	D             float64 // 量尺因子
+	Items         []ItemCalibration
+	Examinees     int
+	Iterations    int
+	Converged     bool
+	MaxChange     float64 // 最后一轮参数的最大变化量
+	LogLikelihood float64 // 边际对数似然
+}
+
+// Calibrator 基于 Bock–Aitkin EM 算法的边际最大似然标定器
+type Calibrator struct {
+	Model            Model
+	QuadraturePoints int
+	MaxIterations    int
+	Convergence      float64
+	MinResponses     int // 参与标定所需的最少作答人数
+	// 猜测参数的 Beta(α, β) 先验，防止 3PL 下 c 参数发散
+	GuessingPriorAlpha float64
+	GuessingPriorBeta  float64
+	// 上渐近线的 Beta(α, β) 先验，防止 4PL 下 d 参数发散
+	UpperAsymptotePriorAlpha float64
+	UpperAsymptotePriorBeta  float64
+}
+
+// NewCalibrator 创建使用默认配置的标定器
+func NewCalibrator(model Model) *Calibrator {
+	return &Calibrator{
+		Model:                    model.resolved(),
+		QuadraturePoints:         21,
+		MaxIterations:            200,
+		Convergence:              0.001,
+		MinResponses:             20,
+		GuessingPriorAlpha:       5,
+		GuessingPriorBeta:        17,
+		UpperAsymptotePriorAlpha: 17,
+		UpperAsymptotePriorBeta:  2,
+	}
+}
+
+// itemState 标定过程中的单题参数
+type itemState struct {
+	a, b, c, d float64
+	skip       bool
+}
+
+// params 转换为响应函数使用的题目参数
+func (item itemState) params() ItemParams {
+	return ItemParams{Difficulty: item.b, Discrimination: item.a, Guessing: item.c, UpperAsymptote: item.d}
+}
+
+// Calibrate 对作答矩阵中的全部题目进行标定
+func (cal *Calibrator) Calibrate(matrix *ResponseMatrix) (*CalibrationResult, error) {
+	if matrix == nil || len(matrix.QuestionIDs) == 0 || len(matrix.Responses) == 0 {
+		return nil, ErrEmptyMatrix
+	}
+	if err := cal.Model.Validate(); err != nil {
+		return nil, err
+	}
+
+	nodes, priorWeights := NormalQuadrature(cal.QuadraturePoints)
+	numItems := len(matrix.QuestionIDs)
+
+	items := make([]itemState, numItems)
+	counts := make([]int, numItems)
+	for i := range items {
+		items[i] = itemState{a: 1, b: 0, d: 1}
+		switch cal.Model.Family {
+		case Model3PL:
+			items[i].c = 0.2
+		case Model4PL:
+			items[i].c = 0.2
+			items[i].d = 0.95
+		}
+		var correct int
+		for _, row := range matrix.Responses {
+			if row[i] == Missing {
+				continue
+			}
+			counts[i]++
+			correct += row[i]
+		}
+		if counts[i] < cal.MinResponses {
+			items[i].skip = true
+			continue
+		}
+		// 以通过率的logit作为难度初值
+		p := (float64(correct) + 0.5) / (float64(counts[i]) + 1)
+		items[i].b = -math.Log(p/(1-p)) / cal.Model.D
+	}
+
+	result := &CalibrationResult{Model: cal.Model.Family, D: cal.Model.D, Examinees: len(matrix.Responses)}
+	n := make([][]float64, numItems) // 各节点上的期望作答人数
+	r := make([][]float64, numItems) // 各节点上的期望答对人数
+	for i := range n {
+		n[i] = make([]float64, len(nodes))
+		r[i] = make([]float64, len(nodes))
+	}
+
+	for iter := 0; iter < cal.MaxIterations; iter++ {
+		result.Iterations = iter + 1
+		result.LogLikelihood = cal.expectation(matrix, items, nodes, priorWeights, n, r)
+
+		maxChange := 0.0
+		for i := range items {
+			if items[i].skip {
+				continue
+			}
+			updated := cal.maximize(items[i], nodes, n[i], r[i])
+			maxChange = math.Max(maxChange, itemChange(updated, items[i]))
+			items[i] = updated
+		}
+		if cal.Model.Family == Model1PL {
+			// 1PL 各题共用区分度，在难度更新后对全部题目联合估计
+			a := cal.commonDiscrimination(items, nodes, n, r)
+			for i := range items {
+				if !items[i].skip {
+					maxChange = math.Max(maxChange, math.Abs(a-items[i].a))
+					items[i].a = a
+				}
+			}
+		}
+		result.MaxChange = maxChange
+		if maxChange < cal.Convergence {
+			result.Converged = true
+			break
+		}
+	}
+
+	// 收敛后重新计算期望计数，用于标准误
+	result.LogLikelihood = cal.expectation(matrix, items, nodes, priorWeights, n, r)
+	result.Items = make([]ItemCalibration, numItems)
+	for i, item := range items {
+		calibration := ItemCalibration{
+			QuestionID:     matrix.QuestionIDs[i],
+			Difficulty:     item.b,
+			Discrimination: item.a,
+			Guessing:       item.c,
+			UpperAsymptote: item.d,
+			ResponseCount:  counts[i],
+			Skipped:        item.skip,
+		}
+		if !item.skip {
+			se := cal.standardErrors(item, nodes, n[i], r[i])
+			calibration.DifficultySE, calibration.DiscriminationSE = se[0], se[1]
+			calibration.GuessingSE, calibration.UpperAsymptoteSE = se[2], se[3]
+		}
+		result.Items[i] = calibration
+	}
+	return result, nil
+}
+
+// itemChange 两轮之间单题参数的最大变化量
+func itemChange(updated, previous itemState) float64 {
+	change := math.Abs(updated.a - previous.a)
+	change = math.Max(change, math.Abs(updated.b-previous.b))
+	change = math.Max(change, math.Abs(updated.c-previous.c))
+	return math.Max(change, math.Abs(updated.d-previous.d))
+}
+
+// expectation E步：计算每个节点上的期望作答人数与答对人数，返回边际对数似然
+func (cal *Calibrator) expectation(matrix *ResponseMatrix, items []itemState, nodes, priorWeights []float64, n, r [][]float64) float64 {
+	for i := range n {
+		for k := range n[i] {
+			n[i][k] = 0
+			r[i][k] = 0
+		}
+	}
+
+	// 预先计算各题在各节点上的答对概率
+	probs := make([][]float64, len(items))
+	for i, item := range items {
+		probs[i] = make([]float64, len(nodes))
+		for k, x := range nodes {
+			probs[i][k] = boundProbability(cal.Model.Probability(x, item.params()))
+		}
+	}
+
+	var logLikelihood float64
+	posterior := make([]float64, len(nodes))
+	for _, row := range matrix.Responses {
+		maxLog := math.Inf(-1)
+		for k := range nodes {
+			ll := math.Log(priorWeights[k])
+			for i, u := range row {
+				if u == Missing || items[i].skip {
+					continue
+				}
+				if u == 1 {
+					ll += math.Log(probs[i][k])
+				} else {
+					ll += math.Log(1 - probs[i][k])
+				}
+			}
+			posterior[k] = ll
+			maxLog = math.Max(maxLog, ll)
+		}
+
+		var total float64
+		for k := range posterior {
+			posterior[k] = math.Exp(posterior[k] - maxLog)
+			total += posterior[k]
+		}
+		logLikelihood += maxLog + math.Log(total)
+
+		for k := range posterior {
+			posterior[k] /= total
+		}
+		for i, u := range row {
+			if u == Missing || items[i].skip {
+				continue
+			}
+			for k, w := range posterior {
+				n[i][k] += w
+				if u == 1 {
+					r[i][k] += w
+				}
+			}
+		}
+	}
+	return logLikelihood
+}
+
+// maximize M步：对单题的期望完全数据对数似然做牛顿迭代
+func (cal *Calibrator) maximize(item itemState, nodes, n, r []float64) itemState {
+	objective := func(x []float64) float64 {
+		return cal.itemObjective(cal.unpack(x, item), nodes, n, r)
+	}
+	return cal.unpack(newtonAscent(objective, cal.pack(item), cal.Convergence/10), item)
+}
+
+// commonDiscrimination 1PL 下以 log a 为变量最大化全部题目的期望完全数据对数似然
+func (cal *Calibrator) commonDiscrimination(items []itemState, nodes []float64, n, r [][]float64) float64 {
+	start := 1.0
+	for _, item := range items {
+		if !item.skip {
+			start = item.a
+			break
+		}
+	}
+	objective := func(x []float64) float64 {
+		a := clampDiscrimination(math.Exp(x[0]))
+		var ll float64
+		for i, item := range items {
+			if item.skip {
+				continue
+			}
+			item.a = a
+			ll += cal.itemObjective(item, nodes, n[i], r[i])
+		}
+		return ll
+	}
+	x := newtonAscent(objective, []float64{math.Log(start)}, cal.Convergence/10)
+	return clampDiscrimination(math.Exp(x[0]))
+}
+
+// newtonAscent 带回溯线搜索的牛顿法，求目标函数的局部最大值，
+// 单步变化量小于 tolerance 时停止
+func newtonAscent(objective func([]float64) float64, current []float64, tolerance float64) []float64 {
+	for step := 0; step < 10; step++ {
+		grad, hess := numericDerivatives(objective, current)
+		delta := solveNewton(hess, grad)
+
+		// 回溯线搜索，确保目标函数不下降
+		base := objective(current)
+		scale := 1.0
+		var candidate []float64
+		improved := false
+		for halving := 0; halving < 10; halving++ {
+			candidate = make([]float64, len(current))
+			for j := range current {
+				candidate[j] = current[j] - scale*delta[j]
+			}
+			if objective(candidate) >= base {
+				improved = true
+				break
+			}
+			scale /= 2
+		}
+		if !improved {
+			break
+		}
+
+		var change float64
+		for j := range current {
+			change = math.Max(change, math.Abs(candidate[j]-current[j]))
+		}
+		current = candidate
+		if change < tolerance {
+			break
+		}
+	}
+	return current
+}
+
+// standardErrors 由目标函数的海森矩阵求 b、a、c、d 的渐近标准误，
+// 未估计的参数或无法求逆时为0
+func (cal *Calibrator) standardErrors(item itemState, nodes, n, r []float64) [4]float64 {
+	var se [4]float64
+	objective := func(x []float64) float64 {
+		return cal.itemObjective(cal.unpack(x, item), nodes, n, r)
+	}
+	x := cal.pack(item)
+	_, hess := numericDerivatives(objective, x)
+	cov, ok := invertNegative(hess)
+	if !ok {
+		return se
+	}
+
+	se[0] = math.Sqrt(cov[0][0])
+	if len(x) > 1 {
+		// 区分度以对数形式估计，按delta法换回原尺度
+		se[1] = math.Sqrt(cov[1][1]) * item.a
+	}
+	if len(x) > 2 {
+		se[2] = math.Sqrt(cov[2][2]) * item.c * (1 - item.c)
+	}
+	if len(x) > 3 {
+		se[3] = math.Sqrt(cov[3][3]) * item.d * (1 - item.d)
+	}
+	return se
+}
+
+// itemObjective 单题期望完全数据对数似然（含猜测参数与上渐近线先验）
+func (cal *Calibrator) itemObjective(item itemState, nodes, n, r []float64) float64 {
+	var ll float64
+	for k, x := range nodes {
+		p := boundProbability(cal.Model.Probability(x, item.params()))
+		ll += r[k]*math.Log(p) + (n[k]-r[k])*math.Log(1-p)
+	}
+	switch cal.Model.Family {
+	case Model3PL, Model4PL:
+		c := boundProbability(item.c)
+		ll += (cal.GuessingPriorAlpha-1)*math.Log(c) + (cal.GuessingPriorBeta-1)*math.Log(1-c)
+	}
+	if cal.Model.Family == Model4PL {
+		d := boundProbability(item.d)
+		ll += (cal.UpperAsymptotePriorAlpha-1)*math.Log(d) + (cal.UpperAsymptotePriorBeta-1)*math.Log(1-d)
+	}
+	return ll
+}
+
+// pack 将题目参数转换为无约束的优化变量：b、log a、logit c、logit d
+func (cal *Calibrator) pack(item itemState) []float64 {
+	switch cal.Model.Family {
+	case ModelRasch, Model1PL:
+		return []float64{item.b}
+	case Model2PL:
+		return []float64{item.b, math.Log(item.a)}
+	case Model3PL:
+		c := boundProbability(item.c)
+		return []float64{item.b, math.Log(item.a), math.Log(c / (1 - c))}
+	default:
+		c := boundProbability(item.c)
+		d := boundProbability(item.d)
+		return []float64{item.b, math.Log(item.a), math.Log(c / (1 - c)), math.Log(d / (1 - d))}
+	}
+}
+
+// unpack 将优化变量还原为题目参数，并限制在合理取值范围内；
+// 未参与优化的参数沿用 template 中的取值
+func (cal *Calibrator) unpack(x []float64, template itemState) itemState {
+	item := itemState{a: template.a, b: math.Max(-4, math.Min(4, x[0])), d: 1}
+	if cal.Model.Family == ModelRasch {
+		item.a = 1
+	}
+	if len(x) > 1 {
+		item.a = clampDiscrimination(math.Exp(x[1]))
+	}
+	if len(x) > 2 {
+		item.c = math.Min(0.5, 1/(1+math.Exp(-x[2])))
+	}
+	if len(x) > 3 {
+		item.d = math.Max(0.5, 1/(1+math.Exp(-x[3])))
+	}
+	return item
+}
+
+// clampDiscrimination 将区分度限制在 [0.2, 4]
+func clampDiscrimination(a float64) float64 {
+	return math.Max(0.2, math.Min(4, a))
+}
+
+// NormalQuadrature 返回标准正态分布在 [-4, 4] 上的等距积分节点及归一化权重
+func NormalQuadrature(points int) ([]float64, []float64) {
+	if points < 2 {
+		points = 2
+	}
+	nodes := make([]float64, points)
+	weights := make([]float64, points)
+	step := 8.0 / float64(points-1)
+	var total float64
+	for k := range nodes {
+		nodes[k] = -4 + float64(k)*step
+		weights[k] = math.Exp(-0.5 * nodes[k] * nodes[k])
+		total += weights[k]
+	}
+	for k := range weights {
+		weights[k] /= total
+	}
+	return nodes, weights
+}
+
+// numericDerivatives 用中心差分计算梯度与海森矩阵
+func numericDerivatives(f func([]float64) float64, x []float64) ([]float64, [][]float64) {
+	const h = 1e-4
+	dim := len(x)
+	grad := make([]float64, dim)
+	hess := make([][]float64, dim)
+	shifted := func(deltas map[int]float64) float64 {
+		y := append([]float64(nil), x...)
+		for j, d := range deltas {
+			y[j] += d
+		}
+		return f(y)
+	}
+
+	f0 := f(x)
+	for i := 0; i < dim; i++ {
+		hess[i] = make([]float64, dim)
+		fp := shifted(map[int]float64{i: h})
+		fm := shifted(map[int]float64{i: -h})
+		grad[i] = (fp - fm) / (2 * h)
+		hess[i][i] = (fp - 2*f0 + fm) / (h * h)
+	}
+	for i := 0; i < dim; i++ {
+		for j := i + 1; j < dim; j++ {
+			fpp := shifted(map[int]float64{i: h, j: h})
+			fpm := shifted(map[int]float64{i: h, j: -h})
+			fmp := shifted(map[int]float64{i: -h, j: h})
+			fmm := shifted(map[int]float64{i: -h, j: -h})
+			hess[i][j] = (fpp - fpm - fmp + fmm) / (4 * h * h)
+			hess[j][i] = hess[i][j]
+		}
+	}
+	return grad, hess
+}
+
+// solveNewton 求解 H·δ = g；海森矩阵非负定时退化为梯度上升
+func solveNewton(hess [][]float64, grad []float64) []float64 {
+	if delta, ok := SolveLinear(hess, grad); ok {
+		// 仅在海森矩阵负定（上升方向）时采用牛顿步
+		var dot float64
+		for i := range delta {
+			dot += delta[i] * grad[i]
+		}
+		if dot < 0 {
+			return delta
+		}
+	}
+	delta := make([]float64, len(grad))
+	for i := range grad {
+		delta[i] = -0.1 * grad[i]
+	}
+	return delta
+}
@@ -0,0 +1,89 @@
+package irt
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// simulatedMatrix 从 N(0, 1) 抽取考生能力并按题目参数生成作答矩阵
+func simulatedMatrix(rng *rand.Rand, model Model, items []ItemParams, examinees int) *ResponseMatrix {
+	matrix := &ResponseMatrix{QuestionIDs: make([]uint, len(items)), Responses: make([][]int, examinees)}
+	for j := range items {
+		matrix.QuestionIDs[j] = uint(j + 1)
+	}
+	for i := range matrix.Responses {
+		theta := rng.NormFloat64()
+		row := make([]int, len(items))
+		for j, item := range items {
+			if rng.Float64() < model.Probability(theta, item) {
+				row[j] = 1
+			}
+		}
+		matrix.Responses[i] = row
+	}
+	return matrix
+}
+
+func TestCalibrateRecoversParameters(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	model := Model{Family: Model2PL, D: ScalingNormal}
+	items := simulatedItems(rng, 20, 0)
+	matrix := simulatedMatrix(rng, model, items, 3000)
+
+	result, err := NewCalibrator(model).Calibrate(matrix)
+	require.NoError(t, err)
+	require.Len(t, result.Items, len(items))
+	assert.True(t, result.Converged)
+	assert.Equal(t, 3000, result.Examinees)
+
+	var sqA, sqB float64
+	for j, item := range result.Items {
+		assert.Equal(t, uint(j+1), item.QuestionID)
+		assert.False(t, item.Skipped)
+		assert.Zero(t, item.Guessing)
+		sqA += math.Pow(item.Discrimination-items[j].Discrimination, 2)
+		sqB += math.Pow(item.Difficulty-items[j].Difficulty, 2)
+		// 估计误差不超过标准误的4倍
+		assert.InDelta(t, items[j].Difficulty, item.Difficulty, 4*item.DifficultySE)
+		assert.InDelta(t, items[j].Discrimination, item.Discrimination, 4*item.DiscriminationSE)
+	}
+	assert.Less(t, math.Sqrt(sqA/float64(len(items))), 0.15)
+	assert.Less(t, math.Sqrt(sqB/float64(len(items))), 0.1)
+}
+
+func TestCalibrateRasch(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+	model := Model{Family: ModelRasch, D: ScalingLogistic}
+	items := simulatedItems(rng, 15, 0)
+	matrix := simulatedMatrix(rng, model, items, 2000)
+
+	result, err := NewCalibrator(model).Calibrate(matrix)
+	require.NoError(t, err)
+	for j, item := range result.Items {
+		assert.Equal(t, 1.0, item.Discrimination)
+		assert.InDelta(t, items[j].Difficulty, item.Difficulty, 0.2)
+	}
+}
+
+func TestCalibrateSkipsSparseItems(t *testing.T) {
+	rng := rand.New(rand.NewSource(17))
+	model := Model{Family: Model2PL, D: ScalingNormal}
+	matrix := simulatedMatrix(rng, model, simulatedItems(rng, 5, 0), 500)
+	// 第5题只有前10名考生作答
+	for i := 10; i < len(matrix.Responses); i++ {
+		matrix.Responses[i][4] = Missing
+	}
+
+	result, err := NewCalibrator(model).Calibrate(matrix)
+	require.NoError(t, err)
+	assert.True(t, result.Items[4].Skipped)
+	assert.Equal(t, 10, result.Items[4].ResponseCount)
+	assert.False(t, result.Items[0].Skipped)
+
+	_, err = NewCalibrator(model).Calibrate(&ResponseMatrix{})
+	assert.ErrorIs(t, err, ErrEmptyMatrix)
+}
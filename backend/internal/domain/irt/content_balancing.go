@@ -0,0 +1,209 @@
+package irt
+
+import (
+	"errors"
+	"math"
+)
+
+// BalancingMethod 内容平衡方法
+type BalancingMethod string
+
+const (
+	BalancingMPI BalancingMethod = "mpi" // 最大优先指数（Cheng & Chang, 2009）
+	BalancingWDM BalancingMethod = "wdm" // 加权离差模型（Stocking & Swanson, 1993）
+)
+
+var ErrUnknownBalancingMethod = errors.New("unknown content balancing method")
+
+// ContentConstraint 蓝图中的一条内容约束，按知识点或题型匹配题目
+type ContentConstraint struct {
+	KnowledgePointID uint    // 非0时按知识点匹配
+	QuestionType     string  // 非空时按题型匹配
+	MinCount         int     // 最少题数
+	MaxCount         int     // 最多题数，0表示不限
+	TargetProportion float64 // 目标比例，非0时按测验长度换算为最少/最多题数
+	Weight           float64 // WDM 权重，缺省为1
+}
+
+// Matches 判断题目是否属于该约束
+func (c ContentConstraint) Matches(item Candidate) bool {
+	if c.KnowledgePointID != 0 {
+		for _, id := range item.KnowledgePointIDs {
+			if id == c.KnowledgePointID {
+				return true
+			}
+		}
+		return false
+	}
+	return c.QuestionType != "" && item.QuestionType == c.QuestionType
+}
+
+// bounds 返回约束在给定测验长度下的最少与最多题数，最多题数为0表示不限；
+// 未设置最多题数时以测验长度为上限，变长测验（测验长度为0）不设上限
+func (c ContentConstraint) bounds(testLength int) (int, int) {
+	lower, upper := c.MinCount, c.MaxCount
+	if c.TargetProportion > 0 && testLength > 0 {
+		target := c.TargetProportion * float64(testLength)
+		lower = int(math.Floor(target))
+		upper = int(math.Ceil(target))
+	}
+	if upper <= 0 {
+		upper = testLength
+	}
+	return lower, upper
+}
+
+// Blueprint 自适应测验的内容蓝图
+type Blueprint struct {
+	Method      BalancingMethod
+	Constraints []ContentConstraint
+}
+
+// NewBalancedSelector 在基础选题准则上叠加内容平衡，administered 为本场已施测题目
+func NewBalancedSelector(base ItemSelector, blueprint *Blueprint, administered []Candidate) (ItemSelector, error) {
+	if blueprint == nil || len(blueprint.Constraints) == 0 {
+		return base, nil
+	}
+	switch blueprint.Method {
+	case BalancingMPI, "":
+		return &mpiSelector{base: base, blueprint: blueprint, administered: administered}, nil
+	case BalancingWDM:
+		return &wdmSelector{base: base, blueprint: blueprint, administered: administered}, nil
+	default:
+		return nil, ErrUnknownBalancingMethod
+	}
+}
+
+// countMatches 统计已施测题目中满足各约束的题数
+func countMatches(blueprint *Blueprint, administered []Candidate) []int {
+	counts := make([]int, len(blueprint.Constraints))
+	for _, item := range administered {
+		for k, c := range blueprint.Constraints {
+			if c.Matches(item) {
+				counts[k]++
+			}
+		}
+	}
+	return counts
+}
+
+// normalizeScores 将基础准则分数线性映射到 (0, 1]，保留不可选标记
+func normalizeScores(scores []float64) []float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range scores {
+		if math.IsInf(s, -1) || math.IsNaN(s) {
+			continue
+		}
+		lo = math.Min(lo, s)
+		hi = math.Max(hi, s)
+	}
+
+	const eps = 1e-6
+	normalized := make([]float64, len(scores))
+	for i, s := range scores {
+		if math.IsInf(s, -1) || math.IsNaN(s) {
+			normalized[i] = math.Inf(-1)
+			continue
+		}
+		normalized[i] = (s - lo + eps) / (hi - lo + eps)
+	}
+	return normalized
+}
+
+// mpiSelector 优先指数 = 准则分数 × Π f_k，
+// 已达上限的约束 f_k = 0；尚未达到下限的约束优先；剩余题量仅够满足下限时排除无关题目
+type mpiSelector struct {
+	base         ItemSelector
+	blueprint    *Blueprint
+	administered []Candidate
+}
+
+func (s *mpiSelector) Score(candidates []Candidate, state SelectionState) []float64 {
+	scores := normalizeScores(s.base.Score(candidates, state))
+	counts := countMatches(s.blueprint, s.administered)
+	remaining := state.TestLength - len(s.administered)
+
+	// 仍需满足下限的题数
+	required := 0
+	for k, c := range s.blueprint.Constraints {
+		lower, _ := c.bounds(state.TestLength)
+		if counts[k] < lower {
+			required += lower - counts[k]
+		}
+	}
+
+	for i, item := range candidates {
+		if math.IsInf(scores[i], -1) {
+			continue
+		}
+		priority := 1.0
+		fillsLower := false
+		for k, c := range s.blueprint.Constraints {
+			if !c.Matches(item) {
+				continue
+			}
+			lower, upper := c.bounds(state.TestLength)
+			switch {
+			case upper > 0 && counts[k] >= upper:
+				priority = 0
+			case counts[k] < lower:
+				fillsLower = true
+				priority *= 1 + float64(lower-counts[k])/float64(lower)
+			case upper > 0:
+				priority *= float64(upper-counts[k]) / float64(upper)
+			}
+		}
+		if state.TestLength > 0 && remaining <= required && !fillsLower {
+			priority = 0
+		}
+		if priority <= 0 {
+			scores[i] = math.Inf(-1)
+			continue
+		}
+		scores[i] *= priority
+	}
+	return scores
+}
+
+// wdmSelector 选择使加权约束离差最小的题目，准则分数作为一项收益
+type wdmSelector struct {
+	base         ItemSelector
+	blueprint    *Blueprint
+	administered []Candidate
+}
+
+func (s *wdmSelector) Score(candidates []Candidate, state SelectionState) []float64 {
+	scores := normalizeScores(s.base.Score(candidates, state))
+	counts := countMatches(s.blueprint, s.administered)
+	// 选入该题后剩余的题量，变长测验不计剩余题量，下限缺口按当前题数计
+	remaining := 0
+	if state.TestLength > 0 {
+		remaining = state.TestLength - len(s.administered) - 1
+	}
+
+	for i, item := range candidates {
+		if math.IsInf(scores[i], -1) {
+			continue
+		}
+		var deviation float64
+		for k, c := range s.blueprint.Constraints {
+			lower, upper := c.bounds(state.TestLength)
+			projected := counts[k]
+			if c.Matches(item) {
+				projected++
+			}
+			weight := c.Weight
+			if weight == 0 {
+				weight = 1
+			}
+			if upper > 0 && projected > upper {
+				deviation += weight * float64(projected-upper)
+			}
+			if shortfall := lower - projected - remaining; shortfall > 0 {
+				deviation += weight * float64(shortfall)
+			}
+		}
+		scores[i] -= deviation
+	}
+	return scores
+}
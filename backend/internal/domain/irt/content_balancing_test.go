@@ -0,0 +1,71 @@
+package irt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	questionTypeSingle = "单选"
+	questionTypeMulti  = "多选"
+)
+
+func balancingCandidates() []Candidate {
+	return []Candidate{
+		{QuestionID: 1, Discrimination: 0.6, QuestionType: questionTypeMulti},
+		{QuestionID: 2, Discrimination: 1.8, QuestionType: questionTypeSingle},
+		{QuestionID: 3, Discrimination: 1.2, QuestionType: questionTypeSingle},
+	}
+}
+
+func balancedScores(t *testing.T, method BalancingMethod, constraint ContentConstraint, administered []Candidate, testLength int) []float64 {
+	base, err := NewItemSelector(StrategyMaxInfo)
+	require.NoError(t, err)
+	selector, err := NewBalancedSelector(base, &Blueprint{Method: method, Constraints: []ContentConstraint{constraint}}, administered)
+	require.NoError(t, err)
+	return selector.Score(balancingCandidates(), SelectionState{TestLength: testLength, ItemsAdministered: len(administered)})
+}
+
+func TestBalancingVariableLengthHasNoUpperBound(t *testing.T) {
+	// 变长测验未设置最多题数时，约束内的题目始终可选
+	constraint := ContentConstraint{QuestionType: questionTypeMulti, MinCount: 2}
+	administered := []Candidate{{QuestionID: 9, QuestionType: questionTypeMulti}}
+	for _, method := range []BalancingMethod{BalancingMPI, BalancingWDM} {
+		scores := balancedScores(t, method, constraint, administered, 0)
+		for i, score := range scores {
+			assert.False(t, math.IsInf(score, -1), "%s excludes candidate %d", method, i)
+		}
+	}
+}
+
+func TestBalancingMaxCountReached(t *testing.T) {
+	constraint := ContentConstraint{QuestionType: questionTypeMulti, MaxCount: 1}
+	administered := []Candidate{{QuestionID: 9, QuestionType: questionTypeMulti}}
+
+	scores := balancedScores(t, BalancingMPI, constraint, administered, 0)
+	assert.True(t, math.IsInf(scores[0], -1))
+	assert.False(t, math.IsInf(scores[1], -1))
+
+	// WDM 不排除题目，超出上限计入离差
+	unconstrained := balancedScores(t, BalancingWDM, ContentConstraint{QuestionType: questionTypeMulti}, administered, 0)
+	scores = balancedScores(t, BalancingWDM, constraint, administered, 0)
+	assert.InDelta(t, unconstrained[0]-1, scores[0], 1e-12)
+	assert.InDelta(t, unconstrained[1], scores[1], 1e-12)
+}
+
+func TestBalancingReservesRemainingItemsForLowerBound(t *testing.T) {
+	// 3题测验已施测1道单选，剩余2题必须都是多选才能满足最少2题
+	constraint := ContentConstraint{QuestionType: questionTypeMulti, MinCount: 2}
+	administered := []Candidate{{QuestionID: 9, QuestionType: questionTypeSingle}}
+
+	scores := balancedScores(t, BalancingMPI, constraint, administered, 3)
+	assert.False(t, math.IsInf(scores[0], -1))
+	assert.True(t, math.IsInf(scores[1], -1))
+	assert.True(t, math.IsInf(scores[2], -1))
+
+	scores = balancedScores(t, BalancingWDM, constraint, administered, 3)
+	assert.Greater(t, scores[0], scores[1])
+}
@@ -0,0 +1,179 @@
+package irt
+
+import (
+	"errors"
+	"math"
+)
+
+// EquatingMethod 原始分等值方法
+type EquatingMethod string
+
+const (
+	EquateTrueScore     EquatingMethod = "true_score"     // IRT 真分数等值
+	EquateObservedScore EquatingMethod = "observed_score" // IRT 观察分数等值（等百分位）
+)
+
+var (
+	ErrUnknownEquatingMethod = errors.New("unknown equating method")
+	ErrEmptyForm             = errors.New("form has no items")
+)
+
+// EquatedScore 试卷 X 的原始分及其在试卷 Y 上的等值分数
+type EquatedScore struct {
+	RawScore float64
+	Theta    float64 // 真分数等值时对应的能力值，超出可达范围取端点；观察分数等值不使用
+	Equated  float64
+}
+
+// Equate 将试卷 X 的原始分等值到试卷 Y，两卷题目参数须在同一量尺上
+func (m Model) Equate(method EquatingMethod, formX, formY []TestItem, scores []float64) ([]EquatedScore, error) {
+	if len(formX) == 0 || len(formY) == 0 {
+		return nil, ErrEmptyForm
+	}
+	switch method {
+	case EquateTrueScore:
+		return m.TrueScoreEquating(formX, formY, scores), nil
+	case EquateObservedScore:
+		return m.ObservedScoreEquating(formX, formY, scores), nil
+	default:
+		return nil, ErrUnknownEquatingMethod
+	}
+}
+
+// TrueScoreEquating 反解试卷 X 的测验特征曲线得到 θ，再取试卷 Y 在 θ 处的期望得分；
+// 低于猜测得分下限 Σc 的原始分按 Kolen–Brennan 的做法线性对应到 Y 的下限
+func (m Model) TrueScoreEquating(formX, formY []TestItem, scores []float64) []EquatedScore {
+	const minTheta, maxTheta = -8.0, 8.0
+	lowerX, lowerY := m.chanceScore(formX), m.chanceScore(formY)
+	maxX, maxY := maxScore(formX), maxScore(formY)
+
+	equated := make([]EquatedScore, len(scores))
+	for i, x := range scores {
+		e := EquatedScore{RawScore: x}
+		switch {
+		case x <= lowerX:
+			e.Theta = minTheta
+			if lowerX > 0 {
+				e.Equated = math.Max(0, x) * lowerY / lowerX
+			}
+		case x >= maxX:
+			e.Theta = maxTheta
+			e.Equated = maxY
+		default:
+			e.Theta = m.ThetaForScore(formX, x, minTheta, maxTheta)
+			e.Equated = m.ExpectedScore(formY, e.Theta)
+		}
+		equated[i] = e
+	}
+	return equated
+}
+
+// ObservedScoreEquating 用 Lord–Wingersky 递推求两卷在标准正态总体上的边际原始分分布，
+// 再做等百分位等值；分值按整数计
+func (m Model) ObservedScoreEquating(formX, formY []TestItem, scores []float64) []EquatedScore {
+	nodes, weights := NormalQuadrature(41)
+	distX := m.MarginalScoreDistribution(formX, nodes, weights)
+	distY := m.MarginalScoreDistribution(formY, nodes, weights)
+	cumX, cumY := cumulative(distX), cumulative(distY)
+
+	equated := make([]EquatedScore, len(scores))
+	for i, x := range scores {
+		equated[i] = EquatedScore{
+			RawScore: x,
+			Equated:  percentileInverse(cumY, distY, percentileRank(cumX, distX, x)),
+		}
+	}
+	return equated
+}
+
+// MarginalScoreDistribution 原始分 0..Σ分值 在给定能力分布上的边际概率
+func (m Model) MarginalScoreDistribution(items []TestItem, nodes, weights []float64) []float64 {
+	total := 0
+	for _, item := range items {
+		total += integerWeight(item)
+	}
+	marginal := make([]float64, total+1)
+	for q, theta := range nodes {
+		dist := make([]float64, total+1)
+		dist[0] = 1
+		reached := 0
+		for _, item := range items {
+			p := m.Probability(theta, item.params())
+			w := integerWeight(item)
+			reached += w
+			for k := reached; k >= 0; k-- {
+				dist[k] *= 1 - p
+				if k >= w {
+					dist[k] += dist[k-w] * p
+				}
+			}
+		}
+		for k := range marginal {
+			marginal[k] += weights[q] * dist[k]
+		}
+	}
+	return marginal
+}
+
+// chanceScore 能力趋于 -∞ 时的期望得分 Σ分值·c
+func (m Model) chanceScore(items []TestItem) float64 {
+	var score float64
+	for _, item := range items {
+		score += item.weight() * m.Constrain(item.params()).Guessing
+	}
+	return score
+}
+
+func maxScore(items []TestItem) float64 {
+	var score float64
+	for _, item := range items {
+		score += item.weight()
+	}
+	return score
+}
+
+// integerWeight 观察分数等值使用的整数分值，至少为1
+func integerWeight(item TestItem) int {
+	return int(math.Max(1, math.Round(item.weight())))
+}
+
+func cumulative(dist []float64) []float64 {
+	cum := make([]float64, len(dist))
+	var sum float64
+	for k, p := range dist {
+		sum += p
+		cum[k] = sum
+	}
+	return cum
+}
+
+// percentileRank 连续化后的百分位 P(x) = F(x*-1) + (x - x* + 0.5)·f(x*)，x* 为最近的整数分
+func percentileRank(cum, dist []float64, x float64) float64 {
+	last := float64(len(dist) - 1)
+	if x < -0.5 {
+		return 0
+	}
+	if x >= last+0.5 {
+		return 1
+	}
+	k := int(math.Floor(x + 0.5))
+	below := 0.0
+	if k > 0 {
+		below = cum[k-1]
+	}
+	return below + (x-float64(k)+0.5)*dist[k]
+}
+
+// percentileInverse 求试卷 Y 上百分位为 p 的连续化分数
+func percentileInverse(cum, dist []float64, p float64) float64 {
+	for k := range dist {
+		if cum[k] > p && dist[k] > 0 {
+			below := 0.0
+			if k > 0 {
+				below = cum[k-1]
+			}
+			return (p-below)/dist[k] + float64(k) - 0.5
+		}
+	}
+	return float64(len(dist)-1) + 0.5
+}
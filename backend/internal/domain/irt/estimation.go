@@ -0,0 +1,209 @@
+package irt
+
+import (
+	"errors"
+	"math"
+
+	"irt-exam-system/backend/internal/utils"
+)
+
+// EstimationMethod 能力值估计方法
+type EstimationMethod string
+
+const (
+	MethodMLE EstimationMethod = "MLE" // 最大似然估计
+	MethodMAP EstimationMethod = "MAP" // 最大后验估计（正态先验）
+	MethodEAP EstimationMethod = "EAP" // 期望后验估计（数值积分）
+)
+
+var (
+	ErrNoResponses   = errors.New("no responses to estimate ability from")
+	ErrUnknownMethod = errors.New("unknown ability estimation method")
+)
+
+// ItemResponse 单题作答及其题目参数
+type ItemResponse struct {
+	QuestionID     uint
+	Difficulty     float64 // b参数
+	Discrimination float64 // a参数
+	Guessing       float64 // c参数
+	UpperAsymptote float64 // d参数，仅4PL使用，0表示1
+	Correct        bool
+
+	// 多级计分，Model 为 GRM/GPCM 时使用 Category 与 Thresholds
+	Model      ItemModel
+	Thresholds []float64 // 类别阈值 b_1..b_m
+	Category   int       // 得分类别 0..m
+}
+
+// AbilityEstimate 能力值估计结果
+type AbilityEstimate struct {
+	Theta         float64
+	StandardError float64
+	Method        EstimationMethod
+	Iterations    int
+	Converged     bool
+}
+
+// Estimator 基于全部作答记录的能力值估计器
+type Estimator struct {
+	Method           EstimationMethod
+	Model            Model   // 科目项目反应模型，零值为3PL
+	PriorMean        float64 // MAP/EAP 正态先验均值
+	PriorSD          float64 // MAP/EAP 正态先验标准差
+	MaxIterations    int     // MLE/MAP 最大迭代次数
+	Convergence      float64 // MLE/MAP 收敛阈值
+	QuadraturePoints int     // EAP 积分节点数
+	MinTheta         float64
+	MaxTheta         float64
+}
+
+// NewEstimator 创建使用默认配置的估计器
+func NewEstimator(method EstimationMethod) *Estimator {
+	return &Estimator{
+		Method:           method,
+		PriorMean:        0,
+		PriorSD:          1,
+		MaxIterations:    50,
+		Convergence:      0.001,
+		QuadraturePoints: 61,
+		MinTheta:         -3,
+		MaxTheta:         3,
+	}
+}
+
+// Estimate 根据作答模式估计能力值及其标准误
+func (e *Estimator) Estimate(responses []ItemResponse) (*AbilityEstimate, error) {
+	if len(responses) == 0 {
+		return nil, ErrNoResponses
+	}
+
+	switch e.Method {
+	case MethodMLE:
+		return e.newtonRaphson(responses, false), nil
+	case MethodMAP:
+		return e.newtonRaphson(responses, true), nil
+	case MethodEAP:
+		return e.expectedAPosteriori(responses), nil
+	default:
+		return nil, ErrUnknownMethod
+	}
+}
+
+// newtonRaphson 使用Fisher得分法求似然（或后验）众数
+// 全对或全错时MLE不存在有限解，估计值停在取值范围边界
+func (e *Estimator) newtonRaphson(responses []ItemResponse, withPrior bool) *AbilityEstimate {
+	theta := e.PriorMean
+	result := &AbilityEstimate{Method: MethodMLE}
+	if withPrior {
+		result.Method = MethodMAP
+	}
+
+	for i := 0; i < e.MaxIterations; i++ {
+		result.Iterations = i + 1
+
+		score, info := e.Model.scoreAndInformation(theta, responses)
+		if withPrior {
+			variance := e.PriorSD * e.PriorSD
+			score -= (theta - e.PriorMean) / variance
+			info += 1 / variance
+		}
+		if info <= 0 {
+			break
+		}
+
+		// 限制单步步长，避免低信息量时发散
+		step := math.Max(-1, math.Min(1, score/info))
+		next := e.clamp(theta + step)
+		if math.Abs(next-theta) < e.Convergence {
+			theta = next
+			result.Converged = true
+			break
+		}
+		theta = next
+	}
+	if theta == e.MinTheta || theta == e.MaxTheta {
+		result.Converged = false
+	}
+
+	_, info := e.Model.scoreAndInformation(theta, responses)
+	if withPrior {
+		info += 1 / (e.PriorSD * e.PriorSD)
+	}
+	result.Theta = theta
+	result.StandardError = utils.CalculateStandardError(info)
+	return result
+}
+
+// expectedAPosteriori 在等距节点上对后验分布做数值积分
+func (e *Estimator) expectedAPosteriori(responses []ItemResponse) *AbilityEstimate {
+	points := e.QuadraturePoints
+	if points < 2 {
+		points = 2
+	}
+	lower := e.PriorMean - 4*e.PriorSD
+	upper := e.PriorMean + 4*e.PriorSD
+	step := (upper - lower) / float64(points-1)
+
+	nodes := make([]float64, points)
+	logPosterior := make([]float64, points)
+	maxLog := math.Inf(-1)
+	for k := range nodes {
+		nodes[k] = lower + float64(k)*step
+		z := (nodes[k] - e.PriorMean) / e.PriorSD
+		logPosterior[k] = e.Model.LogLikelihood(nodes[k], responses) - 0.5*z*z
+		maxLog = math.Max(maxLog, logPosterior[k])
+	}
+
+	var sum, mean float64
+	weights := make([]float64, points)
+	for k := range nodes {
+		weights[k] = math.Exp(logPosterior[k] - maxLog)
+		sum += weights[k]
+		mean += weights[k] * nodes[k]
+	}
+	mean /= sum
+
+	var variance float64
+	for k := range nodes {
+		variance += weights[k] * (nodes[k] - mean) * (nodes[k] - mean)
+	}
+	variance /= sum
+
+	return &AbilityEstimate{
+		Theta:         e.clamp(mean),
+		StandardError: math.Sqrt(variance),
+		Method:        MethodEAP,
+		Iterations:    1,
+		Converged:     true,
+	}
+}
+
+func (e *Estimator) clamp(theta float64) float64 {
+	return math.Max(e.MinTheta, math.Min(e.MaxTheta, theta))
+}
+
+// LogLikelihood 计算作答模式在给定能力值下的对数似然
+func (m Model) LogLikelihood(theta float64, responses []ItemResponse) float64 {
+	var ll float64
+	for _, r := range responses {
+		ll += m.logProbability(theta, r)
+	}
+	return ll
+}
+
+// scoreAndInformation 计算对数似然的一阶导数与测验信息量
+func (m Model) scoreAndInformation(theta float64, responses []ItemResponse) (float64, float64) {
+	var score, info float64
+	for _, r := range responses {
+		score += m.score(theta, r)
+		info += m.ItemInformation(r.Model, theta, r.params(), r.Thresholds)
+	}
+	return score, info
+}
+
+// boundProbability 避免对数运算时出现 log(0)
+func boundProbability(p float64) float64 {
+	const eps = 1e-10
+	return math.Max(eps, math.Min(1-eps, p))
+}
@@ -0,0 +1,107 @@
+package irt
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// simulatedItems 抽取 a ~ U(0.8, 2)、b ~ N(0, 1)、c ~ U(0, c 上限) 的题目参数
+func simulatedItems(rng *rand.Rand, n int, maxGuessing float64) []ItemParams {
+	items := make([]ItemParams, n)
+	for j := range items {
+		items[j] = ItemParams{
+			Discrimination: 0.8 + 1.2*rng.Float64(),
+			Difficulty:     rng.NormFloat64(),
+			Guessing:       maxGuessing * rng.Float64(),
+		}
+	}
+	return items
+}
+
+// simulatedResponses 按真实能力抽取作答
+func simulatedResponses(rng *rand.Rand, model Model, theta float64, items []ItemParams) []ItemResponse {
+	responses := make([]ItemResponse, len(items))
+	for j, item := range items {
+		responses[j] = ItemResponse{
+			QuestionID:     uint(j + 1),
+			Difficulty:     item.Difficulty,
+			Discrimination: item.Discrimination,
+			Guessing:       item.Guessing,
+			Correct:        rng.Float64() < model.Probability(theta, item),
+		}
+	}
+	return responses
+}
+
+func TestEstimateRaschClosedForm(t *testing.T) {
+	// 难度全为0的 Rasch 测验，答对 r 题时 MLE 为 ln(r/(n-r))，标准误为 1/√(n·p·q)
+	const n, r = 20, 14
+	responses := make([]ItemResponse, n)
+	for j := range responses {
+		responses[j] = ItemResponse{QuestionID: uint(j + 1), Discrimination: 1, Correct: j < r}
+	}
+
+	estimator := NewEstimator(MethodMLE)
+	estimator.Model = Model{Family: ModelRasch, D: ScalingLogistic}
+	estimate, err := estimator.Estimate(responses)
+	require.NoError(t, err)
+
+	p := float64(r) / n
+	assert.True(t, estimate.Converged)
+	assert.InDelta(t, math.Log(p/(1-p)), estimate.Theta, 1e-3)
+	assert.InDelta(t, 1/math.Sqrt(n*p*(1-p)), estimate.StandardError, 1e-3)
+}
+
+func TestEstimateRecoversTheta(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	model := DefaultModel()
+	items := simulatedItems(rng, 40, 0.2)
+
+	thetas := make([]float64, 500)
+	patterns := make([][]ItemResponse, len(thetas))
+	for i := range thetas {
+		thetas[i] = rng.NormFloat64()
+		patterns[i] = simulatedResponses(rng, model, thetas[i], items)
+	}
+
+	for _, method := range []EstimationMethod{MethodMLE, MethodMAP, MethodEAP} {
+		t.Run(string(method), func(t *testing.T) {
+			estimator := NewEstimator(method)
+			var bias, squared, meanSE float64
+			covered := 0
+			for i, theta := range thetas {
+				estimate, err := estimator.Estimate(patterns[i])
+				require.NoError(t, err)
+				diff := estimate.Theta - theta
+				bias += diff
+				squared += diff * diff
+				meanSE += estimate.StandardError
+				if math.Abs(diff) <= 1.96*estimate.StandardError {
+					covered++
+				}
+			}
+			n := float64(len(thetas))
+			bias /= n
+			rmse := math.Sqrt(squared / n)
+			meanSE /= n
+
+			assert.Less(t, math.Abs(bias), 0.1)
+			assert.Less(t, rmse, 0.35)
+			// 标准误与实际误差大体一致，95% 区间覆盖率不低于90%
+			assert.InDelta(t, rmse, meanSE, 0.1)
+			assert.GreaterOrEqual(t, float64(covered)/n, 0.9)
+		})
+	}
+}
+
+func TestEstimateNoResponses(t *testing.T) {
+	_, err := NewEstimator(MethodEAP).Estimate(nil)
+	assert.ErrorIs(t, err, ErrNoResponses)
+
+	_, err = NewEstimator("WLE").Estimate([]ItemResponse{{Discrimination: 1, Correct: true}})
+	assert.ErrorIs(t, err, ErrUnknownMethod)
+}
@@ -0,0 +1,258 @@
+package irt
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// ExposureMethod 题目曝光控制方法
+type ExposureMethod string
+
+const (
+	ExposureNone          ExposureMethod = "none"           // 不做曝光控制
+	ExposureRandomesque   ExposureMethod = "randomesque"    // 在最优的前k道题中随机抽取
+	ExposureSympsonHetter ExposureMethod = "sympson_hetter" // 按曝光参数概率性施测
+)
+
+var ErrUnknownExposureMethod = errors.New("unknown exposure control method")
+
+// ExposureControl 在选题准则排序结果上施加曝光控制
+type ExposureControl struct {
+	Method          ExposureMethod
+	RandomesqueSize int              // randomesque 候选题数量
+	Parameters      map[uint]float64 // Sympson–Hetter 曝光参数 K，缺省为1
+	Rand            *rand.Rand
+}
+
+// NewExposureControl 创建曝光控制器，rng为空时使用全局随机源
+func NewExposureControl(method ExposureMethod, randomesqueSize int, parameters map[uint]float64, rng *rand.Rand) (*ExposureControl, error) {
+	switch method {
+	case ExposureNone, ExposureRandomesque, ExposureSympsonHetter, "":
+	default:
+		return nil, ErrUnknownExposureMethod
+	}
+	if randomesqueSize < 1 {
+		randomesqueSize = 1
+	}
+	return &ExposureControl{
+		Method:          method,
+		RandomesqueSize: randomesqueSize,
+		Parameters:      parameters,
+		Rand:            rng,
+	}, nil
+}
+
+// Choose 从排序后的候选题中选出实际施测的题目下标，
+// considered 为 Sympson–Hetter 过程中被选题准则选中（无论是否施测）的题目下标
+func (e *ExposureControl) Choose(ranked []RankedCandidate, candidates []Candidate) (chosen int, considered []int, err error) {
+	if len(ranked) == 0 {
+		return -1, nil, ErrNoCandidates
+	}
+
+	switch e.Method {
+	case ExposureRandomesque:
+		k := e.RandomesqueSize
+		if k > len(ranked) {
+			k = len(ranked)
+		}
+		pick := ranked[e.intn(k)].Index
+		return pick, []int{pick}, nil
+
+	case ExposureSympsonHetter:
+		for _, r := range ranked {
+			considered = append(considered, r.Index)
+			k, ok := e.Parameters[candidates[r.Index].QuestionID]
+			if !ok || e.float64() < k {
+				return r.Index, considered, nil
+			}
+		}
+		// 全部被拒绝时退回到准则最优的题目
+		return ranked[0].Index, considered, nil
+
+	default:
+		return ranked[0].Index, []int{ranked[0].Index}, nil
+	}
+}
+
+func (e *ExposureControl) intn(n int) int {
+	if e.Rand != nil {
+		return e.Rand.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+func (e *ExposureControl) float64() float64 {
+	if e.Rand != nil {
+		return e.Rand.Float64()
+	}
+	return rand.Float64()
+}
+
+// SympsonHetterConfig 曝光参数模拟配置
+type SympsonHetterConfig struct {
+	MaxExposureRate float64 // 目标最大曝光率 r
+	Examinees       int     // 每轮模拟考生数
+	TestLength      int     // 模拟测验长度
+	Iterations      int     // 最大模拟轮数
+	Tolerance       float64 // 最大曝光率允许超出 r 的幅度
+	Strategy        SelectionStrategy
+	Seed            int64
+	Model           Model // 科目项目反应模型
+}
+
+// DefaultSympsonHetterConfig 返回常用的模拟配置
+func DefaultSympsonHetterConfig() SympsonHetterConfig {
+	return SympsonHetterConfig{
+		MaxExposureRate: 0.25,
+		Examinees:       1000,
+		TestLength:      20,
+		Iterations:      10,
+		Tolerance:       0.02,
+		Strategy:        StrategyMaxInfo,
+		Seed:            1,
+	}
+}
+
+// SympsonHetterResult 曝光参数模拟结果
+type SympsonHetterResult struct {
+	Parameters    map[uint]float64 // 各题曝光参数 K
+	ExposureRates map[uint]float64 // 最后一轮模拟的曝光率
+	MaxExposure   float64
+	Iterations    int
+	Converged     bool
+}
+
+// ComputeSympsonHetter 通过反复模拟自适应测验迭代求解曝光参数：
+// 若题目被选中的概率 P(S) 超过 r，则 K = r / P(S)，否则 K = 1
+func ComputeSympsonHetter(bank []Candidate, cfg SympsonHetterConfig) (*SympsonHetterResult, error) {
+	if len(bank) == 0 {
+		return nil, ErrNoCandidates
+	}
+	selector, err := NewItemSelector(cfg.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	parameters := make(map[uint]float64, len(bank))
+	for _, c := range bank {
+		parameters[c.QuestionID] = 1
+	}
+	exposure := &ExposureControl{Method: ExposureSympsonHetter, Parameters: parameters, Rand: rng}
+	estimator := NewEstimator(MethodEAP)
+	estimator.Model = cfg.Model
+
+	result := &SympsonHetterResult{Parameters: parameters}
+	for iter := 0; iter < cfg.Iterations; iter++ {
+		result.Iterations = iter + 1
+
+		selected := make(map[uint]int, len(bank))
+		administered := make(map[uint]int, len(bank))
+		for j := 0; j < cfg.Examinees; j++ {
+			theta := rng.NormFloat64()
+			session := simulateSession(theta, bank, selector, exposure, estimator, cfg.TestLength, rng)
+			for _, idx := range session.considered {
+				selected[bank[idx].QuestionID]++
+			}
+			for _, idx := range session.administered {
+				administered[bank[idx].QuestionID]++
+			}
+		}
+
+		result.ExposureRates = make(map[uint]float64, len(bank))
+		result.MaxExposure = 0
+		for _, c := range bank {
+			rate := float64(administered[c.QuestionID]) / float64(cfg.Examinees)
+			result.ExposureRates[c.QuestionID] = rate
+			if rate > result.MaxExposure {
+				result.MaxExposure = rate
+			}
+
+			pSelected := float64(selected[c.QuestionID]) / float64(cfg.Examinees)
+			if pSelected > cfg.MaxExposureRate {
+				parameters[c.QuestionID] = cfg.MaxExposureRate / pSelected
+			} else {
+				parameters[c.QuestionID] = 1
+			}
+		}
+
+		if result.MaxExposure <= cfg.MaxExposureRate+cfg.Tolerance {
+			result.Converged = true
+			break
+		}
+	}
+	return result, nil
+}
+
+// simulatedSession 单个模拟考生的施测记录
+type simulatedSession struct {
+	administered []int
+	considered   []int
+	estimate     *AbilityEstimate
+}
+
+// simulateSession 对给定真实能力值的模拟考生运行一次定长自适应测验
+func simulateSession(theta float64, bank []Candidate, selector ItemSelector, exposure *ExposureControl, estimator *Estimator, testLength int, rng *rand.Rand) simulatedSession {
+	var session simulatedSession
+	used := make([]bool, len(bank))
+	var responses []ItemResponse
+	state := SelectionState{Theta: estimator.PriorMean, TestLength: testLength, Model: estimator.Model}
+
+	for len(session.administered) < testLength {
+		available := make([]Candidate, 0, len(bank))
+		indexes := make([]int, 0, len(bank))
+		for i, c := range bank {
+			if !used[i] {
+				available = append(available, c)
+				indexes = append(indexes, i)
+			}
+		}
+		ranked := RankCandidates(selector, available, state)
+		chosen, considered, err := exposure.Choose(ranked, available)
+		if err != nil {
+			break
+		}
+		// 被准则选中但未施测的题目本场不再考虑
+		for _, idx := range considered {
+			session.considered = append(session.considered, indexes[idx])
+			used[indexes[idx]] = true
+		}
+
+		item := available[chosen]
+		used[indexes[chosen]] = true
+		session.administered = append(session.administered, indexes[chosen])
+		responses = append(responses, simulateResponse(estimator.Model, theta, item, rng))
+
+		estimate, err := estimator.Estimate(responses)
+		if err != nil {
+			break
+		}
+		session.estimate = estimate
+		state.Theta = estimate.Theta
+		state.StandardError = estimate.StandardError
+		state.ItemsAdministered = len(responses)
+	}
+	return session
+}
+
+// simulateResponse 按真实能力值下各得分类别的概率抽取模拟作答
+func simulateResponse(model Model, theta float64, item Candidate, rng *rand.Rand) ItemResponse {
+	response := ItemResponse{
+		QuestionID:     item.QuestionID,
+		Difficulty:     item.Difficulty,
+		Discrimination: item.Discrimination,
+		Guessing:       item.Guessing,
+		UpperAsymptote: item.UpperAsymptote,
+		Model:          item.Model,
+		Thresholds:     item.Thresholds,
+	}
+	probs := model.candidateProbabilities(theta, item)
+	maxCategory := len(probs) - 1
+	u := rng.Float64()
+	for response.Category < maxCategory && u >= probs[response.Category] {
+		u -= probs[response.Category]
+		response.Category++
+	}
+	response.Correct = response.Category == maxCategory
+	return response
+}
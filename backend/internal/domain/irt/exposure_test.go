@@ -0,0 +1,124 @@
+package irt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rankedBank 按题号顺序排好的候选题，准则分数递减
+func rankedBank(n int) ([]RankedCandidate, []Candidate) {
+	ranked := make([]RankedCandidate, n)
+	candidates := make([]Candidate, n)
+	for i := range ranked {
+		ranked[i] = RankedCandidate{Index: i, Score: float64(n - i)}
+		candidates[i] = Candidate{QuestionID: uint(i + 1)}
+	}
+	return ranked, candidates
+}
+
+func TestRandomesqueChoosesAmongTopItems(t *testing.T) {
+	ranked, candidates := rankedBank(10)
+	exposure, err := NewExposureControl(ExposureRandomesque, 3, nil, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+
+	counts := make(map[int]int)
+	for i := 0; i < 600; i++ {
+		chosen, considered, err := exposure.Choose(ranked, candidates)
+		require.NoError(t, err)
+		assert.Equal(t, []int{chosen}, considered)
+		counts[chosen]++
+	}
+	// 只在前3题中抽取，且大致均匀
+	assert.Len(t, counts, 3)
+	for idx, count := range counts {
+		assert.Less(t, idx, 3)
+		assert.InDelta(t, 200, count, 50)
+	}
+
+	// 候选题少于 n 时在全部候选题中抽取
+	chosen, _, err := exposure.Choose(ranked[:1], candidates)
+	require.NoError(t, err)
+	assert.Equal(t, 0, chosen)
+}
+
+func TestSympsonHetterChoose(t *testing.T) {
+	ranked, candidates := rankedBank(4)
+
+	// K=0 的题目总被拒绝，顺延到下一道；未设置曝光参数的题目 K 视为1
+	exposure, err := NewExposureControl(ExposureSympsonHetter, 0, map[uint]float64{1: 0, 2: 0}, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	chosen, considered, err := exposure.Choose(ranked, candidates)
+	require.NoError(t, err)
+	assert.Equal(t, 2, chosen)
+	assert.Equal(t, []int{0, 1, 2}, considered)
+
+	// 全部被拒绝时退回准则最优的题目
+	exposure.Parameters = map[uint]float64{1: 0, 2: 0, 3: 0, 4: 0}
+	chosen, considered, err = exposure.Choose(ranked, candidates)
+	require.NoError(t, err)
+	assert.Equal(t, 0, chosen)
+	assert.Equal(t, []int{0, 1, 2, 3}, considered)
+
+	// K=0.3 时约30%的情况下施测
+	exposure.Parameters = map[uint]float64{1: 0.3}
+	administered := 0
+	for i := 0; i < 2000; i++ {
+		chosen, _, err := exposure.Choose(ranked, candidates)
+		require.NoError(t, err)
+		if chosen == 0 {
+			administered++
+		}
+	}
+	assert.InDelta(t, 0.3, float64(administered)/2000, 0.03)
+}
+
+func TestSympsonHetterParameterUpdate(t *testing.T) {
+	bank := itemBank(60, 3)
+	cfg := DefaultSympsonHetterConfig()
+	cfg.Examinees = 300
+	cfg.TestLength = 10
+	cfg.MaxExposureRate = 0.3
+
+	// 第一轮所有 K=1，被选中即施测：P(S) 等于曝光率，超过 r 的题目 K = r/P(S)
+	cfg.Iterations = 1
+	first, err := ComputeSympsonHetter(bank, cfg)
+	require.NoError(t, err)
+	overexposed := 0
+	for id, rate := range first.ExposureRates {
+		if rate > cfg.MaxExposureRate {
+			overexposed++
+			assert.InDelta(t, cfg.MaxExposureRate/rate, first.Parameters[id], 1e-12)
+		} else {
+			assert.Equal(t, 1.0, first.Parameters[id])
+		}
+	}
+	require.Greater(t, overexposed, 0)
+	assert.Greater(t, first.MaxExposure, cfg.MaxExposureRate)
+
+	// 迭代后最大曝光率降到目标附近，模拟考生数有限时在目标上下波动
+	cfg.Iterations = 10
+	result, err := ComputeSympsonHetter(bank, cfg)
+	require.NoError(t, err)
+	assert.Less(t, result.MaxExposure, cfg.MaxExposureRate+0.1)
+	for _, k := range result.Parameters {
+		assert.Greater(t, k, 0.0)
+		assert.LessOrEqual(t, k, 1.0)
+	}
+}
+
+func TestExposureControlErrors(t *testing.T) {
+	_, err := NewExposureControl("shadow_test", 5, nil, nil)
+	assert.ErrorIs(t, err, ErrUnknownExposureMethod)
+
+	exposure, err := NewExposureControl(ExposureNone, 0, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, exposure.RandomesqueSize)
+	_, _, err = exposure.Choose(nil, nil)
+	assert.ErrorIs(t, err, ErrNoCandidates)
+
+	_, err = ComputeSympsonHetter(nil, DefaultSympsonHetterConfig())
+	assert.ErrorIs(t, err, ErrNoCandidates)
+}
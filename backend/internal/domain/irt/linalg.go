@@ -0,0 +1,148 @@
+package irt
+
+import "math"
+
+// 标定、多维估计、选题与成长追踪共用的小型稠密矩阵运算，矩阵以行切片表示
+
+// newMatrix 创建 dim×dim 的零矩阵
+func newMatrix(dim int) [][]float64 {
+	m := make([][]float64, dim)
+	for k := range m {
+		m[k] = make([]float64, dim)
+	}
+	return m
+}
+
+// addMatrix 将 b 累加到 a 上
+func addMatrix(a, b [][]float64) {
+	for k := range a {
+		for l := range a[k] {
+			a[k][l] += b[k][l]
+		}
+	}
+}
+
+// MultiplyMatrix 计算矩阵乘积 a·b
+func MultiplyMatrix(a, b [][]float64) [][]float64 {
+	product := make([][]float64, len(a))
+	for i := range a {
+		product[i] = make([]float64, len(b[0]))
+		for j := range product[i] {
+			for k := range b {
+				product[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return product
+}
+
+// TransposeMatrix 计算转置矩阵
+func TransposeMatrix(m [][]float64) [][]float64 {
+	t := make([][]float64, len(m[0]))
+	for j := range t {
+		t[j] = make([]float64, len(m))
+		for i := range m {
+			t[j][i] = m[i][j]
+		}
+	}
+	return t
+}
+
+// SolveLinear 高斯消元求解线性方程组 matrix·x = rhs（部分主元），矩阵奇异时返回 false
+func SolveLinear(matrix [][]float64, rhs []float64) ([]float64, bool) {
+	dim := len(rhs)
+	a := make([][]float64, dim)
+	for i := range matrix {
+		a[i] = append(append([]float64(nil), matrix[i]...), rhs[i])
+	}
+	for col := 0; col < dim; col++ {
+		pivot := col
+		for row := col + 1; row < dim; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := col + 1; row < dim; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k <= dim; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+		}
+	}
+	x := make([]float64, dim)
+	for row := dim - 1; row >= 0; row-- {
+		sum := a[row][dim]
+		for k := row + 1; k < dim; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, true
+}
+
+// InvertMatrix 逐列解线性方程组求逆矩阵，矩阵奇异时返回 false
+func InvertMatrix(m [][]float64) ([][]float64, bool) {
+	dim := len(m)
+	inv := newMatrix(dim)
+	for col := 0; col < dim; col++ {
+		unit := make([]float64, dim)
+		unit[col] = 1
+		x, ok := SolveLinear(m, unit)
+		if !ok {
+			return nil, false
+		}
+		for row := range x {
+			inv[row][col] = x[row]
+		}
+	}
+	return inv, true
+}
+
+// invertNegative 计算 (-H)^-1，结果对角元非正（H 在该点不是负定）时返回 false
+func invertNegative(hess [][]float64) ([][]float64, bool) {
+	neg := newMatrix(len(hess))
+	for i := range hess {
+		for j := range hess[i] {
+			neg[i][j] = -hess[i][j]
+		}
+	}
+	inv, ok := InvertMatrix(neg)
+	if !ok {
+		return nil, false
+	}
+	for i := range inv {
+		if inv[i][i] <= 0 {
+			return nil, false
+		}
+	}
+	return inv, true
+}
+
+// logDeterminant 用 Cholesky 分解求正定矩阵行列式的对数，非正定时返回 false
+func logDeterminant(m [][]float64) (float64, bool) {
+	dim := len(m)
+	l := newMatrix(dim)
+	var logDet float64
+	for i := 0; i < dim; i++ {
+		for j := 0; j <= i; j++ {
+			sum := m[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return 0, false
+				}
+				l[i][i] = math.Sqrt(sum)
+				logDet += 2 * math.Log(l[i][i])
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return logDet, true
+}
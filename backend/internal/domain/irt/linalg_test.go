@@ -0,0 +1,49 @@
+package irt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveLinear(t *testing.T) {
+	// 首列主元为0，需要换行
+	matrix := [][]float64{{0, 2, 1}, {1, 1, 1}, {2, 1, 3}}
+	x, ok := SolveLinear(matrix, []float64{7, 6, 13})
+	require.True(t, ok)
+	assert.InDeltaSlice(t, []float64{1, 2, 3}, x, 1e-12)
+
+	_, ok = SolveLinear([][]float64{{1, 2}, {2, 4}}, []float64{1, 2})
+	assert.False(t, ok)
+}
+
+func TestInvertMatrix(t *testing.T) {
+	m := [][]float64{{4, 7}, {2, 6}}
+	inv, ok := InvertMatrix(m)
+	require.True(t, ok)
+	assert.InDeltaSlice(t, []float64{0.6, -0.7}, inv[0], 1e-12)
+	assert.InDeltaSlice(t, []float64{-0.2, 0.4}, inv[1], 1e-12)
+
+	product := MultiplyMatrix(m, inv)
+	assert.InDeltaSlice(t, []float64{1, 0}, product[0], 1e-12)
+	assert.InDeltaSlice(t, []float64{0, 1}, product[1], 1e-12)
+	assert.Equal(t, [][]float64{{4, 2}, {7, 6}}, TransposeMatrix(m))
+
+	// 负定 Hessian 的 (-H)^-1 为正定协方差，正定 Hessian 不是极大值点
+	cov, ok := invertNegative([][]float64{{-4, 0}, {0, -0.25}})
+	require.True(t, ok)
+	assert.InDeltaSlice(t, []float64{0.25, 4}, []float64{cov[0][0], cov[1][1]}, 1e-12)
+	_, ok = invertNegative([][]float64{{4, 0}, {0, 1}})
+	assert.False(t, ok)
+}
+
+func TestLogDeterminant(t *testing.T) {
+	logDet, ok := logDeterminant([][]float64{{4, 2}, {2, 3}})
+	require.True(t, ok)
+	assert.InDelta(t, math.Log(8), logDet, 1e-12)
+
+	_, ok = logDeterminant([][]float64{{1, 2}, {2, 1}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,159 @@
+package irt
+
+import (
+	"errors"
+	"math"
+)
+
+// LinkingMethod 量尺连接方法
+type LinkingMethod string
+
+const (
+	LinkMeanMean     LinkingMethod = "mean_mean"     // 区分度均值之比与难度均值
+	LinkMeanSigma    LinkingMethod = "mean_sigma"    // 难度标准差之比与难度均值
+	LinkHaebara      LinkingMethod = "haebara"       // 最小化各锚题特征曲线差的平方和
+	LinkStockingLord LinkingMethod = "stocking_lord" // 最小化锚题测验特征曲线差的平方
+)
+
+var (
+	ErrUnknownLinkingMethod = errors.New("unknown linking method")
+	ErrTooFewAnchors        = errors.New("at least two anchor items are required for linking")
+	ErrDegenerateAnchors    = errors.New("anchor item parameters do not determine a scale transformation")
+)
+
+// AnchorItem 锚题在新量尺（待转换）与基准量尺上的参数
+type AnchorItem struct {
+	QuestionID uint
+	New        ItemParams
+	Base       ItemParams
+}
+
+// Transformation 新量尺到基准量尺的线性变换 θ* = Aθ + B
+type Transformation struct {
+	A float64
+	B float64
+}
+
+// IdentityTransformation 恒等变换
+func IdentityTransformation() Transformation {
+	return Transformation{A: 1}
+}
+
+// Theta 将能力值转换到基准量尺
+func (t Transformation) Theta(theta float64) float64 {
+	return t.A*theta + t.B
+}
+
+// StandardError 将能力值或难度的标准误转换到基准量尺
+func (t Transformation) StandardError(se float64) float64 {
+	return t.A * se
+}
+
+// Item 将题目参数转换到基准量尺：b* = Ab + B，a* = a/A，c、d 不变
+func (t Transformation) Item(item ItemParams) ItemParams {
+	item.Difficulty = t.A*item.Difficulty + t.B
+	item.Discrimination /= t.A
+	return item
+}
+
+// Link 由锚题参数求量尺转换常数，特征曲线法以 mean/sigma 结果为初值，
+// 在基准量尺的标准正态积分节点上最小化准则函数
+func (m Model) Link(anchors []AnchorItem, method LinkingMethod) (Transformation, error) {
+	if len(anchors) < 2 {
+		return Transformation{}, ErrTooFewAnchors
+	}
+	switch method {
+	case LinkMeanMean:
+		return meanMean(anchors)
+	case LinkMeanSigma:
+		return meanSigma(anchors)
+	case LinkHaebara, LinkStockingLord:
+	default:
+		return Transformation{}, ErrUnknownLinkingMethod
+	}
+
+	start, err := meanSigma(anchors)
+	if err != nil {
+		if start, err = meanMean(anchors); err != nil {
+			start = IdentityTransformation()
+		}
+	}
+	nodes, weights := NormalQuadrature(41)
+	objective := func(x []float64) float64 {
+		t := Transformation{A: math.Exp(x[0]), B: x[1]}
+		return -m.LinkingCriterion(anchors, method, t, nodes, weights)
+	}
+	x := newtonAscent(objective, []float64{math.Log(start.A), start.B}, 1e-6)
+	x = newtonAscent(objective, x, 1e-6)
+	t := Transformation{A: math.Exp(x[0]), B: x[1]}
+	if math.IsNaN(t.A) || math.IsNaN(t.B) || math.IsInf(t.A, 0) {
+		return Transformation{}, ErrDegenerateAnchors
+	}
+	return t, nil
+}
+
+// LinkingCriterion 特征曲线法的准则函数值：
+// Haebara 为 Σ_q w_q Σ_j (P_j - P*_j)²，Stocking–Lord 为 Σ_q w_q (Σ_j P_j - Σ_j P*_j)²
+func (m Model) LinkingCriterion(anchors []AnchorItem, method LinkingMethod, t Transformation, nodes, weights []float64) float64 {
+	var total float64
+	for q, theta := range nodes {
+		var itemSum, baseTCC, newTCC float64
+		for _, anchor := range anchors {
+			base := m.Probability(theta, anchor.Base)
+			transformed := m.Probability(theta, t.Item(anchor.New))
+			itemSum += (base - transformed) * (base - transformed)
+			baseTCC += base
+			newTCC += transformed
+		}
+		if method == LinkStockingLord {
+			total += weights[q] * (baseTCC - newTCC) * (baseTCC - newTCC)
+		} else {
+			total += weights[q] * itemSum
+		}
+	}
+	return total
+}
+
+// meanMean A = ā_new / ā_base，B = b̄_base - A·b̄_new
+func meanMean(anchors []AnchorItem) (Transformation, error) {
+	var aNew, aBase, bNew, bBase float64
+	for _, anchor := range anchors {
+		aNew += anchor.New.Discrimination
+		aBase += anchor.Base.Discrimination
+		bNew += anchor.New.Difficulty
+		bBase += anchor.Base.Difficulty
+	}
+	if aNew <= 0 || aBase <= 0 {
+		return Transformation{}, ErrDegenerateAnchors
+	}
+	n := float64(len(anchors))
+	a := aNew / aBase
+	return Transformation{A: a, B: bBase/n - a*bNew/n}, nil
+}
+
+// meanSigma A = σ(b_base) / σ(b_new)，B = b̄_base - A·b̄_new
+func meanSigma(anchors []AnchorItem) (Transformation, error) {
+	newMean, newSD := difficultyMoments(anchors, func(a AnchorItem) float64 { return a.New.Difficulty })
+	baseMean, baseSD := difficultyMoments(anchors, func(a AnchorItem) float64 { return a.Base.Difficulty })
+	if newSD < 1e-9 || baseSD < 1e-9 {
+		return Transformation{}, ErrDegenerateAnchors
+	}
+	a := baseSD / newSD
+	return Transformation{A: a, B: baseMean - a*newMean}, nil
+}
+
+// difficultyMoments 锚题难度的均值与总体标准差
+func difficultyMoments(anchors []AnchorItem, difficulty func(AnchorItem) float64) (float64, float64) {
+	n := float64(len(anchors))
+	var mean float64
+	for _, anchor := range anchors {
+		mean += difficulty(anchor)
+	}
+	mean /= n
+	var variance float64
+	for _, anchor := range anchors {
+		d := difficulty(anchor) - mean
+		variance += d * d
+	}
+	return mean, math.Sqrt(variance / n)
+}
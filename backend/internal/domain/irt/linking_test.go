@@ -0,0 +1,55 @@
+package irt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkRecoversKnownTransformation(t *testing.T) {
+	// 锚题在基准量尺上的参数由新量尺参数经 θ* = 1.3θ − 0.4 精确变换得到
+	known := Transformation{A: 1.3, B: -0.4}
+	rng := rand.New(rand.NewSource(19))
+	anchors := make([]AnchorItem, 12)
+	for j, item := range simulatedItems(rng, len(anchors), 0.2) {
+		anchors[j] = AnchorItem{QuestionID: uint(j + 1), New: item, Base: known.Item(item)}
+	}
+
+	for _, method := range []LinkingMethod{LinkMeanMean, LinkMeanSigma, LinkHaebara, LinkStockingLord} {
+		t.Run(string(method), func(t *testing.T) {
+			got, err := DefaultModel().Link(anchors, method)
+			require.NoError(t, err)
+			assert.InDelta(t, known.A, got.A, 1e-3)
+			assert.InDelta(t, known.B, got.B, 1e-3)
+		})
+	}
+}
+
+func TestTransformationItem(t *testing.T) {
+	transform := Transformation{A: 2, B: 1}
+	item := transform.Item(ItemParams{Difficulty: 0.5, Discrimination: 1.2, Guessing: 0.2})
+	assert.InDelta(t, 2.0, item.Difficulty, 1e-12)
+	assert.InDelta(t, 0.6, item.Discrimination, 1e-12)
+	assert.Equal(t, 0.2, item.Guessing)
+
+	// 变换前后同一考生的答对概率不变
+	model := DefaultModel()
+	original := ItemParams{Difficulty: 0.5, Discrimination: 1.2, Guessing: 0.2}
+	assert.InDelta(t, model.Probability(0.3, original), model.Probability(transform.Theta(0.3), item), 1e-12)
+	assert.Equal(t, 0.6, transform.StandardError(0.3))
+}
+
+func TestLinkErrors(t *testing.T) {
+	anchor := AnchorItem{New: ItemParams{Discrimination: 1}, Base: ItemParams{Discrimination: 1}}
+	_, err := DefaultModel().Link([]AnchorItem{anchor}, LinkHaebara)
+	assert.ErrorIs(t, err, ErrTooFewAnchors)
+
+	_, err = DefaultModel().Link([]AnchorItem{anchor, anchor}, "fixed")
+	assert.ErrorIs(t, err, ErrUnknownLinkingMethod)
+
+	// 锚题难度全部相同时 mean/sigma 无法确定斜率
+	_, err = DefaultModel().Link([]AnchorItem{anchor, anchor}, LinkMeanSigma)
+	assert.ErrorIs(t, err, ErrDegenerateAnchors)
+}
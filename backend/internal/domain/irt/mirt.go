@@ -0,0 +1,682 @@
+package irt
+
+import (
+	"errors"
+	"math"
+)
+
+// 补偿型多维项目反应模型（M2PL/M3PL）：能力为向量 θ，题目在各维度上有区分度分量，
+// P(θ) = c + (1-c)/(1+exp(-D(a·θ+d)))，某一维度的不足可由其他维度补偿；
+// 4PL 科目按 M3PL 处理，上渐近线不进入多维模型
+
+// MaxDimensions 多维标定支持的最大维数，积分节点数随维数指数增长
+const MaxDimensions = 4
+
+var (
+	ErrNoDimensions        = errors.New("multidimensional model needs at least one dimension")
+	ErrTooManyDimensions   = errors.New("too many dimensions for multidimensional calibration")
+	ErrDimensionMismatch   = errors.New("ability vector and discrimination vector differ in dimension")
+	ErrInvalidLoadings     = errors.New("loading pattern must have one row per item and one column per dimension")
+	ErrInvalidPriorMatrix  = errors.New("prior covariance matrix must be positive definite")
+	ErrSingularInformation = errors.New("test information matrix is singular")
+)
+
+// MultiItem 补偿型多维题目参数
+type MultiItem struct {
+	QuestionID     uint
+	Discrimination []float64 // 区分度向量 a，未加载的维度为0
+	Intercept      float64   // 截距 d
+	Guessing       float64   // 猜测参数 c，M2PL 为0
+}
+
+// MultiDiscrimination 多维区分度 MDISC = |a|
+func (item MultiItem) MultiDiscrimination() float64 {
+	var sum float64
+	for _, a := range item.Discrimination {
+		sum += a * a
+	}
+	return math.Sqrt(sum)
+}
+
+// MultiDifficulty 多维难度 MDIFF = -d/|a|，即沿区分度方向答对概率过半的位置
+func (item MultiItem) MultiDifficulty() float64 {
+	mdisc := item.MultiDiscrimination()
+	if mdisc == 0 {
+		return 0
+	}
+	return -item.Intercept / mdisc
+}
+
+// MultiFamily 多维模型名称：2PL 及以下为 M2PL，含猜测参数的模型为 M3PL
+func (m Model) MultiFamily() string {
+	if m.multiGuessing() {
+		return "M3PL"
+	}
+	return "M2PL"
+}
+
+// multiGuessing 多维模型是否估计猜测参数
+func (m Model) multiGuessing() bool {
+	switch m.resolved().Family {
+	case ModelRasch, Model1PL, Model2PL:
+		return false
+	default:
+		return true
+	}
+}
+
+// MultiProbability 在能力向量 θ 处答对的概率
+func (m Model) MultiProbability(theta []float64, item MultiItem) float64 {
+	c := 0.0
+	if m.multiGuessing() {
+		c = item.Guessing
+	}
+	z := item.Intercept
+	for k, a := range item.Discrimination {
+		z += a * theta[k]
+	}
+	return c + (1-c)/(1+math.Exp(-m.Scaling()*z))
+}
+
+// MultiInformation 题目在 θ 处的 Fisher 信息矩阵 I(θ) = D²aaᵀ·(P-c)²(1-P)/((1-c)²P)
+func (m Model) MultiInformation(theta []float64, item MultiItem) [][]float64 {
+	dim := len(item.Discrimination)
+	info := newMatrix(dim)
+	c := 0.0
+	if m.multiGuessing() {
+		c = item.Guessing
+	}
+	p := m.MultiProbability(theta, item)
+	if p <= 0 || p >= 1 || c >= 1 {
+		return info
+	}
+	d := m.Scaling()
+	weight := d * d * (p - c) * (p - c) * (1 - p) / ((1 - c) * (1 - c) * p)
+	for k := 0; k < dim; k++ {
+		for l := 0; l < dim; l++ {
+			info[k][l] = weight * item.Discrimination[k] * item.Discrimination[l]
+		}
+	}
+	return info
+}
+
+// MultiResponse 单题作答及其多维题目参数
+type MultiResponse struct {
+	Item    MultiItem
+	Correct bool
+}
+
+// MultiAbilityEstimate 多维能力估计：能力向量及其后验协方差矩阵
+type MultiAbilityEstimate struct {
+	Theta          []float64
+	StandardErrors []float64
+	Covariance     [][]float64
+	Iterations     int
+	Converged      bool
+}
+
+// MultiEstimator 多元正态先验下的多维能力 MAP 估计器
+type MultiEstimator struct {
+	Model           Model
+	PriorMean       []float64
+	PriorCovariance [][]float64 // 各维能力的先验协方差，通常取标定得到的维度相关矩阵
+	MaxIterations   int
+	Convergence     float64
+	Bound           float64 // 各维能力估计的取值范围 [-Bound, Bound]
+}
+
+// NewMultiEstimator 创建先验为标准多元正态分布的估计器
+func NewMultiEstimator(model Model, dimensions int) *MultiEstimator {
+	prior := newMatrix(dimensions)
+	for k := range prior {
+		prior[k][k] = 1
+	}
+	return &MultiEstimator{
+		Model:           model,
+		PriorMean:       make([]float64, dimensions),
+		PriorCovariance: prior,
+		MaxIterations:   50,
+		Convergence:     0.001,
+		Bound:           4,
+	}
+}
+
+// Estimate 用 Fisher 得分法求后验众数，协方差取后验信息矩阵之逆；
+// 没有作答时返回先验分布
+func (e *MultiEstimator) Estimate(responses []MultiResponse) (*MultiAbilityEstimate, error) {
+	dim := len(e.PriorMean)
+	if dim == 0 {
+		return nil, ErrNoDimensions
+	}
+	for _, r := range responses {
+		if len(r.Item.Discrimination) != dim {
+			return nil, ErrDimensionMismatch
+		}
+	}
+	precision, ok := InvertMatrix(e.PriorCovariance)
+	if !ok || len(precision) != dim {
+		return nil, ErrInvalidPriorMatrix
+	}
+
+	theta := append([]float64(nil), e.PriorMean...)
+	result := &MultiAbilityEstimate{}
+	scaling := e.Model.Scaling()
+	guessing := e.Model.multiGuessing()
+	for iter := 0; iter < e.MaxIterations; iter++ {
+		result.Iterations = iter + 1
+		grad := make([]float64, dim)
+		for k := range grad {
+			for l := range grad {
+				grad[k] -= precision[k][l] * (theta[l] - e.PriorMean[l])
+			}
+		}
+		for _, r := range responses {
+			c := 0.0
+			if guessing {
+				c = r.Item.Guessing
+			}
+			p := boundProbability(e.Model.MultiProbability(theta, r.Item))
+			u := 0.0
+			if r.Correct {
+				u = 1
+			}
+			// ∂logL/∂θ = D·a·(u-P)(P-c)/((1-c)P)
+			w := scaling * (u - p) * (p - c) / ((1 - c) * p)
+			for k, a := range r.Item.Discrimination {
+				grad[k] += w * a
+			}
+		}
+
+		info := e.information(theta, responses, precision)
+		delta, ok := SolveLinear(info, grad)
+		if !ok {
+			return nil, ErrSingularInformation
+		}
+		var change float64
+		for k := range delta {
+			change = math.Max(change, math.Abs(delta[k]))
+		}
+		// 单步变化过大时等比缩小，避免早期作答较少时越过众数
+		scale := 1.0
+		if change > 1 {
+			scale = 1 / change
+		}
+		for k := range theta {
+			theta[k] = math.Max(-e.Bound, math.Min(e.Bound, theta[k]+scale*delta[k]))
+		}
+		if change < e.Convergence {
+			result.Converged = true
+			break
+		}
+	}
+
+	cov, ok := InvertMatrix(e.information(theta, responses, precision))
+	if !ok {
+		return nil, ErrSingularInformation
+	}
+	result.Theta = theta
+	result.Covariance = cov
+	result.StandardErrors = make([]float64, dim)
+	for k := range cov {
+		result.StandardErrors[k] = math.Sqrt(math.Max(0, cov[k][k]))
+	}
+	return result, nil
+}
+
+// Information 在 θ 处的后验信息矩阵：先验精度加已作答题目的信息矩阵之和
+func (e *MultiEstimator) Information(theta []float64, items []MultiItem) ([][]float64, error) {
+	precision, ok := InvertMatrix(e.PriorCovariance)
+	if !ok || len(precision) != len(theta) {
+		return nil, ErrInvalidPriorMatrix
+	}
+	responses := make([]MultiResponse, len(items))
+	for i, item := range items {
+		if len(item.Discrimination) != len(theta) {
+			return nil, ErrDimensionMismatch
+		}
+		responses[i] = MultiResponse{Item: item}
+	}
+	return e.information(theta, responses, precision), nil
+}
+
+func (e *MultiEstimator) information(theta []float64, responses []MultiResponse, precision [][]float64) [][]float64 {
+	info := newMatrix(len(theta))
+	addMatrix(info, precision)
+	for _, r := range responses {
+		addMatrix(info, e.Model.MultiInformation(theta, r.Item))
+	}
+	return info
+}
+
+// LoadingPattern 验证性载荷结构：题目×维度，true 表示题目在该维度上有区分度
+type LoadingPattern [][]bool
+
+// MultiItemCalibration 单题多维标定结果
+type MultiItemCalibration struct {
+	QuestionID       uint
+	Discrimination   []float64
+	Intercept        float64
+	Guessing         float64
+	DiscriminationSE []float64
+	InterceptSE      float64
+	GuessingSE       float64
+	ResponseCount    int
+	Skipped          bool // 作答人数不足或没有加载任何维度，未参与标定
+}
+
+// MultiCalibrationResult 多维标定运行结果
+type MultiCalibrationResult struct {
+	Model         string // M2PL、M3PL
+	D             float64
+	Dimensions    int
+	Items         []MultiItemCalibration
+	Correlations  [][]float64 // 各维能力的相关矩阵
+	Examinees     int
+	Iterations    int
+	Converged     bool
+	MaxChange     float64
+	LogLikelihood float64
+}
+
+// MultiCalibrator 基于 Bock–Aitkin EM 算法的补偿型多维模型标定器，在维度乘积网格上积分，
+// 能力的均值固定为0、方差固定为1，维度间相关由后验二阶矩估计
+type MultiCalibrator struct {
+	Model                Model
+	QuadraturePoints     int // 每维积分节点数，0 表示按维数自动选择
+	MaxIterations        int
+	Convergence          float64
+	MinResponses         int
+	EstimateCorrelations bool // false 时各维能力视为独立
+	// 猜测参数的 Beta(α, β) 先验
+	GuessingPriorAlpha float64
+	GuessingPriorBeta  float64
+}
+
+// NewMultiCalibrator 创建使用默认配置的多维标定器
+func NewMultiCalibrator(model Model) *MultiCalibrator {
+	return &MultiCalibrator{
+		Model:                model.resolved(),
+		MaxIterations:        100,
+		Convergence:          0.001,
+		MinResponses:         20,
+		EstimateCorrelations: true,
+		GuessingPriorAlpha:   5,
+		GuessingPriorBeta:    17,
+	}
+}
+
+// multiItemState 多维标定过程中的单题参数
+type multiItemState struct {
+	a      []float64
+	d, c   float64
+	loaded []bool
+	skip   bool
+}
+
+// item 转换为响应函数使用的题目参数
+func (s multiItemState) item() MultiItem {
+	return MultiItem{Discrimination: s.a, Intercept: s.d, Guessing: s.c}
+}
+
+// Calibrate 按载荷结构对作答矩阵中的全部题目进行多维标定
+func (cal *MultiCalibrator) Calibrate(matrix *ResponseMatrix, pattern LoadingPattern) (*MultiCalibrationResult, error) {
+	if matrix == nil || len(matrix.QuestionIDs) == 0 || len(matrix.Responses) == 0 {
+		return nil, ErrEmptyMatrix
+	}
+	if err := cal.Model.Validate(); err != nil {
+		return nil, err
+	}
+	if len(pattern) != len(matrix.QuestionIDs) {
+		return nil, ErrInvalidLoadings
+	}
+	dim := len(pattern[0])
+	if dim == 0 {
+		return nil, ErrNoDimensions
+	}
+	if dim > MaxDimensions {
+		return nil, ErrTooManyDimensions
+	}
+	for _, row := range pattern {
+		if len(row) != dim {
+			return nil, ErrInvalidLoadings
+		}
+	}
+
+	grid := productGrid(dim, cal.quadraturePoints(dim))
+	correlations := newMatrix(dim)
+	for k := range correlations {
+		correlations[k][k] = 1
+	}
+
+	items := make([]multiItemState, len(matrix.QuestionIDs))
+	counts := make([]int, len(items))
+	for i := range items {
+		items[i] = multiItemState{a: make([]float64, dim), loaded: pattern[i]}
+		var correct, loaded int
+		for _, row := range matrix.Responses {
+			if row[i] == Missing {
+				continue
+			}
+			counts[i]++
+			correct += row[i]
+		}
+		for k, l := range pattern[i] {
+			if l {
+				items[i].a[k] = 1
+				loaded++
+			}
+		}
+		if counts[i] < cal.MinResponses || loaded == 0 {
+			items[i].skip = true
+			continue
+		}
+		if cal.Model.multiGuessing() {
+			items[i].c = 0.2
+		}
+		// 以通过率的 logit 作为截距初值
+		p := (float64(correct) + 0.5) / (float64(counts[i]) + 1)
+		items[i].d = math.Log(p/(1-p)) / cal.Model.D
+	}
+
+	result := &MultiCalibrationResult{
+		Model:      cal.Model.MultiFamily(),
+		D:          cal.Model.D,
+		Dimensions: dim,
+		Examinees:  len(matrix.Responses),
+	}
+	n := make([][]float64, len(items))
+	r := make([][]float64, len(items))
+	for i := range n {
+		n[i] = make([]float64, len(grid))
+		r[i] = make([]float64, len(grid))
+	}
+
+	for iter := 0; iter < cal.MaxIterations; iter++ {
+		result.Iterations = iter + 1
+		var moments [][]float64
+		result.LogLikelihood, moments = cal.expectation(matrix, items, grid, gridWeights(grid, correlations), n, r)
+
+		maxChange := 0.0
+		for i := range items {
+			if items[i].skip {
+				continue
+			}
+			updated := cal.maximize(items[i], grid, n[i], r[i])
+			maxChange = math.Max(maxChange, multiItemChange(updated, items[i]))
+			items[i] = updated
+		}
+		if cal.EstimateCorrelations && dim > 1 {
+			// 由后验二阶矩更新协方差并标准化为相关矩阵，保持各维方差为1
+			for k := 0; k < dim; k++ {
+				for l := k + 1; l < dim; l++ {
+					rho := moments[k][l] / math.Sqrt(moments[k][k]*moments[l][l])
+					rho = math.Max(-0.95, math.Min(0.95, rho))
+					maxChange = math.Max(maxChange, math.Abs(rho-correlations[k][l]))
+					correlations[k][l], correlations[l][k] = rho, rho
+				}
+			}
+		}
+		result.MaxChange = maxChange
+		if maxChange < cal.Convergence {
+			result.Converged = true
+			break
+		}
+	}
+
+	// 收敛后重新计算期望计数，用于标准误
+	result.LogLikelihood, _ = cal.expectation(matrix, items, grid, gridWeights(grid, correlations), n, r)
+	result.Correlations = correlations
+	result.Items = make([]MultiItemCalibration, len(items))
+	for i, item := range items {
+		calibration := MultiItemCalibration{
+			QuestionID:       matrix.QuestionIDs[i],
+			Discrimination:   item.a,
+			Intercept:        item.d,
+			Guessing:         item.c,
+			DiscriminationSE: make([]float64, dim),
+			ResponseCount:    counts[i],
+			Skipped:          item.skip,
+		}
+		if item.skip {
+			calibration.Discrimination = make([]float64, dim)
+		} else {
+			cal.standardErrors(item, grid, n[i], r[i], &calibration)
+		}
+		result.Items[i] = calibration
+	}
+	return result, nil
+}
+
+// quadraturePoints 每维积分节点数：维数越高节点越稀，网格规模控制在数千个节点以内
+func (cal *MultiCalibrator) quadraturePoints(dim int) int {
+	if cal.QuadraturePoints > 0 {
+		return cal.QuadraturePoints
+	}
+	switch dim {
+	case 1:
+		return 21
+	case 2:
+		return 15
+	case 3:
+		return 9
+	default:
+		return 7
+	}
+}
+
+// expectation E步：计算每个网格节点上的期望作答人数与答对人数，
+// 返回边际对数似然与能力后验的平均二阶矩 E[θθᵀ]
+func (cal *MultiCalibrator) expectation(matrix *ResponseMatrix, items []multiItemState, grid [][]float64, weights []float64, n, r [][]float64) (float64, [][]float64) {
+	for i := range n {
+		for q := range n[i] {
+			n[i][q] = 0
+			r[i][q] = 0
+		}
+	}
+	probs := make([][]float64, len(items))
+	for i, item := range items {
+		if item.skip {
+			continue
+		}
+		probs[i] = make([]float64, len(grid))
+		for q, x := range grid {
+			probs[i][q] = boundProbability(cal.Model.MultiProbability(x, item.item()))
+		}
+	}
+
+	dim := len(grid[0])
+	moments := newMatrix(dim)
+	var logLikelihood float64
+	posterior := make([]float64, len(grid))
+	for _, row := range matrix.Responses {
+		maxLog := math.Inf(-1)
+		for q := range grid {
+			ll := math.Log(weights[q])
+			for i, u := range row {
+				if u == Missing || items[i].skip {
+					continue
+				}
+				if u == 1 {
+					ll += math.Log(probs[i][q])
+				} else {
+					ll += math.Log(1 - probs[i][q])
+				}
+			}
+			posterior[q] = ll
+			maxLog = math.Max(maxLog, ll)
+		}
+
+		var total float64
+		for q := range posterior {
+			posterior[q] = math.Exp(posterior[q] - maxLog)
+			total += posterior[q]
+		}
+		logLikelihood += maxLog + math.Log(total)
+
+		for q, x := range grid {
+			w := posterior[q] / total
+			posterior[q] = w
+			for k := 0; k < dim; k++ {
+				for l := k; l < dim; l++ {
+					moments[k][l] += w * x[k] * x[l]
+				}
+			}
+		}
+		for i, u := range row {
+			if u == Missing || items[i].skip {
+				continue
+			}
+			for q, w := range posterior {
+				n[i][q] += w
+				if u == 1 {
+					r[i][q] += w
+				}
+			}
+		}
+	}
+	for k := 0; k < dim; k++ {
+		for l := k; l < dim; l++ {
+			moments[k][l] /= float64(len(matrix.Responses))
+			moments[l][k] = moments[k][l]
+		}
+	}
+	return logLikelihood, moments
+}
+
+// maximize M步：对单题的期望完全数据对数似然做牛顿迭代
+func (cal *MultiCalibrator) maximize(item multiItemState, grid [][]float64, n, r []float64) multiItemState {
+	objective := func(x []float64) float64 {
+		return cal.itemObjective(cal.unpack(x, item), grid, n, r)
+	}
+	return cal.unpack(newtonAscent(objective, cal.pack(item), cal.Convergence/10), item)
+}
+
+// itemObjective 单题期望完全数据对数似然（含猜测参数先验）
+func (cal *MultiCalibrator) itemObjective(item multiItemState, grid [][]float64, n, r []float64) float64 {
+	var ll float64
+	for q, x := range grid {
+		p := boundProbability(cal.Model.MultiProbability(x, item.item()))
+		ll += r[q]*math.Log(p) + (n[q]-r[q])*math.Log(1-p)
+	}
+	if cal.Model.multiGuessing() {
+		c := boundProbability(item.c)
+		ll += (cal.GuessingPriorAlpha-1)*math.Log(c) + (cal.GuessingPriorBeta-1)*math.Log(1-c)
+	}
+	return ll
+}
+
+// pack 将题目参数转换为无约束的优化变量：d、各加载维度的 log a、logit c
+func (cal *MultiCalibrator) pack(item multiItemState) []float64 {
+	x := []float64{item.d}
+	for k, loaded := range item.loaded {
+		if loaded {
+			x = append(x, math.Log(item.a[k]))
+		}
+	}
+	if cal.Model.multiGuessing() {
+		c := boundProbability(item.c)
+		x = append(x, math.Log(c/(1-c)))
+	}
+	return x
+}
+
+// unpack 将优化变量还原为题目参数，并限制在合理取值范围内
+func (cal *MultiCalibrator) unpack(x []float64, template multiItemState) multiItemState {
+	item := multiItemState{
+		a:      make([]float64, len(template.a)),
+		d:      math.Max(-8, math.Min(8, x[0])),
+		loaded: template.loaded,
+	}
+	j := 1
+	for k, loaded := range template.loaded {
+		if loaded {
+			item.a[k] = math.Max(0.05, math.Min(4, math.Exp(x[j])))
+			j++
+		}
+	}
+	if cal.Model.multiGuessing() {
+		item.c = math.Min(0.5, 1/(1+math.Exp(-x[j])))
+	}
+	return item
+}
+
+// standardErrors 由目标函数的海森矩阵求截距、区分度与猜测参数的渐近标准误，无法求逆时为0
+func (cal *MultiCalibrator) standardErrors(item multiItemState, grid [][]float64, n, r []float64, calibration *MultiItemCalibration) {
+	objective := func(x []float64) float64 {
+		return cal.itemObjective(cal.unpack(x, item), grid, n, r)
+	}
+	_, hess := numericDerivatives(objective, cal.pack(item))
+	cov, ok := invertNegative(hess)
+	if !ok {
+		return
+	}
+	calibration.InterceptSE = math.Sqrt(cov[0][0])
+	j := 1
+	for k, loaded := range item.loaded {
+		if loaded {
+			// 区分度以对数形式估计，按delta法换回原尺度
+			calibration.DiscriminationSE[k] = math.Sqrt(cov[j][j]) * item.a[k]
+			j++
+		}
+	}
+	if cal.Model.multiGuessing() {
+		calibration.GuessingSE = math.Sqrt(cov[j][j]) * item.c * (1 - item.c)
+	}
+}
+
+// multiItemChange 两轮之间单题参数的最大变化量
+func multiItemChange(updated, previous multiItemState) float64 {
+	change := math.Abs(updated.d - previous.d)
+	change = math.Max(change, math.Abs(updated.c-previous.c))
+	for k := range updated.a {
+		change = math.Max(change, math.Abs(updated.a[k]-previous.a[k]))
+	}
+	return change
+}
+
+// productGrid 各维取 [-4, 4] 上等距节点的乘积网格
+func productGrid(dim, points int) [][]float64 {
+	nodes, _ := NormalQuadrature(points)
+	total := 1
+	for k := 0; k < dim; k++ {
+		total *= len(nodes)
+	}
+	grid := make([][]float64, total)
+	for q := range grid {
+		x := make([]float64, dim)
+		index := q
+		for k := dim - 1; k >= 0; k-- {
+			x[k] = nodes[index%len(nodes)]
+			index /= len(nodes)
+		}
+		grid[q] = x
+	}
+	return grid
+}
+
+// gridWeights 均值为0、协方差为 cov 的多元正态分布在网格节点上的归一化权重
+func gridWeights(grid [][]float64, cov [][]float64) []float64 {
+	precision, ok := InvertMatrix(cov)
+	if !ok {
+		precision = newMatrix(len(cov))
+		for k := range precision {
+			precision[k][k] = 1
+		}
+	}
+	weights := make([]float64, len(grid))
+	var total float64
+	for q, x := range grid {
+		var quad float64
+		for k := range x {
+			for l := range x {
+				quad += x[k] * precision[k][l] * x[l]
+			}
+		}
+		weights[q] = math.Exp(-0.5 * quad)
+		total += weights[q]
+	}
+	for q := range weights {
+		weights[q] /= total
+	}
+	return weights
+}
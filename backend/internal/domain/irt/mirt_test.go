@@ -0,0 +1,142 @@
+package irt
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// correlatedThetas 从相关系数为 rho 的二元标准正态分布抽取能力
+func correlatedThetas(rng *rand.Rand, n int, rho float64) [][]float64 {
+	thetas := make([][]float64, n)
+	for i := range thetas {
+		z1, z2 := rng.NormFloat64(), rng.NormFloat64()
+		thetas[i] = []float64{z1, rho*z1 + math.Sqrt(1-rho*rho)*z2}
+	}
+	return thetas
+}
+
+// twoDimensionalItems 每维 perDimension 道单维题，另加 shared 道同时加载两维的题
+func twoDimensionalItems(rng *rand.Rand, perDimension, shared int) ([]MultiItem, LoadingPattern) {
+	var items []MultiItem
+	var pattern LoadingPattern
+	for j := 0; j < 2*perDimension+shared; j++ {
+		loaded := []bool{j < perDimension || j >= 2*perDimension, j >= perDimension}
+		a := make([]float64, 2)
+		for k := range a {
+			if loaded[k] {
+				a[k] = 0.6 + 0.8*rng.Float64()
+			}
+		}
+		items = append(items, MultiItem{QuestionID: uint(j + 1), Discrimination: a, Intercept: rng.NormFloat64()})
+		pattern = append(pattern, loaded)
+	}
+	return items, pattern
+}
+
+func TestMultiEstimateRecoversTheta(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	model := Model{Family: Model2PL, D: ScalingNormal}
+	items, _ := twoDimensionalItems(rng, 30, 10)
+	thetas := correlatedThetas(rng, 300, 0.5)
+
+	estimator := NewMultiEstimator(model, 2)
+	var squared [2]float64
+	estimates := make([][]float64, 2)
+	for _, theta := range thetas {
+		responses := make([]MultiResponse, len(items))
+		for j, item := range items {
+			responses[j] = MultiResponse{Item: item, Correct: rng.Float64() < model.MultiProbability(theta, item)}
+		}
+		estimate, err := estimator.Estimate(responses)
+		require.NoError(t, err)
+		require.True(t, estimate.Converged)
+		for k := range theta {
+			diff := estimate.Theta[k] - theta[k]
+			squared[k] += diff * diff
+			estimates[k] = append(estimates[k], estimate.Theta[k])
+			assert.Greater(t, estimate.StandardErrors[k], 0.0)
+		}
+	}
+	for k := range squared {
+		truth := make([]float64, len(thetas))
+		for i, theta := range thetas {
+			truth[i] = theta[k]
+		}
+		assert.Less(t, math.Sqrt(squared[k]/float64(len(thetas))), 0.4)
+		assert.Greater(t, pearson(truth, estimates[k]), 0.9)
+	}
+}
+
+func TestMultiEstimateWithoutResponsesReturnsPrior(t *testing.T) {
+	estimator := NewMultiEstimator(DefaultModel(), 2)
+	estimate, err := estimator.Estimate(nil)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{0, 0}, estimate.Theta, 1e-12)
+	assert.InDeltaSlice(t, []float64{1, 1}, estimate.StandardErrors, 1e-12)
+
+	_, err = estimator.Estimate([]MultiResponse{{Item: MultiItem{Discrimination: []float64{1}}}})
+	assert.ErrorIs(t, err, ErrDimensionMismatch)
+}
+
+func TestMultiCalibrateRecoversParameters(t *testing.T) {
+	rng := rand.New(rand.NewSource(29))
+	model := Model{Family: Model2PL, D: ScalingNormal}
+	items, pattern := twoDimensionalItems(rng, 10, 4)
+	thetas := correlatedThetas(rng, 1000, 0.4)
+
+	matrix := &ResponseMatrix{QuestionIDs: make([]uint, len(items)), Responses: make([][]int, len(thetas))}
+	for j, item := range items {
+		matrix.QuestionIDs[j] = item.QuestionID
+	}
+	for i, theta := range thetas {
+		matrix.Responses[i] = make([]int, len(items))
+		for j, item := range items {
+			if rng.Float64() < model.MultiProbability(theta, item) {
+				matrix.Responses[i][j] = 1
+			}
+		}
+	}
+
+	result, err := NewMultiCalibrator(model).Calibrate(matrix, pattern)
+	require.NoError(t, err)
+	assert.True(t, result.Converged)
+	assert.Equal(t, "M2PL", result.Model)
+	assert.InDelta(t, 0.4, result.Correlations[0][1], 0.1)
+
+	var sqA, sqD float64
+	var loadings int
+	for j, item := range result.Items {
+		require.False(t, item.Skipped)
+		sqD += math.Pow(item.Intercept-items[j].Intercept, 2)
+		for k, loaded := range pattern[j] {
+			if !loaded {
+				assert.Zero(t, item.Discrimination[k])
+				continue
+			}
+			sqA += math.Pow(item.Discrimination[k]-items[j].Discrimination[k], 2)
+			loadings++
+		}
+	}
+	assert.Less(t, math.Sqrt(sqA/float64(loadings)), 0.2)
+	assert.Less(t, math.Sqrt(sqD/float64(len(items))), 0.15)
+}
+
+func pearson(x, y []float64) float64 {
+	n := float64(len(x))
+	var mx, my float64
+	for i := range x {
+		mx += x[i] / n
+		my += y[i] / n
+	}
+	var sxy, sxx, syy float64
+	for i := range x {
+		sxy += (x[i] - mx) * (y[i] - my)
+		sxx += (x[i] - mx) * (x[i] - mx)
+		syy += (y[i] - my) * (y[i] - my)
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
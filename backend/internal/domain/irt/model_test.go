@@ -0,0 +1,109 @@
+package irt
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewModelValidates(t *testing.T) {
+	for _, family := range []ModelFamily{ModelRasch, Model1PL, Model2PL, Model3PL, Model4PL} {
+		for _, d := range []float64{ScalingLogistic, ScalingNormal} {
+			model, err := NewModel(family, d)
+			require.NoError(t, err)
+			assert.Equal(t, Model{Family: family, D: d}, model)
+		}
+	}
+
+	_, err := NewModel("5PL", ScalingLogistic)
+	assert.ErrorIs(t, err, ErrUnknownModel)
+	_, err = NewModel(Model2PL, 1.702)
+	assert.ErrorIs(t, err, ErrInvalidScaling)
+
+	// 零值按 D=1.7 的3PL计算
+	assert.Equal(t, ScalingNormal, Model{}.Scaling())
+	item := ItemParams{Difficulty: 0.3, Discrimination: 1.2, Guessing: 0.2}
+	assert.Equal(t, DefaultModel().Probability(0.5, item), Model{}.Probability(0.5, item))
+}
+
+func TestModelFamilyConstrainsParameters(t *testing.T) {
+	item := ItemParams{Difficulty: 0.5, Discrimination: 1.8, Guessing: 0.2, UpperAsymptote: 0.9}
+	tests := []struct {
+		family ModelFamily
+		want   ItemParams
+	}{
+		{ModelRasch, ItemParams{Difficulty: 0.5, Discrimination: 1, UpperAsymptote: 1}},
+		{Model1PL, ItemParams{Difficulty: 0.5, Discrimination: 1.8, UpperAsymptote: 1}},
+		{Model2PL, ItemParams{Difficulty: 0.5, Discrimination: 1.8, UpperAsymptote: 1}},
+		{Model3PL, ItemParams{Difficulty: 0.5, Discrimination: 1.8, Guessing: 0.2, UpperAsymptote: 1}},
+		{Model4PL, ItemParams{Difficulty: 0.5, Discrimination: 1.8, Guessing: 0.2, UpperAsymptote: 0.9}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.family), func(t *testing.T) {
+			model := Model{Family: tt.family, D: ScalingLogistic}
+			assert.Equal(t, tt.want, model.Constrain(item))
+
+			// θ = b 时 logistic 部分为 1/2，P = c + (d-c)/2
+			want := tt.want.Guessing + (tt.want.UpperAsymptote-tt.want.Guessing)/2
+			assert.InDelta(t, want, model.Probability(0.5, item), 1e-12)
+		})
+	}
+
+	// 4PL 下未设置或超出范围的上渐近线视为1
+	model := Model{Family: Model4PL, D: ScalingLogistic}
+	assert.Equal(t, 1.0, model.Constrain(ItemParams{}).UpperAsymptote)
+	assert.Equal(t, 1.0, model.Constrain(ItemParams{UpperAsymptote: 1.3}).UpperAsymptote)
+}
+
+func TestModelScalingAndInformation(t *testing.T) {
+	// Rasch、D=1：θ - b = ln 3 时 P = 3/4，I = PQ = 3/16
+	item := ItemParams{Difficulty: -0.2, Discrimination: 2}
+	rasch := Model{Family: ModelRasch, D: ScalingLogistic}
+	assert.InDelta(t, 0.75, rasch.Probability(math.Log(3)-0.2, item), 1e-12)
+	assert.InDelta(t, 3.0/16, rasch.Information(math.Log(3)-0.2, item), 1e-12)
+
+	// D=1.7 等价于把区分度乘以1.7
+	normal := Model{Family: Model2PL, D: ScalingNormal}
+	logistic := Model{Family: Model2PL, D: ScalingLogistic}
+	scaled := item
+	scaled.Discrimination *= ScalingNormal
+	assert.InDelta(t, logistic.Probability(0.4, scaled), normal.Probability(0.4, item), 1e-12)
+
+	// 各模型族的导数与信息量和中心差分一致
+	item = ItemParams{Difficulty: 0.3, Discrimination: 1.4, Guessing: 0.2, UpperAsymptote: 0.95}
+	const h = 1e-6
+	for _, family := range []ModelFamily{ModelRasch, Model1PL, Model2PL, Model3PL, Model4PL} {
+		model := Model{Family: family, D: ScalingNormal}
+		for _, theta := range []float64{-2, 0.3, 1.5} {
+			d := (model.Probability(theta+h, item) - model.Probability(theta-h, item)) / (2 * h)
+			assert.InDelta(t, d, model.Derivative(theta, item), 1e-6, "%s θ=%v", family, theta)
+			p := model.Probability(theta, item)
+			assert.InDelta(t, d*d/(p*(1-p)), model.Information(theta, item), 1e-6, "%s θ=%v", family, theta)
+		}
+	}
+}
+
+func TestCalibrateOnePLSharesDiscrimination(t *testing.T) {
+	rng := rand.New(rand.NewSource(19))
+	model := Model{Family: Model1PL, D: ScalingNormal}
+	items := simulatedItems(rng, 12, 0)
+	for j := range items {
+		items[j].Discrimination = 0.8
+	}
+	matrix := simulatedMatrix(rng, model, items, 2000)
+
+	result, err := NewCalibrator(model).Calibrate(matrix)
+	require.NoError(t, err)
+	assert.Equal(t, Model1PL, result.Model)
+	for _, item := range result.Items {
+		assert.Equal(t, result.Items[0].Discrimination, item.Discrimination)
+		assert.Zero(t, item.Guessing)
+	}
+	assert.InDelta(t, 0.8, result.Items[0].Discrimination, 0.1)
+
+	_, err = NewCalibrator(Model{Family: "5PL", D: ScalingNormal}).Calibrate(matrix)
+	assert.ErrorIs(t, err, ErrUnknownModel)
+}
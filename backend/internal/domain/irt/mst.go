@@ -0,0 +1,284 @@
+package irt
+
+import (
+	"errors"
+	"math"
+)
+
+// 多阶段测验（MST）：预先组好的模块按阶段组成测验板，考生每完成一个模块按路由规则
+// 进入下一阶段的某个模块；同一阶段的模块按难度由易到难排列
+
+// RoutingMethod 模块间路由依据
+type RoutingMethod string
+
+const (
+	RoutingNumberCorrect RoutingMethod = "number_correct" // 刚完成模块的答对题数，多选题按部分分累计
+	RoutingTheta         RoutingMethod = "theta"          // 本场全部计分题的能力估计
+)
+
+// RoutingCutMethod 路由切点的确定方法
+type RoutingCutMethod string
+
+const (
+	RoutingCutManual     RoutingCutMethod = "manual" // 人工给定
+	RoutingCutMaxInfo    RoutingCutMethod = "ami"    // 近似最大信息：相邻后续模块信息函数的交点
+	RoutingCutPopulation RoutingCutMethod = "dpi"    // 既定群体：目标群体按给定比例进入各后续模块
+)
+
+var (
+	ErrUnknownRoutingMethod      = errors.New("unknown routing method")
+	ErrUnknownRoutingCutMethod   = errors.New("unknown routing cut method")
+	ErrInvalidRoutingCuts        = errors.New("routing cuts must be strictly ascending and one fewer than the next-stage modules")
+	ErrInvalidRoutingProportions = errors.New("routing proportions must be positive and one per next-stage module")
+	ErrModulesNotOrdered         = errors.New("next-stage modules must be ordered from easiest to hardest")
+	ErrEmptyModule               = errors.New("module has no items")
+)
+
+// RoutingConfig 路由切点推导配置
+type RoutingConfig struct {
+	Model          Model
+	Method         RoutingMethod
+	CutMethod      RoutingCutMethod
+	Proportions    []float64 // 既定群体路由中各后续模块的目标比例，为空时均分
+	PopulationMean float64   // 既定群体的能力分布 N(μ, σ²)
+	PopulationSD   float64
+	MinTheta       float64 // 信息函数交点的搜索范围
+	MaxTheta       float64
+}
+
+// DefaultRoutingConfig 返回常用的路由配置：按能力估计、近似最大信息切点
+func DefaultRoutingConfig() RoutingConfig {
+	return RoutingConfig{
+		Model:        DefaultModel(),
+		Method:       RoutingTheta,
+		CutMethod:    RoutingCutMaxInfo,
+		PopulationSD: 1,
+		MinTheta:     -4,
+		MaxTheta:     4,
+	}
+}
+
+// RoutingCuts 推导完成 source 模块后进入 next 各模块（由易到难）的路由切点，
+// 返回 len(next)-1 个升序切点，单位由 Method 决定：答对题数或能力值
+func RoutingCuts(source []TestItem, next [][]TestItem, cfg RoutingConfig) ([]float64, error) {
+	switch cfg.Method {
+	case RoutingNumberCorrect, RoutingTheta:
+	default:
+		return nil, ErrUnknownRoutingMethod
+	}
+	if len(source) == 0 {
+		return nil, ErrEmptyModule
+	}
+	for _, module := range next {
+		if len(module) == 0 {
+			return nil, ErrEmptyModule
+		}
+	}
+	if len(next) < 2 {
+		return []float64{}, nil
+	}
+
+	var cuts []float64
+	switch cfg.CutMethod {
+	case RoutingCutMaxInfo:
+		thetaCuts, err := maxInfoCuts(cfg.Model, next, cfg.MinTheta, cfg.MaxTheta)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Method == RoutingTheta {
+			cuts = thetaCuts
+			break
+		}
+		// 答对题数切点取来源模块期望答对题数在能力切点处的取整值
+		cuts = make([]float64, len(thetaCuts))
+		for k, theta := range thetaCuts {
+			cuts[k] = math.Round(cfg.Model.expectedNumberCorrect(source, theta))
+		}
+
+	case RoutingCutPopulation:
+		cumulative, err := cumulativeProportions(cfg.Proportions, len(next))
+		if err != nil {
+			return nil, err
+		}
+		sd := cfg.PopulationSD
+		if sd <= 0 {
+			sd = 1
+		}
+		if cfg.Method == RoutingTheta {
+			cuts = make([]float64, len(cumulative))
+			for k, q := range cumulative {
+				cuts[k] = cfg.PopulationMean + sd*math.Sqrt2*math.Erfinv(2*q-1)
+			}
+			break
+		}
+		cuts = numberCorrectQuantiles(cfg.Model, source, cfg.PopulationMean, sd, cumulative)
+
+	default:
+		return nil, ErrUnknownRoutingCutMethod
+	}
+
+	if err := ValidateRoutingCuts(cuts, len(next)); err != nil {
+		return nil, err
+	}
+	return cuts, nil
+}
+
+// ValidateRoutingCuts 检查切点个数比后续模块数少一且严格递增
+func ValidateRoutingCuts(cuts []float64, modules int) error {
+	if modules < 1 || len(cuts) != modules-1 {
+		return ErrInvalidRoutingCuts
+	}
+	for k := 1; k < len(cuts); k++ {
+		if cuts[k] <= cuts[k-1] {
+			return ErrInvalidRoutingCuts
+		}
+	}
+	return nil
+}
+
+// Route 路由值不低于的切点个数，即下一阶段模块的下标
+func Route(cuts []float64, value float64) int {
+	next := 0
+	for _, cut := range cuts {
+		if value >= cut {
+			next++
+		}
+	}
+	return next
+}
+
+// ModuleInformation 模块在 θ 处的信息量
+func (m Model) ModuleInformation(items []TestItem, theta float64) float64 {
+	var info float64
+	for _, item := range items {
+		info += m.Information(theta, item.params())
+	}
+	return info
+}
+
+// expectedNumberCorrect 模块在 θ 处的期望答对题数 Σ P(θ)
+func (m Model) expectedNumberCorrect(items []TestItem, theta float64) float64 {
+	var count float64
+	for _, item := range items {
+		count += m.Probability(theta, item.params())
+	}
+	return count
+}
+
+// maxInfoCuts 相邻模块信息函数在两者峰值之间的交点；没有交点时取两峰值的中点
+func maxInfoCuts(model Model, modules [][]TestItem, minTheta, maxTheta float64) ([]float64, error) {
+	if maxTheta <= minTheta {
+		minTheta, maxTheta = -4, 4
+	}
+	peaks := make([]float64, len(modules))
+	for k, module := range modules {
+		peaks[k] = informationPeak(model, module, minTheta, maxTheta)
+		if k > 0 && peaks[k] <= peaks[k-1] {
+			return nil, ErrModulesNotOrdered
+		}
+	}
+
+	cuts := make([]float64, len(modules)-1)
+	for k := range cuts {
+		easy, hard := modules[k], modules[k+1]
+		diff := func(theta float64) float64 {
+			return model.ModuleInformation(hard, theta) - model.ModuleInformation(easy, theta)
+		}
+		lo, hi := peaks[k], peaks[k+1]
+		if diff(lo) >= 0 || diff(hi) <= 0 {
+			cuts[k] = (lo + hi) / 2
+			continue
+		}
+		for i := 0; i < 60; i++ {
+			mid := (lo + hi) / 2
+			if diff(mid) < 0 {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		cuts[k] = (lo + hi) / 2
+	}
+	return cuts, nil
+}
+
+// informationPeak 网格搜索模块信息函数的最大值点
+func informationPeak(model Model, items []TestItem, minTheta, maxTheta float64) float64 {
+	const step = 0.01
+	peak, best := minTheta, -1.0
+	for theta := minTheta; theta <= maxTheta+1e-9; theta += step {
+		if info := model.ModuleInformation(items, theta); info > best {
+			peak, best = theta, info
+		}
+	}
+	return peak
+}
+
+// cumulativeProportions 各后续模块目标比例的前 n-1 个累积值，比例为空时均分
+func cumulativeProportions(proportions []float64, modules int) ([]float64, error) {
+	if len(proportions) == 0 {
+		proportions = make([]float64, modules)
+		for k := range proportions {
+			proportions[k] = 1
+		}
+	}
+	if len(proportions) != modules {
+		return nil, ErrInvalidRoutingProportions
+	}
+	var total float64
+	for _, p := range proportions {
+		if p <= 0 {
+			return nil, ErrInvalidRoutingProportions
+		}
+		total += p
+	}
+	cumulative := make([]float64, modules-1)
+	var sum float64
+	for k := range cumulative {
+		sum += proportions[k]
+		cumulative[k] = sum / total
+	}
+	return cumulative, nil
+}
+
+// numberCorrectQuantiles 由 Lord–Wingersky 递推求目标群体在来源模块上的答对题数边际分布，
+// 切点 c 取使 P(X < c) 最接近累积比例的整数，并保证严格递增
+func numberCorrectQuantiles(model Model, items []TestItem, mean, sd float64, cumulative []float64) []float64 {
+	nodes, weights := NormalQuadrature(41)
+	marginal := make([]float64, len(items)+1)
+	for q, node := range nodes {
+		theta := mean + sd*node
+		dist := []float64{1}
+		for _, item := range items {
+			p := model.Probability(theta, item.params())
+			next := make([]float64, len(dist)+1)
+			for x, f := range dist {
+				next[x] += f * (1 - p)
+				next[x+1] += f * p
+			}
+			dist = next
+		}
+		for x, f := range dist {
+			marginal[x] += weights[q] * f
+		}
+	}
+
+	// below[c] = P(X < c)
+	below := make([]float64, len(marginal)+1)
+	for x, f := range marginal {
+		below[x+1] = below[x] + f
+	}
+	cuts := make([]float64, len(cumulative))
+	previous := 0
+	for k, target := range cumulative {
+		best := previous + 1
+		for c := previous + 1; c <= len(items); c++ {
+			if math.Abs(below[c]-target) < math.Abs(below[best]-target) {
+				best = c
+			}
+		}
+		cuts[k] = float64(best)
+		previous = best
+	}
+	return cuts
+}
@@ -0,0 +1,118 @@
+package irt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// module n 道难度相同的 Rasch 题目组成的模块
+func module(n int, difficulty float64) []TestItem {
+	items := make([]TestItem, n)
+	for j := range items {
+		items[j] = TestItem{QuestionID: uint(j + 1), Difficulty: difficulty, Discrimination: 1}
+	}
+	return items
+}
+
+func routingConfig(method RoutingMethod, cutMethod RoutingCutMethod) RoutingConfig {
+	cfg := DefaultRoutingConfig()
+	cfg.Model = Model{Family: ModelRasch, D: ScalingLogistic}
+	cfg.Method = method
+	cfg.CutMethod = cutMethod
+	return cfg
+}
+
+func TestMaxInfoRoutingCuts(t *testing.T) {
+	source := module(10, 0)
+
+	// 难度关于0对称的模块，相邻信息函数交于两者难度的中点
+	cfg := routingConfig(RoutingTheta, RoutingCutMaxInfo)
+	cuts, err := RoutingCuts(source, [][]TestItem{module(5, -1), module(5, 1)}, cfg)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{0}, cuts, 1e-6)
+
+	cuts, err = RoutingCuts(source, [][]TestItem{module(5, -1.5), module(5, 0), module(5, 1.5)}, cfg)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{-0.75, 0.75}, cuts, 1e-6)
+
+	// 按答对题数路由时，切点为来源模块在 θ 切点处的期望答对题数：θ = 0 时 10·1/2
+	cfg.Method = RoutingNumberCorrect
+	cuts, err = RoutingCuts(source, [][]TestItem{module(5, -1), module(5, 1)}, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{5}, cuts)
+
+	// 后续模块须由易到难排列
+	cfg.Method = RoutingTheta
+	_, err = RoutingCuts(source, [][]TestItem{module(5, 1), module(5, -1)}, cfg)
+	assert.ErrorIs(t, err, ErrModulesNotOrdered)
+}
+
+func TestPopulationRoutingCuts(t *testing.T) {
+	source := module(10, 0)
+	next := [][]TestItem{module(5, -1), module(5, 0), module(5, 1)}
+
+	// 按能力路由：N(0, 1) 的 1/3 与 2/3 分位数 ±0.4307
+	cfg := routingConfig(RoutingTheta, RoutingCutPopulation)
+	cuts, err := RoutingCuts(source, next, cfg)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{-0.430727, 0.430727}, cuts, 1e-6)
+
+	// 比例 1:2:1 与总体均值、标准差：μ + σ·Φ⁻¹(1/4)，μ + σ·Φ⁻¹(3/4)
+	cfg.Proportions = []float64{1, 2, 1}
+	cfg.PopulationMean = 0.5
+	cfg.PopulationSD = 2
+	cuts, err = RoutingCuts(source, next, cfg)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{0.5 - 2*0.674490, 0.5 + 2*0.674490}, cuts, 1e-5)
+
+	// 按答对题数路由：总体关于来源模块难度对称，P(X < c) = 1 − P(X < 11 − c)，两个切点之和为11
+	cfg = routingConfig(RoutingNumberCorrect, RoutingCutPopulation)
+	cuts, err = RoutingCuts(source, next, cfg)
+	require.NoError(t, err)
+	require.Len(t, cuts, 2)
+	assert.Less(t, cuts[0], cuts[1])
+	assert.Equal(t, 11.0, cuts[0]+cuts[1])
+
+	cfg.Proportions = []float64{1, 0, 1}
+	_, err = RoutingCuts(source, next, cfg)
+	assert.ErrorIs(t, err, ErrInvalidRoutingProportions)
+	cfg.Proportions = []float64{1, 1}
+	_, err = RoutingCuts(source, next, cfg)
+	assert.ErrorIs(t, err, ErrInvalidRoutingProportions)
+}
+
+func TestRoutingCutsErrors(t *testing.T) {
+	source := module(10, 0)
+	next := [][]TestItem{module(5, -1), module(5, 1)}
+
+	_, err := RoutingCuts(source, next, routingConfig("sum_score", RoutingCutMaxInfo))
+	assert.ErrorIs(t, err, ErrUnknownRoutingMethod)
+	_, err = RoutingCuts(source, next, routingConfig(RoutingTheta, "equipercentile"))
+	assert.ErrorIs(t, err, ErrUnknownRoutingCutMethod)
+	_, err = RoutingCuts(nil, next, routingConfig(RoutingTheta, RoutingCutMaxInfo))
+	assert.ErrorIs(t, err, ErrEmptyModule)
+	_, err = RoutingCuts(source, [][]TestItem{module(5, -1), nil}, routingConfig(RoutingTheta, RoutingCutMaxInfo))
+	assert.ErrorIs(t, err, ErrEmptyModule)
+
+	// 只有一个后续模块时不需要切点
+	cuts, err := RoutingCuts(source, next[:1], routingConfig(RoutingTheta, RoutingCutMaxInfo))
+	require.NoError(t, err)
+	assert.Empty(t, cuts)
+}
+
+func TestRouteAndValidateCuts(t *testing.T) {
+	cuts := []float64{4, 7}
+	assert.Equal(t, 0, Route(cuts, 3))
+	assert.Equal(t, 1, Route(cuts, 4))
+	assert.Equal(t, 1, Route(cuts, 6.5))
+	assert.Equal(t, 2, Route(cuts, 10))
+	assert.Equal(t, 0, Route(nil, 10))
+
+	assert.NoError(t, ValidateRoutingCuts(cuts, 3))
+	assert.NoError(t, ValidateRoutingCuts(nil, 1))
+	assert.ErrorIs(t, ValidateRoutingCuts(cuts, 2), ErrInvalidRoutingCuts)
+	assert.ErrorIs(t, ValidateRoutingCuts([]float64{7, 7}, 3), ErrInvalidRoutingCuts)
+	assert.ErrorIs(t, ValidateRoutingCuts(nil, 0), ErrInvalidRoutingCuts)
+}
@@ -0,0 +1,227 @@
+package irt
+
+import (
+	"errors"
+	"math"
+)
+
+// ItemModel 题目的项目反应模型
+type ItemModel string
+
+const (
+	ModelThreePL ItemModel = "3pl"  // 二级计分，按科目配置的 Model 计算，空字符串等同于3pl
+	ModelGRM     ItemModel = "grm"  // Samejima 等级反应模型
+	ModelGPCM    ItemModel = "gpcm" // 广义分部评分模型
+)
+
+var (
+	ErrUnknownItemModel  = errors.New("unknown item response model")
+	ErrInvalidThresholds = errors.New("invalid category thresholds")
+)
+
+// Polytomous 是否为多级计分模型
+func (m ItemModel) Polytomous() bool {
+	return m == ModelGRM || m == ModelGPCM
+}
+
+// ValidateThresholds 校验多级计分题的类别阈值：至少一个阈值，GRM 阈值须严格递增
+func ValidateThresholds(model ItemModel, thresholds []float64) error {
+	switch model {
+	case ModelThreePL, "":
+		return nil
+	case ModelGRM, ModelGPCM:
+	default:
+		return ErrUnknownItemModel
+	}
+	if len(thresholds) == 0 {
+		return ErrInvalidThresholds
+	}
+	for k, b := range thresholds {
+		if math.IsNaN(b) || math.IsInf(b, 0) {
+			return ErrInvalidThresholds
+		}
+		if model == ModelGRM && k > 0 && b <= thresholds[k-1] {
+			return ErrInvalidThresholds
+		}
+	}
+	return nil
+}
+
+// CategoryProbabilities 多级计分题在 θ 处各得分类别 0..m 的概率，m 为阈值个数，
+// 量尺因子取科目模型的 D
+//
+// GRM：P*_k = 1/(1+exp(-Da(θ-b_k)))，P_k = P*_k - P*_{k+1}
+// GPCM：P_k ∝ exp(Σ_{v≤k} Da(θ-b_v))
+func (m Model) CategoryProbabilities(model ItemModel, theta, discrimination float64, thresholds []float64) []float64 {
+	last := len(thresholds)
+	probs := make([]float64, last+1)
+	scale := m.Scaling()
+	switch model {
+	case ModelGRM:
+		upper := 1.0
+		for k := 0; k <= last; k++ {
+			lower := 0.0
+			if k < last {
+				lower = 1 / (1 + math.Exp(-scale*discrimination*(theta-thresholds[k])))
+			}
+			probs[k] = upper - lower
+			upper = lower
+		}
+	case ModelGPCM:
+		// 以最大指数为基准避免溢出
+		exponents := make([]float64, last+1)
+		maxExponent := 0.0
+		for k := 1; k <= last; k++ {
+			exponents[k] = exponents[k-1] + scale*discrimination*(theta-thresholds[k-1])
+			maxExponent = math.Max(maxExponent, exponents[k])
+		}
+		var sum float64
+		for k := range probs {
+			probs[k] = math.Exp(exponents[k] - maxExponent)
+			sum += probs[k]
+		}
+		for k := range probs {
+			probs[k] /= sum
+		}
+	}
+	return probs
+}
+
+// categoryDerivatives 各类别概率对 θ 的一阶导数
+func (m Model) categoryDerivatives(model ItemModel, theta, discrimination float64, thresholds []float64) []float64 {
+	last := len(thresholds)
+	derivs := make([]float64, last+1)
+	scale := m.Scaling()
+	switch model {
+	case ModelGRM:
+		// dP*_k/dθ = Da P*_k (1-P*_k)
+		upper := 0.0
+		for k := 0; k <= last; k++ {
+			lower := 0.0
+			if k < last {
+				p := 1 / (1 + math.Exp(-scale*discrimination*(theta-thresholds[k])))
+				lower = scale * discrimination * p * (1 - p)
+			}
+			derivs[k] = upper - lower
+			upper = lower
+		}
+	case ModelGPCM:
+		// dP_k/dθ = Da P_k (k - E[k])
+		probs := m.CategoryProbabilities(model, theta, discrimination, thresholds)
+		var mean float64
+		for k, p := range probs {
+			mean += float64(k) * p
+		}
+		for k, p := range probs {
+			derivs[k] = scale * discrimination * p * (float64(k) - mean)
+		}
+	}
+	return derivs
+}
+
+// PolytomousInformation 多级计分题的 Fisher 信息量 Σ (P'_k)²/P_k
+func (m Model) PolytomousInformation(model ItemModel, theta, discrimination float64, thresholds []float64) float64 {
+	probs := m.CategoryProbabilities(model, theta, discrimination, thresholds)
+	derivs := m.categoryDerivatives(model, theta, discrimination, thresholds)
+	var info float64
+	for k, p := range probs {
+		if p > 0 {
+			info += derivs[k] * derivs[k] / p
+		}
+	}
+	return info
+}
+
+// ItemInformation 按题目模型计算 θ 处的信息量
+func (m Model) ItemInformation(model ItemModel, theta float64, item ItemParams, thresholds []float64) float64 {
+	if model.Polytomous() {
+		return m.PolytomousInformation(model, theta, item.Discrimination, thresholds)
+	}
+	return m.Information(theta, item)
+}
+
+// ExpectedCategory 题目在 θ 处的期望得分类别，二级计分题为答对概率
+func (m Model) ExpectedCategory(model ItemModel, theta float64, item ItemParams, thresholds []float64) float64 {
+	var mean float64
+	for k, p := range m.itemProbabilities(model, theta, item, thresholds) {
+		mean += float64(k) * p
+	}
+	return mean
+}
+
+// ScoreCategory 将得分率 credit∈[0,1] 换算为 0..categories-1 的得分类别
+func ScoreCategory(credit float64, categories int) int {
+	if categories < 2 {
+		return 0
+	}
+	return int(math.Round(math.Max(0, math.Min(1, credit)) * float64(categories-1)))
+}
+
+// itemProbabilities 各得分类别的概率，二级计分题为 [1-P, P]
+func (m Model) itemProbabilities(model ItemModel, theta float64, item ItemParams, thresholds []float64) []float64 {
+	if model.Polytomous() {
+		return m.CategoryProbabilities(model, theta, item.Discrimination, thresholds)
+	}
+	p := m.Probability(theta, item)
+	return []float64{1 - p, p}
+}
+
+// category 作答所在的得分类别，超出范围时截断
+func (r ItemResponse) category() int {
+	if !r.Model.Polytomous() {
+		if r.Correct {
+			return 1
+		}
+		return 0
+	}
+	return int(math.Max(0, math.Min(float64(len(r.Thresholds)), float64(r.Category))))
+}
+
+// params 作答题目的二级计分参数
+func (r ItemResponse) params() ItemParams {
+	return ItemParams{
+		Difficulty:     r.Difficulty,
+		Discrimination: r.Discrimination,
+		Guessing:       r.Guessing,
+		UpperAsymptote: r.UpperAsymptote,
+	}
+}
+
+// logProbability 作答所在类别概率的对数
+func (m Model) logProbability(theta float64, r ItemResponse) float64 {
+	probs := m.itemProbabilities(r.Model, theta, r.params(), r.Thresholds)
+	return math.Log(boundProbability(probs[r.category()]))
+}
+
+// score 对数似然对 θ 的一阶导数 P'_x/P_x
+func (m Model) score(theta float64, r ItemResponse) float64 {
+	if !r.Model.Polytomous() {
+		p := boundProbability(m.Probability(theta, r.params()))
+		dp := m.Derivative(theta, r.params())
+		return (float64(r.category()) - p) * dp / (p * (1 - p))
+	}
+	x := r.category()
+	probs := m.CategoryProbabilities(r.Model, theta, r.Discrimination, r.Thresholds)
+	derivs := m.categoryDerivatives(r.Model, theta, r.Discrimination, r.Thresholds)
+	return derivs[x] / boundProbability(probs[x])
+}
+
+// params 候选题的二级计分参数
+func (c Candidate) params() ItemParams {
+	return ItemParams{
+		Difficulty:     c.Difficulty,
+		Discrimination: c.Discrimination,
+		Guessing:       c.Guessing,
+		UpperAsymptote: c.UpperAsymptote,
+	}
+}
+
+// candidateInformation 候选题在 θ 处的信息量
+func (m Model) candidateInformation(theta float64, c Candidate) float64 {
+	return m.ItemInformation(c.Model, theta, c.params(), c.Thresholds)
+}
+
+// candidateProbabilities 候选题各得分类别的概率
+func (m Model) candidateProbabilities(theta float64, c Candidate) []float64 {
+	return m.itemProbabilities(c.Model, theta, c.params(), c.Thresholds)
+}
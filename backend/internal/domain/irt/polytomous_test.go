@@ -0,0 +1,102 @@
+package irt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryProbabilitiesSumToOne(t *testing.T) {
+	thresholds := []float64{-1.5, -0.2, 0.7, 2}
+	for _, model := range []ItemModel{ModelGRM, ModelGPCM} {
+		for _, theta := range []float64{-40, -3, -0.5, 0, 1.2, 3, 40} {
+			probs := DefaultModel().CategoryProbabilities(model, theta, 1.3, thresholds)
+			require.Len(t, probs, len(thresholds)+1)
+			var sum float64
+			for _, p := range probs {
+				assert.GreaterOrEqual(t, p, 0.0, "%s θ=%v", model, theta)
+				sum += p
+			}
+			assert.InDelta(t, 1.0, sum, 1e-12, "%s θ=%v", model, theta)
+		}
+	}
+}
+
+func TestCategoryProbabilitiesHandComputed(t *testing.T) {
+	model := Model{Family: Model2PL, D: ScalingLogistic}
+
+	// GRM：θ = 0、阈值 ±ln 3 时 P*_1 = 3/4、P*_2 = 1/4，类别概率为 1/4、1/2、1/4
+	probs := model.CategoryProbabilities(ModelGRM, 0, 1, []float64{-math.Log(3), math.Log(3)})
+	assert.InDeltaSlice(t, []float64{0.25, 0.5, 0.25}, probs, 1e-12)
+
+	// GPCM：阈值为0时指数为 0、θ、2θ，θ = ln 2 时概率比为 1:2:4
+	probs = model.CategoryProbabilities(ModelGPCM, math.Log(2), 1, []float64{0, 0})
+	assert.InDeltaSlice(t, []float64{1.0 / 7, 2.0 / 7, 4.0 / 7}, probs, 1e-12)
+
+	// 只有一个阈值时两种模型都退化为2PL
+	item := ItemParams{Difficulty: 0.4, Discrimination: 1.6}
+	for _, itemModel := range []ItemModel{ModelGRM, ModelGPCM} {
+		probs := model.CategoryProbabilities(itemModel, -0.3, 1.6, []float64{0.4})
+		assert.InDelta(t, model.Probability(-0.3, item), probs[1], 1e-12)
+		assert.InDelta(t, model.Information(-0.3, item), model.PolytomousInformation(itemModel, -0.3, 1.6, []float64{0.4}), 1e-12)
+	}
+}
+
+func TestPolytomousInformationMatchesNumericDerivative(t *testing.T) {
+	// 信息量 Σ(P'_k)²/P_k，导数用中心差分核对
+	model := DefaultModel()
+	thresholds := []float64{-1, 0.3, 1.1}
+	const h = 1e-6
+	for _, itemModel := range []ItemModel{ModelGRM, ModelGPCM} {
+		for _, theta := range []float64{-1.5, 0, 0.8} {
+			probs := model.CategoryProbabilities(itemModel, theta, 1.4, thresholds)
+			plus := model.CategoryProbabilities(itemModel, theta+h, 1.4, thresholds)
+			minus := model.CategoryProbabilities(itemModel, theta-h, 1.4, thresholds)
+			var info float64
+			for k, p := range probs {
+				d := (plus[k] - minus[k]) / (2 * h)
+				info += d * d / p
+			}
+			assert.InDelta(t, info, model.PolytomousInformation(itemModel, theta, 1.4, thresholds), 1e-6)
+		}
+	}
+}
+
+func TestPolytomousEstimationAndScoring(t *testing.T) {
+	// 高类别作答使能力估计高于低类别作答；阈值对称时两者关于0对称
+	responses := func(category int) []ItemResponse {
+		items := make([]ItemResponse, 6)
+		for j := range items {
+			items[j] = ItemResponse{QuestionID: uint(j + 1), Discrimination: 1.2, Model: ModelGPCM, Thresholds: []float64{-1, 0, 1}, Category: category}
+		}
+		return items
+	}
+	estimator := NewEstimator(MethodEAP)
+	low, err := estimator.Estimate(responses(1))
+	require.NoError(t, err)
+	high, err := estimator.Estimate(responses(2))
+	require.NoError(t, err)
+	assert.Greater(t, high.Theta, low.Theta)
+	assert.InDelta(t, 0, low.Theta+high.Theta, 1e-6)
+
+	// 期望得分类别与得分率换算
+	item := ItemParams{Discrimination: 1.2}
+	assert.InDelta(t, 1.5, DefaultModel().ExpectedCategory(ModelGRM, 0, item, []float64{-1, 0, 1}), 1e-12)
+	assert.Equal(t, 0, ScoreCategory(0.1, 4))
+	assert.Equal(t, 2, ScoreCategory(0.6, 4))
+	assert.Equal(t, 3, ScoreCategory(1.5, 4))
+	assert.Equal(t, 0, ScoreCategory(1, 1))
+}
+
+func TestValidateThresholds(t *testing.T) {
+	assert.NoError(t, ValidateThresholds("", nil))
+	assert.NoError(t, ValidateThresholds(ModelGRM, []float64{-1, 0, 1}))
+	assert.NoError(t, ValidateThresholds(ModelGPCM, []float64{1, -1}))
+
+	assert.ErrorIs(t, ValidateThresholds(ModelGRM, []float64{0, 0}), ErrInvalidThresholds)
+	assert.ErrorIs(t, ValidateThresholds(ModelGPCM, nil), ErrInvalidThresholds)
+	assert.ErrorIs(t, ValidateThresholds(ModelGPCM, []float64{math.NaN()}), ErrInvalidThresholds)
+	assert.ErrorIs(t, ValidateThresholds("nominal", []float64{0}), ErrUnknownItemModel)
+}
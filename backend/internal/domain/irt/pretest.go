@@ -0,0 +1,279 @@
+package irt
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// OnlineMethod 试测题在线标定方法
+type OnlineMethod string
+
+const (
+	OnlineMethodA OnlineMethod = "method_a" // 以考生能力点估计为固定值的极大似然
+	OnlineOEM     OnlineMethod = "oem"      // 一轮EM：能力后验只由计分题作答决定
+	OnlineMEM     OnlineMethod = "mem"      // 多轮EM：能力后验同时纳入试测题作答并迭代至收敛
+)
+
+var ErrUnknownOnlineMethod = errors.New("unknown online calibration method")
+
+// PretestPlan 试测题嵌入策略
+type PretestPlan struct {
+	Rate       float64 // 试测题占已施测题目的目标比例，0表示不嵌入
+	MaxItems   int     // 每场考试最多嵌入的试测题数，0表示不限
+	StartAfter int     // 前若干道计分题不嵌入试测题，避免能力估计不稳定时施测
+	Rand       *rand.Rand
+}
+
+// ShouldSeed 判断下一题是否施测试测题：嵌入后试测题比例不超过 Rate 时嵌入
+func (p PretestPlan) ShouldSeed(operational, pretest int) bool {
+	if p.Rate <= 0 || operational < p.StartAfter {
+		return false
+	}
+	if p.MaxItems > 0 && pretest >= p.MaxItems {
+		return false
+	}
+	return float64(pretest+1) <= p.Rate*float64(operational+pretest+1)
+}
+
+// Choose 从试测题中选出已收集作答最少的一道，并列时随机选取，使各题样本量均衡增长
+func (p PretestPlan) Choose(responseCounts []int64) int {
+	if len(responseCounts) == 0 {
+		return -1
+	}
+	best := make([]int, 0, len(responseCounts))
+	for i, n := range responseCounts {
+		switch {
+		case len(best) == 0 || n < responseCounts[best[0]]:
+			best = append(best[:0], i)
+		case n == responseCounts[best[0]]:
+			best = append(best, i)
+		}
+	}
+	return best[p.intn(len(best))]
+}
+
+// FormCount 固定卷中嵌入的试测题数，使试测题占全卷的比例不超过 Rate
+func (p PretestPlan) FormCount(operational int) int {
+	if p.Rate <= 0 || p.Rate >= 1 || operational <= p.StartAfter {
+		return 0
+	}
+	count := int(math.Floor(p.Rate * float64(operational) / (1 - p.Rate)))
+	if p.MaxItems > 0 && count > p.MaxItems {
+		count = p.MaxItems
+	}
+	return count
+}
+
+// FormPositions 在 operational+count 道题的固定卷中为试测题随机安排位置（从0开始、升序），
+// 前 StartAfter 个位置留给计分题
+func (p PretestPlan) FormPositions(operational, count int) []int {
+	slots := make([]int, 0, operational+count)
+	for i := p.StartAfter; i < operational+count; i++ {
+		slots = append(slots, i)
+	}
+	for i := 0; i < count && i < len(slots); i++ {
+		j := i + p.intn(len(slots)-i)
+		slots[i], slots[j] = slots[j], slots[i]
+	}
+	if count > len(slots) {
+		count = len(slots)
+	}
+	positions := append([]int(nil), slots[:count]...)
+	sort.Ints(positions)
+	return positions
+}
+
+func (p PretestPlan) intn(n int) int {
+	if p.Rand != nil {
+		return p.Rand.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+// PretestObservation 一名考生在某道试测题上的作答
+type PretestObservation struct {
+	Theta       float64        // 考生能力点估计，Method A 使用
+	Operational []ItemResponse // 考生计分题作答，OEM/MEM 由此计算能力后验
+	Correct     bool
+}
+
+// CalibrateOnline 以计分题参数固定的量尺对单道试测题做在线标定；
+// start 为参数初值，1PL 下区分度固定取 start 中的共同区分度
+func (cal *Calibrator) CalibrateOnline(questionID uint, method OnlineMethod, start ItemParams, observations []PretestObservation) (ItemCalibration, error) {
+	switch method {
+	case OnlineMethodA, OnlineOEM, OnlineMEM:
+	default:
+		return ItemCalibration{}, ErrUnknownOnlineMethod
+	}
+	if err := cal.Model.Validate(); err != nil {
+		return ItemCalibration{}, err
+	}
+
+	result := ItemCalibration{QuestionID: questionID, ResponseCount: len(observations)}
+	item := itemState{a: start.Discrimination, b: start.Difficulty, c: start.Guessing, d: start.UpperAsymptote}
+	if item.a <= 0 {
+		item.a = 1
+	}
+	if item.d <= 0 {
+		item.d = 1
+	}
+	if len(observations) < cal.MinResponses {
+		result.Skipped = true
+		return result, nil
+	}
+
+	var nodes, n, r []float64
+	switch method {
+	case OnlineMethodA:
+		// 能力点估计即积分节点，每名考生权重为1
+		nodes = make([]float64, len(observations))
+		n = make([]float64, len(observations))
+		r = make([]float64, len(observations))
+		for i, o := range observations {
+			nodes[i] = o.Theta
+			n[i] = 1
+			if o.Correct {
+				r[i] = 1
+			}
+		}
+		item = cal.converge(item, nodes, n, r)
+
+	default:
+		var priorWeights []float64
+		nodes, priorWeights = NormalQuadrature(cal.QuadraturePoints)
+		base := cal.operationalPosteriors(observations, nodes, priorWeights)
+		n = make([]float64, len(nodes))
+		r = make([]float64, len(nodes))
+		cycles := 1
+		if method == OnlineMEM {
+			cycles = cal.MaxIterations
+		}
+		for cycle := 0; cycle < cycles; cycle++ {
+			// 第一轮后验只含计分题（即OEM）；MEM 之后各轮再乘上试测题当前参数下的似然
+			cal.pretestCounts(item, observations, nodes, base, cycle > 0, n, r)
+			var updated itemState
+			if method == OnlineMEM {
+				updated = cal.maximize(item, nodes, n, r)
+			} else {
+				updated = cal.converge(item, nodes, n, r)
+			}
+			change := itemChange(updated, item)
+			item = updated
+			if change < cal.Convergence {
+				break
+			}
+		}
+		if method == OnlineMEM {
+			cal.pretestCounts(item, observations, nodes, base, true, n, r)
+		}
+	}
+
+	result.Difficulty = item.b
+	result.Discrimination = item.a
+	result.Guessing = item.c
+	result.UpperAsymptote = item.d
+	se := cal.standardErrors(item, nodes, n, r)
+	result.DifficultySE, result.DiscriminationSE = se[0], se[1]
+	result.GuessingSE, result.UpperAsymptoteSE = se[2], se[3]
+	return result, nil
+}
+
+// converge 期望计数固定时反复做M步直至参数收敛
+func (cal *Calibrator) converge(item itemState, nodes, n, r []float64) itemState {
+	for iter := 0; iter < cal.MaxIterations; iter++ {
+		updated := cal.maximize(item, nodes, n, r)
+		change := itemChange(updated, item)
+		item = updated
+		if change < cal.Convergence {
+			break
+		}
+	}
+	return item
+}
+
+// operationalPosteriors 各考生仅由计分题作答得到的能力后验（未归一化的对数值）
+func (cal *Calibrator) operationalPosteriors(observations []PretestObservation, nodes, priorWeights []float64) [][]float64 {
+	posteriors := make([][]float64, len(observations))
+	for i, o := range observations {
+		posteriors[i] = make([]float64, len(nodes))
+		for k, x := range nodes {
+			posteriors[i][k] = math.Log(priorWeights[k]) + cal.Model.LogLikelihood(x, o.Operational)
+		}
+	}
+	return posteriors
+}
+
+// pretestCounts 计算试测题在各节点上的期望作答人数与答对人数；
+// withItem 为 true 时后验同时包含试测题本身的作答
+func (cal *Calibrator) pretestCounts(item itemState, observations []PretestObservation, nodes []float64, base [][]float64, withItem bool, n, r []float64) {
+	for k := range n {
+		n[k] = 0
+		r[k] = 0
+	}
+	posterior := make([]float64, len(nodes))
+	for i, o := range observations {
+		maxLog := math.Inf(-1)
+		for k, x := range nodes {
+			ll := base[i][k]
+			if withItem {
+				p := boundProbability(cal.Model.Probability(x, item.params()))
+				if o.Correct {
+					ll += math.Log(p)
+				} else {
+					ll += math.Log(1 - p)
+				}
+			}
+			posterior[k] = ll
+			maxLog = math.Max(maxLog, ll)
+		}
+		var total float64
+		for k := range posterior {
+			posterior[k] = math.Exp(posterior[k] - maxLog)
+			total += posterior[k]
+		}
+		for k, w := range posterior {
+			w /= total
+			n[k] += w
+			if o.Correct {
+				r[k] += w
+			}
+		}
+	}
+}
+
+// PromotionCriteria 试测题转为正式题的条件，标准误阈值为0表示不检查该参数
+type PromotionCriteria struct {
+	MinResponses        int
+	MaxDifficultySE     float64
+	MaxDiscriminationSE float64
+	MaxGuessingSE       float64
+	MaxUpperAsymptoteSE float64
+}
+
+// Ready 判断在线标定结果是否满足转正条件；模型估计的参数标准误为0表示无法求得，视为未达标
+func (c PromotionCriteria) Ready(model Model, item ItemCalibration) bool {
+	if item.Skipped || item.ResponseCount < c.MinResponses {
+		return false
+	}
+	family := model.resolved().Family
+	checks := []struct {
+		se, max   float64
+		estimated bool
+	}{
+		{item.DifficultySE, c.MaxDifficultySE, true},
+		{item.DiscriminationSE, c.MaxDiscriminationSE, family == Model2PL || family == Model3PL || family == Model4PL},
+		{item.GuessingSE, c.MaxGuessingSE, family == Model3PL || family == Model4PL},
+		{item.UpperAsymptoteSE, c.MaxUpperAsymptoteSE, family == Model4PL},
+	}
+	for _, check := range checks {
+		if !check.estimated || check.max <= 0 {
+			continue
+		}
+		if check.se <= 0 || math.IsNaN(check.se) || check.se > check.max {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package irt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pretestObservations 考生先作答计分题，再作答参数为 pretest 的试测题
+func pretestObservations(rng *rand.Rand, model Model, operational []ItemParams, pretest ItemParams, examinees int) []PretestObservation {
+	observations := make([]PretestObservation, examinees)
+	for i := range observations {
+		theta := rng.NormFloat64()
+		observations[i] = PretestObservation{
+			Theta:       theta,
+			Operational: simulatedResponses(rng, model, theta, operational),
+			Correct:     rng.Float64() < model.Probability(theta, pretest),
+		}
+	}
+	return observations
+}
+
+func TestCalibrateOnlineRecoversPretestItem(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	model := Model{Family: Model2PL, D: ScalingNormal}
+	operational := simulatedItems(rng, 25, 0)
+	pretest := ItemParams{Difficulty: 0.5, Discrimination: 1.3}
+	observations := pretestObservations(rng, model, operational, pretest, 1500)
+
+	// 三种方法从同一初值出发都应收敛到真实参数附近
+	start := ItemParams{Discrimination: 1}
+	for _, method := range []OnlineMethod{OnlineMethodA, OnlineOEM, OnlineMEM} {
+		t.Run(string(method), func(t *testing.T) {
+			result, err := NewCalibrator(model).CalibrateOnline(99, method, start, observations)
+			require.NoError(t, err)
+			assert.Equal(t, uint(99), result.QuestionID)
+			assert.Equal(t, 1500, result.ResponseCount)
+			assert.False(t, result.Skipped)
+			assert.InDelta(t, pretest.Difficulty, result.Difficulty, 0.15)
+			assert.InDelta(t, pretest.Discrimination, result.Discrimination, 0.3)
+			assert.Greater(t, result.DifficultySE, 0.0)
+			assert.Greater(t, result.DiscriminationSE, 0.0)
+		})
+	}
+}
+
+func TestCalibrateOnlineMEMCorrectsOEMShrinkage(t *testing.T) {
+	// 计分题很少时能力后验偏向先验，OEM 低估区分度；MEM 把试测题作答纳入后验后偏差减小
+	rng := rand.New(rand.NewSource(29))
+	model := Model{Family: Model2PL, D: ScalingNormal}
+	operational := simulatedItems(rng, 5, 0)
+	pretest := ItemParams{Difficulty: 0, Discrimination: 1.5}
+	observations := pretestObservations(rng, model, operational, pretest, 2000)
+
+	cal := NewCalibrator(model)
+	oem, err := cal.CalibrateOnline(1, OnlineOEM, ItemParams{Discrimination: 1}, observations)
+	require.NoError(t, err)
+	mem, err := cal.CalibrateOnline(1, OnlineMEM, ItemParams{Discrimination: 1}, observations)
+	require.NoError(t, err)
+	assert.Less(t, oem.Discrimination, pretest.Discrimination)
+	assert.Greater(t, mem.Discrimination, oem.Discrimination)
+}
+
+func TestCalibrateOnlineSkipsAndErrors(t *testing.T) {
+	cal := NewCalibrator(Model{Family: Model2PL, D: ScalingNormal})
+	result, err := cal.CalibrateOnline(3, OnlineOEM, ItemParams{}, make([]PretestObservation, cal.MinResponses-1))
+	require.NoError(t, err)
+	assert.True(t, result.Skipped)
+
+	_, err = cal.CalibrateOnline(3, "method_b", ItemParams{}, nil)
+	assert.ErrorIs(t, err, ErrUnknownOnlineMethod)
+}
+
+func TestPretestPlanSeeding(t *testing.T) {
+	plan := PretestPlan{Rate: 0.2, MaxItems: 2, StartAfter: 3, Rand: rand.New(rand.NewSource(1))}
+
+	// 前3道计分题之后，试测题比例不超过20%
+	assert.False(t, plan.ShouldSeed(2, 0))
+	assert.True(t, plan.ShouldSeed(4, 0))
+	assert.False(t, plan.ShouldSeed(4, 1))
+	assert.True(t, plan.ShouldSeed(9, 1))
+	assert.False(t, plan.ShouldSeed(20, 2))
+	assert.False(t, PretestPlan{}.ShouldSeed(10, 0))
+
+	// 选择作答最少的试测题，并列时随机
+	assert.Equal(t, 2, plan.Choose([]int64{5, 3, 1, 4}))
+	assert.Equal(t, -1, plan.Choose(nil))
+	chosen := make(map[int]bool)
+	for i := 0; i < 50; i++ {
+		chosen[plan.Choose([]int64{2, 7, 2})] = true
+	}
+	assert.Equal(t, map[int]bool{0: true, 2: true}, chosen)
+
+	// 固定卷：16道计分题嵌入 floor(0.2·16/0.8) = 4 道，受 MaxItems 限制为2道
+	assert.Equal(t, 2, plan.FormCount(16))
+	assert.Equal(t, 4, PretestPlan{Rate: 0.2}.FormCount(16))
+	assert.Equal(t, 0, plan.FormCount(3))
+	positions := plan.FormPositions(16, 2)
+	require.Len(t, positions, 2)
+	assert.Less(t, positions[0], positions[1])
+	assert.GreaterOrEqual(t, positions[0], plan.StartAfter)
+	assert.Less(t, positions[1], 18)
+}
+
+func TestPromotionCriteriaReady(t *testing.T) {
+	criteria := PromotionCriteria{MinResponses: 200, MaxDifficultySE: 0.2, MaxDiscriminationSE: 0.25, MaxGuessingSE: 0.05}
+	item := ItemCalibration{ResponseCount: 300, DifficultySE: 0.1, DiscriminationSE: 0.2}
+	model2PL := Model{Family: Model2PL, D: ScalingNormal}
+
+	assert.True(t, criteria.Ready(model2PL, item))
+
+	// 3PL 需要猜测参数的标准误，未估计出时不转正
+	assert.False(t, criteria.Ready(DefaultModel(), item))
+	item.GuessingSE = 0.04
+	assert.True(t, criteria.Ready(DefaultModel(), item))
+
+	// Rasch 不检查区分度
+	item.DiscriminationSE = 0.5
+	assert.False(t, criteria.Ready(model2PL, item))
+	assert.True(t, criteria.Ready(Model{Family: ModelRasch, D: ScalingLogistic}, item))
+
+	item.ResponseCount = 100
+	assert.False(t, criteria.Ready(Model{Family: ModelRasch, D: ScalingLogistic}, item))
+}
@@ -0,0 +1,378 @@
+package irt
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var ErrNoTimedResponses = errors.New("no response times to calibrate")
+
+// minSeconds 作答用时下界，避免对0取对数
+const minSeconds = 0.5
+
+// TimeParams 对数正态作答时间模型的题目参数（van der Linden, 2006）：
+// ln T ~ N(β − τ, 1/α²)，Discrimination 为0表示未标定
+type TimeParams struct {
+	Intensity      float64 // β：时间强度
+	Discrimination float64 // α：时间区分度
+}
+
+// Calibrated 题目是否已有作答时间参数
+func (p TimeParams) Calibrated() bool {
+	return p.Discrimination > 0
+}
+
+// ExpectedSeconds 速度为 tau 的考生的期望作答用时
+func (p TimeParams) ExpectedSeconds(tau float64) float64 {
+	return math.Exp(p.Intensity - tau + 1/(2*p.Discrimination*p.Discrimination))
+}
+
+// Residual 标准化对数用时残差 α(ln t − (β − τ))，负值表示比模型预期更快
+func (p TimeParams) Residual(seconds, tau float64) float64 {
+	return p.Discrimination * (logSeconds(seconds) - (p.Intensity - tau))
+}
+
+// LogDensity 作答用时的对数密度
+func (p TimeParams) LogDensity(seconds, tau float64) float64 {
+	z := p.Residual(seconds, tau)
+	return math.Log(p.Discrimination) - logSeconds(seconds) - 0.5*math.Log(2*math.Pi) - 0.5*z*z
+}
+
+// TimedResponse 单题作答用时及其时间参数
+type TimedResponse struct {
+	QuestionID uint
+	Item       TimeParams
+	Seconds    float64
+}
+
+// SpeedEstimate 考生速度估计
+type SpeedEstimate struct {
+	Tau           float64
+	StandardError float64
+}
+
+// EstimateSpeed 在正态先验 N(priorMean, priorSD²) 下估计考生速度，
+// 对数正态模型与正态先验共轭，后验均值和标准差有闭式解；priorSD 为0时退化为最大似然估计
+func EstimateSpeed(responses []TimedResponse, priorMean, priorSD float64) SpeedEstimate {
+	var precision, weighted float64
+	if priorSD > 0 {
+		precision = 1 / (priorSD * priorSD)
+		weighted = priorMean * precision
+	}
+	for _, r := range responses {
+		if !r.Item.Calibrated() {
+			continue
+		}
+		w := r.Item.Discrimination * r.Item.Discrimination
+		precision += w
+		weighted += w * (r.Item.Intensity - logSeconds(r.Seconds))
+	}
+	if precision == 0 {
+		return SpeedEstimate{Tau: priorMean, StandardError: priorSD}
+	}
+	return SpeedEstimate{Tau: weighted / precision, StandardError: 1 / math.Sqrt(precision)}
+}
+
+// TimeMatrix 作答用时矩阵（秒），行对应考生，列对应题目，未作答为 NaN
+type TimeMatrix struct {
+	QuestionIDs []uint
+	Seconds     [][]float64
+}
+
+// TimeCalibrationConfig 作答时间参数标定配置
+type TimeCalibrationConfig struct {
+	MaxIterations int
+	Tolerance     float64 // 参数最大变化量小于该值时收敛
+	MinResponses  int     // 题目作答数少于该值时不标定
+}
+
+// DefaultTimeCalibrationConfig 返回常用的作答时间标定配置
+func DefaultTimeCalibrationConfig() TimeCalibrationConfig {
+	return TimeCalibrationConfig{
+		MaxIterations: 200,
+		Tolerance:     1e-4,
+		MinResponses:  20,
+	}
+}
+
+// ItemTimeCalibration 单题作答时间参数标定结果
+type ItemTimeCalibration struct {
+	QuestionID       uint
+	Intensity        float64
+	Discrimination   float64
+	IntensitySE      float64
+	DiscriminationSE float64
+	ResponseCount    int
+	Skipped          bool // 作答数不足
+}
+
+// Params 标定得到的时间参数
+func (c ItemTimeCalibration) Params() TimeParams {
+	if c.Skipped {
+		return TimeParams{}
+	}
+	return TimeParams{Intensity: c.Intensity, Discrimination: c.Discrimination}
+}
+
+// TimeCalibrationResult 作答时间模型标定结果
+type TimeCalibrationResult struct {
+	Items      []ItemTimeCalibration
+	Speeds     []SpeedEstimate // 各行考生的速度后验
+	SpeedSD    float64         // 总体速度标准差，速度均值固定为0以识别模型
+	Iterations int
+	Converged  bool
+}
+
+// CalibrateResponseTimes 用 EM 算法标定对数正态作答时间模型：
+// 速度 τ ~ N(0, σ²) 作为随机效应，E 步取 τ 的共轭后验，M 步更新 β、α 和 σ
+func CalibrateResponseTimes(matrix *TimeMatrix, cfg TimeCalibrationConfig) (*TimeCalibrationResult, error) {
+	items := len(matrix.QuestionIDs)
+	result := &TimeCalibrationResult{
+		Items:   make([]ItemTimeCalibration, items),
+		Speeds:  make([]SpeedEstimate, len(matrix.Seconds)),
+		SpeedSD: 1,
+	}
+
+	// 初值：对数用时的题目均值与标准差
+	logTimes := make([][]float64, len(matrix.Seconds))
+	for i, row := range matrix.Seconds {
+		logTimes[i] = make([]float64, items)
+		for j, t := range row {
+			logTimes[i][j] = math.NaN()
+			if !math.IsNaN(t) {
+				logTimes[i][j] = logSeconds(t)
+			}
+		}
+	}
+	calibrated := 0
+	for j, id := range matrix.QuestionIDs {
+		item := &result.Items[j]
+		item.QuestionID = id
+		var sum, sumSq float64
+		for i := range logTimes {
+			if v := logTimes[i][j]; !math.IsNaN(v) {
+				item.ResponseCount++
+				sum += v
+				sumSq += v * v
+			}
+		}
+		if item.ResponseCount < cfg.MinResponses || item.ResponseCount < 2 {
+			item.Skipped = true
+			continue
+		}
+		n := float64(item.ResponseCount)
+		item.Intensity = sum / n
+		item.Discrimination = 1 / math.Sqrt(math.Max(sumSq/n-item.Intensity*item.Intensity, 1e-4))
+		calibrated++
+	}
+	if calibrated == 0 {
+		return nil, ErrNoTimedResponses
+	}
+
+	responses := make([]TimedResponse, 0, items)
+	for result.Iterations < cfg.MaxIterations {
+		result.Iterations++
+
+		// E 步：各考生速度的后验均值与方差
+		for i, row := range matrix.Seconds {
+			responses = responses[:0]
+			for j, t := range row {
+				if !math.IsNaN(t) && !result.Items[j].Skipped {
+					responses = append(responses, TimedResponse{Item: result.Items[j].Params(), Seconds: t})
+				}
+			}
+			result.Speeds[i] = EstimateSpeed(responses, 0, result.SpeedSD)
+		}
+
+		// M 步
+		change := 0.0
+		for j := range result.Items {
+			item := &result.Items[j]
+			if item.Skipped {
+				continue
+			}
+			var sum float64
+			for i := range logTimes {
+				if v := logTimes[i][j]; !math.IsNaN(v) {
+					sum += v + result.Speeds[i].Tau
+				}
+			}
+			n := float64(item.ResponseCount)
+			intensity := sum / n
+			var ss float64
+			for i := range logTimes {
+				if v := logTimes[i][j]; !math.IsNaN(v) {
+					d := v - intensity + result.Speeds[i].Tau
+					se := result.Speeds[i].StandardError
+					ss += d*d + se*se
+				}
+			}
+			discrimination := 1 / math.Sqrt(math.Max(ss/n, 1e-4))
+			change = math.Max(change, math.Max(math.Abs(intensity-item.Intensity), math.Abs(discrimination-item.Discrimination)))
+			item.Intensity = intensity
+			item.Discrimination = discrimination
+			item.IntensitySE = 1 / (discrimination * math.Sqrt(n))
+			item.DiscriminationSE = discrimination / math.Sqrt(2*n)
+		}
+		var variance float64
+		for _, s := range result.Speeds {
+			variance += s.Tau*s.Tau + s.StandardError*s.StandardError
+		}
+		if len(result.Speeds) > 0 {
+			sd := math.Sqrt(math.Max(variance/float64(len(result.Speeds)), 1e-4))
+			change = math.Max(change, math.Abs(sd-result.SpeedSD))
+			result.SpeedSD = sd
+		}
+
+		if change < cfg.Tolerance {
+			result.Converged = true
+			break
+		}
+	}
+	return result, nil
+}
+
+// PersonPopulation 考生能力与速度的二元正态总体分布，
+// 即 van der Linden (2007) 分层框架中的考生层模型
+type PersonPopulation struct {
+	MeanTheta   float64
+	SDTheta     float64
+	MeanSpeed   float64
+	SDSpeed     float64
+	Correlation float64 // 能力与速度的相关
+}
+
+// EstimatePopulation 由能力与速度的后验估计计算总体分布，
+// 后验均值向总体均值收缩，方差加回平均后验方差、相关除以两者的信度以校正测量误差
+func EstimatePopulation(abilities []*AbilityEstimate, speeds []SpeedEstimate) PersonPopulation {
+	n := len(abilities)
+	if n == 0 || len(speeds) != n {
+		return PersonPopulation{SDTheta: 1, SDSpeed: 1}
+	}
+	var pop PersonPopulation
+	for i := range abilities {
+		pop.MeanTheta += abilities[i].Theta
+		pop.MeanSpeed += speeds[i].Tau
+	}
+	pop.MeanTheta /= float64(n)
+	pop.MeanSpeed /= float64(n)
+
+	var varTheta, varSpeed, cov, errTheta, errSpeed float64
+	for i := range abilities {
+		dt := abilities[i].Theta - pop.MeanTheta
+		ds := speeds[i].Tau - pop.MeanSpeed
+		varTheta += dt * dt
+		varSpeed += ds * ds
+		cov += dt * ds
+		errTheta += abilities[i].StandardError * abilities[i].StandardError
+		errSpeed += speeds[i].StandardError * speeds[i].StandardError
+	}
+	varTheta /= float64(n)
+	varSpeed /= float64(n)
+	cov /= float64(n)
+	errTheta /= float64(n)
+	errSpeed /= float64(n)
+
+	pop.SDTheta = math.Sqrt(varTheta + errTheta)
+	pop.SDSpeed = math.Sqrt(varSpeed + errSpeed)
+	relTheta := varTheta / (varTheta + errTheta)
+	relSpeed := varSpeed / (varSpeed + errSpeed)
+	if relTheta > 0 && relSpeed > 0 && pop.SDTheta > 0 && pop.SDSpeed > 0 {
+		rho := cov / (relTheta * relSpeed * pop.SDTheta * pop.SDSpeed)
+		pop.Correlation = math.Max(-0.99, math.Min(0.99, rho))
+	}
+	return pop
+}
+
+// ThetaPrior 给定速度估计时能力的条件正态先验，速度估计的不确定性计入先验方差
+func (p PersonPopulation) ThetaPrior(speed SpeedEstimate) (mean, sd float64) {
+	if p.SDSpeed <= 0 || p.SDTheta <= 0 {
+		return p.MeanTheta, math.Max(p.SDTheta, 1)
+	}
+	slope := p.Correlation * p.SDTheta / p.SDSpeed
+	mean = p.MeanTheta + slope*(speed.Tau-p.MeanSpeed)
+	variance := p.SDTheta*p.SDTheta*(1-p.Correlation*p.Correlation) + slope*slope*speed.StandardError*speed.StandardError
+	return mean, math.Sqrt(variance)
+}
+
+// JointEstimator 以作答速度为附属信息的能力估计器，先验取给定速度时能力的条件分布
+func (p PersonPopulation) JointEstimator(method EstimationMethod, model Model, speed SpeedEstimate) *Estimator {
+	estimator := NewEstimator(method)
+	estimator.Model = model
+	estimator.PriorMean, estimator.PriorSD = p.ThetaPrior(speed)
+	return estimator
+}
+
+// timeAwareSelector 限时测验中按预计用时调整基础选题准则
+type timeAwareSelector struct {
+	base ItemSelector
+}
+
+// NewTimeAwareSelector 在基础选题准则上叠加时间约束：按考生当前速度估计各题预计用时，
+// 预计用时超过剩余时间的题目不可选，超过剩余每题平均可用时间的题目按比例降权；
+// SelectionState.TimeRemaining 为0（不限时）或题目未标定时间参数时不做调整
+func NewTimeAwareSelector(base ItemSelector) ItemSelector {
+	return &timeAwareSelector{base: base}
+}
+
+func (s *timeAwareSelector) Score(candidates []Candidate, state SelectionState) []float64 {
+	scores := s.base.Score(candidates, state)
+	if state.TimeRemaining <= 0 {
+		return scores
+	}
+	remaining := state.TimeRemaining.Seconds()
+	items := 1
+	if state.TestLength > state.ItemsAdministered {
+		items = state.TestLength - state.ItemsAdministered
+	}
+	budget := remaining / float64(items)
+
+	adjusted := normalizeScores(scores)
+	feasible := false
+	for i, c := range candidates {
+		if math.IsInf(adjusted[i], -1) {
+			continue
+		}
+		params := c.timeParams()
+		if !params.Calibrated() {
+			feasible = true
+			continue
+		}
+		expected := params.ExpectedSeconds(state.Speed)
+		if expected > remaining {
+			adjusted[i] = math.Inf(-1)
+			continue
+		}
+		feasible = true
+		if expected > budget {
+			adjusted[i] *= budget / expected
+		}
+	}
+	// 所有题目都来不及作答时不再排除，交给时间上限终止规则处理
+	if !feasible {
+		return scores
+	}
+	return adjusted
+}
+
+// timeParams 候选题目的作答时间参数
+func (c Candidate) timeParams() TimeParams {
+	return TimeParams{Intensity: c.TimeIntensity, Discrimination: c.TimeDiscrimination}
+}
+
+// logSeconds 作答用时的对数，不足 minSeconds 时取下界
+func logSeconds(seconds float64) float64 {
+	return math.Log(math.Max(seconds, minSeconds))
+}
+
+// TimeRemaining 由时间上限与已用时间计算剩余时间，不限时返回0
+func TimeRemaining(limit, elapsed time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+	if elapsed >= limit {
+		return time.Nanosecond
+	}
+	return limit - elapsed
+}
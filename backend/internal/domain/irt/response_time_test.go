@@ -0,0 +1,163 @@
+package irt
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeParamsHandComputed(t *testing.T) {
+	// β = ln 60、α = 2：τ = 0 时对数用时的中位数为 ln 60，期望用时 60·exp(1/8)
+	item := TimeParams{Intensity: math.Log(60), Discrimination: 2}
+	assert.True(t, item.Calibrated())
+	assert.False(t, TimeParams{Intensity: 3}.Calibrated())
+	assert.InDelta(t, 60*math.Exp(0.125), item.ExpectedSeconds(0), 1e-9)
+	assert.InDelta(t, 60*math.Exp(0.125-0.5), item.ExpectedSeconds(0.5), 1e-9)
+
+	// 残差 α(ln t − (β − τ))：用时为中位数的 e 倍时残差为 α
+	assert.InDelta(t, 0, item.Residual(60, 0), 1e-12)
+	assert.InDelta(t, 2, item.Residual(60*math.E, 0), 1e-12)
+	assert.InDelta(t, item.Residual(60, 0.5), item.Residual(60*math.Exp(0.5), 0), 1e-12)
+
+	// 对数密度在用时上积分为1
+	var total float64
+	for s := 0.5; s < 1000; s += 0.01 {
+		total += math.Exp(item.LogDensity(s, 0)) * 0.01
+	}
+	assert.InDelta(t, 1.0, total, 1e-3)
+}
+
+func TestEstimateSpeedClosedForm(t *testing.T) {
+	// 共轭后验：精度 1/σ² + Σα²，均值为先验均值与 β − ln t 的精度加权平均
+	responses := []TimedResponse{
+		{QuestionID: 1, Item: TimeParams{Intensity: math.Log(40), Discrimination: 1}, Seconds: 20},
+		{QuestionID: 2, Item: TimeParams{Intensity: math.Log(90), Discrimination: 2}, Seconds: 45},
+		{QuestionID: 3, Item: TimeParams{}, Seconds: 1},
+	}
+	estimate := EstimateSpeed(responses, 0, 1)
+	assert.InDelta(t, 5*math.Log(2)/6, estimate.Tau, 1e-12)
+	assert.InDelta(t, 1/math.Sqrt(6), estimate.StandardError, 1e-12)
+
+	// 不带先验时为最大似然估计
+	estimate = EstimateSpeed(responses, 0, 0)
+	assert.InDelta(t, math.Log(2), estimate.Tau, 1e-12)
+	assert.InDelta(t, 1/math.Sqrt(5), estimate.StandardError, 1e-12)
+
+	// 没有已标定的题目时返回先验
+	assert.Equal(t, SpeedEstimate{Tau: 0.3, StandardError: 0.8}, EstimateSpeed(responses[2:], 0.3, 0.8))
+}
+
+// timeData 按对数正态模型生成作答用时矩阵，speeds 为考生真实速度
+func timeData(rng *rand.Rand, items []TimeParams, speeds []float64) *TimeMatrix {
+	matrix := &TimeMatrix{QuestionIDs: make([]uint, len(items)), Seconds: make([][]float64, len(speeds))}
+	for j := range items {
+		matrix.QuestionIDs[j] = uint(j + 1)
+	}
+	for i, tau := range speeds {
+		row := make([]float64, len(items))
+		for j, item := range items {
+			row[j] = math.Exp(item.Intensity - tau + rng.NormFloat64()/item.Discrimination)
+		}
+		matrix.Seconds[i] = row
+	}
+	return matrix
+}
+
+func TestCalibrateResponseTimesRecoversParameters(t *testing.T) {
+	rng := rand.New(rand.NewSource(43))
+	items := make([]TimeParams, 15)
+	for j := range items {
+		items[j] = TimeParams{Intensity: 3 + rng.Float64(), Discrimination: 1.2 + rng.Float64()}
+	}
+	speeds := make([]float64, 1500)
+	for i := range speeds {
+		speeds[i] = 0.4 * rng.NormFloat64()
+	}
+	matrix := timeData(rng, items, speeds)
+	// 第15题只有10人作答，不参与标定
+	for i := 10; i < len(matrix.Seconds); i++ {
+		matrix.Seconds[i][14] = math.NaN()
+	}
+
+	result, err := CalibrateResponseTimes(matrix, DefaultTimeCalibrationConfig())
+	require.NoError(t, err)
+	assert.True(t, result.Converged)
+	assert.InDelta(t, 0.4, result.SpeedSD, 0.03)
+
+	for j, item := range result.Items[:14] {
+		assert.False(t, item.Skipped)
+		assert.InDelta(t, items[j].Intensity, item.Intensity, 4*item.IntensitySE+0.05, "question %d", item.QuestionID)
+		assert.InDelta(t, items[j].Discrimination, item.Discrimination, 0.15, "question %d", item.QuestionID)
+	}
+	assert.True(t, result.Items[14].Skipped)
+	assert.Equal(t, 10, result.Items[14].ResponseCount)
+	assert.Equal(t, TimeParams{}, result.Items[14].Params())
+
+	// 速度后验与真实速度高度相关
+	var cov, varTrue, varEst float64
+	for i, s := range result.Speeds {
+		cov += s.Tau * speeds[i]
+		varTrue += speeds[i] * speeds[i]
+		varEst += s.Tau * s.Tau
+	}
+	assert.Greater(t, cov/math.Sqrt(varTrue*varEst), 0.9)
+
+	_, err = CalibrateResponseTimes(&TimeMatrix{QuestionIDs: []uint{1}, Seconds: [][]float64{{30}}}, DefaultTimeCalibrationConfig())
+	assert.ErrorIs(t, err, ErrNoTimedResponses)
+}
+
+func TestPersonPopulationConditionalPrior(t *testing.T) {
+	// 无测量误差时总体参数即样本矩
+	abilities := []*AbilityEstimate{{Theta: -1}, {Theta: 0}, {Theta: 1}, {Theta: 2}}
+	speeds := []SpeedEstimate{{Tau: -0.5}, {Tau: 0}, {Tau: 0.5}, {Tau: 1}}
+	pop := EstimatePopulation(abilities, speeds)
+	assert.InDelta(t, 0.5, pop.MeanTheta, 1e-12)
+	assert.InDelta(t, 0.25, pop.MeanSpeed, 1e-12)
+	assert.InDelta(t, math.Sqrt(1.25), pop.SDTheta, 1e-12)
+	assert.InDelta(t, 0.99, pop.Correlation, 1e-12)
+
+	// 条件先验：均值 μθ + ρσθ/στ·(τ − μτ)，方差 σθ²(1−ρ²) 加上速度估计误差的贡献
+	pop = PersonPopulation{SDTheta: 1, SDSpeed: 0.5, Correlation: 0.4}
+	mean, sd := pop.ThetaPrior(SpeedEstimate{Tau: 0.5})
+	assert.InDelta(t, 0.4, mean, 1e-12)
+	assert.InDelta(t, math.Sqrt(0.84), sd, 1e-12)
+	_, sd = pop.ThetaPrior(SpeedEstimate{Tau: 0.5, StandardError: 0.5})
+	assert.InDelta(t, 1.0, sd, 1e-12)
+
+	estimator := pop.JointEstimator(MethodEAP, DefaultModel(), SpeedEstimate{Tau: 0.5})
+	assert.InDelta(t, 0.4, estimator.PriorMean, 1e-12)
+
+	assert.Equal(t, PersonPopulation{SDTheta: 1, SDSpeed: 1}, EstimatePopulation(nil, nil))
+}
+
+func TestTimeAwareSelectorDropsSlowItems(t *testing.T) {
+	// 三道信息量相同的题目，预计用时约 680、68、23 秒
+	base := TimeParams{Intensity: math.Log(20), Discrimination: 2}
+	candidates := []Candidate{
+		{QuestionID: 1, Discrimination: 1, TimeIntensity: base.Intensity + math.Log(30), TimeDiscrimination: 2},
+		{QuestionID: 2, Discrimination: 1, TimeIntensity: base.Intensity + math.Log(3), TimeDiscrimination: 2},
+		{QuestionID: 3, Discrimination: 1, TimeIntensity: base.Intensity, TimeDiscrimination: 2},
+	}
+	selector := NewTimeAwareSelector(maxInfoSelector{})
+	state := SelectionState{Model: Model{Family: Model2PL, D: ScalingLogistic}, TimeRemaining: 2 * time.Minute, TestLength: 4}
+
+	// 剩余2分钟、4题：第1题来不及作答，第2题超过每题30秒的平均可用时间而降权
+	scores := selector.Score(candidates, state)
+	assert.True(t, math.IsInf(scores[0], -1))
+	assert.Less(t, scores[1], scores[2])
+	idx, err := SelectItem(selector, candidates, state)
+	require.NoError(t, err)
+	assert.Equal(t, 2, idx)
+
+	// 不限时不调整
+	state.TimeRemaining = 0
+	assert.Equal(t, maxInfoSelector{}.Score(candidates, state), selector.Score(candidates, state))
+
+	assert.Equal(t, time.Duration(0), TimeRemaining(0, time.Minute))
+	assert.Equal(t, time.Nanosecond, TimeRemaining(time.Minute, time.Hour))
+	assert.Equal(t, 30*time.Second, TimeRemaining(time.Minute, 30*time.Second))
+}
@@ -0,0 +1,139 @@
+package irt
+
+import (
+	"errors"
+	"math"
+)
+
+// ScaleTransform 能力值到报告分数的转换方式
+type ScaleTransform string
+
+const (
+	ScaleLinear    ScaleTransform = "linear"    // 线性转换 Slope·θ + Intercept
+	ScaleNonlinear ScaleTransform = "nonlinear" // 按转换表分段线性插值，表外按两端线段外推
+)
+
+// RoundingMode 报告分数的取整方式
+type RoundingMode string
+
+const (
+	RoundNearest RoundingMode = "nearest"
+	RoundFloor   RoundingMode = "floor"
+	RoundCeil    RoundingMode = "ceil"
+)
+
+var (
+	ErrUnknownScaleTransform = errors.New("unknown scale transform")
+	ErrUnknownRoundingMode   = errors.New("unknown rounding mode")
+	ErrInvalidScaleSlope     = errors.New("linear scale slope must be positive")
+	ErrInvalidScalePoints    = errors.New("conversion table needs at least two points with increasing theta and non-decreasing score")
+	ErrInvalidScaleRange     = errors.New("scale minimum must be below maximum")
+)
+
+// ScalePoint 转换表中的一个点
+type ScalePoint struct {
+	Theta float64
+	Score float64
+}
+
+// ReportingScale 能力值 θ 到报告分数的单调转换
+type ReportingScale struct {
+	Transform ScaleTransform
+	Slope     float64
+	Intercept float64
+	Points    []ScalePoint // 非线性转换表，按 θ 升序
+
+	RoundingStep float64 // 取整步长，如 1 或 0.5，0 表示不取整
+	Rounding     RoundingMode
+
+	// 报告分数的上下限，Min ≥ Max 时不截断
+	Min float64
+	Max float64
+}
+
+// Validate 检查转换参数
+func (s ReportingScale) Validate() error {
+	switch s.Transform {
+	case ScaleLinear:
+		if s.Slope <= 0 {
+			return ErrInvalidScaleSlope
+		}
+	case ScaleNonlinear:
+		if len(s.Points) < 2 {
+			return ErrInvalidScalePoints
+		}
+		for k := 1; k < len(s.Points); k++ {
+			if s.Points[k].Theta <= s.Points[k-1].Theta || s.Points[k].Score < s.Points[k-1].Score {
+				return ErrInvalidScalePoints
+			}
+		}
+	default:
+		return ErrUnknownScaleTransform
+	}
+	switch s.Rounding {
+	case "", RoundNearest, RoundFloor, RoundCeil:
+	default:
+		return ErrUnknownRoundingMode
+	}
+	if s.RoundingStep < 0 {
+		return ErrUnknownRoundingMode
+	}
+	if s.Min > s.Max {
+		return ErrInvalidScaleRange
+	}
+	return nil
+}
+
+// Unrounded 未取整的报告分数，已按上下限截断
+func (s ReportingScale) Unrounded(theta float64) float64 {
+	var score float64
+	if s.Transform == ScaleNonlinear && len(s.Points) >= 2 {
+		k := 1
+		for k < len(s.Points)-1 && theta > s.Points[k].Theta {
+			k++
+		}
+		lo, hi := s.Points[k-1], s.Points[k]
+		score = lo.Score + (theta-lo.Theta)*(hi.Score-lo.Score)/(hi.Theta-lo.Theta)
+	} else {
+		score = s.Slope*theta + s.Intercept
+	}
+	if s.Min < s.Max {
+		score = math.Max(s.Min, math.Min(s.Max, score))
+	}
+	return score
+}
+
+// Score 取整后的报告分数
+func (s ReportingScale) Score(theta float64) float64 {
+	return s.Round(s.Unrounded(theta))
+}
+
+// Round 按取整步长与方式取整，取整后仍不超出上下限
+func (s ReportingScale) Round(score float64) float64 {
+	if s.RoundingStep <= 0 {
+		return score
+	}
+	x := score / s.RoundingStep
+	switch s.Rounding {
+	case RoundFloor:
+		x = math.Floor(x + 1e-9)
+	case RoundCeil:
+		x = math.Ceil(x - 1e-9)
+	default:
+		x = math.Round(x)
+	}
+	rounded := x * s.RoundingStep
+	if s.Min < s.Max {
+		rounded = math.Max(s.Min, math.Min(s.Max, rounded))
+	}
+	return rounded
+}
+
+// StandardError 报告分数上的条件标准误：取 θ±SE 处未取整分数差的一半，
+// 线性转换时即 Slope·SE，非线性转换时为局部斜率乘以 SE
+func (s ReportingScale) StandardError(theta, standardError float64) float64 {
+	if standardError <= 0 || math.IsInf(standardError, 0) || math.IsNaN(standardError) {
+		return standardError
+	}
+	return (s.Unrounded(theta+standardError) - s.Unrounded(theta-standardError)) / 2
+}
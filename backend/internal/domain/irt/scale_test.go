@@ -0,0 +1,91 @@
+package irt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinearReportingScale(t *testing.T) {
+	scale := ReportingScale{Transform: ScaleLinear, Slope: 100, Intercept: 500, RoundingStep: 10, Min: 200, Max: 800}
+	assert.NoError(t, scale.Validate())
+
+	assert.InDelta(t, 623, scale.Unrounded(1.23), 1e-9)
+	assert.Equal(t, 620.0, scale.Score(1.23))
+	assert.Equal(t, 630.0, scale.Score(1.25))
+
+	// 超出上下限时截断，取整后仍在范围内
+	assert.Equal(t, 800.0, scale.Score(3.5))
+	assert.Equal(t, 200.0, scale.Score(-4))
+
+	// 线性转换的标准误为 Slope·SE，截断区域内标准误缩小
+	assert.InDelta(t, 30, scale.StandardError(0, 0.3), 1e-9)
+	assert.InDelta(t, 15, scale.StandardError(3, 0.3), 1e-9)
+	assert.True(t, math.IsInf(scale.StandardError(0, math.Inf(1)), 1))
+}
+
+func TestRoundingModes(t *testing.T) {
+	scale := ReportingScale{Transform: ScaleLinear, Slope: 1, RoundingStep: 0.5}
+	tests := []struct {
+		mode RoundingMode
+		want []float64
+	}{
+		{RoundNearest, []float64{1, 1.5, -1}},
+		{RoundFloor, []float64{1, 1, -1.5}},
+		{RoundCeil, []float64{1.5, 1.5, -1}},
+		{"", []float64{1, 1.5, -1}},
+	}
+	for _, tt := range tests {
+		scale.Rounding = tt.mode
+		got := []float64{scale.Round(1.2), scale.Round(1.3), scale.Round(-1.2)}
+		assert.Equal(t, tt.want, got, "mode %q", tt.mode)
+	}
+
+	// 浮点误差不影响恰好落在步长上的分数
+	scale.Rounding = RoundFloor
+	assert.Equal(t, 1.5, scale.Round(0.1*15))
+	scale.Rounding = RoundCeil
+	assert.Equal(t, 1.5, scale.Round(0.1*15))
+
+	scale.RoundingStep = 0
+	assert.Equal(t, 1.23, scale.Round(1.23))
+}
+
+func TestNonlinearReportingScale(t *testing.T) {
+	scale := ReportingScale{
+		Transform: ScaleNonlinear,
+		Points:    []ScalePoint{{Theta: -2, Score: 0}, {Theta: 0, Score: 40}, {Theta: 1, Score: 80}},
+	}
+	assert.NoError(t, scale.Validate())
+
+	// 表内分段线性插值，表外按两端线段外推
+	assert.InDelta(t, 20, scale.Unrounded(-1), 1e-9)
+	assert.InDelta(t, 60, scale.Unrounded(0.5), 1e-9)
+	assert.InDelta(t, -20, scale.Unrounded(-3), 1e-9)
+	assert.InDelta(t, 120, scale.Unrounded(2), 1e-9)
+
+	// 标准误取局部斜率：第一段斜率20，第二段斜率40
+	assert.InDelta(t, 4, scale.StandardError(-1, 0.2), 1e-9)
+	assert.InDelta(t, 8, scale.StandardError(0.5, 0.2), 1e-9)
+	assert.InDelta(t, 9, scale.StandardError(0, 0.3), 1e-9)
+}
+
+func TestReportingScaleValidate(t *testing.T) {
+	tests := []struct {
+		scale ReportingScale
+		err   error
+	}{
+		{ReportingScale{Transform: "log"}, ErrUnknownScaleTransform},
+		{ReportingScale{Transform: ScaleLinear}, ErrInvalidScaleSlope},
+		{ReportingScale{Transform: ScaleNonlinear, Points: []ScalePoint{{0, 1}}}, ErrInvalidScalePoints},
+		{ReportingScale{Transform: ScaleNonlinear, Points: []ScalePoint{{0, 1}, {0, 2}}}, ErrInvalidScalePoints},
+		{ReportingScale{Transform: ScaleNonlinear, Points: []ScalePoint{{0, 2}, {1, 1}}}, ErrInvalidScalePoints},
+		{ReportingScale{Transform: ScaleLinear, Slope: 1, Rounding: "banker"}, ErrUnknownRoundingMode},
+		{ReportingScale{Transform: ScaleLinear, Slope: 1, RoundingStep: -1}, ErrUnknownRoundingMode},
+		{ReportingScale{Transform: ScaleLinear, Slope: 1, Min: 10, Max: 0}, ErrInvalidScaleRange},
+	}
+	for _, tt := range tests {
+		assert.ErrorIs(t, tt.scale.Validate(), tt.err)
+	}
+}
@@ -0,0 +1,255 @@
+package irt
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// SelectionStrategy 自适应选题策略
+type SelectionStrategy string
+
+const (
+	StrategyMaxInfo     SelectionStrategy = "max_info"     // 最大Fisher信息量
+	StrategyKL          SelectionStrategy = "kl"           // Kullback–Leibler 全局信息
+	StrategyAStratified SelectionStrategy = "a_stratified" // 按区分度分层
+	StrategyBMatching   SelectionStrategy = "b_matching"   // 难度匹配
+	StrategyDOptimal    SelectionStrategy = "d_optimal"    // 多维 D 最优：使测验信息矩阵行列式最大
+)
+
+var (
+	ErrNoCandidates    = errors.New("no candidate items left to select")
+	ErrUnknownStrategy = errors.New("unknown item selection strategy")
+)
+
+// Candidate 候选题目及其题目参数，多级计分题的 Difficulty 取阈值均值作为位置参数
+type Candidate struct {
+	QuestionID     uint
+	Difficulty     float64
+	Discrimination float64
+	Guessing       float64
+	UpperAsymptote float64 // 4PL 上渐近线，0表示1
+
+	// 多级计分模型
+	Model      ItemModel
+	Thresholds []float64
+
+	// 内容属性，用于内容平衡
+	KnowledgePointIDs []uint
+	QuestionType      string
+
+	// 对数正态作答时间模型参数，用于限时选题，TimeDiscrimination 为0表示未标定
+	TimeIntensity      float64
+	TimeDiscrimination float64
+
+	// 补偿型多维模型参数，用于 D 最优选题，Loadings 为空表示题目未做多维标定
+	Loadings  []float64
+	Intercept float64
+}
+
+// SelectionState 选题时的会话状态
+type SelectionState struct {
+	Theta             float64
+	StandardError     float64
+	ItemsAdministered int
+	TestLength        int   // 计划测验长度，用于分层选题
+	Model             Model // 科目项目反应模型，零值为3PL
+
+	// 限时测验，TimeRemaining 为0表示不限时
+	Speed         float64       // 考生作答速度估计 τ
+	TimeRemaining time.Duration // 剩余作答时间
+
+	// 多维能力估计及其后验信息矩阵（含先验精度），ThetaVector 为空时 D 最优退化为最大信息量
+	ThetaVector       []float64
+	InformationMatrix [][]float64
+}
+
+// ItemSelector 选题准则，为每道候选题打分，分数越高越优先
+// 不可选的题目返回 -Inf
+type ItemSelector interface {
+	Score(candidates []Candidate, state SelectionState) []float64
+}
+
+// NewItemSelector 根据策略名称创建选题器，空字符串使用最大信息量
+func NewItemSelector(strategy SelectionStrategy) (ItemSelector, error) {
+	switch strategy {
+	case StrategyMaxInfo, "":
+		return maxInfoSelector{}, nil
+	case StrategyKL:
+		return klSelector{}, nil
+	case StrategyAStratified:
+		return aStratifiedSelector{strata: 4}, nil
+	case StrategyBMatching:
+		return bMatchingSelector{}, nil
+	case StrategyDOptimal:
+		return dOptimalSelector{}, nil
+	default:
+		return nil, ErrUnknownStrategy
+	}
+}
+
+// RankedCandidate 带准则分数的候选题目
+type RankedCandidate struct {
+	Index int // 在候选列表中的下标
+	Score float64
+}
+
+// RankCandidates 按准则分数从高到低排列可选题目
+func RankCandidates(selector ItemSelector, candidates []Candidate, state SelectionState) []RankedCandidate {
+	scores := selector.Score(candidates, state)
+	ranked := make([]RankedCandidate, 0, len(candidates))
+	for i, score := range scores {
+		if math.IsInf(score, -1) || math.IsNaN(score) {
+			continue
+		}
+		ranked = append(ranked, RankedCandidate{Index: i, Score: score})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// SelectItem 返回准则分数最高的候选题目下标
+func SelectItem(selector ItemSelector, candidates []Candidate, state SelectionState) (int, error) {
+	ranked := RankCandidates(selector, candidates, state)
+	if len(ranked) == 0 {
+		return -1, ErrNoCandidates
+	}
+	return ranked[0].Index, nil
+}
+
+// maxInfoSelector 选择当前能力值处信息量最大的题目
+type maxInfoSelector struct{}
+
+func (maxInfoSelector) Score(candidates []Candidate, state SelectionState) []float64 {
+	scores := make([]float64, len(candidates))
+	for i, c := range candidates {
+		scores[i] = state.Model.candidateInformation(state.Theta, c)
+	}
+	return scores
+}
+
+// klSelector 在能力值置信区间上积分KL信息量（Chang & Ying, 1996）
+// 测验初期能力估计不稳定时比点信息量更稳健
+type klSelector struct{}
+
+func (klSelector) Score(candidates []Candidate, state SelectionState) []float64 {
+	const points = 21
+	delta := 3.0
+	if state.ItemsAdministered > 0 {
+		delta = 3 / math.Sqrt(float64(state.ItemsAdministered))
+	}
+	step := 2 * delta / float64(points-1)
+
+	scores := make([]float64, len(candidates))
+	for i, c := range candidates {
+		p0 := state.Model.candidateProbabilities(state.Theta, c)
+		var sum float64
+		for k := 0; k < points; k++ {
+			theta := state.Theta - delta + float64(k)*step
+			// 对各得分类别求和，3PL 时即二项分布的KL散度
+			for x, p := range state.Model.candidateProbabilities(theta, c) {
+				q0 := boundProbability(p0[x])
+				sum += q0 * math.Log(q0/boundProbability(p))
+			}
+		}
+		scores[i] = sum * step
+	}
+	return scores
+}
+
+// aStratifiedSelector 按区分度从低到高分层，测验前期使用低区分度层，
+// 层内按难度匹配选题（Chang & Ying, 1999）
+type aStratifiedSelector struct {
+	strata int
+}
+
+func (s aStratifiedSelector) Score(candidates []Candidate, state SelectionState) []float64 {
+	scores := make([]float64, len(candidates))
+	if len(candidates) == 0 {
+		return scores
+	}
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return candidates[order[i]].Discrimination < candidates[order[j]].Discrimination
+	})
+
+	strata := s.strata
+	if strata > len(candidates) {
+		strata = len(candidates)
+	}
+	current := strata - 1
+	if state.TestLength > 0 {
+		current = state.ItemsAdministered * strata / state.TestLength
+		if current >= strata {
+			current = strata - 1
+		}
+	}
+
+	for rank, idx := range order {
+		stratum := rank * strata / len(candidates)
+		if stratum != current {
+			scores[idx] = math.Inf(-1)
+			continue
+		}
+		scores[idx] = -math.Abs(candidates[idx].Difficulty - state.Theta)
+	}
+	return scores
+}
+
+// bMatchingSelector 选择难度最接近当前能力值的题目
+type bMatchingSelector struct{}
+
+func (bMatchingSelector) Score(candidates []Candidate, state SelectionState) []float64 {
+	scores := make([]float64, len(candidates))
+	for i, c := range candidates {
+		scores[i] = -math.Abs(c.Difficulty - state.Theta)
+	}
+	return scores
+}
+
+// dOptimalSelector 多维 D 最优选题：选择加入后使后验信息矩阵行列式最大的题目，
+// 即能力向量置信椭球体积缩小最多；未做多维标定的题目不可选
+type dOptimalSelector struct{}
+
+func (dOptimalSelector) Score(candidates []Candidate, state SelectionState) []float64 {
+	if len(state.ThetaVector) == 0 {
+		return maxInfoSelector{}.Score(candidates, state)
+	}
+	dim := len(state.ThetaVector)
+	base := state.InformationMatrix
+	if len(base) != dim {
+		base = newMatrix(dim)
+		for k := range base {
+			base[k][k] = 1
+		}
+	}
+
+	scores := make([]float64, len(candidates))
+	for i, c := range candidates {
+		if len(c.Loadings) != dim {
+			scores[i] = math.Inf(-1)
+			continue
+		}
+		info := state.Model.MultiInformation(state.ThetaVector, MultiItem{
+			QuestionID:     c.QuestionID,
+			Discrimination: c.Loadings,
+			Intercept:      c.Intercept,
+			Guessing:       c.Guessing,
+		})
+		addMatrix(info, base)
+		logDet, ok := logDeterminant(info)
+		if !ok {
+			scores[i] = math.Inf(-1)
+			continue
+		}
+		scores[i] = logDet
+	}
+	return scores
+}
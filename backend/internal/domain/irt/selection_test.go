@@ -0,0 +1,107 @@
+package irt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func selectWith(t *testing.T, strategy SelectionStrategy, candidates []Candidate, state SelectionState) uint {
+	selector, err := NewItemSelector(strategy)
+	require.NoError(t, err)
+	idx, err := SelectItem(selector, candidates, state)
+	require.NoError(t, err)
+	return candidates[idx].QuestionID
+}
+
+func TestMaxInfoSelectsMostInformativeItem(t *testing.T) {
+	state := SelectionState{Theta: 0.5, Model: Model{Family: Model2PL, D: ScalingLogistic}}
+	candidates := []Candidate{
+		{QuestionID: 1, Difficulty: 0.5, Discrimination: 1},
+		{QuestionID: 2, Difficulty: 0.5, Discrimination: 2},
+		{QuestionID: 3, Difficulty: 3, Discrimination: 2.2},
+	}
+	assert.Equal(t, uint(2), selectWith(t, StrategyMaxInfo, candidates, state))
+
+	// 2PL 信息量 I = a²·P·Q，难度等于能力值时 P = 1/2
+	scores := maxInfoSelector{}.Score(candidates, state)
+	assert.InDelta(t, 0.25, scores[0], 1e-12)
+	assert.InDelta(t, 1.0, scores[1], 1e-12)
+
+	// 空策略名使用最大信息量
+	assert.Equal(t, uint(2), selectWith(t, "", candidates, state))
+}
+
+func TestKLPrefersItemsNearAbility(t *testing.T) {
+	candidates := []Candidate{
+		{QuestionID: 1, Difficulty: 2.5, Discrimination: 1.2},
+		{QuestionID: 2, Difficulty: 0, Discrimination: 1.2},
+		{QuestionID: 3, Difficulty: -2.5, Discrimination: 1.2},
+	}
+	for _, administered := range []int{0, 20} {
+		state := SelectionState{Theta: 0, ItemsAdministered: administered}
+		assert.Equal(t, uint(2), selectWith(t, StrategyKL, candidates, state))
+
+		for _, score := range (klSelector{}).Score(candidates, state) {
+			assert.Greater(t, score, 0.0)
+		}
+	}
+
+	// 积分区间随施测题数收窄，KL 信息量随之减小
+	early := klSelector{}.Score(candidates, SelectionState{Theta: 0})
+	late := klSelector{}.Score(candidates, SelectionState{Theta: 0, ItemsAdministered: 25})
+	assert.Greater(t, early[1], late[1])
+}
+
+func TestAStratifiedUsesLowDiscriminationFirst(t *testing.T) {
+	// 8题分4层，每层2题；层内按难度匹配
+	candidates := []Candidate{
+		{QuestionID: 1, Discrimination: 2.0, Difficulty: 0.1},
+		{QuestionID: 2, Discrimination: 0.5, Difficulty: 1.0},
+		{QuestionID: 3, Discrimination: 1.2, Difficulty: 0},
+		{QuestionID: 4, Discrimination: 0.6, Difficulty: 0.2},
+		{QuestionID: 5, Discrimination: 1.8, Difficulty: 1.5},
+		{QuestionID: 6, Discrimination: 0.9, Difficulty: 0},
+		{QuestionID: 7, Discrimination: 1.0, Difficulty: 0.4},
+		{QuestionID: 8, Discrimination: 1.4, Difficulty: -1},
+	}
+
+	state := SelectionState{Theta: 0, TestLength: 8}
+	scores := aStratifiedSelector{strata: 4}.Score(candidates, state)
+	for i, score := range scores {
+		inFirstStratum := candidates[i].QuestionID == 2 || candidates[i].QuestionID == 4
+		assert.Equal(t, !inFirstStratum, math.IsInf(score, -1), "question %d", candidates[i].QuestionID)
+	}
+	assert.Equal(t, uint(4), selectWith(t, StrategyAStratified, candidates, state))
+
+	// 测验后期进入高区分度层
+	state.ItemsAdministered = 7
+	assert.Equal(t, uint(1), selectWith(t, StrategyAStratified, candidates, state))
+
+	// 未设置测验长度时使用最高层
+	assert.Equal(t, uint(1), selectWith(t, StrategyAStratified, candidates, SelectionState{Theta: 0}))
+}
+
+func TestBMatchingSelectsClosestDifficulty(t *testing.T) {
+	candidates := []Candidate{
+		{QuestionID: 1, Difficulty: -1, Discrimination: 2},
+		{QuestionID: 2, Difficulty: 0.9, Discrimination: 0.5},
+		{QuestionID: 3, Difficulty: 2, Discrimination: 1},
+	}
+	state := SelectionState{Theta: 1}
+	assert.Equal(t, uint(2), selectWith(t, StrategyBMatching, candidates, state))
+
+	scores := bMatchingSelector{}.Score(candidates, state)
+	assert.InDelta(t, -2.0, scores[0], 1e-12)
+	assert.InDelta(t, -1.0, scores[2], 1e-12)
+}
+
+func TestSelectionErrors(t *testing.T) {
+	_, err := NewItemSelector("random")
+	assert.ErrorIs(t, err, ErrUnknownStrategy)
+
+	_, err = SelectItem(maxInfoSelector{}, nil, SelectionState{})
+	assert.ErrorIs(t, err, ErrNoCandidates)
+}
@@ -0,0 +1,343 @@
+package irt
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ThetaDistribution 模拟考生真实能力的抽样分布
+type ThetaDistribution string
+
+const (
+	ThetaNormal  ThetaDistribution = "normal"  // 正态分布 N(Mean, SD²)
+	ThetaUniform ThetaDistribution = "uniform" // [Min, Max] 上的均匀分布
+)
+
+var ErrUnknownThetaDistribution = errors.New("unknown theta distribution")
+
+// CATSimulationConfig 自适应测验 Monte Carlo 模拟配置；
+// 选题、曝光控制、能力估计与终止判断与线上会话使用同一套实现，时间上限不参与模拟
+type CATSimulationConfig struct {
+	Examinees    int
+	Distribution ThetaDistribution
+	Mean         float64
+	SD           float64
+	Min          float64
+	Max          float64
+
+	Strategy           SelectionStrategy
+	Blueprint          *Blueprint // 内容蓝图，为空时不做内容平衡
+	ExposureMethod     ExposureMethod
+	RandomesqueSize    int
+	ExposureParameters map[uint]float64 // Sympson–Hetter 曝光参数
+	Estimation         EstimationMethod
+	Stopping           StoppingRule
+	Model              Model
+
+	BinWidth float64 // 条件统计量的真实能力分组宽度，分组范围为 [-3, 3]，范围以外的考生计入两端分组
+	Seed     int64
+}
+
+// DefaultCATSimulationConfig 返回与线上默认配置一致的模拟配置
+func DefaultCATSimulationConfig() CATSimulationConfig {
+	return CATSimulationConfig{
+		Examinees:    1000,
+		Distribution: ThetaNormal,
+		SD:           1,
+		Min:          -3,
+		Max:          3,
+		Strategy:     StrategyMaxInfo,
+		Estimation:   MethodEAP,
+		Stopping:     DefaultStoppingRule(),
+		Model:        DefaultModel(),
+		BinWidth:     0.5,
+		Seed:         1,
+	}
+}
+
+// ConditionalPerformance 真实能力分组内的估计精度
+type ConditionalPerformance struct {
+	ThetaMin       float64
+	ThetaMax       float64
+	Examinees      int
+	Bias           float64
+	RMSE           float64
+	MeanSE         float64 // 条件标准误：组内最终标准误的平均
+	MeanTestLength float64
+}
+
+// SimulatedExposure 单题在模拟中的曝光率
+type SimulatedExposure struct {
+	QuestionID   uint
+	Administered int
+	Rate         float64
+}
+
+// CATSimulationResult 自适应测验模拟结果
+type CATSimulationResult struct {
+	Examinees      int
+	Bias           float64
+	RMSE           float64
+	Correlation    float64 // 真实能力与估计值的相关
+	MeanSE         float64
+	MeanTestLength float64
+	MinTestLength  int
+	MaxTestLength  int
+	StopReasons    map[StopReason]int
+
+	// 分类终止或设置了划界分数时，按最终分类（未分类终止时比较能力估计与划界分数）计算的分类正确率
+	ClassificationAccuracy float64
+
+	Conditional     []ConditionalPerformance
+	Exposure        []SimulatedExposure // 按曝光率降序
+	MaxExposureRate float64
+	UnusedItems     int // 从未被施测的题目数
+}
+
+// simulatedExaminee 单个模拟考生的结果
+type simulatedExaminee struct {
+	theta    float64
+	estimate *AbilityEstimate
+	length   int
+	decision StopDecision
+}
+
+// SimulateCAT 对题库运行 Monte Carlo 自适应测验模拟：从指定分布抽取真实能力，
+// 逐题选题、按真实能力抽取作答、估计能力并判断终止，汇总偏差、RMSE、测验长度、条件标准误与曝光率
+func SimulateCAT(bank []Candidate, cfg CATSimulationConfig) (*CATSimulationResult, error) {
+	if len(bank) == 0 {
+		return nil, ErrNoCandidates
+	}
+	switch cfg.Distribution {
+	case ThetaNormal, ThetaUniform:
+	default:
+		return nil, ErrUnknownThetaDistribution
+	}
+	selector, err := NewItemSelector(cfg.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	exposure, err := NewExposureControl(cfg.ExposureMethod, cfg.RandomesqueSize, cfg.ExposureParameters, rng)
+	if err != nil {
+		return nil, err
+	}
+	estimator := NewEstimator(cfg.Estimation)
+	estimator.Model = cfg.Model
+	cfg.Stopping.Model = cfg.Model
+
+	administered := make([]int, len(bank))
+	examinees := make([]simulatedExaminee, 0, cfg.Examinees)
+	for n := 0; n < cfg.Examinees; n++ {
+		var theta float64
+		if cfg.Distribution == ThetaUniform {
+			theta = cfg.Min + (cfg.Max-cfg.Min)*rng.Float64()
+		} else {
+			theta = cfg.Mean + cfg.SD*rng.NormFloat64()
+		}
+		examinee, items, err := runAdaptiveTest(theta, bank, selector, exposure, estimator, cfg, rng)
+		if err != nil {
+			return nil, err
+		}
+		for _, idx := range items {
+			administered[idx]++
+		}
+		examinees = append(examinees, examinee)
+	}
+	return summarizeSimulation(bank, examinees, administered, cfg), nil
+}
+
+// runAdaptiveTest 按线上会话的流程施测一名模拟考生：初始能力为0，每题作答后以全部作答重新估计能力并检查终止规则
+func runAdaptiveTest(theta float64, bank []Candidate, selector ItemSelector, exposure *ExposureControl, estimator *Estimator, cfg CATSimulationConfig, rng *rand.Rand) (simulatedExaminee, []int, error) {
+	examinee := simulatedExaminee{theta: theta}
+	used := make([]bool, len(bank))
+	items := make([]int, 0)
+	administeredItems := make([]Candidate, 0)
+	responses := make([]ItemResponse, 0)
+
+	testLength := cfg.Stopping.MaxItems
+	if testLength <= 0 {
+		testLength = DefaultStoppingRule().MaxItems
+	}
+	state := SelectionState{TestLength: testLength, Model: cfg.Model}
+
+	for {
+		available := make([]Candidate, 0, len(bank)-len(items))
+		indexes := make([]int, 0, len(bank)-len(items))
+		for i, c := range bank {
+			if !used[i] {
+				available = append(available, c)
+				indexes = append(indexes, i)
+			}
+		}
+		if len(available) == 0 {
+			examinee.decision = StopDecision{Stop: true, Reason: StopBankExhausted}
+			break
+		}
+
+		current := selector
+		if cfg.Blueprint != nil {
+			balanced, err := NewBalancedSelector(selector, cfg.Blueprint, administeredItems)
+			if err != nil {
+				return examinee, nil, err
+			}
+			current = balanced
+		}
+		ranked := RankCandidates(current, available, state)
+		chosen, _, err := exposure.Choose(ranked, available)
+		if err != nil {
+			return examinee, nil, err
+		}
+
+		item := available[chosen]
+		used[indexes[chosen]] = true
+		items = append(items, indexes[chosen])
+		administeredItems = append(administeredItems, item)
+		responses = append(responses, simulateResponse(cfg.Model, theta, item, rng))
+
+		estimate, err := estimator.Estimate(responses)
+		if err != nil {
+			return examinee, nil, err
+		}
+		previous := state.Theta
+		examinee.estimate = estimate
+		state.Theta = estimate.Theta
+		state.StandardError = estimate.StandardError
+		state.ItemsAdministered = len(responses)
+
+		decision := cfg.Stopping.Evaluate(StoppingState{
+			Responses:     responses,
+			Estimate:      estimate,
+			PreviousTheta: previous,
+		})
+		if decision.Stop {
+			examinee.decision = decision
+			break
+		}
+	}
+	examinee.length = len(items)
+	return examinee, items, nil
+}
+
+// summarizeSimulation 汇总全体与分组的估计精度及题目曝光率
+func summarizeSimulation(bank []Candidate, examinees []simulatedExaminee, administered []int, cfg CATSimulationConfig) *CATSimulationResult {
+	result := &CATSimulationResult{
+		Examinees:   len(examinees),
+		StopReasons: make(map[StopReason]int),
+		Exposure:    make([]SimulatedExposure, len(bank)),
+	}
+
+	width := cfg.BinWidth
+	if width <= 0 {
+		width = 0.5
+	}
+	bins := int(math.Ceil(6 / width))
+	conditional := make([]ConditionalPerformance, bins)
+	sqErrors := make([]float64, bins)
+	for k := range conditional {
+		conditional[k].ThetaMin = -3 + float64(k)*width
+		conditional[k].ThetaMax = math.Min(-3+float64(k+1)*width, 3)
+	}
+
+	classify := cfg.Stopping.Classification != ClassificationNone || cfg.Stopping.CutScore != 0
+	var sumError, sumSqError, sumSE, sumLength float64
+	var sumTheta, sumEstimate, sumThetaSq, sumEstimateSq, sumCross float64
+	var correct int
+	result.MinTestLength = math.MaxInt32
+	for _, e := range examinees {
+		if e.estimate == nil {
+			continue
+		}
+		diff := e.estimate.Theta - e.theta
+		sumError += diff
+		sumSqError += diff * diff
+		sumSE += e.estimate.StandardError
+		sumLength += float64(e.length)
+		sumTheta += e.theta
+		sumEstimate += e.estimate.Theta
+		sumThetaSq += e.theta * e.theta
+		sumEstimateSq += e.estimate.Theta * e.estimate.Theta
+		sumCross += e.theta * e.estimate.Theta
+		result.StopReasons[e.decision.Reason]++
+		if e.length < result.MinTestLength {
+			result.MinTestLength = e.length
+		}
+		if e.length > result.MaxTestLength {
+			result.MaxTestLength = e.length
+		}
+
+		if classify {
+			pass := e.estimate.Theta >= cfg.Stopping.CutScore
+			if e.decision.Classification != "" {
+				pass = e.decision.Classification == ClassificationPass
+			}
+			if pass == (e.theta >= cfg.Stopping.CutScore) {
+				correct++
+			}
+		}
+
+		k := int(math.Floor((e.theta + 3) / width))
+		if k < 0 {
+			k = 0
+		}
+		if k >= bins {
+			k = bins - 1
+		}
+		c := &conditional[k]
+		c.Examinees++
+		c.Bias += diff
+		sqErrors[k] += diff * diff
+		c.MeanSE += e.estimate.StandardError
+		c.MeanTestLength += float64(e.length)
+	}
+
+	n := float64(len(examinees))
+	if n > 0 {
+		result.Bias = sumError / n
+		result.RMSE = math.Sqrt(sumSqError / n)
+		result.MeanSE = sumSE / n
+		result.MeanTestLength = sumLength / n
+		covariance := sumCross/n - (sumTheta/n)*(sumEstimate/n)
+		varTheta := sumThetaSq/n - (sumTheta/n)*(sumTheta/n)
+		varEstimate := sumEstimateSq/n - (sumEstimate/n)*(sumEstimate/n)
+		if varTheta > 0 && varEstimate > 0 {
+			result.Correlation = covariance / math.Sqrt(varTheta*varEstimate)
+		}
+		if classify {
+			result.ClassificationAccuracy = float64(correct) / n
+		}
+	} else {
+		result.MinTestLength = 0
+	}
+
+	for k := range conditional {
+		c := &conditional[k]
+		if c.Examinees == 0 {
+			continue
+		}
+		count := float64(c.Examinees)
+		c.Bias /= count
+		c.RMSE = math.Sqrt(sqErrors[k] / count)
+		c.MeanSE /= count
+		c.MeanTestLength /= count
+		result.Conditional = append(result.Conditional, *c)
+	}
+
+	for i, c := range bank {
+		rate := 0.0
+		if n > 0 {
+			rate = float64(administered[i]) / n
+		}
+		result.Exposure[i] = SimulatedExposure{QuestionID: c.QuestionID, Administered: administered[i], Rate: rate}
+		if administered[i] == 0 {
+			result.UnusedItems++
+		}
+		result.MaxExposureRate = math.Max(result.MaxExposureRate, rate)
+	}
+	sort.SliceStable(result.Exposure, func(a, b int) bool {
+		return result.Exposure[a].Rate > result.Exposure[b].Rate
+	})
+	return result
+}
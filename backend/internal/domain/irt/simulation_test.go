@@ -0,0 +1,151 @@
+package irt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// itemBank 生成3PL模拟题库
+func itemBank(size int, seed int64) []Candidate {
+	rng := rand.New(rand.NewSource(seed))
+	bank := make([]Candidate, size)
+	for i := range bank {
+		bank[i] = Candidate{
+			QuestionID:     uint(i + 1),
+			Difficulty:     rng.NormFloat64(),
+			Discrimination: 0.8 + 1.2*rng.Float64(),
+			Guessing:       0.2 * rng.Float64(),
+		}
+	}
+	return bank
+}
+
+// 模拟考生走完整场自适应考试
+func TestSimulateCAT(t *testing.T) {
+	bank := itemBank(300, 1)
+
+	t.Run("DefaultSettings", func(t *testing.T) {
+		cfg := DefaultCATSimulationConfig()
+		cfg.Examinees = 300
+
+		result, err := SimulateCAT(bank, cfg)
+		require.NoError(t, err)
+		assert.Equal(t, 300, result.Examinees)
+		assert.Less(t, result.RMSE, 0.4)
+		assert.Greater(t, result.Correlation, 0.9)
+		assert.LessOrEqual(t, result.MaxTestLength, cfg.Stopping.MaxItems)
+		assert.GreaterOrEqual(t, result.MinTestLength, cfg.Stopping.MinItems)
+
+		stopped := 0
+		for _, count := range result.StopReasons {
+			stopped += count
+		}
+		assert.Equal(t, result.Examinees, stopped)
+	})
+
+	t.Run("ExposureControl", func(t *testing.T) {
+		cfg := DefaultCATSimulationConfig()
+		cfg.Examinees = 200
+		uncontrolled, err := SimulateCAT(bank, cfg)
+		require.NoError(t, err)
+
+		cfg.ExposureMethod = ExposureRandomesque
+		cfg.RandomesqueSize = 5
+		controlled, err := SimulateCAT(bank, cfg)
+		require.NoError(t, err)
+		assert.Less(t, controlled.MaxExposureRate, uncontrolled.MaxExposureRate)
+		assert.Less(t, controlled.UnusedItems, uncontrolled.UnusedItems)
+	})
+
+	t.Run("FixedLengthExposure", func(t *testing.T) {
+		cfg := DefaultCATSimulationConfig()
+		cfg.Examinees = 100
+		cfg.Stopping = StoppingRule{MinItems: 15, MaxItems: 15}
+		result, err := SimulateCAT(bank, cfg)
+		require.NoError(t, err)
+		assert.Equal(t, map[StopReason]int{StopMaxItems: 100}, result.StopReasons)
+		assert.Equal(t, 15.0, result.MeanTestLength)
+
+		// 曝光次数之和等于施测总题数，曝光率按降序排列
+		total := 0
+		for i, exposure := range result.Exposure {
+			total += exposure.Administered
+			assert.InDelta(t, float64(exposure.Administered)/100, exposure.Rate, 1e-12)
+			if i > 0 {
+				assert.LessOrEqual(t, exposure.Rate, result.Exposure[i-1].Rate)
+			}
+		}
+		assert.Equal(t, 1500, total)
+		assert.Equal(t, result.Exposure[0].Rate, result.MaxExposureRate)
+		assert.Len(t, result.Exposure, len(bank))
+
+		// 同一随机种子的模拟结果可复现
+		again, err := SimulateCAT(bank, cfg)
+		require.NoError(t, err)
+		assert.Equal(t, result, again)
+	})
+
+	t.Run("UniformConditional", func(t *testing.T) {
+		cfg := DefaultCATSimulationConfig()
+		cfg.Examinees = 300
+		cfg.Distribution = ThetaUniform
+		cfg.Min, cfg.Max = -2, 2
+		cfg.BinWidth = 1
+		result, err := SimulateCAT(bank, cfg)
+		require.NoError(t, err)
+
+		// 真实能力落在 [-2, 2]，只有中间4个分组有考生
+		require.Len(t, result.Conditional, 4)
+		examinees := 0
+		for k, c := range result.Conditional {
+			assert.Equal(t, -2+float64(k), c.ThetaMin)
+			assert.Equal(t, c.ThetaMin+1, c.ThetaMax)
+			assert.Greater(t, c.Examinees, 0)
+			assert.Less(t, c.RMSE, 0.6)
+			assert.GreaterOrEqual(t, c.MeanTestLength, float64(cfg.Stopping.MinItems))
+			examinees += c.Examinees
+		}
+		assert.Equal(t, 300, examinees)
+		assert.Zero(t, result.ClassificationAccuracy)
+	})
+
+	t.Run("Classification", func(t *testing.T) {
+		cfg := DefaultCATSimulationConfig()
+		cfg.Examinees = 200
+		cfg.Stopping.Classification = ClassificationSPRT
+		cfg.Stopping.CutScore = 0.5
+		result, err := SimulateCAT(bank, cfg)
+		require.NoError(t, err)
+		assert.Greater(t, result.StopReasons[StopClassification], 0)
+		assert.Greater(t, result.ClassificationAccuracy, 0.85)
+	})
+
+	t.Run("BankExhausted", func(t *testing.T) {
+		cfg := DefaultCATSimulationConfig()
+		cfg.Examinees = 10
+		cfg.Stopping = StoppingRule{MaxItems: 30}
+		result, err := SimulateCAT(bank[:8], cfg)
+		require.NoError(t, err)
+		assert.Equal(t, map[StopReason]int{StopBankExhausted: 10}, result.StopReasons)
+		assert.Equal(t, 8, result.MaxTestLength)
+		assert.Zero(t, result.UnusedItems)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := SimulateCAT(nil, DefaultCATSimulationConfig())
+		assert.ErrorIs(t, err, ErrNoCandidates)
+
+		cfg := DefaultCATSimulationConfig()
+		cfg.Distribution = "beta"
+		_, err = SimulateCAT(bank, cfg)
+		assert.ErrorIs(t, err, ErrUnknownThetaDistribution)
+
+		cfg = DefaultCATSimulationConfig()
+		cfg.Strategy = "random"
+		_, err = SimulateCAT(bank, cfg)
+		assert.ErrorIs(t, err, ErrUnknownStrategy)
+	})
+}
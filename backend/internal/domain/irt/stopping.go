@@ -0,0 +1,138 @@
+package irt
+
+import (
+	"math"
+	"time"
+)
+
+// StopReason 自适应测验结束原因
+type StopReason string
+
+const (
+	StopMaxItems       StopReason = "max_items"           // 达到最大题数
+	StopTargetSE       StopReason = "target_se"           // 标准误达到目标精度
+	StopTimeLimit      StopReason = "time_limit"          // 超出时间上限
+	StopThetaChange    StopReason = "theta_change"        // 能力估计变化小于阈值
+	StopClassification StopReason = "classification"      // 已能判定高于或低于划界分数
+	StopBankExhausted  StopReason = "item_bank_exhausted" // 题库中已无可选题目
+	StopPanelCompleted StopReason = "panel_completed"     // 多阶段测验已完成最后一个阶段的模块
+)
+
+// ClassificationMethod 分类终止方法
+type ClassificationMethod string
+
+const (
+	ClassificationNone ClassificationMethod = ""     // 不做分类终止
+	ClassificationSPRT ClassificationMethod = "sprt" // 序贯概率比检验
+	ClassificationCI   ClassificationMethod = "ci"   // 能力值置信区间
+)
+
+// 分类结果
+const (
+	ClassificationPass = "pass"
+	ClassificationFail = "fail"
+)
+
+// StoppingRule 自适应测验终止规则，阈值为0表示不启用该条规则
+type StoppingRule struct {
+	MinItems    int           // 最少题数，未达到前只受最大题数与时间上限约束
+	MaxItems    int           // 最大题数
+	TargetSE    float64       // 目标标准误
+	TimeLimit   time.Duration // 时间上限
+	ThetaChange float64       // 相邻两次能力估计之差的阈值
+
+	Classification     ClassificationMethod
+	CutScore           float64 // 能力量尺上的划界分数
+	IndifferenceRegion float64 // SPRT 无差异区间半宽 δ
+	ErrorRate          float64 // SPRT 的 α、β，或置信区间的 1 - 置信水平
+	Model              Model   // 计算 SPRT 似然比使用的科目项目反应模型
+}
+
+// DefaultStoppingRule 返回与原先固定阈值一致的终止规则
+func DefaultStoppingRule() StoppingRule {
+	return StoppingRule{
+		MinItems:           5,
+		MaxItems:           30,
+		TargetSE:           0.3,
+		IndifferenceRegion: 0.2,
+		ErrorRate:          0.05,
+	}
+}
+
+// StoppingState 判断终止时的会话状态
+type StoppingState struct {
+	Responses     []ItemResponse
+	Estimate      *AbilityEstimate
+	PreviousTheta float64 // 本题作答前的能力估计
+	Elapsed       time.Duration
+}
+
+// StopDecision 终止判断结果
+type StopDecision struct {
+	Stop           bool
+	Reason         StopReason
+	Classification string // 分类终止时为 pass 或 fail
+}
+
+// Evaluate 依次检查时间上限、最大题数、最少题数、目标精度、能力变化与分类规则
+func (r StoppingRule) Evaluate(state StoppingState) StopDecision {
+	n := len(state.Responses)
+	if r.TimeLimit > 0 && state.Elapsed >= r.TimeLimit {
+		return StopDecision{Stop: true, Reason: StopTimeLimit}
+	}
+	if r.MaxItems > 0 && n >= r.MaxItems {
+		return StopDecision{Stop: true, Reason: StopMaxItems}
+	}
+	if n == 0 || n < r.MinItems || state.Estimate == nil {
+		return StopDecision{}
+	}
+
+	if r.TargetSE > 0 && state.Estimate.StandardError > 0 && state.Estimate.StandardError <= r.TargetSE {
+		return StopDecision{Stop: true, Reason: StopTargetSE}
+	}
+	if r.ThetaChange > 0 && n > 1 && math.Abs(state.Estimate.Theta-state.PreviousTheta) < r.ThetaChange {
+		return StopDecision{Stop: true, Reason: StopThetaChange}
+	}
+	if class := r.classify(state); class != "" {
+		return StopDecision{Stop: true, Reason: StopClassification, Classification: class}
+	}
+	return StopDecision{}
+}
+
+// classify 返回可确定的分类结果，尚不能判定时返回空字符串
+func (r StoppingRule) classify(state StoppingState) string {
+	alpha := r.ErrorRate
+	if alpha <= 0 || alpha >= 0.5 {
+		alpha = 0.05
+	}
+
+	switch r.Classification {
+	case ClassificationSPRT:
+		delta := r.IndifferenceRegion
+		if delta <= 0 {
+			delta = 0.2
+		}
+		// 以 α = β 构造 Wald 检验边界
+		ratio := r.Model.LogLikelihood(r.CutScore+delta, state.Responses) - r.Model.LogLikelihood(r.CutScore-delta, state.Responses)
+		upper := math.Log((1 - alpha) / alpha)
+		if ratio >= upper {
+			return ClassificationPass
+		}
+		if ratio <= -upper {
+			return ClassificationFail
+		}
+
+	case ClassificationCI:
+		if state.Estimate.StandardError <= 0 {
+			return ""
+		}
+		z := math.Sqrt2 * math.Erfinv(1-alpha)
+		if state.Estimate.Theta-z*state.Estimate.StandardError > r.CutScore {
+			return ClassificationPass
+		}
+		if state.Estimate.Theta+z*state.Estimate.StandardError < r.CutScore {
+			return ClassificationFail
+		}
+	}
+	return ""
+}
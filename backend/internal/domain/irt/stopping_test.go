@@ -0,0 +1,99 @@
+package irt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// answered n 道难度为0、区分度为1的题目，前 correct 道答对
+func answered(n, correct int) []ItemResponse {
+	responses := make([]ItemResponse, n)
+	for j := range responses {
+		responses[j] = ItemResponse{QuestionID: uint(j + 1), Discrimination: 1, Correct: j < correct}
+	}
+	return responses
+}
+
+func TestStoppingReasons(t *testing.T) {
+	rule := StoppingRule{MinItems: 3, MaxItems: 10, TargetSE: 0.3, TimeLimit: time.Hour, ThetaChange: 0.01}
+	imprecise := &AbilityEstimate{Theta: 1, StandardError: 0.5}
+
+	tests := []struct {
+		name   string
+		state  StoppingState
+		reason StopReason
+	}{
+		{"TimeLimit", StoppingState{Responses: answered(1, 1), Elapsed: time.Hour}, StopTimeLimit},
+		{"MaxItems", StoppingState{Responses: answered(10, 5), Estimate: imprecise}, StopMaxItems},
+		{"TargetSE", StoppingState{Responses: answered(4, 2), Estimate: &AbilityEstimate{StandardError: 0.3}, PreviousTheta: 1}, StopTargetSE},
+		{"ThetaChange", StoppingState{Responses: answered(4, 2), Estimate: imprecise, PreviousTheta: 1.005}, StopThetaChange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := rule.Evaluate(tt.state)
+			assert.True(t, decision.Stop)
+			assert.Equal(t, tt.reason, decision.Reason)
+		})
+	}
+
+	decision := rule.Evaluate(StoppingState{Responses: answered(4, 2), Estimate: imprecise, PreviousTheta: 0})
+	assert.False(t, decision.Stop)
+}
+
+func TestStoppingMinItemsGate(t *testing.T) {
+	rule := StoppingRule{MinItems: 5, TargetSE: 0.3, TimeLimit: time.Minute}
+	precise := &AbilityEstimate{StandardError: 0.1}
+
+	// 未达到最少题数时精度规则不生效，但最大题数与时间上限仍然生效
+	assert.False(t, rule.Evaluate(StoppingState{Responses: answered(4, 2), Estimate: precise}).Stop)
+	assert.True(t, rule.Evaluate(StoppingState{Responses: answered(5, 2), Estimate: precise}).Stop)
+	assert.Equal(t, StopTimeLimit, rule.Evaluate(StoppingState{Responses: answered(1, 1), Elapsed: time.Minute}).Reason)
+
+	rule.MaxItems = 3
+	assert.Equal(t, StopMaxItems, rule.Evaluate(StoppingState{Responses: answered(3, 2), Estimate: precise}).Reason)
+
+	// 没有作答或能力估计时不终止
+	assert.False(t, StoppingRule{TargetSE: 0.3}.Evaluate(StoppingState{Estimate: precise}).Stop)
+	assert.False(t, StoppingRule{TargetSE: 0.3}.Evaluate(StoppingState{Responses: answered(2, 1)}).Stop)
+}
+
+func TestStoppingSPRTBounds(t *testing.T) {
+	// Rasch 模型下划界分数处的题目，答对一题似然比对数增加 δ，答错减少 δ；
+	// α = β = 0.05 时边界为 ±ln 19 ≈ 2.944，δ = 0.2 时需净答对15题
+	rule := StoppingRule{
+		Classification:     ClassificationSPRT,
+		IndifferenceRegion: 0.2,
+		ErrorRate:          0.05,
+		Model:              Model{Family: ModelRasch, D: ScalingLogistic},
+	}
+	estimate := &AbilityEstimate{StandardError: 1}
+
+	decision := rule.Evaluate(StoppingState{Responses: answered(14, 14), Estimate: estimate})
+	assert.False(t, decision.Stop)
+
+	decision = rule.Evaluate(StoppingState{Responses: answered(15, 15), Estimate: estimate})
+	assert.Equal(t, StopDecision{Stop: true, Reason: StopClassification, Classification: ClassificationPass}, decision)
+
+	decision = rule.Evaluate(StoppingState{Responses: answered(17, 1), Estimate: estimate})
+	assert.Equal(t, ClassificationFail, decision.Classification)
+
+	// 答对答错相抵时继续施测
+	assert.False(t, rule.Evaluate(StoppingState{Responses: answered(40, 20), Estimate: estimate}).Stop)
+}
+
+func TestStoppingConfidenceInterval(t *testing.T) {
+	// 95% 置信区间 θ ± 1.96·SE 不含划界分数时分类
+	rule := StoppingRule{Classification: ClassificationCI, CutScore: 0, ErrorRate: 0.05}
+	responses := answered(5, 3)
+
+	decision := rule.Evaluate(StoppingState{Responses: responses, Estimate: &AbilityEstimate{Theta: 0.5, StandardError: 0.25}})
+	assert.Equal(t, StopDecision{Stop: true, Reason: StopClassification, Classification: ClassificationPass}, decision)
+
+	decision = rule.Evaluate(StoppingState{Responses: responses, Estimate: &AbilityEstimate{Theta: 0.5, StandardError: 0.26}})
+	assert.False(t, decision.Stop)
+
+	decision = rule.Evaluate(StoppingState{Responses: responses, Estimate: &AbilityEstimate{Theta: -0.5, StandardError: 0.25}})
+	assert.Equal(t, ClassificationFail, decision.Classification)
+}
@@ -0,0 +1,106 @@
+package irt
+
+import (
+	"errors"
+	"math"
+
+	"irt-exam-system/backend/internal/utils"
+)
+
+var ErrInvalidGrid = errors.New("invalid theta grid")
+
+// TestItem 试卷中的一道题及其分值
+type TestItem struct {
+	QuestionID     uint
+	Difficulty     float64
+	Discrimination float64
+	Guessing       float64
+	UpperAsymptote float64 // 4PL 上渐近线，0表示1
+	Score          float64 // 分值，0按1分计
+}
+
+// CurvePoint 测验信息函数与测验特征曲线上的一个点
+type CurvePoint struct {
+	Theta                 float64
+	Information           float64
+	SEM                   float64 // 条件测量标准误 1/√I(θ)，信息量为0时为 +Inf
+	ExpectedScore         float64 // 期望得分 Σ 分值·P(θ)
+	ExpectedNumberCorrect float64 // 期望答对题数 Σ P(θ)
+}
+
+// ThetaGrid 生成 [min, max] 上步长为 step 的能力值网格
+func ThetaGrid(min, max, step float64) ([]float64, error) {
+	if step <= 0 || max < min || (max-min)/step > 10000 {
+		return nil, ErrInvalidGrid
+	}
+	count := int(math.Floor((max-min)/step+1e-9)) + 1
+	grid := make([]float64, count)
+	for i := range grid {
+		grid[i] = min + float64(i)*step
+	}
+	return grid, nil
+}
+
+// ExpectedScore 测验特征曲线在 θ 处的取值（期望得分）
+func (m Model) ExpectedScore(items []TestItem, theta float64) float64 {
+	var score float64
+	for _, item := range items {
+		score += item.weight() * m.Probability(theta, item.params())
+	}
+	return score
+}
+
+// TestCurves 计算网格上每个能力值的测验信息、条件测量标准误与期望得分
+func (m Model) TestCurves(items []TestItem, grid []float64) []CurvePoint {
+	points := make([]CurvePoint, len(grid))
+	for i, theta := range grid {
+		point := CurvePoint{Theta: theta}
+		for _, item := range items {
+			p := m.Probability(theta, item.params())
+			point.Information += m.Information(theta, item.params())
+			point.ExpectedScore += item.weight() * p
+			point.ExpectedNumberCorrect += p
+		}
+		point.SEM = utils.CalculateStandardError(point.Information)
+		points[i] = point
+	}
+	return points
+}
+
+// ThetaForScore 反解测验特征曲线，求期望得分等于 score 的能力值，
+// 超出 [minTheta, maxTheta] 可达范围时返回端点
+func (m Model) ThetaForScore(items []TestItem, score, minTheta, maxTheta float64) float64 {
+	lo, hi := minTheta, maxTheta
+	if score <= m.ExpectedScore(items, lo) {
+		return lo
+	}
+	if score >= m.ExpectedScore(items, hi) {
+		return hi
+	}
+	// 期望得分关于 θ 单调递增，二分求解
+	for i := 0; i < 60; i++ {
+		mid := (lo + hi) / 2
+		if m.ExpectedScore(items, mid) < score {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+func (item TestItem) params() ItemParams {
+	return ItemParams{
+		Difficulty:     item.Difficulty,
+		Discrimination: item.Discrimination,
+		Guessing:       item.Guessing,
+		UpperAsymptote: item.UpperAsymptote,
+	}
+}
+
+func (item TestItem) weight() float64 {
+	if item.Score <= 0 {
+		return 1
+	}
+	return item.Score
+}
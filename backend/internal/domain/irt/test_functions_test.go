@@ -0,0 +1,88 @@
+package irt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThetaGrid(t *testing.T) {
+	grid, err := ThetaGrid(-1, 1, 0.5)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{-1, -0.5, 0, 0.5, 1}, grid)
+
+	// 步长除不尽时不超过上限
+	grid, err = ThetaGrid(0, 1, 0.3)
+	require.NoError(t, err)
+	assert.Len(t, grid, 4)
+
+	for _, bounds := range [][3]float64{{0, 1, 0}, {1, 0, 0.1}, {-4, 4, 1e-4}} {
+		_, err := ThetaGrid(bounds[0], bounds[1], bounds[2])
+		assert.ErrorIs(t, err, ErrInvalidGrid)
+	}
+}
+
+func TestTestCurvesHandComputed(t *testing.T) {
+	// Rasch 题目难度为0，θ = 0 时每题 P = 1/2、I = 1/4；θ = ln 3 时 P = 3/4、I = 3/16
+	model := Model{Family: ModelRasch, D: ScalingLogistic}
+	items := []TestItem{
+		{QuestionID: 1, Discrimination: 1, Score: 2},
+		{QuestionID: 2, Discrimination: 1},
+		{QuestionID: 3, Discrimination: 1, Score: 1},
+		{QuestionID: 4, Discrimination: 1, Score: 1},
+	}
+
+	points := model.TestCurves(items, []float64{0, math.Log(3)})
+	assert.InDelta(t, 1.0, points[0].Information, 1e-12)
+	assert.InDelta(t, 1.0, points[0].SEM, 1e-12)
+	assert.InDelta(t, 2.5, points[0].ExpectedScore, 1e-12)
+	assert.InDelta(t, 2.0, points[0].ExpectedNumberCorrect, 1e-12)
+
+	assert.InDelta(t, 0.75, points[1].Information, 1e-12)
+	assert.InDelta(t, 1/math.Sqrt(0.75), points[1].SEM, 1e-12)
+	assert.InDelta(t, 3.75, points[1].ExpectedScore, 1e-12)
+	assert.InDelta(t, 3.0, points[1].ExpectedNumberCorrect, 1e-12)
+
+	// 没有题目时信息量为0，测量标准误为 +Inf
+	empty := model.TestCurves(nil, []float64{0})
+	assert.True(t, math.IsInf(empty[0].SEM, 1))
+}
+
+func TestTestCharacteristicCurve(t *testing.T) {
+	model := DefaultModel()
+	items := []TestItem{
+		{QuestionID: 1, Difficulty: -1, Discrimination: 1.2, Guessing: 0.2},
+		{QuestionID: 2, Difficulty: 0, Discrimination: 0.8, Guessing: 0.25, Score: 2},
+		{QuestionID: 3, Difficulty: 1.5, Discrimination: 1.5, Guessing: 0.1, Score: 3},
+	}
+	grid, err := ThetaGrid(-4, 4, 0.25)
+	require.NoError(t, err)
+	points := model.TestCurves(items, grid)
+
+	// 期望得分单调递增，介于猜测下限与满分之间；测量标准误在信息量最大处最小
+	minScore := 0.2 + 2*0.25 + 3*0.1
+	best := 0
+	for i, point := range points {
+		assert.Greater(t, point.ExpectedScore, minScore)
+		assert.Less(t, point.ExpectedScore, 6.0)
+		if i > 0 {
+			assert.Greater(t, point.ExpectedScore, points[i-1].ExpectedScore)
+		}
+		if point.Information > points[best].Information {
+			best = i
+		}
+	}
+	for _, point := range points {
+		assert.GreaterOrEqual(t, point.SEM, points[best].SEM)
+	}
+
+	// 反解测验特征曲线
+	for _, theta := range []float64{-1.3, 0, 0.8} {
+		score := model.ExpectedScore(items, theta)
+		assert.InDelta(t, theta, model.ThetaForScore(items, score, -4, 4), 1e-9)
+	}
+	assert.Equal(t, -4.0, model.ThetaForScore(items, 0, -4, 4))
+	assert.Equal(t, 4.0, model.ThetaForScore(items, 6, -4, 4))
+}
@@ -32,6 +32,10 @@ type ExamSessionResponse struct {
 	ID             uint    `json:"id"`
 	StartTime      string  `json:"start_time"`
 	CurrentAbility float64 `json:"current_ability"`
+
+	// 多阶段测验时返回分配的测验板与当前模块路径
+	PanelID    *uint    `json:"panel_id,omitempty"`
+	ModulePath []string `json:"module_path,omitempty"`
 }
 
 // QuestionDTO 试题响应
@@ -52,7 +56,25 @@ type AnswerRequest struct {
 type AnswerResponse struct {
 	IsCorrect      bool    `json:"is_correct"`
 	CurrentAbility float64 `json:"current_ability"`
+	StandardError  float64 `json:"standard_error"`
 	NextDifficulty float64 `json:"next_difficulty"`
+
+	// 会话结束时返回
+	Finished       bool   `json:"finished"`
+	StopReason     string `json:"stop_reason,omitempty"`
+	Classification string `json:"classification,omitempty"`
+	EndTime        string `json:"end_time,omitempty"`
+
+	// 科目配置了报告分数量表时返回，百分位等级需已建立常模
+	ScaledScore    *float64 `json:"scaled_score,omitempty"`
+	ScaledSEM      *float64 `json:"scaled_sem,omitempty"`
+	PercentileRank *float64 `json:"percentile_rank,omitempty"`
+
+	// 科目设定了表现水平时返回
+	PerformanceLevel string `json:"performance_level,omitempty"`
+
+	// 多阶段测验时返回模块路径，完成模块并路由后包含下一模块
+	ModulePath []string `json:"module_path,omitempty"`
 }
 
 // ErrorResponse 错误响应
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"gorm.io/gorm"
+)
+
+// ExamBlueprint 试卷的内容蓝图，约束自适应选题在知识点与题型上的分布
+type ExamBlueprint struct {
+	gorm.Model
+	ExamPaperID uint                  `gorm:"not null;uniqueIndex"`
+	Method      string                `gorm:"size:16;not null;default:'mpi'"` // 内容平衡方法：mpi、wdm
+	Constraints []BlueprintConstraint `gorm:"foreignKey:BlueprintID"`
+}
+
+// IRTBlueprint 转换为内容平衡引擎使用的结构
+func (b *ExamBlueprint) IRTBlueprint() *irt.Blueprint {
+	result := &irt.Blueprint{
+		Method:      irt.BalancingMethod(b.Method),
+		Constraints: make([]irt.ContentConstraint, len(b.Constraints)),
+	}
+	for i, c := range b.Constraints {
+		result.Constraints[i] = irt.ContentConstraint{
+			KnowledgePointID: c.KnowledgePointID,
+			QuestionType:     c.QuestionType,
+			MinCount:         c.MinCount,
+			MaxCount:         c.MaxCount,
+			TargetProportion: c.TargetProportion,
+			Weight:           c.Weight,
+		}
+	}
+	return result
+}
+
+// BlueprintConstraint 蓝图中的一条约束，知识点与题型二选一
+type BlueprintConstraint struct {
+	gorm.Model
+	BlueprintID      uint    `gorm:"not null;index"`
+	KnowledgePointID uint    `gorm:"index"`
+	QuestionType     string  `gorm:"size:20"`
+	MinCount         int     `gorm:"not null;default:0"`
+	MaxCount         int     `gorm:"not null;default:0"` // 0表示不限
+	TargetProportion float64 `gorm:"not null;default:0"` // 目标比例，非0时优先于题数上下限
+	Weight           float64 `gorm:"not null;default:1"` // WDM 权重
+}
@@ -1,6 +1,12 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"gorm.io/gorm"
+)
 
 type ExamPaper struct {
 	ID          uint    `gorm:"primarykey"`
@@ -8,6 +14,51 @@ type ExamPaper struct {
 	Description string  `gorm:"type:text"`
 	Duration    int     `gorm:"not null"` // in minutes
 	TotalScore  float64 `gorm:"not null"`
+	SubjectID   uint    `gorm:"not null;index"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	// 自适应考试配置
+	SelectionStrategy string `gorm:"size:32;not null;default:'max_info'"` // 选题策略：max_info、kl、a_stratified、b_matching、d_optimal
+
+	// 终止规则，阈值为0表示不启用，时间上限取 Duration
+	MinItems            int     `gorm:"not null;default:5"`
+	MaxItems            int     `gorm:"not null;default:30"`
+	TargetSE            float64 `gorm:"not null;default:0.3"`
+	ThetaChange         float64 `gorm:"not null;default:0"`
+	Classification      string  `gorm:"size:16;not null;default:''"` // 分类终止方法：sprt、ci，空表示不启用
+	CutScore            float64 `gorm:"not null;default:0"`          // 能力量尺上的划界分数
+	IndifferenceRegion  float64 `gorm:"not null;default:0.2"`        // SPRT 无差异区间半宽
+	ClassificationError float64 `gorm:"not null;default:0.05"`       // 分类错误率
+
+	// 限时选题：按考生作答速度估计各题预计用时，避开剩余时间内来不及作答的题目
+	TimeAwareSelection bool `gorm:"not null;default:false"`
+}
+
+// StoppingRule 由试卷配置构造自适应测验终止规则，时间上限取试卷时长
+func (p *ExamPaper) StoppingRule(model irt.Model) irt.StoppingRule {
+	return irt.StoppingRule{
+		MinItems:           p.MinItems,
+		MaxItems:           p.MaxItems,
+		TargetSE:           p.TargetSE,
+		TimeLimit:          time.Duration(p.Duration) * time.Minute,
+		ThetaChange:        p.ThetaChange,
+		Classification:     irt.ClassificationMethod(p.Classification),
+		CutScore:           p.CutScore,
+		IndifferenceRegion: p.IndifferenceRegion,
+		ErrorRate:          p.ClassificationError,
+		Model:              model,
+	}
+}
+
+// ExamPaperQuestion 试卷题目关联
+type ExamPaperQuestion struct {
+	gorm.Model
+	ExamPaperID uint    `gorm:"not null;index"`
+	QuestionID  uint    `gorm:"not null;index"`
+	Score       float64 `gorm:"not null"`
+	Order       int64   `gorm:"not null"`
+
+	// 嵌入的试测题位置，分值为0，不计入试卷总分
+	Pretest bool `gorm:"not null;default:false"`
 }
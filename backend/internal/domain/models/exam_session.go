@@ -9,8 +9,24 @@ import (
 type ExamSession struct {
 	gorm.Model
 	UserID         uint      `gorm:"not null"`
+	ExamPaperID    uint      `gorm:"not null;index"`
 	StartTime      time.Time `gorm:"not null"`
 	EndTime        time.Time
 	CurrentAbility float64 // 考生当前能力值估计
+	StandardError  float64 // 当前能力值估计的标准误
 	Status         string  `gorm:"default:'in_progress'"`
+
+	// 终止信息
+	StopReason     string `gorm:"size:32"` // 结束原因，见 irt.StopReason
+	Classification string `gorm:"size:8"`  // 分类终止结果：pass、fail
+
+	// 多阶段测验分配的测验板，逐题自适应考试为空；模块路径见 ExamSessionModule
+	PanelID *uint `gorm:"index"`
+}
+
+// ExamSessionRejection 本场 Sympson–Hetter 曝光控制中被选题准则选中但未施测的题目，本场后续不再参与选题
+type ExamSessionRejection struct {
+	gorm.Model
+	ExamSessionID uint `gorm:"not null;uniqueIndex:idx_session_rejection"`
+	QuestionID    uint `gorm:"not null;uniqueIndex:idx_session_rejection"`
 }
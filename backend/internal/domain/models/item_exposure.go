@@ -0,0 +1,21 @@
+package models
+
+import "gorm.io/gorm"
+
+// ExposureSetting 科目级自适应考试曝光控制配置
+type ExposureSetting struct {
+	gorm.Model
+	SubjectID       uint    `gorm:"not null;uniqueIndex"`
+	Method          string  `gorm:"size:32;not null;default:'none'"` // none、randomesque、sympson_hetter
+	RandomesqueSize int     `gorm:"not null;default:5"`              // randomesque 候选题数量
+	MaxExposureRate float64 `gorm:"not null;default:0.25"`           // Sympson–Hetter 目标最大曝光率
+}
+
+// ItemExposure 题目曝光统计及 Sympson–Hetter 曝光参数
+type ItemExposure struct {
+	gorm.Model
+	QuestionID        uint    `gorm:"not null;uniqueIndex"`
+	SubjectID         uint    `gorm:"not null;index"`
+	ExposureParameter float64 `gorm:"not null;default:1"` // 曝光参数 K
+	AdministeredCount int64   `gorm:"not null;default:0"` // 实际施测次数
+}
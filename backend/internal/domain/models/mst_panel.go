@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MSTPanel 多阶段测验板：试卷存在启用的测验板时，考试按模块施测而不再逐题自适应选题，
+// 同一试卷的多个启用测验板为平行板，开考时随机分配
+type MSTPanel struct {
+	gorm.Model
+	ExamPaperID uint             `gorm:"not null;index"`
+	Name        string           `gorm:"size:100;not null"`
+	Active      bool             `gorm:"not null;default:true"`
+	Modules     []MSTModule      `gorm:"foreignKey:PanelID"`
+	Rules       []MSTRoutingRule `gorm:"foreignKey:PanelID"`
+}
+
+// MSTModule 测验板中的模块，Stage 从1开始，Position 为阶段内由易到难的位置，从0开始
+type MSTModule struct {
+	gorm.Model
+	PanelID   uint                `gorm:"not null;index"`
+	Stage     int                 `gorm:"not null"`
+	Position  int                 `gorm:"not null"`
+	Label     string              `gorm:"size:32;not null"` // 如 1M、2E、2H
+	Questions []MSTModuleQuestion `gorm:"foreignKey:ModuleID"`
+}
+
+// MSTModuleQuestion 模块题目关联，与试卷题目关联一致按 Order 施测
+type MSTModuleQuestion struct {
+	gorm.Model
+	ModuleID   uint    `gorm:"not null;index"`
+	QuestionID uint    `gorm:"not null;index"`
+	Score      float64 `gorm:"not null"`
+	Order      int64   `gorm:"not null"`
+}
+
+// MSTRoutingRule 完成第 Stage 阶段第 Position 个模块后的路由规则
+type MSTRoutingRule struct {
+	gorm.Model
+	PanelID   uint            `gorm:"not null;uniqueIndex:idx_mst_routing_rule"`
+	Stage     int             `gorm:"not null;uniqueIndex:idx_mst_routing_rule"`
+	Position  int             `gorm:"not null;uniqueIndex:idx_mst_routing_rule"`
+	Method    string          `gorm:"size:16;not null"`                  // 路由依据：number_correct、theta
+	CutMethod string          `gorm:"size:16;not null;default:'manual'"` // 切点来源：manual、ami、dpi
+	Cuts      []MSTRoutingCut `gorm:"foreignKey:RuleID"`
+}
+
+// MSTRoutingCut 路由切点：路由值不低于第 Position 个切点的考生进入下一阶段更难的模块
+type MSTRoutingCut struct {
+	gorm.Model
+	RuleID   uint    `gorm:"not null;index"`
+	Position int     `gorm:"not null"`
+	Value    float64 `gorm:"not null"`
+}
+
+// ExamSessionModule 多阶段测验会话的模块路径，按阶段依次追加
+type ExamSessionModule struct {
+	gorm.Model
+	ExamSessionID uint     `gorm:"not null;index"`
+	Stage         int      `gorm:"not null"`
+	ModuleID      uint     `gorm:"not null"`
+	Label         string   `gorm:"size:32;not null"`
+	RoutingValue  *float64 // 完成模块时用于路由的答对题数或能力估计，未完成或最后阶段为空
+	CompletedAt   *time.Time
+}
+
+// Stages 按阶段分组、阶段内由易到难排列的模块
+func (p *MSTPanel) Stages() [][]*MSTModule {
+	var stages [][]*MSTModule
+	for i := range p.Modules {
+		module := &p.Modules[i]
+		for len(stages) < module.Stage {
+			stages = append(stages, nil)
+		}
+		stages[module.Stage-1] = append(stages[module.Stage-1], module)
+	}
+	for _, modules := range stages {
+		sort.Slice(modules, func(a, b int) bool { return modules[a].Position < modules[b].Position })
+	}
+	return stages
+}
+
+// Design 测验板结构，如 1-3-3
+func (p *MSTPanel) Design() string {
+	stages := p.Stages()
+	parts := make([]string, len(stages))
+	for i, modules := range stages {
+		parts[i] = fmt.Sprint(len(modules))
+	}
+	return strings.Join(parts, "-")
+}
+
+// Module 按 ID 查找模块
+func (p *MSTPanel) Module(moduleID uint) *MSTModule {
+	for i := range p.Modules {
+		if p.Modules[i].ID == moduleID {
+			return &p.Modules[i]
+		}
+	}
+	return nil
+}
+
+// Rule 模块完成后的路由规则，最后阶段的模块没有路由规则
+func (p *MSTPanel) Rule(module *MSTModule) *MSTRoutingRule {
+	for i := range p.Rules {
+		if p.Rules[i].Stage == module.Stage && p.Rules[i].Position == module.Position {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
+
+// OrderedQuestions 按施测顺序排列的模块题目
+func (m *MSTModule) OrderedQuestions() []MSTModuleQuestion {
+	questions := append([]MSTModuleQuestion(nil), m.Questions...)
+	sort.Slice(questions, func(a, b int) bool { return questions[a].Order < questions[b].Order })
+	return questions
+}
+
+// CutValues 按顺序排列的切点值
+func (r *MSTRoutingRule) CutValues() []float64 {
+	cuts := append([]MSTRoutingCut(nil), r.Cuts...)
+	sort.Slice(cuts, func(a, b int) bool { return cuts[a].Position < cuts[b].Position })
+	values := make([]float64, len(cuts))
+	for i, c := range cuts {
+		values[i] = c.Value
+	}
+	return values
+}
@@ -4,6 +4,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// 题型
+const (
+	QuestionTypeSingleChoice   = "单选"
+	QuestionTypeMultipleChoice = "多选"
+	QuestionTypeTrueFalse      = "判断"
+)
+
 type Question struct {
 	gorm.Model
 	Content        string  `gorm:"type:text;not null"`
@@ -16,6 +23,15 @@ type Question struct {
 	Discrimination float64 `gorm:"not null"` // IRT区分度参数
 	GuessParameter float64 `gorm:"not null"` // IRT猜测参数
 	Score          float64 `gorm:"not null"` // Add this field
+
+	// 内容属性
+	Type string `gorm:"size:20"` // 题型，用于内容平衡
+
+	// 组卷属性
+	EstimatedSeconds int `gorm:"not null;default:0"` // 预计作答时间（秒），0表示未设置
+
+	// 试测题：参数未标定，只作为试测题嵌入考试，不参与正式选题与能力估计
+	Pretest bool `gorm:"not null;default:false;index"`
 }
 
 type QuestionKnowledgePoint struct {
@@ -27,3 +43,42 @@ type QuestionKnowledgePoint struct {
 func (QuestionKnowledgePoint) TableName() string {
 	return "question_knowledge_points"
 }
+
+// QuestionEnemy 互斥题对，两题考查内容重叠或互相提示答案，不能出现在同一份试卷中
+type QuestionEnemy struct {
+	ID         uint `gorm:"primaryKey"`
+	QuestionID uint `gorm:"not null;uniqueIndex:idx_question_enemy"`
+	EnemyID    uint `gorm:"not null;uniqueIndex:idx_question_enemy"`
+}
+
+func (QuestionEnemy) TableName() string {
+	return "question_enemies"
+}
+
+// QuestionCategoryParameter 多级计分题的类别阈值，ItemModel 读自题目参数表
+type QuestionCategoryParameter struct {
+	ID         uint `gorm:"primaryKey"`
+	QuestionID uint
+	Category   int
+	Threshold  float64
+	ItemModel  string `gorm:"->"`
+}
+
+func (QuestionCategoryParameter) TableName() string {
+	return "question_category_parameters"
+}
+
+// ItemParameter 题目已标定的IRT参数，读自题目参数表；选题、能力估计与曝光模拟都以此为准，
+// 题目表上的难度、区分度等列不再参与计算
+type ItemParameter struct {
+	QuestionID     uint
+	ItemModel      string
+	Difficulty     float64
+	Discrimination float64
+	Guessing       float64
+	UpperAsymptote float64
+}
+
+func (ItemParameter) TableName() string {
+	return "question_parameters"
+}
@@ -11,4 +11,10 @@ type QuestionResponse struct {
 	TimeSpent  int64   `gorm:"default:0"` // in seconds
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+
+	// 自适应考试会话中的作答所属会话，按考试记录提交的作答为0
+	ExamSessionID uint `gorm:"not null;default:0;index"`
+
+	// 施测时为试测题，不计分、不参与能力估计
+	Pretest bool `gorm:"not null;default:false"`
 }
@@ -0,0 +1,27 @@
+package models
+
+import "irt-exam-system/backend/internal/domain/irt"
+
+// Subject 科目及其项目反应模型配置
+type Subject struct {
+	ID              uint `gorm:"primaryKey"`
+	Name            string
+	IRTModel        string  `gorm:"not null;default:'3PL'"` // Rasch、1PL、2PL、3PL、4PL
+	ScalingConstant float64 `gorm:"not null;default:1.7"`   // 量尺因子 D，1.0 或 1.7
+}
+
+func (Subject) TableName() string {
+	return "subjects"
+}
+
+// ResponseModel 科目使用的项目反应模型，科目不存在或未配置时为默认3PL
+func (s *Subject) ResponseModel() irt.Model {
+	if s == nil {
+		return irt.DefaultModel()
+	}
+	model := irt.Model{Family: irt.ModelFamily(s.IRTModel), D: s.ScalingConstant}
+	if model.Validate() != nil {
+		return irt.DefaultModel()
+	}
+	return model
+}
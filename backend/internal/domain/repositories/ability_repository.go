@@ -20,10 +20,20 @@ type AbilityRepository interface {
 	ListUserEstimations(ctx context.Context, userID uint, offset, limit int) ([]*models.AbilityEstimation, int64, error)
 	ListSubjectEstimations(ctx context.Context, subjectID uint, offset, limit int) ([]*models.AbilityEstimation, int64, error)
 	GetLatestEstimation(ctx context.Context, userID, subjectID uint) (*models.AbilityEstimation, error)
+	// ListUserSubjectEstimations 考生在科目内的全部能力值估计，按估计时间升序
+	ListUserSubjectEstimations(ctx context.Context, userID, subjectID uint) ([]*models.AbilityEstimation, error)
 
 	// 题目参数操作
 	UpdateQuestionParameters(ctx context.Context, params *models.QuestionParameter) error
 	GetQuestionParameters(ctx context.Context, questionID uint) (*models.QuestionParameter, error)
 	BatchUpdateParameters(ctx context.Context, params []*models.QuestionParameter) error
 	ListQuestionParameters(ctx context.Context, questionIDs []uint) ([]*models.QuestionParameter, error)
+	// ListQuestions 批量查询题目，用于读取题目的科目、分值与试测标记
+	ListQuestions(ctx context.Context, questionIDs []uint) ([]*models.Question, error)
+	// SaveItemModel 保存题目参数并替换其多级计分类别阈值
+	SaveItemModel(ctx context.Context, params *models.QuestionParameter) error
+
+	// 作答数据操作
+	// ListSubjectResponses 列出科目下全部作答记录，预加载题目与考试记录
+	ListSubjectResponses(ctx context.Context, subjectID uint) ([]*models.ExamResponse, error)
 }
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/internal/domain/models"
+)
+
+// BlueprintRepository 试卷内容蓝图仓储接口
+type BlueprintRepository interface {
+	FindByPaperID(ctx context.Context, examPaperID uint) (*models.ExamBlueprint, error)
+	// Save 保存蓝图并整体替换其约束
+	Save(ctx context.Context, blueprint *models.ExamBlueprint) error
+	Delete(ctx context.Context, examPaperID uint) error
+}
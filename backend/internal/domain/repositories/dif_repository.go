@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/models"
+)
+
+// DIFRepository DIF 分析仓储接口
+type DIFRepository interface {
+	// ReplaceResults 以本次分析结果替换同一科目、分组属性与组别下的旧结果
+	ReplaceResults(ctx context.Context, subjectID uint, attribute, reference, focal string, results []*models.DIFResult) error
+	ListResults(ctx context.Context, subjectID uint, attribute string) ([]*models.DIFResult, error)
+	ListUsers(ctx context.Context, userIDs []uint) ([]*models.User, error)
+}
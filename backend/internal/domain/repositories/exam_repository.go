@@ -16,6 +16,8 @@ type ExamRepository interface {
 	ListPapers(ctx context.Context, offset, limit int) ([]*models.ExamPaper, int64, error)
 	ListPapersBySubject(ctx context.Context, subjectID uint, offset, limit int) ([]*models.ExamPaper, int64, error)
 	ListPapersByStatus(ctx context.Context, status string, offset, limit int) ([]*models.ExamPaper, int64, error)
+	// CreatePaperWithQuestions 在同一事务中创建试卷及其题目关联
+	CreatePaperWithQuestions(ctx context.Context, paper *models.ExamPaper, questions []*models.ExamPaperQuestion) error
 
 	// 考试记录相关
 	CreateRecord(ctx context.Context, record *models.ExamSession) error
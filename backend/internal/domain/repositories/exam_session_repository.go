@@ -7,8 +7,15 @@ import (
 
 type ExamSessionRepository interface {
 	Create(ctx context.Context, session *models.ExamSession) error
+	// FindByID 会话不存在时返回 nil
 	FindByID(ctx context.Context, id uint) (*models.ExamSession, error)
 	SaveResponse(ctx context.Context, response *models.QuestionResponse) error
-	UpdateAbility(ctx context.Context, sessionID uint, newAbility float64) error
+	UpdateAbility(ctx context.Context, sessionID uint, newAbility, standardError float64) error
 	GetResponses(ctx context.Context, sessionID uint) ([]*models.QuestionResponse, error)
+	// Finish 结束会话并记录结束原因
+	Finish(ctx context.Context, session *models.ExamSession) error
+
+	// 曝光控制拒绝的题目
+	SaveRejections(ctx context.Context, sessionID uint, questionIDs []uint) error
+	ListRejectedQuestionIDs(ctx context.Context, sessionID uint) ([]uint, error)
 }
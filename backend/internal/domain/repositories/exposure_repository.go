@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/internal/domain/models"
+)
+
+// ExposureRepository 题目曝光控制仓储接口
+type ExposureRepository interface {
+	// 曝光控制配置
+	FindSetting(ctx context.Context, subjectID uint) (*models.ExposureSetting, error)
+	SaveSetting(ctx context.Context, setting *models.ExposureSetting) error
+
+	// 题目曝光统计
+	ListSubjectExposures(ctx context.Context, subjectID uint) ([]*models.ItemExposure, error)
+	SaveExposureParameters(ctx context.Context, subjectID uint, parameters map[uint]float64) error
+	IncrementAdministered(ctx context.Context, subjectID, questionID uint) error
+	CountSubjectSessions(ctx context.Context, subjectID uint) (int64, error)
+}
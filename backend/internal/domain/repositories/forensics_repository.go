@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/models"
+)
+
+// ForensicsRepository 作答异常与作弊检测仓储接口
+type ForensicsRepository interface {
+	FindPaper(ctx context.Context, paperID uint) (*models.ExamPaper, error)
+	// ListPaperSubmissions 列出试卷全部考试记录的每一次答案提交（含被后续提交覆盖的），
+	// 按考试记录与提交时间排序，预加载题目及其选项与考试记录
+	ListPaperSubmissions(ctx context.Context, paperID uint) ([]*models.ExamResponse, error)
+}
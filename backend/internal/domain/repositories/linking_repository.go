@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/models"
+)
+
+// LinkingRepository 量尺连接与等值仓储接口
+type LinkingRepository interface {
+	FindPaper(ctx context.Context, paperID uint) (*models.ExamPaper, error)
+	// ListPaperQuestions 按题目顺序列出试卷题目关联，预加载题目
+	ListPaperQuestions(ctx context.Context, paperID uint) ([]*models.ExamPaperQuestion, error)
+	// ListPaperResponses 列出试卷全部考试记录的作答，预加载题目与考试记录
+	ListPaperResponses(ctx context.Context, paperID uint) ([]*models.ExamResponse, error)
+
+	// 量尺转换历史
+	CreateTransformation(ctx context.Context, transformation *models.ScaleTransformation) error
+	ListTransformations(ctx context.Context, subjectID uint) ([]*models.ScaleTransformation, error)
+	// ApplyTransformation 在同一事务中保存转换记录与转换后的题目参数，
+	// 并将 recordIDs 对应的能力估计及 userIDs 在该科目的当前能力值转换到基准量尺
+	ApplyTransformation(ctx context.Context, transformation *models.ScaleTransformation, params []*models.QuestionParameter, recordIDs, userIDs []uint) error
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/models"
+)
+
+// MIRTRepository 多维标定与考生能力剖面仓储接口
+type MIRTRepository interface {
+	// 多维标定
+	// FindActiveCalibration 科目当前启用的最新版本多维标定，预加载维度、相关与题目参数，未标定时返回 nil
+	FindActiveCalibration(ctx context.Context, subjectID uint) (*models.MIRTCalibration, error)
+	FindCalibration(ctx context.Context, calibrationID uint) (*models.MIRTCalibration, error)
+	// ListCalibrations 科目的全部多维标定，只预加载维度与相关
+	ListCalibrations(ctx context.Context, subjectID uint) ([]*models.MIRTCalibration, error)
+	// CreateCalibration 以科目下一个版本号保存多维标定，并停用该科目的其他版本
+	CreateCalibration(ctx context.Context, calibration *models.MIRTCalibration) error
+
+	// 能力剖面
+	FindProfile(ctx context.Context, userID, subjectID uint) (*models.UserAbilityProfile, error)
+	// SaveProfile 保存考生能力剖面，替换该考生在科目下已有的剖面
+	SaveProfile(ctx context.Context, profile *models.UserAbilityProfile) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/internal/domain/models"
+)
+
+// MSTRepository 多阶段测验板与会话模块路径仓储接口
+type MSTRepository interface {
+	// CreatePanel 连同模块、模块题目、路由规则与切点一并保存
+	CreatePanel(ctx context.Context, panel *models.MSTPanel) error
+	// FindPanel 预加载模块题目与路由切点，不存在时返回 nil
+	FindPanel(ctx context.Context, panelID uint) (*models.MSTPanel, error)
+	ListPanels(ctx context.Context, examPaperID uint) ([]*models.MSTPanel, error)
+	ListActivePanels(ctx context.Context, examPaperID uint) ([]*models.MSTPanel, error)
+	SetPanelActive(ctx context.Context, panelID uint, active bool) error
+
+	// 会话模块路径
+	CreateSessionModule(ctx context.Context, module *models.ExamSessionModule) error
+	// CompleteSessionModule 记录模块完成时间与路由值
+	CompleteSessionModule(ctx context.Context, module *models.ExamSessionModule) error
+	// ListSessionModules 按阶段顺序返回会话的模块路径
+	ListSessionModules(ctx context.Context, sessionID uint) ([]*models.ExamSessionModule, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/models"
+)
+
+// PerformanceLevelRepository 科目表现水平仓储接口
+type PerformanceLevelRepository interface {
+	// FindActiveSet 科目当前启用的最新版本表现水平，预加载各水平，未设定时返回 nil
+	FindActiveSet(ctx context.Context, subjectID uint) (*models.PerformanceLevelSet, error)
+	ListSets(ctx context.Context, subjectID uint) ([]*models.PerformanceLevelSet, error)
+	// CreateSet 以科目下一个版本号保存表现水平，并停用该科目的其他版本
+	CreateSet(ctx context.Context, set *models.PerformanceLevelSet) error
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/models"
+)
+
+// PretestRepository 试测题嵌入与在线标定仓储接口
+type PretestRepository interface {
+	// 科目配置
+	FindSetting(ctx context.Context, subjectID uint) (*models.PretestSetting, error)
+	SaveSetting(ctx context.Context, setting *models.PretestSetting) error
+
+	// SetPretest 标记或取消标记科目中的试测题，返回实际更新的题目数
+	SetPretest(ctx context.Context, subjectID uint, questionIDs []uint, pretest bool) (int64, error)
+	// ListPretestItems 列出科目中除 excludeIDs 外的试测题及其已收集的作答数（固定卷与自适应会话合计）
+	ListPretestItems(ctx context.Context, subjectID uint, excludeIDs []uint) ([]*models.PretestItem, error)
+	// ListResponses 列出科目下全部考试记录与自适应会话的作答，按考生归并顺序排列
+	ListResponses(ctx context.Context, subjectID uint) ([]*models.PretestResponse, error)
+
+	// 在线标定结果
+	SaveCalibrations(ctx context.Context, calibrations []*models.PretestCalibration) error
+	ListCalibrations(ctx context.Context, subjectID uint) ([]*models.PretestCalibration, error)
+	// Promote 在同一事务中写入转正题目的参数并取消试测标记，同时更新自适应选题使用的题目参数
+	Promote(ctx context.Context, calibrations []*models.PretestCalibration, params []*models.QuestionParameter) error
+}
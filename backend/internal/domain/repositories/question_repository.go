@@ -20,7 +20,8 @@ type QuestionRepository interface {
 	ListByType(ctx context.Context, questionType string, offset, limit int) ([]*models.Question, int64, error)
 	Search(ctx context.Context, keyword string, offset, limit int) ([]*models.Question, int64, error)
 	ListByExamPaper(ctx context.Context, examPaperID uint) ([]*models.Question, error)
-	FindByDifficulty(ctx context.Context, difficulty float64) (*models.Question, error)
+	// ListCandidates 列出科目题库中除已作答题目外的全部候选题，试测题不参与正式选题与组卷
+	ListCandidates(ctx context.Context, subjectID uint, excludeIDs []uint) ([]*models.Question, error)
 
 	// 选项操作
 	CreateOption(ctx context.Context, option *models.QuestionOption) error
@@ -32,9 +33,21 @@ type QuestionRepository interface {
 	AddKnowledgePoint(ctx context.Context, questionID, knowledgePointID uint) error
 	RemoveKnowledgePoint(ctx context.Context, questionID, knowledgePointID uint) error
 	ListKnowledgePoints(ctx context.Context, questionID uint) ([]*models.KnowledgePoint, error)
+	// ListKnowledgePointLinks 批量查询题目与知识点的关联
+	ListKnowledgePointLinks(ctx context.Context, questionIDs []uint) ([]*models.QuestionKnowledgePoint, error)
+
+	// 互斥题对
+	AddEnemy(ctx context.Context, questionID, enemyID uint) error
+	RemoveEnemy(ctx context.Context, questionID, enemyID uint) error
+	// ListEnemyPairs 列出涉及给定题目的全部互斥题对
+	ListEnemyPairs(ctx context.Context, questionIDs []uint) ([]*models.QuestionEnemy, error)
 
 	// IRT参数操作
 	UpdateParameters(ctx context.Context, params *models.QuestionParameter) error
 	GetParameters(ctx context.Context, questionID uint) (*models.QuestionParameter, error)
 	BatchGetParameters(ctx context.Context, questionIDs []uint) ([]*models.QuestionParameter, error)
+	// ListItemParameters 批量查询题目已标定的IRT参数，未标定的题目没有记录
+	ListItemParameters(ctx context.Context, questionIDs []uint) ([]*models.ItemParameter, error)
+	// ListCategoryParameters 批量查询多级计分题的类别阈值，按题目与类别排序
+	ListCategoryParameters(ctx context.Context, questionIDs []uint) ([]*models.QuestionCategoryParameter, error)
 }
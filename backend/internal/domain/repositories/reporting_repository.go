@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"irt-exam-system/backend/models"
+)
+
+// ReportingRepository 报告分数量表与常模仓储接口
+type ReportingRepository interface {
+	// 量表
+	// FindActiveScale 科目当前启用的最新版本量表，预加载转换表，未配置时返回 nil
+	FindActiveScale(ctx context.Context, subjectID uint) (*models.ReportingScale, error)
+	FindScale(ctx context.Context, scaleID uint) (*models.ReportingScale, error)
+	ListScales(ctx context.Context, subjectID uint) ([]*models.ReportingScale, error)
+	// CreateScale 以科目下一个版本号保存量表，并停用该科目的其他版本
+	CreateScale(ctx context.Context, scale *models.ReportingScale) error
+
+	// 常模表
+	// FindActiveNormTable 量表当前启用的最新版本常模表，预加载常模条目，未建立时返回 nil
+	FindActiveNormTable(ctx context.Context, scaleID uint) (*models.NormTable, error)
+	FindNormTable(ctx context.Context, tableID uint) (*models.NormTable, error)
+	ListNormTables(ctx context.Context, scaleID uint) ([]*models.NormTable, error)
+	// CreateNormTable 以量表下一个版本号保存常模表及其条目，并停用该量表的其他常模表
+	CreateNormTable(ctx context.Context, table *models.NormTable) error
+
+	// ListCohortAbilities 参照群体：科目下能力值更新时间在范围内的考生，范围为空时不限
+	ListCohortAbilities(ctx context.Context, subjectID uint, from, to *time.Time) ([]*models.UserAbility, error)
+}
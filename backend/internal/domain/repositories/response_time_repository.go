@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/models"
+)
+
+// ResponseTimeRepository 作答时间模型仓储接口
+type ResponseTimeRepository interface {
+	// ListTimedResponses 列出科目下全部考试记录与自适应会话中记录了用时的作答，
+	// 按考生归并、考生内按施测顺序排列
+	ListTimedResponses(ctx context.Context, subjectID uint) ([]*models.TimedResponse, error)
+
+	// 题目时间参数
+	ListParameters(ctx context.Context, questionIDs []uint) ([]*models.ResponseTimeParameter, error)
+	// SaveCalibration 在同一事务中保存题目时间参数与考生总体分布
+	SaveCalibration(ctx context.Context, params []*models.ResponseTimeParameter, population *models.SpeedPopulation) error
+
+	// FindPopulation 科目考生能力与速度的总体分布，未标定时返回 nil
+	FindPopulation(ctx context.Context, subjectID uint) (*models.SpeedPopulation, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/models"
+)
+
+// StandardSettingRepository 标准设定会议仓储接口
+type StandardSettingRepository interface {
+	// 会议
+	CreateSession(ctx context.Context, session *models.StandardSettingSession) error
+	// FindSession 预加载表现水平，不存在时返回 nil
+	FindSession(ctx context.Context, sessionID uint) (*models.StandardSettingSession, error)
+	ListSessions(ctx context.Context, subjectID uint) ([]*models.StandardSettingSession, error)
+	UpdateSession(ctx context.Context, session *models.StandardSettingSession) error
+
+	// 评定
+	// SaveRatings 保存评定，同一评委同一轮次对同一分界点与题目的重复评定覆盖原值
+	SaveRatings(ctx context.Context, ratings []*models.StandardSettingRating) error
+	ListRatings(ctx context.Context, sessionID uint, round int) ([]*models.StandardSettingRating, error)
+
+	// 试卷与参照群体
+	FindPaper(ctx context.Context, paperID uint) (*models.ExamPaper, error)
+	// ListPaperQuestions 按题目顺序列出试卷题目关联，预加载题目
+	ListPaperQuestions(ctx context.Context, paperID uint) ([]*models.ExamPaperQuestion, error)
+	// ListSubjectThetas 科目全部考生的当前能力值，用于影响分析
+	ListSubjectThetas(ctx context.Context, subjectID uint) ([]float64, error)
+}
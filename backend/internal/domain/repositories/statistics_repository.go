@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/models"
+)
+
+// StatisticsRepository 试卷经典题目统计仓储接口
+type StatisticsRepository interface {
+	FindPaper(ctx context.Context, paperID uint) (*models.ExamPaper, error)
+	// ListPaperQuestions 按题目顺序列出试卷题目关联，预加载题目及其选项
+	ListPaperQuestions(ctx context.Context, paperID uint) ([]*models.ExamPaperQuestion, error)
+	// ListCompletedResponses 列出试卷已交卷考试记录的作答，预加载题目与考试记录
+	ListCompletedResponses(ctx context.Context, paperID uint) ([]*models.ExamResponse, error)
+}
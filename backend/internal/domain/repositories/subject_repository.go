@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/internal/domain/models"
+)
+
+// SubjectRepository 科目仓储接口
+type SubjectRepository interface {
+	FindByID(ctx context.Context, id uint) (*models.Subject, error)
+	// UpdateIRTModel 更新科目的项目反应模型与量尺因子
+	UpdateIRTModel(ctx context.Context, id uint, family string, scaling float64) error
+}
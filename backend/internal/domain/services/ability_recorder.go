@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+// AbilityRecorder 记录考生能力值估计历史并更新其当前能力值，由应用层能力值服务实现
+type AbilityRecorder interface {
+	// 保存自适应考试会话结束时的能力值估计
+	SaveSessionEstimation(ctx context.Context, userID, subjectID, sessionID uint, estimate *irt.AbilityEstimate) error
+}
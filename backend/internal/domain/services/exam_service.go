@@ -2,11 +2,20 @@ package services
 
 import (
 	"context"
+	"errors"
+
 	"irt-exam-system/backend/internal/domain/models"
 )
 
+var (
+	ErrExamPaperNotFound    = errors.New("exam paper not found")
+	ErrSessionNotFound      = errors.New("exam session not found")
+	ErrSessionNotInProgress = errors.New("exam session is not in progress")
+	ErrQuestionNotInModule  = errors.New("question does not belong to the current module")
+)
+
 type ExamService interface {
-	StartExam(ctx context.Context, userID uint) (*models.ExamSessionResponse, error)
+	StartExam(ctx context.Context, userID, paperID uint) (*models.ExamSessionResponse, error)
 	GetNextQuestion(ctx context.Context, sessionID uint) (*models.QuestionDTO, error)
 	SubmitAnswer(ctx context.Context, sessionID uint, answer *models.AnswerRequest) (*models.AnswerResponse, error)
 }
@@ -3,48 +3,121 @@ package services
 import (
 	"context"
 	"errors"
+	"math"
+	"math/rand"
+	"strings"
 	"time"
+	"unicode"
 
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
 	"irt-exam-system/backend/internal/domain/models"
 	"irt-exam-system/backend/internal/domain/repositories"
-	"irt-exam-system/backend/pkg/utils"
 )
 
 type ExamServiceImpl struct {
-	questionRepo    repositories.QuestionRepository
-	examSessionRepo repositories.ExamSessionRepository
-	irtService      IRTService
+	questionRepo     repositories.QuestionRepository
+	examRepo         repositories.ExamRepository
+	examSessionRepo  repositories.ExamSessionRepository
+	exposureRepo     repositories.ExposureRepository
+	blueprintRepo    repositories.BlueprintRepository
+	subjectRepo      repositories.SubjectRepository
+	pretestRepo      repositories.PretestRepository
+	responseTimeRepo repositories.ResponseTimeRepository
+	reportingRepo    repositories.ReportingRepository
+	performanceRepo  repositories.PerformanceLevelRepository
+	mstRepo          repositories.MSTRepository
+	mirtRepo         repositories.MIRTRepository
+	abilityRecorder  AbilityRecorder
+	irtService       IRTService
 }
 
 func NewExamService(
 	questionRepo repositories.QuestionRepository,
+	examRepo repositories.ExamRepository,
 	examSessionRepo repositories.ExamSessionRepository,
+	exposureRepo repositories.ExposureRepository,
+	blueprintRepo repositories.BlueprintRepository,
+	subjectRepo repositories.SubjectRepository,
+	pretestRepo repositories.PretestRepository,
+	responseTimeRepo repositories.ResponseTimeRepository,
+	reportingRepo repositories.ReportingRepository,
+	performanceRepo repositories.PerformanceLevelRepository,
+	mstRepo repositories.MSTRepository,
+	mirtRepo repositories.MIRTRepository,
+	abilityRecorder AbilityRecorder,
 	irtService IRTService,
 ) ExamService {
 	return &ExamServiceImpl{
-		questionRepo:    questionRepo,
-		examSessionRepo: examSessionRepo,
-		irtService:      irtService,
+		questionRepo:     questionRepo,
+		examRepo:         examRepo,
+		examSessionRepo:  examSessionRepo,
+		exposureRepo:     exposureRepo,
+		blueprintRepo:    blueprintRepo,
+		subjectRepo:      subjectRepo,
+		pretestRepo:      pretestRepo,
+		responseTimeRepo: responseTimeRepo,
+		reportingRepo:    reportingRepo,
+		performanceRepo:  performanceRepo,
+		mstRepo:          mstRepo,
+		mirtRepo:         mirtRepo,
+		abilityRecorder:  abilityRecorder,
+		irtService:       irtService,
 	}
 }
 
-func (s *ExamServiceImpl) StartExam(ctx context.Context, userID uint) (*models.ExamSessionResponse, error) {
+func (s *ExamServiceImpl) StartExam(ctx context.Context, userID, paperID uint) (*models.ExamSessionResponse, error) {
+	paper, err := s.examRepo.FindPaperByID(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+	if paper == nil {
+		return nil, ErrExamPaperNotFound
+	}
+
 	session := &models.ExamSession{
 		UserID:         userID,
+		ExamPaperID:    paper.ID,
 		StartTime:      time.Now(),
 		CurrentAbility: 0.0, // 初始能力值设为0
 		Status:         "in_progress",
 	}
 
+	// 试卷启用了多阶段测验板时随机分配一个平行板，从第一阶段的路由模块开始
+	panels, err := s.mstRepo.ListActivePanels(ctx, paper.ID)
+	if err != nil {
+		return nil, err
+	}
+	var panel *models.MSTPanel
+	if len(panels) > 0 {
+		panel = panels[rand.Intn(len(panels))]
+		session.PanelID = &panel.ID
+	}
+
 	if err := s.examSessionRepo.Create(ctx, session); err != nil {
 		return nil, err
 	}
 
-	return &models.ExamSessionResponse{
+	resp := &models.ExamSessionResponse{
 		ID:             session.ID,
 		StartTime:      session.StartTime.Format(time.RFC3339),
 		CurrentAbility: session.CurrentAbility,
-	}, nil
+	}
+	if panel != nil {
+		first := panel.Stages()[0][0]
+		entry := &models.ExamSessionModule{
+			ExamSessionID: session.ID,
+			Stage:         first.Stage,
+			ModuleID:      first.ID,
+			Label:         first.Label,
+		}
+		if err := s.mstRepo.CreateSessionModule(ctx, entry); err != nil {
+			return nil, err
+		}
+		resp.PanelID = session.PanelID
+		resp.ModulePath = []string{first.Label}
+	}
+	return resp, nil
 }
 
 func (s *ExamServiceImpl) GetNextQuestion(ctx context.Context, sessionID uint) (*models.QuestionDTO, error) {
@@ -52,15 +125,28 @@ func (s *ExamServiceImpl) GetNextQuestion(ctx context.Context, sessionID uint) (
 	if err != nil {
 		return nil, err
 	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
 
 	if session.Status != "in_progress" {
-		return nil, errors.New("exam session is not in progress")
+		return nil, ErrSessionNotInProgress
 	}
 
-	// 根据当前能力值选择合适难度的题目
-	question, err := s.questionRepo.FindByDifficulty(ctx, session.CurrentAbility)
+	var question *models.Question
+	if session.PanelID != nil {
+		question, err = s.nextModuleQuestion(ctx, session)
+	} else {
+		question, err = s.selectNextQuestion(ctx, session)
+	}
+	if errors.Is(err, irt.ErrNoCandidates) {
+		if err := s.finishSession(ctx, session, irt.StopDecision{Stop: true, Reason: irt.StopBankExhausted}); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
 	if err != nil {
-		return nil, errors.New("no suitable questions found")
+		return nil, err
 	}
 
 	return &models.QuestionDTO{
@@ -80,83 +166,866 @@ func (s *ExamServiceImpl) SubmitAnswer(ctx context.Context, sessionID uint, answ
 	if err != nil {
 		return nil, err
 	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if session.Status != "in_progress" {
+		return nil, ErrSessionNotInProgress
+	}
+	previousTheta := session.CurrentAbility
 
 	question, err := s.questionRepo.FindByID(ctx, answer.QuestionID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 记录答题结果
-	isCorrect := answer.Answer == question.Answer
+	// 多阶段测验只接受当前模块中的题目
+	var mst *mstState
+	if session.PanelID != nil {
+		mst, err = s.loadMSTState(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		if !mst.inModule(question.ID) {
+			return nil, ErrQuestionNotInModule
+		}
+	}
+
+	// 记录答题结果，得分按题目满分折算，多选题按得分率计部分分
 	response := &models.QuestionResponse{
 		ExamSessionID: sessionID,
 		QuestionID:    question.ID,
-		UserAnswer:    answer.Answer,
-		IsCorrect:     isCorrect,
-		TimeSpent:     answer.TimeSpent,
+		Answer:        answer.Answer,
+		Score:         answerCredit(question, answer.Answer) * fullMarks(question),
+		TimeSpent:     int64(answer.TimeSpent),
+		Pretest:       question.Pretest,
 	}
 	if err := s.examSessionRepo.SaveResponse(ctx, response); err != nil {
 		return nil, err
 	}
+	isCorrect := responseCredit(response, question) == 1
 
-	// 更新考生能力值估计
-	newAbility := s.irtService.EstimateAbility(
-		session.CurrentAbility,
-		question.Difficulty,
-		question.Discrimination,
-		question.GuessParameter,
-		isCorrect,
-	)
+	// 试测题不计分，能力估计与终止判断保持不变
+	if question.Pretest {
+		return &models.AnswerResponse{
+			IsCorrect:      isCorrect,
+			CurrentAbility: session.CurrentAbility,
+			StandardError:  session.StandardError,
+			NextDifficulty: s.irtService.GetNextQuestionDifficulty(session.CurrentAbility),
+		}, nil
+	}
 
-	if err := s.examSessionRepo.UpdateAbility(ctx, sessionID, newAbility); err != nil {
+	paper, err := s.examRepo.FindPaperByID(ctx, session.ExamPaperID)
+	if err != nil {
+		return nil, err
+	}
+	if paper == nil {
+		return nil, ErrExamPaperNotFound
+	}
+	model, err := s.subjectModel(ctx, paper.SubjectID)
+	if err != nil {
 		return nil, err
 	}
 
-	// 计算下一题的建议难度
-	nextDifficulty := s.irtService.GetNextQuestionDifficulty(newAbility)
+	// 逐题自适应考试的计分题在提交作答时计入曝光次数，重复获取下一题不会重复计数
+	if mst == nil {
+		if err := s.exposureRepo.IncrementAdministered(ctx, paper.SubjectID, question.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 基于本场全部作答记录更新考生能力值估计
+	items, err := s.buildItemResponses(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := s.irtService.EstimateAbilityFromResponses(model, items)
+	if err != nil {
+		return nil, err
+	}
+	newAbility := estimate.Theta
+	session.CurrentAbility = newAbility
+	session.StandardError = estimate.StandardError
 
-	return &models.AnswerResponse{
+	if err := s.examSessionRepo.UpdateAbility(ctx, sessionID, newAbility, estimate.StandardError); err != nil {
+		return nil, err
+	}
+
+	var decision irt.StopDecision
+	if mst != nil {
+		// 多阶段测验完成模块后路由到下一阶段，完成最后阶段或超时时结束
+		decision, err = s.advanceModule(ctx, paper, session, mst, estimate)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// 按试卷终止规则判断会话是否结束
+		decision = paper.StoppingRule(model).Evaluate(irt.StoppingState{
+			Responses:     items,
+			Estimate:      estimate,
+			PreviousTheta: previousTheta,
+			Elapsed:       time.Since(session.StartTime),
+		})
+	}
+
+	result := &models.AnswerResponse{
 		IsCorrect:      isCorrect,
 		CurrentAbility: newAbility,
-		NextDifficulty: nextDifficulty,
-	}, nil
+		StandardError:  estimate.StandardError,
+	}
+	if mst != nil {
+		result.ModulePath = mst.labels()
+	}
+	if decision.Stop {
+		if err := s.finishSession(ctx, session, decision); err != nil {
+			return nil, err
+		}
+		result.Finished = true
+		result.StopReason = session.StopReason
+		result.Classification = session.Classification
+		result.EndTime = session.EndTime.Format(time.RFC3339)
+		if err := s.reportScaledScore(ctx, paper.SubjectID, result); err != nil {
+			return nil, err
+		}
+		if err := s.reportPerformanceLevel(ctx, paper.SubjectID, result); err != nil {
+			return nil, err
+		}
+		if err := s.saveAbilityProfile(ctx, session, paper.SubjectID); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	// 计算下一题的建议难度
+	result.NextDifficulty = s.irtService.GetNextQuestionDifficulty(newAbility)
+	return result, nil
 }
 
-// calculateTestInformation 计算整个测验的信息量
-func (s *ExamServiceImpl) calculateTestInformation(ctx context.Context, ability float64, questions []*models.Question) float64 {
-	var totalInfo float64
-	for _, q := range questions {
-		info := utils.CalculateItemInformation(
-			ability,
-			q.Difficulty,
-			q.Discrimination,
-			q.GuessParameter,
-		)
-		totalInfo += info
+// finishSession 结束会话并记录结束原因，本场的最终能力值估计写入考生能力值历史
+func (s *ExamServiceImpl) finishSession(ctx context.Context, session *models.ExamSession, decision irt.StopDecision) error {
+	session.Status = "completed"
+	session.EndTime = time.Now()
+	session.StopReason = string(decision.Reason)
+	session.Classification = decision.Classification
+	if err := s.examSessionRepo.Finish(ctx, session); err != nil {
+		return err
+	}
+	return s.saveEstimation(ctx, session)
+}
+
+// saveEstimation 由本场全部计分题作答估计最终能力值并保存，本场没有计分题作答时不保存
+func (s *ExamServiceImpl) saveEstimation(ctx context.Context, session *models.ExamSession) error {
+	paper, err := s.examRepo.FindPaperByID(ctx, session.ExamPaperID)
+	if err != nil {
+		return err
+	}
+	if paper == nil {
+		return ErrExamPaperNotFound
+	}
+	items, err := s.buildItemResponses(ctx, session.ID)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	model, err := s.subjectModel(ctx, paper.SubjectID)
+	if err != nil {
+		return err
+	}
+	estimate, err := s.irtService.EstimateAbilityFromResponses(model, items)
+	if err != nil {
+		return err
+	}
+	return s.abilityRecorder.SaveSessionEstimation(ctx, session.UserID, paper.SubjectID, session.ID, estimate)
+}
+
+// reportScaledScore 按科目当前量表与常模填写最终能力值的报告分数，科目未配置量表时不填写
+func (s *ExamServiceImpl) reportScaledScore(ctx context.Context, subjectID uint, result *models.AnswerResponse) error {
+	scale, err := s.reportingRepo.FindActiveScale(ctx, subjectID)
+	if err != nil || scale == nil {
+		return err
+	}
+	conversion := scale.IRTScale()
+	score := conversion.Score(result.CurrentAbility)
+	sem := conversion.StandardError(result.CurrentAbility, result.StandardError)
+	result.ScaledScore = &score
+	result.ScaledSEM = &sem
+
+	norms, err := s.reportingRepo.FindActiveNormTable(ctx, scale.ID)
+	if err != nil || norms == nil {
+		return err
+	}
+	rank := analysis.PercentileRank(norms.NormEntries(), score)
+	result.PercentileRank = &rank
+	return nil
+}
+
+// reportPerformanceLevel 按科目启用的表现水平填写最终能力值所属水平，科目未设定表现水平时不填写
+func (s *ExamServiceImpl) reportPerformanceLevel(ctx context.Context, subjectID uint, result *models.AnswerResponse) error {
+	set, err := s.performanceRepo.FindActiveSet(ctx, subjectID)
+	if err != nil || set == nil {
+		return err
+	}
+	if level := set.Classify(result.CurrentAbility); level != nil {
+		result.PerformanceLevel = level.Name
+	}
+	return nil
+}
+
+// mstState 多阶段测验会话的测验板、模块路径与当前模块
+type mstState struct {
+	panel   *models.MSTPanel
+	path    []*models.ExamSessionModule
+	current *models.ExamSessionModule
+	module  *models.MSTModule
+}
+
+// inModule 题目是否属于当前模块
+func (m *mstState) inModule(questionID uint) bool {
+	for _, q := range m.module.Questions {
+		if q.QuestionID == questionID {
+			return true
+		}
+	}
+	return false
+}
+
+// labels 模块路径上的模块标签
+func (m *mstState) labels() []string {
+	labels := make([]string, len(m.path))
+	for i, entry := range m.path {
+		labels[i] = entry.Label
+	}
+	return labels
+}
+
+// loadMSTState 读取会话分配的测验板与模块路径，路径最后一个模块为当前模块
+func (s *ExamServiceImpl) loadMSTState(ctx context.Context, session *models.ExamSession) (*mstState, error) {
+	panel, err := s.mstRepo.FindPanel(ctx, *session.PanelID)
+	if err != nil {
+		return nil, err
+	}
+	if panel == nil {
+		return nil, errors.New("mst panel not found")
+	}
+	path, err := s.mstRepo.ListSessionModules(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return nil, errors.New("exam session has no current module")
+	}
+	current := path[len(path)-1]
+	module := panel.Module(current.ModuleID)
+	if module == nil {
+		return nil, errors.New("current module not found in panel")
 	}
-	return totalInfo
+	return &mstState{panel: panel, path: path, current: current, module: module}, nil
 }
 
-// shouldStopTest 判断是否应该结束测验
-func (s *ExamServiceImpl) shouldStopTest(ctx context.Context, session *models.ExamSession) bool {
+// nextModuleQuestion 按顺序返回当前模块中第一道未作答的题目
+func (s *ExamServiceImpl) nextModuleQuestion(ctx context.Context, session *models.ExamSession) (*models.Question, error) {
+	mst, err := s.loadMSTState(ctx, session)
+	if err != nil {
+		return nil, err
+	}
 	responses, err := s.examSessionRepo.GetResponses(ctx, session.ID)
 	if err != nil {
-		return true
+		return nil, err
+	}
+	answered := make(map[uint]bool, len(responses))
+	for _, resp := range responses {
+		answered[resp.QuestionID] = true
+	}
+	for _, q := range mst.module.OrderedQuestions() {
+		if !answered[q.QuestionID] {
+			return s.questionRepo.FindByID(ctx, q.QuestionID)
+		}
+	}
+	return nil, irt.ErrNoCandidates
+}
+
+// advanceModule 当前模块作答完毕时按路由规则进入下一阶段模块；
+// 时间上限仍取试卷时长，完成最后阶段的模块时结束会话
+func (s *ExamServiceImpl) advanceModule(ctx context.Context, paper *models.ExamPaper, session *models.ExamSession, mst *mstState, estimate *irt.AbilityEstimate) (irt.StopDecision, error) {
+	timeLimit := irt.StoppingRule{TimeLimit: time.Duration(paper.Duration) * time.Minute}
+	if decision := timeLimit.Evaluate(irt.StoppingState{Elapsed: time.Since(session.StartTime)}); decision.Stop {
+		return decision, nil
+	}
+
+	responses, err := s.examSessionRepo.GetResponses(ctx, session.ID)
+	if err != nil {
+		return irt.StopDecision{}, err
+	}
+	credits, err := s.responseCredits(ctx, responses)
+	if err != nil {
+		return irt.StopDecision{}, err
+	}
+	var numberCorrect float64
+	for _, q := range mst.module.Questions {
+		credit, ok := credits[q.QuestionID]
+		if !ok {
+			return irt.StopDecision{}, nil
+		}
+		numberCorrect += credit
+	}
+
+	now := time.Now()
+	mst.current.CompletedAt = &now
+	stages := mst.panel.Stages()
+	if mst.module.Stage >= len(stages) {
+		if err := s.mstRepo.CompleteSessionModule(ctx, mst.current); err != nil {
+			return irt.StopDecision{}, err
+		}
+		return irt.StopDecision{Stop: true, Reason: irt.StopPanelCompleted}, nil
+	}
+
+	rule := mst.panel.Rule(mst.module)
+	if rule == nil {
+		return irt.StopDecision{}, errors.New("routing rule not found for module " + mst.module.Label)
+	}
+	value := estimate.Theta
+	if irt.RoutingMethod(rule.Method) == irt.RoutingNumberCorrect {
+		value = numberCorrect
+	}
+	candidates := stages[mst.module.Stage]
+	next := candidates[min(irt.Route(rule.CutValues(), value), len(candidates)-1)]
+
+	mst.current.RoutingValue = &value
+	if err := s.mstRepo.CompleteSessionModule(ctx, mst.current); err != nil {
+		return irt.StopDecision{}, err
+	}
+	entry := &models.ExamSessionModule{
+		ExamSessionID: session.ID,
+		Stage:         next.Stage,
+		ModuleID:      next.ID,
+		Label:         next.Label,
+	}
+	if err := s.mstRepo.CreateSessionModule(ctx, entry); err != nil {
+		return irt.StopDecision{}, err
+	}
+	mst.path = append(mst.path, entry)
+	return irt.StopDecision{}, nil
+}
+
+// subjectModel 科目配置的项目反应模型
+func (s *ExamServiceImpl) subjectModel(ctx context.Context, subjectID uint) (irt.Model, error) {
+	subject, err := s.subjectRepo.FindByID(ctx, subjectID)
+	if err != nil {
+		return irt.Model{}, err
+	}
+	return subject.ResponseModel(), nil
+}
+
+// selectNextQuestion 按试卷配置的选题策略，从未作答的题目中选出下一题
+func (s *ExamServiceImpl) selectNextQuestion(ctx context.Context, session *models.ExamSession) (*models.Question, error) {
+	paper, err := s.examRepo.FindPaperByID(ctx, session.ExamPaperID)
+	if err != nil {
+		return nil, err
+	}
+	if paper == nil {
+		return nil, ErrExamPaperNotFound
 	}
 
-	// 将 QuestionResponse 转换为 Question 切片
-	var questions []*models.Question
+	responses, err := s.examSessionRepo.GetResponses(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	answered := make([]uint, 0, len(responses))
+	administered := make([]*models.Question, 0, len(responses))
+	scored := make([]*models.QuestionResponse, 0, len(responses))
+	pretestCount := 0
 	for _, resp := range responses {
+		answered = append(answered, resp.QuestionID)
+		if resp.Pretest {
+			pretestCount++
+			continue
+		}
 		question, err := s.questionRepo.FindByID(ctx, resp.QuestionID)
 		if err != nil {
+			return nil, err
+		}
+		administered = append(administered, question)
+		scored = append(scored, resp)
+	}
+	timed, err := s.timedResponses(ctx, scored)
+	if err != nil {
+		return nil, err
+	}
+
+	pretest, err := s.nextPretestQuestion(ctx, paper.SubjectID, len(administered), pretestCount, answered)
+	if err != nil {
+		return nil, err
+	}
+	if pretest != nil {
+		return pretest, nil
+	}
+
+	// 本场已作答及被曝光控制拒绝的题目不再参与选题
+	rejected, err := s.examSessionRepo.ListRejectedQuestionIDs(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.questionRepo.ListCandidates(ctx, paper.SubjectID, append(answered, rejected...))
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.toCandidates(ctx, questions)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := irt.NewItemSelector(irt.SelectionStrategy(paper.SelectionStrategy))
+	if err != nil {
+		return nil, err
+	}
+	selector, err = s.balancedSelector(ctx, paper.ID, selector, administered)
+	if err != nil {
+		return nil, err
+	}
+
+	testLength := paper.MaxItems
+	if testLength <= 0 {
+		testLength = irt.DefaultStoppingRule().MaxItems
+	}
+	model, err := s.subjectModel(ctx, paper.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	state := irt.SelectionState{
+		Theta:             session.CurrentAbility,
+		StandardError:     session.StandardError,
+		ItemsAdministered: len(administered),
+		TestLength:        testLength,
+		Model:             model,
+	}
+	if paper.TimeAwareSelection {
+		if err := s.timeAwareState(ctx, paper, session, timed, &state); err != nil {
+			return nil, err
+		}
+		selector = irt.NewTimeAwareSelector(selector)
+	}
+	if irt.SelectionStrategy(paper.SelectionStrategy) == irt.StrategyDOptimal {
+		if err := s.multidimensionalState(ctx, paper.SubjectID, responses, candidates, &state); err != nil {
+			return nil, err
+		}
+	}
+	exposure, err := s.exposureControl(ctx, paper.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := irt.RankCandidates(selector, candidates, state)
+	idx, considered, err := exposure.Choose(ranked, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// 曝光次数在提交作答时计入；被 Sympson–Hetter 拒绝的题目记入本场，后续选题不再考虑
+	chosen := candidates[idx].QuestionID
+	rejections := make([]uint, 0, len(considered))
+	for _, i := range considered {
+		if i != idx {
+			rejections = append(rejections, candidates[i].QuestionID)
+		}
+	}
+	if err := s.examSessionRepo.SaveRejections(ctx, session.ID, rejections); err != nil {
+		return nil, err
+	}
+	for _, q := range questions {
+		if q.ID == chosen {
+			return q, nil
+		}
+	}
+	return nil, irt.ErrNoCandidates
+}
+
+// timeAwareState 限时选题：由本场计分题用时估计考生速度，速度先验取科目考生总体分布，
+// 剩余时间取试卷时长减去已用时间
+func (s *ExamServiceImpl) timeAwareState(ctx context.Context, paper *models.ExamPaper, session *models.ExamSession, timed []irt.TimedResponse, state *irt.SelectionState) error {
+	population, err := s.responseTimeRepo.FindPopulation(ctx, paper.SubjectID)
+	if err != nil {
+		return err
+	}
+	priorMean, priorSD := 0.0, 1.0
+	if population != nil {
+		priorMean, priorSD = population.MeanSpeed, population.SDSpeed
+	}
+	state.Speed = irt.EstimateSpeed(timed, priorMean, priorSD).Tau
+	state.TimeRemaining = irt.TimeRemaining(time.Duration(paper.Duration)*time.Minute, time.Since(session.StartTime))
+	return nil
+}
+
+// multidimensionalState D 最优选题：按科目当前多维标定估计本场的能力向量与后验信息矩阵，
+// 并为候选题填写多维参数；科目未做多维标定时保持单维状态，选题退化为最大信息量
+func (s *ExamServiceImpl) multidimensionalState(ctx context.Context, subjectID uint, responses []*models.QuestionResponse, candidates []irt.Candidate, state *irt.SelectionState) error {
+	calibration, err := s.mirtRepo.FindActiveCalibration(ctx, subjectID)
+	if err != nil || calibration == nil {
+		return err
+	}
+	items := calibration.MultiItems()
+	for i := range candidates {
+		if item, ok := items[candidates[i].QuestionID]; ok {
+			candidates[i].Loadings = item.Discrimination
+			candidates[i].Intercept = item.Intercept
+			// 多维信息量使用多维标定的猜测参数
+			candidates[i].Guessing = item.Guessing
+		}
+	}
+
+	credits, err := s.responseCredits(ctx, responses)
+	if err != nil {
+		return err
+	}
+	answered := multiResponses(responses, credits, items)
+	estimator := calibration.Estimator()
+	estimate, err := estimator.Estimate(answered)
+	if err != nil {
+		return err
+	}
+	administered := make([]irt.MultiItem, len(answered))
+	for i, r := range answered {
+		administered[i] = r.Item
+	}
+	info, err := estimator.Information(estimate.Theta, administered)
+	if err != nil {
+		return err
+	}
+	state.Model = calibration.ResponseModel()
+	state.ThetaVector = estimate.Theta
+	state.InformationMatrix = info
+	return nil
+}
+
+// saveAbilityProfile 科目有多维标定时，由本场计分题估计考生的多维能力剖面
+func (s *ExamServiceImpl) saveAbilityProfile(ctx context.Context, session *models.ExamSession, subjectID uint) error {
+	calibration, err := s.mirtRepo.FindActiveCalibration(ctx, subjectID)
+	if err != nil || calibration == nil {
+		return err
+	}
+	responses, err := s.examSessionRepo.GetResponses(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+	credits, err := s.responseCredits(ctx, responses)
+	if err != nil {
+		return err
+	}
+	answered := multiResponses(responses, credits, calibration.MultiItems())
+	if len(answered) == 0 {
+		return nil
+	}
+	estimate, err := calibration.Estimator().Estimate(answered)
+	if err != nil {
+		return err
+	}
+	return s.mirtRepo.SaveProfile(ctx, calibration.Profile(session.UserID, estimate, len(answered)))
+}
+
+// multiResponses 本场有多维参数的计分题作答，试测题与未做多维标定的题目不参与，得满分记为答对
+func multiResponses(responses []*models.QuestionResponse, credits map[uint]float64, items map[uint]irt.MultiItem) []irt.MultiResponse {
+	answered := make([]irt.MultiResponse, 0, len(responses))
+	for _, resp := range responses {
+		item, ok := items[resp.QuestionID]
+		if !ok || resp.Pretest {
 			continue
 		}
-		questions = append(questions, question)
+		answered = append(answered, irt.MultiResponse{Item: item, Correct: credits[resp.QuestionID] == 1})
 	}
+	return answered
+}
 
-	// 计算当前信息量
-	info := s.calculateTestInformation(ctx, session.CurrentAbility, questions)
-	se := utils.CalculateStandardError(info)
+// nextPretestQuestion 按科目试测配置判断下一题是否嵌入试测题，优先施测作答最少的试测题；
+// 不嵌入时返回 nil
+func (s *ExamServiceImpl) nextPretestQuestion(ctx context.Context, subjectID uint, operational, pretest int, answered []uint) (*models.Question, error) {
+	setting, err := s.pretestRepo.FindSetting(ctx, subjectID)
+	if err != nil || setting == nil {
+		return nil, err
+	}
+	plan := irt.PretestPlan{Rate: setting.Rate, MaxItems: setting.MaxItems, StartAfter: setting.StartAfter}
+	if !plan.ShouldSeed(operational, pretest) {
+		return nil, nil
+	}
 
-	return se < 0.3 || len(responses) >= 30
+	items, err := s.pretestRepo.ListPretestItems(ctx, subjectID, answered)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	counts := make([]int64, len(items))
+	for i, item := range items {
+		counts[i] = item.ResponseCount
+	}
+	return s.questionRepo.FindByID(ctx, items[plan.Choose(counts)].QuestionID)
+}
+
+// balancedSelector 试卷配置了内容蓝图时在选题准则上叠加内容平衡
+func (s *ExamServiceImpl) balancedSelector(ctx context.Context, paperID uint, selector irt.ItemSelector, administered []*models.Question) (irt.ItemSelector, error) {
+	blueprint, err := s.blueprintRepo.FindByPaperID(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+	if blueprint == nil {
+		return selector, nil
+	}
+
+	items, err := s.toCandidates(ctx, administered)
+	if err != nil {
+		return nil, err
+	}
+	return irt.NewBalancedSelector(selector, blueprint.IRTBlueprint(), items)
+}
+
+// toCandidates 将题目连同IRT参数、知识点、题型属性转换为选题候选，未标定的题目不参与选题
+func (s *ExamServiceImpl) toCandidates(ctx context.Context, questions []*models.Question) ([]irt.Candidate, error) {
+	ids := make([]uint, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+	}
+	params, err := s.itemParameters(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.questionRepo.ListKnowledgePointLinks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	pointsByQuestion := make(map[uint][]uint, len(questions))
+	for _, link := range links {
+		pointsByQuestion[link.QuestionID] = append(pointsByQuestion[link.QuestionID], link.KnowledgePointID)
+	}
+
+	polytomous, err := s.polytomousItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	timeParams, err := s.timeParameters(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]irt.Candidate, 0, len(questions))
+	for _, q := range questions {
+		p, ok := params[q.ID]
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, irt.Candidate{
+			QuestionID:         q.ID,
+			Difficulty:         p.Difficulty,
+			Discrimination:     p.Discrimination,
+			Guessing:           p.Guessing,
+			UpperAsymptote:     p.UpperAsymptote,
+			Model:              polytomous[q.ID].model,
+			Thresholds:         polytomous[q.ID].thresholds,
+			KnowledgePointIDs:  pointsByQuestion[q.ID],
+			QuestionType:       q.Type,
+			TimeIntensity:      timeParams[q.ID].Intensity,
+			TimeDiscrimination: timeParams[q.ID].Discrimination,
+		})
+	}
+	return candidates, nil
+}
+
+// itemParameters 查询题目已标定的IRT参数，区分度不为正的记录视为未标定
+func (s *ExamServiceImpl) itemParameters(ctx context.Context, questionIDs []uint) (map[uint]*models.ItemParameter, error) {
+	params, err := s.questionRepo.ListItemParameters(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	calibrated := make(map[uint]*models.ItemParameter, len(params))
+	for _, p := range params {
+		if p.Discrimination > 0 {
+			calibrated[p.QuestionID] = p
+		}
+	}
+	return calibrated, nil
+}
+
+// timeParameters 查询题目的作答时间参数，未做时间标定的题目不在结果中
+func (s *ExamServiceImpl) timeParameters(ctx context.Context, questionIDs []uint) (map[uint]irt.TimeParams, error) {
+	params, err := s.responseTimeRepo.ListParameters(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	timeParams := make(map[uint]irt.TimeParams, len(params))
+	for _, p := range params {
+		timeParams[p.QuestionID] = irt.TimeParams{Intensity: p.Intensity, Discrimination: p.Discrimination}
+	}
+	return timeParams, nil
+}
+
+// timedResponses 本场计分题的用时连同题目时间参数，未做时间标定的题目参数为零值，不参与速度估计
+func (s *ExamServiceImpl) timedResponses(ctx context.Context, responses []*models.QuestionResponse) ([]irt.TimedResponse, error) {
+	ids := make([]uint, len(responses))
+	for i, resp := range responses {
+		ids[i] = resp.QuestionID
+	}
+	timeParams, err := s.timeParameters(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	timed := make([]irt.TimedResponse, len(responses))
+	for i, resp := range responses {
+		timed[i] = irt.TimedResponse{
+			QuestionID: resp.QuestionID,
+			Item:       timeParams[resp.QuestionID],
+			Seconds:    float64(resp.TimeSpent),
+		}
+	}
+	return timed, nil
+}
+
+// polytomousItem 多级计分题的模型与类别阈值
+type polytomousItem struct {
+	model      irt.ItemModel
+	thresholds []float64
+}
+
+// polytomousItems 查询题目中的多级计分题，未设置阈值的题目按3PL处理
+func (s *ExamServiceImpl) polytomousItems(ctx context.Context, questionIDs []uint) (map[uint]polytomousItem, error) {
+	params, err := s.questionRepo.ListCategoryParameters(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[uint]polytomousItem)
+	for _, p := range params {
+		model := irt.ItemModel(p.ItemModel)
+		if !model.Polytomous() {
+			continue
+		}
+		item := items[p.QuestionID]
+		item.model = model
+		item.thresholds = append(item.thresholds, p.Threshold)
+		items[p.QuestionID] = item
+	}
+	return items, nil
+}
+
+// answerCredit 计算作答得分率：完全正确为1；多选题未选错选项时按选对的比例计部分分
+func answerCredit(question *models.Question, answer string) float64 {
+	if question.Type != models.QuestionTypeMultipleChoice {
+		if strings.EqualFold(strings.TrimSpace(answer), strings.TrimSpace(question.Answer)) {
+			return 1
+		}
+		return 0
+	}
+	key, selected := answerOptions(question.Answer), answerOptions(answer)
+	if len(key) == 0 || len(selected) == 0 {
+		return 0
+	}
+	for option := range selected {
+		if !key[option] {
+			return 0
+		}
+	}
+	return float64(len(selected)) / float64(len(key))
+}
+
+// answerOptions 将多选题作答规范为选项集合：不区分大小写，忽略逗号、空格等分隔符，重复选项只计一次
+func answerOptions(answer string) map[rune]bool {
+	options := make(map[rune]bool)
+	for _, r := range strings.ToUpper(answer) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			options[r] = true
+		}
+	}
+	return options
+}
+
+// exposureControl 按科目配置构造曝光控制器，未配置时不做控制
+func (s *ExamServiceImpl) exposureControl(ctx context.Context, subjectID uint) (*irt.ExposureControl, error) {
+	setting, err := s.exposureRepo.FindSetting(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		return irt.NewExposureControl(irt.ExposureNone, 1, nil, nil)
+	}
+
+	var parameters map[uint]float64
+	if irt.ExposureMethod(setting.Method) == irt.ExposureSympsonHetter {
+		exposures, err := s.exposureRepo.ListSubjectExposures(ctx, subjectID)
+		if err != nil {
+			return nil, err
+		}
+		parameters = make(map[uint]float64, len(exposures))
+		for _, e := range exposures {
+			parameters[e.QuestionID] = e.ExposureParameter
+		}
+	}
+	return irt.NewExposureControl(irt.ExposureMethod(setting.Method), setting.RandomesqueSize, parameters, nil)
+}
+
+// fullMarks 题目满分，未设置分值的题目按1分计
+func fullMarks(question *models.Question) float64 {
+	if question.Score > 0 {
+		return question.Score
+	}
+	return 1
+}
+
+// responseCredit 由作答得分与题目满分换算得分率
+func responseCredit(resp *models.QuestionResponse, question *models.Question) float64 {
+	return math.Max(0, math.Min(1, resp.Score/fullMarks(question)))
+}
+
+// responseCredits 按题目满分换算本场各题作答的得分率
+func (s *ExamServiceImpl) responseCredits(ctx context.Context, responses []*models.QuestionResponse) (map[uint]float64, error) {
+	credits := make(map[uint]float64, len(responses))
+	for _, resp := range responses {
+		question, err := s.questionRepo.FindByID(ctx, resp.QuestionID)
+		if err != nil {
+			return nil, err
+		}
+		credits[resp.QuestionID] = responseCredit(resp, question)
+	}
+	return credits, nil
+}
+
+// buildItemResponses 将会话作答记录转换为能力估计所需的作答模式，试测题与未标定题目的作答不参与
+func (s *ExamServiceImpl) buildItemResponses(ctx context.Context, sessionID uint) ([]irt.ItemResponse, error) {
+	responses, err := s.examSessionRepo.GetResponses(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(responses))
+	for i, resp := range responses {
+		ids[i] = resp.QuestionID
+	}
+	params, err := s.itemParameters(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	polytomous, err := s.polytomousItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]irt.ItemResponse, 0, len(responses))
+	for _, resp := range responses {
+		p, ok := params[resp.QuestionID]
+		if resp.Pretest || !ok {
+			continue
+		}
+		question, err := s.questionRepo.FindByID(ctx, resp.QuestionID)
+		if err != nil {
+			return nil, err
+		}
+		credit := responseCredit(resp, question)
+		item := irt.ItemResponse{
+			QuestionID:     question.ID,
+			Difficulty:     p.Difficulty,
+			Discrimination: p.Discrimination,
+			Guessing:       p.Guessing,
+			UpperAsymptote: p.UpperAsymptote,
+			Correct:        credit == 1,
+		}
+		if poly, ok := polytomous[question.ID]; ok {
+			item.Model = poly.model
+			item.Thresholds = poly.thresholds
+			item.Category = irt.ScoreCategory(credit, len(poly.thresholds)+1)
+		}
+		items = append(items, item)
+	}
+	return items, nil
 }
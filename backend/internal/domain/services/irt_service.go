@@ -1,8 +1,12 @@
 package services
 
+import "irt-exam-system/backend/internal/domain/irt"
+
 type IRTService interface {
 	// 估计考生能力值
-	EstimateAbility(currentAbility, difficulty, discrimination, guessParameter float64, isCorrect bool) float64
+	EstimateAbility(model irt.Model, currentAbility float64, item irt.ItemParams, isCorrect bool) float64
+	// 根据考生全部作答记录估计能力值及标准误
+	EstimateAbilityFromResponses(model irt.Model, responses []irt.ItemResponse) (*irt.AbilityEstimate, error)
 	// 获取下一题的建议难度
 	GetNextQuestionDifficulty(currentAbility float64) float64
 }
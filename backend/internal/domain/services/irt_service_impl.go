@@ -2,31 +2,34 @@ package services
 
 import (
 	"math"
+
+	"irt-exam-system/backend/internal/domain/irt"
 )
 
 type IRTServiceImpl struct {
 	// IRT参数配置
-	maxIterations int     // 最大迭代次数
-	convergence   float64 // 收敛阈值
+	maxIterations int            // 最大迭代次数
+	convergence   float64        // 收敛阈值
+	estimator     *irt.Estimator // 全作答模式能力估计器
 }
 
 func NewIRTService() IRTService {
 	return &IRTServiceImpl{
 		maxIterations: 50,
 		convergence:   0.001,
+		estimator:     irt.NewEstimator(irt.MethodEAP),
 	}
 }
 
-// EstimateAbility 使用三参数IRT模型估计考生能力值
-// P(θ) = c + (1-c)/(1 + e^(-Da(θ-b)))
+// EstimateAbility 使用科目配置的IRT模型估计考生能力值
+// P(θ) = c + (d-c)/(1 + e^(-Da(θ-b)))
 // θ: 能力值
 // a: 区分度
 // b: 难度
 // c: 猜测参数
-// D: 常数(通常取1.7)
-func (s *IRTServiceImpl) EstimateAbility(currentAbility, difficulty, discrimination, guessParameter float64, isCorrect bool) float64 {
-	const D = 1.7
-
+// d: 上渐近线（4PL）
+// D: 量尺因子(1.0 或 1.7)
+func (s *IRTServiceImpl) EstimateAbility(model irt.Model, currentAbility float64, item irt.ItemParams, isCorrect bool) float64 {
 	// 使用最大似然估计（MLE）方法更新能力值
 	var newAbility float64 = currentAbility
 	var lastAbility float64
@@ -35,13 +38,13 @@ func (s *IRTServiceImpl) EstimateAbility(currentAbility, difficulty, discriminat
 		lastAbility = newAbility
 
 		// 计算作答正确的概率
-		p := s.calculateProbability(newAbility, difficulty, discrimination, guessParameter)
+		p := model.Probability(newAbility, item)
 
 		// 计算信息函数
-		info := s.calculateInformation(newAbility, difficulty, discrimination, guessParameter, p)
+		info := model.Information(newAbility, item)
 
 		// 计算得分函数
-		score := s.calculateScore(isCorrect, p)
+		score := s.calculateScore(isCorrect, p, model.Derivative(newAbility, item))
 
 		// 更新能力值估计
 		if info != 0 {
@@ -64,32 +67,28 @@ func (s *IRTServiceImpl) EstimateAbility(currentAbility, difficulty, discriminat
 	return newAbility
 }
 
+// EstimateAbilityFromResponses 使用全部作答记录估计能力值
+// 单题牛顿迭代在首题答对时会直接漂移到边界，会话内应优先使用本方法
+func (s *IRTServiceImpl) EstimateAbilityFromResponses(model irt.Model, responses []irt.ItemResponse) (*irt.AbilityEstimate, error) {
+	estimator := *s.estimator
+	estimator.Model = model
+	return estimator.Estimate(responses)
+}
+
 // GetNextQuestionDifficulty 根据当前能力值确定下一题的难度
 func (s *IRTServiceImpl) GetNextQuestionDifficulty(currentAbility float64) float64 {
 	// 在自适应测试中，通常选择难度接近当前能力值的题目
 	return currentAbility
 }
 
-// calculateProbability 计算作答正确的概率
-func (s *IRTServiceImpl) calculateProbability(ability, difficulty, discrimination, guessParameter float64) float64 {
-	const D = 1.7
-	z := D * discrimination * (ability - difficulty)
-	return guessParameter + (1-guessParameter)/(1+math.Exp(-z))
-}
-
-// calculateInformation 计算信息函数
-func (s *IRTServiceImpl) calculateInformation(ability, difficulty, discrimination, guessParameter, probability float64) float64 {
-	const D = 1.7
-	q := 1 - probability
-	numerator := math.Pow(D*discrimination*(probability-guessParameter), 2)
-	denominator := (probability - guessParameter) * q
-	return numerator / denominator
-}
-
-// calculateScore 计算得分函数
-func (s *IRTServiceImpl) calculateScore(isCorrect bool, probability float64) float64 {
+// calculateScore 计算得分函数 (u-P)P'/(P(1-P))
+func (s *IRTServiceImpl) calculateScore(isCorrect bool, probability, derivative float64) float64 {
+	if probability <= 0 || probability >= 1 {
+		return 0
+	}
+	u := 0.0
 	if isCorrect {
-		return 1 - probability
+		u = 1
 	}
-	return -probability
+	return (u - probability) * derivative / (probability * (1 - probability))
 }
@@ -2,6 +2,8 @@ package database
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -52,3 +54,29 @@ func NewConnection(config *Config) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+// ConfigFromEnv 从环境变量读取数据库配置
+func ConfigFromEnv() *Config {
+	maxIdle, _ := strconv.Atoi(getEnv("DB_MAX_IDLE_CONNS", "10"))
+	maxOpen, _ := strconv.Atoi(getEnv("DB_MAX_OPEN_CONNS", "100"))
+	lifetime, _ := strconv.Atoi(getEnv("DB_CONN_MAX_LIFETIME", "3600"))
+
+	return &Config{
+		Host:         getEnv("DB_HOST", "localhost"),
+		Port:         getEnv("DB_PORT", "5432"),
+		User:         getEnv("DB_USER", "postgres"),
+		Password:     os.Getenv("DB_PASSWORD"),
+		Database:     getEnv("DB_NAME", "irt_exam_system"),
+		SSLMode:      getEnv("DB_SSL_MODE", "disable"),
+		MaxIdleConns: maxIdle,
+		MaxOpenConns: maxOpen,
+		MaxLifetime:  time.Duration(lifetime) * time.Second,
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
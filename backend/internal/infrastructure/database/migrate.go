@@ -0,0 +1,198 @@
+package database
+
+import (
+	"fmt"
+
+	domain "irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// newTables 标定、自适应考试与测量分析新增的表
+func newTables() []interface{} {
+	return []interface{}{
+		// 多级计分题类别阈值
+		&models.QuestionCategoryParameter{},
+		// 组卷蓝图与互斥题
+		&domain.ExamBlueprint{},
+		&domain.BlueprintConstraint{},
+		&domain.QuestionEnemy{},
+		// 曝光控制
+		&domain.ExposureSetting{},
+		&domain.ItemExposure{},
+		&domain.ExamSessionRejection{},
+		// DIF 与等值
+		&models.DIFResult{},
+		&models.ScaleTransformation{},
+		// 试测题与在线标定
+		&models.PretestSetting{},
+		&models.PretestCalibration{},
+		// 作答时间模型
+		&models.ResponseTimeParameter{},
+		&models.SpeedPopulation{},
+		// 报告量尺与常模
+		&models.ReportingScale{},
+		&models.ScaleConversionPoint{},
+		&models.NormTable{},
+		&models.NormTableEntry{},
+		// 标准设定与表现水平
+		&models.StandardSettingSession{},
+		&models.StandardSettingLevel{},
+		&models.StandardSettingRating{},
+		&models.PerformanceLevelSet{},
+		&models.PerformanceLevel{},
+		// 多阶段测验
+		&domain.MSTPanel{},
+		&domain.MSTModule{},
+		&domain.MSTModuleQuestion{},
+		&domain.MSTRoutingRule{},
+		&domain.MSTRoutingCut{},
+		&domain.ExamSessionModule{},
+		// 多维项目反应理论
+		&models.MIRTCalibration{},
+		&models.MIRTDimension{},
+		&models.MIRTCorrelation{},
+		&models.MIRTItemParameter{},
+		&models.MIRTLoading{},
+		&models.UserAbilityProfile{},
+		&models.AbilityProfileDimension{},
+		&models.AbilityProfileCovariance{},
+	}
+}
+
+// addedColumn 已有表上新增的列，列定义取自模型字段
+type addedColumn struct {
+	model interface{}
+	field string
+	index bool
+}
+
+// addedColumns 已有表上新增的列；两套模型映射同一张表时以带列类型的 models 为准
+func addedColumns() []addedColumn {
+	return []addedColumn{
+		{model: &models.Subject{}, field: "IRTModel"},
+		{model: &models.Subject{}, field: "ScalingConstant"},
+		{model: &models.User{}, field: "ClassName", index: true},
+		{model: &models.User{}, field: "Region", index: true},
+		{model: &models.User{}, field: "Gender"},
+		{model: &models.Question{}, field: "Pretest", index: true},
+		{model: &domain.Question{}, field: "Type"},
+		{model: &domain.Question{}, field: "EstimatedSeconds"},
+		{model: &models.QuestionParameter{}, field: "UpperAsymptote"},
+		{model: &models.QuestionParameter{}, field: "DifficultySE"},
+		{model: &models.QuestionParameter{}, field: "DiscriminationSE"},
+		{model: &models.QuestionParameter{}, field: "GuessingSE"},
+		{model: &models.QuestionParameter{}, field: "UpperAsymptoteSE"},
+		{model: &models.QuestionParameter{}, field: "CalibrationModel"},
+		{model: &models.QuestionParameter{}, field: "CalibratedAt"},
+		{model: &models.QuestionParameter{}, field: "ItemModel"},
+		{model: &models.AbilityEstimation{}, field: "ExamSessionID", index: true},
+		{model: &models.ExamPaper{}, field: "SelectionStrategy"},
+		{model: &models.ExamPaper{}, field: "MinItems"},
+		{model: &models.ExamPaper{}, field: "MaxItems"},
+		{model: &models.ExamPaper{}, field: "TargetSE"},
+		{model: &models.ExamPaper{}, field: "ThetaChange"},
+		{model: &models.ExamPaper{}, field: "Classification"},
+		{model: &models.ExamPaper{}, field: "CutScore"},
+		{model: &models.ExamPaper{}, field: "IndifferenceRegion"},
+		{model: &models.ExamPaper{}, field: "ClassificationError"},
+		{model: &domain.ExamPaper{}, field: "TimeAwareSelection"},
+		{model: &models.ExamPaperQuestion{}, field: "Pretest"},
+		{model: &domain.ExamSession{}, field: "StandardError"},
+		{model: &domain.ExamSession{}, field: "StopReason"},
+		{model: &domain.ExamSession{}, field: "Classification"},
+		{model: &domain.ExamSession{}, field: "PanelID", index: true},
+		{model: &domain.QuestionResponse{}, field: "ExamSessionID", index: true},
+		{model: &domain.QuestionResponse{}, field: "Pretest"},
+	}
+}
+
+// Migrate 创建新增的表、为已有表补齐新增的列，并回填试卷所属科目与会话所属试卷；
+// 已存在的表与列不做修改，可重复执行
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(newTables()...); err != nil {
+		return fmt.Errorf("failed to create tables: %w", err)
+	}
+
+	migrator := db.Migrator()
+	for _, c := range addedColumns() {
+		if !migrator.HasColumn(c.model, c.field) {
+			if err := migrator.AddColumn(c.model, c.field); err != nil {
+				return fmt.Errorf("failed to add column %s: %w", c.field, err)
+			}
+		}
+		if c.index && !migrator.HasIndex(c.model, c.field) {
+			if err := migrator.CreateIndex(c.model, c.field); err != nil {
+				return fmt.Errorf("failed to create index on %s: %w", c.field, err)
+			}
+		}
+	}
+
+	if err := backfillPaperSubjects(db); err != nil {
+		return err
+	}
+	return addSessionPapers(db)
+}
+
+// backfillPaperSubjects 为试卷补齐所属科目：取试卷题目中占多数的科目；
+// 全部试卷都有科目后再加非空约束，没有题目的试卷需人工指定科目
+func backfillPaperSubjects(db *gorm.DB) error {
+	migrator := db.Migrator()
+	paper := &domain.ExamPaper{}
+	if !migrator.HasColumn(paper, "SubjectID") {
+		if err := db.Exec("ALTER TABLE exam_papers ADD COLUMN subject_id bigint").Error; err != nil {
+			return fmt.Errorf("failed to add column subject_id: %w", err)
+		}
+	}
+
+	err := db.Exec(`
+        UPDATE exam_papers SET subject_id = (
+            SELECT questions.subject_id FROM exam_paper_questions
+            JOIN questions ON questions.id = exam_paper_questions.question_id
+            WHERE exam_paper_questions.exam_paper_id = exam_papers.id
+              AND exam_paper_questions.deleted_at IS NULL
+            GROUP BY questions.subject_id
+            ORDER BY COUNT(*) DESC, questions.subject_id
+            LIMIT 1
+        )
+        WHERE (subject_id IS NULL OR subject_id = 0)
+          AND EXISTS (
+            SELECT 1 FROM exam_paper_questions
+            WHERE exam_paper_questions.exam_paper_id = exam_papers.id
+              AND exam_paper_questions.deleted_at IS NULL
+          )
+    `).Error
+	if err != nil {
+		return fmt.Errorf("failed to backfill exam paper subjects: %w", err)
+	}
+
+	var missing int64
+	if err := db.Table("exam_papers").Where("subject_id IS NULL").Count(&missing).Error; err != nil {
+		return err
+	}
+	if missing == 0 {
+		if err := db.Exec("ALTER TABLE exam_papers ALTER COLUMN subject_id SET NOT NULL").Error; err != nil {
+			return fmt.Errorf("failed to set subject_id not null: %w", err)
+		}
+	}
+	if !migrator.HasIndex(paper, "SubjectID") {
+		return migrator.CreateIndex(paper, "SubjectID")
+	}
+	return nil
+}
+
+// addSessionPapers 为会话补齐所属试卷，早于按试卷施测的会话无从追溯，记为0
+func addSessionPapers(db *gorm.DB) error {
+	migrator := db.Migrator()
+	session := &domain.ExamSession{}
+	if !migrator.HasColumn(session, "ExamPaperID") {
+		if err := db.Exec("ALTER TABLE exam_sessions ADD COLUMN exam_paper_id bigint NOT NULL DEFAULT 0").Error; err != nil {
+			return fmt.Errorf("failed to add column exam_paper_id: %w", err)
+		}
+	}
+	if !migrator.HasIndex(session, "ExamPaperID") {
+		return migrator.CreateIndex(session, "ExamPaperID")
+	}
+	return nil
+}
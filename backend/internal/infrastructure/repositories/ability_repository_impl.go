@@ -117,6 +117,13 @@ func (r *abilityRepository) GetLatestEstimation(ctx context.Context, userID, sub
 	return &estimation, nil
 }
 
+func (r *abilityRepository) ListUserSubjectEstimations(ctx context.Context, userID, subjectID uint) ([]*models.AbilityEstimation, error) {
+	var estimations []*models.AbilityEstimation
+	err := r.db.WithContext(ctx).Where("user_id = ? AND subject_id = ?", userID, subjectID).
+		Order("created_at ASC, id ASC").Find(&estimations).Error
+	return estimations, err
+}
+
 // 题目参数操作实现
 func (r *abilityRepository) UpdateQuestionParameters(ctx context.Context, params *models.QuestionParameter) error {
 	return r.db.WithContext(ctx).Save(params).Error
@@ -124,7 +131,8 @@ func (r *abilityRepository) UpdateQuestionParameters(ctx context.Context, params
 
 func (r *abilityRepository) GetQuestionParameters(ctx context.Context, questionID uint) (*models.QuestionParameter, error) {
 	var params models.QuestionParameter
-	err := r.db.WithContext(ctx).Where("question_id = ?", questionID).First(&params).Error
+	err := r.db.WithContext(ctx).Preload("Question").Preload("Categories", orderByCategory).
+		Where("question_id = ?", questionID).First(&params).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -147,6 +155,50 @@ func (r *abilityRepository) BatchUpdateParameters(ctx context.Context, params []
 
 func (r *abilityRepository) ListQuestionParameters(ctx context.Context, questionIDs []uint) ([]*models.QuestionParameter, error) {
 	var params []*models.QuestionParameter
-	err := r.db.WithContext(ctx).Where("question_id IN ?", questionIDs).Find(&params).Error
+	err := r.db.WithContext(ctx).Preload("Categories", orderByCategory).
+		Where("question_id IN ?", questionIDs).Find(&params).Error
 	return params, err
 }
+
+func (r *abilityRepository) ListQuestions(ctx context.Context, questionIDs []uint) ([]*models.Question, error) {
+	var questions []*models.Question
+	err := r.db.WithContext(ctx).Where("id IN ?", questionIDs).Find(&questions).Error
+	return questions, err
+}
+
+func (r *abilityRepository) SaveItemModel(ctx context.Context, params *models.QuestionParameter) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Categories").Save(params).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("question_id = ?", params.QuestionID).Delete(&models.QuestionCategoryParameter{}).Error; err != nil {
+			return err
+		}
+		for i := range params.Categories {
+			params.Categories[i].ID = 0
+			params.Categories[i].QuestionID = params.QuestionID
+		}
+		if len(params.Categories) == 0 {
+			return nil
+		}
+		return tx.Create(&params.Categories).Error
+	})
+}
+
+// orderByCategory 按类别顺序预加载阈值参数
+func orderByCategory(db *gorm.DB) *gorm.DB {
+	return db.Order("category")
+}
+
+// 作答数据操作实现
+func (r *abilityRepository) ListSubjectResponses(ctx context.Context, subjectID uint) ([]*models.ExamResponse, error) {
+	var responses []*models.ExamResponse
+	err := r.db.WithContext(ctx).
+		Preload("Question").Preload("ExamRecord").
+		Joins("JOIN exam_records ON exam_records.id = exam_responses.exam_record_id").
+		Joins("JOIN exam_papers ON exam_papers.id = exam_records.exam_paper_id").
+		Where("exam_papers.subject_id = ?", subjectID).
+		Order("exam_responses.exam_record_id, exam_responses.created_at").
+		Find(&responses).Error
+	return responses, err
+}
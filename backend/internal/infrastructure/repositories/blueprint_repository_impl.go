@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type blueprintRepository struct {
+	db *gorm.DB
+}
+
+// NewBlueprintRepository 创建内容蓝图仓储实例
+func NewBlueprintRepository(db *gorm.DB) repositories.BlueprintRepository {
+	return &blueprintRepository{db: db}
+}
+
+func (r *blueprintRepository) FindByPaperID(ctx context.Context, examPaperID uint) (*models.ExamBlueprint, error) {
+	var blueprint models.ExamBlueprint
+	err := r.db.WithContext(ctx).Preload("Constraints").
+		Where("exam_paper_id = ?", examPaperID).First(&blueprint).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &blueprint, nil
+}
+
+func (r *blueprintRepository) Save(ctx context.Context, blueprint *models.ExamBlueprint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		constraints := blueprint.Constraints
+		blueprint.Constraints = nil
+		if err := tx.Save(blueprint).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("blueprint_id = ?", blueprint.ID).Delete(&models.BlueprintConstraint{}).Error; err != nil {
+			return err
+		}
+		for i := range constraints {
+			constraints[i].ID = 0
+			constraints[i].BlueprintID = blueprint.ID
+		}
+		if len(constraints) > 0 {
+			if err := tx.Create(&constraints).Error; err != nil {
+				return err
+			}
+		}
+		blueprint.Constraints = constraints
+		return nil
+	})
+}
+
+func (r *blueprintRepository) Delete(ctx context.Context, examPaperID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var blueprint models.ExamBlueprint
+		err := tx.Where("exam_paper_id = ?", examPaperID).First(&blueprint).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if err := tx.Unscoped().Where("blueprint_id = ?", blueprint.ID).Delete(&models.BlueprintConstraint{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&blueprint).Error
+	})
+}
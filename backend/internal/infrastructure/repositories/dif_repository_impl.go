@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+)
+
+type difRepository struct {
+	db *gorm.DB
+}
+
+// NewDIFRepository 创建 DIF 分析仓储实例
+func NewDIFRepository(db *gorm.DB) repositories.DIFRepository {
+	return &difRepository{db: db}
+}
+
+func (r *difRepository) ReplaceResults(ctx context.Context, subjectID uint, attribute, reference, focal string, results []*models.DIFResult) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Where("subject_id = ? AND group_attribute = ? AND reference_group = ? AND focal_group = ?", subjectID, attribute, reference, focal).
+			Delete(&models.DIFResult{}).Error
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			return nil
+		}
+		return tx.Create(&results).Error
+	})
+}
+
+func (r *difRepository) ListResults(ctx context.Context, subjectID uint, attribute string) ([]*models.DIFResult, error) {
+	var results []*models.DIFResult
+	query := r.db.WithContext(ctx).Where("subject_id = ?", subjectID)
+	if attribute != "" {
+		query = query.Where("group_attribute = ?", attribute)
+	}
+	err := query.Order("question_id").Find(&results).Error
+	return results, err
+}
+
+func (r *difRepository) ListUsers(ctx context.Context, userIDs []uint) ([]*models.User, error) {
+	var users []*models.User
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", userIDs).Find(&users).Error
+	return users, err
+}
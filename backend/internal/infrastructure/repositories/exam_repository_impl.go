@@ -34,7 +34,7 @@ func (r *examRepository) DeletePaper(ctx context.Context, id uint) error {
 
 func (r *examRepository) FindPaperByID(ctx context.Context, id uint) (*models.ExamPaper, error) {
 	var paper models.ExamPaper
-	err := r.db.WithContext(ctx).Preload("Questions").First(&paper, id).Error
+	err := r.db.WithContext(ctx).First(&paper, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -97,6 +97,21 @@ func (r *examRepository) ListPapersByStatus(ctx context.Context, status string,
 	return papers, total, nil
 }
 
+func (r *examRepository) CreatePaperWithQuestions(ctx context.Context, paper *models.ExamPaper, questions []*models.ExamPaperQuestion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(paper).Error; err != nil {
+			return err
+		}
+		for _, q := range questions {
+			q.ExamPaperID = paper.ID
+		}
+		if len(questions) == 0 {
+			return nil
+		}
+		return tx.Create(&questions).Error
+	})
+}
+
 // 考试记录相关实现
 func (r *examRepository) CreateRecord(ctx context.Context, record *models.ExamSession) error {
 	return r.db.WithContext(ctx).Create(record).Error
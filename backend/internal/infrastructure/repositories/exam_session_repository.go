@@ -1,8 +1,13 @@
 package repositories
 
 import (
+	"context"
+	"errors"
+
 	"irt-exam-system/backend/internal/domain/models"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ExamSessionRepositoryImpl struct {
@@ -13,24 +18,66 @@ func NewExamSessionRepository(db *gorm.DB) *ExamSessionRepositoryImpl {
 	return &ExamSessionRepositoryImpl{db: db}
 }
 
-func (r *ExamSessionRepositoryImpl) Create(session *models.ExamSession) error {
-	return r.db.Create(session).Error
+func (r *ExamSessionRepositoryImpl) Create(ctx context.Context, session *models.ExamSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
 }
 
-func (r *ExamSessionRepositoryImpl) FindByID(id uint) (*models.ExamSession, error) {
+func (r *ExamSessionRepositoryImpl) FindByID(ctx context.Context, id uint) (*models.ExamSession, error) {
 	var session models.ExamSession
-	if err := r.db.First(&session, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&session, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
 		return nil, err
 	}
 	return &session, nil
 }
 
-func (r *ExamSessionRepositoryImpl) SaveResponse(response *models.QuestionResponse) error {
-	return r.db.Create(response).Error
+func (r *ExamSessionRepositoryImpl) SaveResponse(ctx context.Context, response *models.QuestionResponse) error {
+	return r.db.WithContext(ctx).Create(response).Error
 }
 
-func (r *ExamSessionRepositoryImpl) UpdateAbility(sessionID uint, ability float64) error {
-	return r.db.Model(&models.ExamSession{}).
+func (r *ExamSessionRepositoryImpl) UpdateAbility(ctx context.Context, sessionID uint, ability, standardError float64) error {
+	return r.db.WithContext(ctx).Model(&models.ExamSession{}).
 		Where("id = ?", sessionID).
-		Update("current_ability", ability).Error
+		Updates(map[string]interface{}{
+			"current_ability": ability,
+			"standard_error":  standardError,
+		}).Error
+}
+
+func (r *ExamSessionRepositoryImpl) GetResponses(ctx context.Context, sessionID uint) ([]*models.QuestionResponse, error) {
+	var responses []*models.QuestionResponse
+	err := r.db.WithContext(ctx).Where("exam_session_id = ?", sessionID).
+		Order("created_at ASC").Find(&responses).Error
+	return responses, err
+}
+
+func (r *ExamSessionRepositoryImpl) Finish(ctx context.Context, session *models.ExamSession) error {
+	return r.db.WithContext(ctx).Model(&models.ExamSession{}).
+		Where("id = ?", session.ID).
+		Updates(map[string]interface{}{
+			"status":         session.Status,
+			"end_time":       session.EndTime,
+			"stop_reason":    session.StopReason,
+			"classification": session.Classification,
+		}).Error
+}
+
+func (r *ExamSessionRepositoryImpl) SaveRejections(ctx context.Context, sessionID uint, questionIDs []uint) error {
+	if len(questionIDs) == 0 {
+		return nil
+	}
+	rejections := make([]*models.ExamSessionRejection, len(questionIDs))
+	for i, id := range questionIDs {
+		rejections[i] = &models.ExamSessionRejection{ExamSessionID: sessionID, QuestionID: id}
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rejections).Error
+}
+
+func (r *ExamSessionRepositoryImpl) ListRejectedQuestionIDs(ctx context.Context, sessionID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&models.ExamSessionRejection{}).
+		Where("exam_session_id = ?", sessionID).Pluck("question_id", &ids).Error
+	return ids, err
 }
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type exposureRepository struct {
+	db *gorm.DB
+}
+
+// NewExposureRepository 创建曝光控制仓储实例
+func NewExposureRepository(db *gorm.DB) repositories.ExposureRepository {
+	return &exposureRepository{db: db}
+}
+
+// 曝光控制配置实现
+func (r *exposureRepository) FindSetting(ctx context.Context, subjectID uint) (*models.ExposureSetting, error) {
+	var setting models.ExposureSetting
+	err := r.db.WithContext(ctx).Where("subject_id = ?", subjectID).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *exposureRepository) SaveSetting(ctx context.Context, setting *models.ExposureSetting) error {
+	return r.db.WithContext(ctx).Save(setting).Error
+}
+
+// 题目曝光统计实现
+func (r *exposureRepository) ListSubjectExposures(ctx context.Context, subjectID uint) ([]*models.ItemExposure, error) {
+	var exposures []*models.ItemExposure
+	err := r.db.WithContext(ctx).Where("subject_id = ?", subjectID).Find(&exposures).Error
+	return exposures, err
+}
+
+func (r *exposureRepository) SaveExposureParameters(ctx context.Context, subjectID uint, parameters map[uint]float64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for questionID, k := range parameters {
+			exposure := &models.ItemExposure{
+				QuestionID:        questionID,
+				SubjectID:         subjectID,
+				ExposureParameter: k,
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "question_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"exposure_parameter", "updated_at"}),
+			}).Create(exposure).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *exposureRepository) IncrementAdministered(ctx context.Context, subjectID, questionID uint) error {
+	exposure := &models.ItemExposure{
+		QuestionID:        questionID,
+		SubjectID:         subjectID,
+		ExposureParameter: 1,
+		AdministeredCount: 1,
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "question_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"administered_count": gorm.Expr("item_exposures.administered_count + 1"),
+		}),
+	}).Create(exposure).Error
+}
+
+func (r *exposureRepository) CountSubjectSessions(ctx context.Context, subjectID uint) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&models.ExamSession{}).
+		Joins("JOIN exam_papers ON exam_papers.id = exam_sessions.exam_paper_id").
+		Where("exam_papers.subject_id = ?", subjectID).
+		Count(&total).Error
+	return total, err
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+)
+
+type forensicsRepository struct {
+	db *gorm.DB
+}
+
+// NewForensicsRepository 创建作答异常检测仓储实例
+func NewForensicsRepository(db *gorm.DB) repositories.ForensicsRepository {
+	return &forensicsRepository{db: db}
+}
+
+func (r *forensicsRepository) FindPaper(ctx context.Context, paperID uint) (*models.ExamPaper, error) {
+	var paper models.ExamPaper
+	err := r.db.WithContext(ctx).First(&paper, paperID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &paper, nil
+}
+
+func (r *forensicsRepository) ListPaperSubmissions(ctx context.Context, paperID uint) ([]*models.ExamResponse, error) {
+	var responses []*models.ExamResponse
+	err := r.db.WithContext(ctx).
+		Preload("Question").Preload("Question.Options").Preload("ExamRecord").
+		Joins("JOIN exam_records ON exam_records.id = exam_responses.exam_record_id").
+		Where("exam_records.exam_paper_id = ?", paperID).
+		Order("exam_responses.exam_record_id, exam_responses.created_at, exam_responses.id").
+		Find(&responses).Error
+	return responses, err
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+)
+
+type linkingRepository struct {
+	db *gorm.DB
+}
+
+// NewLinkingRepository 创建量尺连接仓储实例
+func NewLinkingRepository(db *gorm.DB) repositories.LinkingRepository {
+	return &linkingRepository{db: db}
+}
+
+func (r *linkingRepository) FindPaper(ctx context.Context, paperID uint) (*models.ExamPaper, error) {
+	var paper models.ExamPaper
+	err := r.db.WithContext(ctx).First(&paper, paperID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &paper, nil
+}
+
+func (r *linkingRepository) ListPaperQuestions(ctx context.Context, paperID uint) ([]*models.ExamPaperQuestion, error) {
+	var questions []*models.ExamPaperQuestion
+	err := r.db.WithContext(ctx).Preload("Question").Where("exam_paper_id = ?", paperID).Order(`"order"`).Find(&questions).Error
+	return questions, err
+}
+
+func (r *linkingRepository) ListPaperResponses(ctx context.Context, paperID uint) ([]*models.ExamResponse, error) {
+	var responses []*models.ExamResponse
+	err := r.db.WithContext(ctx).
+		Preload("Question").Preload("ExamRecord").
+		Joins("JOIN exam_records ON exam_records.id = exam_responses.exam_record_id").
+		Where("exam_records.exam_paper_id = ?", paperID).
+		Order("exam_responses.exam_record_id, exam_responses.created_at").
+		Find(&responses).Error
+	return responses, err
+}
+
+func (r *linkingRepository) CreateTransformation(ctx context.Context, transformation *models.ScaleTransformation) error {
+	return r.db.WithContext(ctx).Create(transformation).Error
+}
+
+func (r *linkingRepository) ListTransformations(ctx context.Context, subjectID uint) ([]*models.ScaleTransformation, error) {
+	var transformations []*models.ScaleTransformation
+	err := r.db.WithContext(ctx).Where("subject_id = ?", subjectID).Order("created_at DESC").Find(&transformations).Error
+	return transformations, err
+}
+
+func (r *linkingRepository) ApplyTransformation(ctx context.Context, transformation *models.ScaleTransformation, params []*models.QuestionParameter, recordIDs, userIDs []uint) error {
+	a, b := transformation.Slope, transformation.Intercept
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(transformation).Error; err != nil {
+			return err
+		}
+		for _, p := range params {
+			if err := tx.Save(p).Error; err != nil {
+				return err
+			}
+		}
+		if len(recordIDs) > 0 {
+			err := tx.Model(&models.AbilityEstimation{}).Where("exam_record_id IN ?", recordIDs).
+				Updates(map[string]interface{}{
+					"ability":        gorm.Expr("? * ability + ?", a, b),
+					"standard_error": gorm.Expr("? * standard_error", a),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(userIDs) > 0 {
+			err := tx.Model(&models.UserAbility{}).
+				Where("subject_id = ? AND user_id IN ?", transformation.SubjectID, userIDs).
+				Updates(map[string]interface{}{
+					"ability":        gorm.Expr("? * ability + ?", a, b),
+					"standard_error": gorm.Expr("? * standard_error", a),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
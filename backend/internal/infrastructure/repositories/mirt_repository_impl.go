@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+)
+
+type mirtRepository struct {
+	db *gorm.DB
+}
+
+// NewMIRTRepository 创建多维标定与能力剖面仓储实例
+func NewMIRTRepository(db *gorm.DB) repositories.MIRTRepository {
+	return &mirtRepository{db: db}
+}
+
+// 多维标定实现
+func (r *mirtRepository) FindActiveCalibration(ctx context.Context, subjectID uint) (*models.MIRTCalibration, error) {
+	var calibration models.MIRTCalibration
+	err := r.preloadCalibration(r.db.WithContext(ctx)).
+		Where("subject_id = ? AND active = ?", subjectID, true).
+		Order("version DESC").First(&calibration).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &calibration, nil
+}
+
+func (r *mirtRepository) FindCalibration(ctx context.Context, calibrationID uint) (*models.MIRTCalibration, error) {
+	var calibration models.MIRTCalibration
+	err := r.preloadCalibration(r.db.WithContext(ctx)).First(&calibration, calibrationID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &calibration, nil
+}
+
+func (r *mirtRepository) ListCalibrations(ctx context.Context, subjectID uint) ([]*models.MIRTCalibration, error) {
+	var calibrations []*models.MIRTCalibration
+	err := r.db.WithContext(ctx).Preload("Dimensions").Preload("Correlations").
+		Where("subject_id = ?", subjectID).Order("version DESC").Find(&calibrations).Error
+	return calibrations, err
+}
+
+func (r *mirtRepository) CreateCalibration(ctx context.Context, calibration *models.MIRTCalibration) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var version int
+		err := tx.Model(&models.MIRTCalibration{}).Where("subject_id = ?", calibration.SubjectID).
+			Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.MIRTCalibration{}).Where("subject_id = ? AND active = ?", calibration.SubjectID, true).
+			Update("active", false).Error
+		if err != nil {
+			return err
+		}
+		calibration.Version = version + 1
+		calibration.Active = true
+		return tx.Create(calibration).Error
+	})
+}
+
+func (r *mirtRepository) preloadCalibration(db *gorm.DB) *gorm.DB {
+	return db.Preload("Dimensions").Preload("Correlations").Preload("Items.Loadings")
+}
+
+// 能力剖面实现
+func (r *mirtRepository) FindProfile(ctx context.Context, userID, subjectID uint) (*models.UserAbilityProfile, error) {
+	var profile models.UserAbilityProfile
+	err := r.db.WithContext(ctx).Preload("Dimensions").Preload("Covariance").
+		Where("user_id = ? AND subject_id = ?", userID, subjectID).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *mirtRepository) SaveProfile(ctx context.Context, profile *models.UserAbilityProfile) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.UserAbilityProfile
+		err := tx.Where("user_id = ? AND subject_id = ?", profile.UserID, profile.SubjectID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(profile).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		// 覆盖已有剖面：删除原维度能力与协方差后按新估计写入
+		if err := tx.Unscoped().Where("profile_id = ?", existing.ID).Delete(&models.AbilityProfileDimension{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("profile_id = ?", existing.ID).Delete(&models.AbilityProfileCovariance{}).Error; err != nil {
+			return err
+		}
+		profile.ID = existing.ID
+		profile.CreatedAt = existing.CreatedAt
+		return tx.Save(profile).Error
+	})
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type mstRepository struct {
+	db *gorm.DB
+}
+
+// NewMSTRepository 创建多阶段测验板仓储实例
+func NewMSTRepository(db *gorm.DB) repositories.MSTRepository {
+	return &mstRepository{db: db}
+}
+
+func (r *mstRepository) CreatePanel(ctx context.Context, panel *models.MSTPanel) error {
+	return r.db.WithContext(ctx).Create(panel).Error
+}
+
+func (r *mstRepository) FindPanel(ctx context.Context, panelID uint) (*models.MSTPanel, error) {
+	var panel models.MSTPanel
+	err := r.withPanelDetails(r.db.WithContext(ctx)).First(&panel, panelID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &panel, nil
+}
+
+func (r *mstRepository) ListPanels(ctx context.Context, examPaperID uint) ([]*models.MSTPanel, error) {
+	var panels []*models.MSTPanel
+	err := r.withPanelDetails(r.db.WithContext(ctx)).
+		Where("exam_paper_id = ?", examPaperID).Order("id ASC").Find(&panels).Error
+	return panels, err
+}
+
+func (r *mstRepository) ListActivePanels(ctx context.Context, examPaperID uint) ([]*models.MSTPanel, error) {
+	var panels []*models.MSTPanel
+	err := r.withPanelDetails(r.db.WithContext(ctx)).
+		Where("exam_paper_id = ? AND active = ?", examPaperID, true).Order("id ASC").Find(&panels).Error
+	return panels, err
+}
+
+func (r *mstRepository) SetPanelActive(ctx context.Context, panelID uint, active bool) error {
+	return r.db.WithContext(ctx).Model(&models.MSTPanel{}).
+		Where("id = ?", panelID).Update("active", active).Error
+}
+
+// withPanelDetails 预加载模块题目与路由切点
+func (r *mstRepository) withPanelDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("Modules.Questions").Preload("Rules.Cuts")
+}
+
+// 会话模块路径实现
+func (r *mstRepository) CreateSessionModule(ctx context.Context, module *models.ExamSessionModule) error {
+	return r.db.WithContext(ctx).Create(module).Error
+}
+
+func (r *mstRepository) CompleteSessionModule(ctx context.Context, module *models.ExamSessionModule) error {
+	return r.db.WithContext(ctx).Model(&models.ExamSessionModule{}).
+		Where("id = ?", module.ID).
+		Updates(map[string]interface{}{
+			"routing_value": module.RoutingValue,
+			"completed_at":  module.CompletedAt,
+		}).Error
+}
+
+func (r *mstRepository) ListSessionModules(ctx context.Context, sessionID uint) ([]*models.ExamSessionModule, error) {
+	var modules []*models.ExamSessionModule
+	err := r.db.WithContext(ctx).Where("exam_session_id = ?", sessionID).
+		Order("stage ASC").Find(&modules).Error
+	return modules, err
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+)
+
+type performanceLevelRepository struct {
+	db *gorm.DB
+}
+
+// NewPerformanceLevelRepository 创建表现水平仓储实例
+func NewPerformanceLevelRepository(db *gorm.DB) repositories.PerformanceLevelRepository {
+	return &performanceLevelRepository{db: db}
+}
+
+func (r *performanceLevelRepository) FindActiveSet(ctx context.Context, subjectID uint) (*models.PerformanceLevelSet, error) {
+	var set models.PerformanceLevelSet
+	err := r.db.WithContext(ctx).Preload("Levels", func(db *gorm.DB) *gorm.DB {
+		return db.Order(`"order"`)
+	}).Where("subject_id = ? AND active = ?", subjectID, true).Order("version DESC").First(&set).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &set, nil
+}
+
+func (r *performanceLevelRepository) ListSets(ctx context.Context, subjectID uint) ([]*models.PerformanceLevelSet, error) {
+	var sets []*models.PerformanceLevelSet
+	err := r.db.WithContext(ctx).Preload("Levels", func(db *gorm.DB) *gorm.DB {
+		return db.Order(`"order"`)
+	}).Where("subject_id = ?", subjectID).Order("version DESC").Find(&sets).Error
+	return sets, err
+}
+
+func (r *performanceLevelRepository) CreateSet(ctx context.Context, set *models.PerformanceLevelSet) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var version int
+		err := tx.Model(&models.PerformanceLevelSet{}).Where("subject_id = ?", set.SubjectID).
+			Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.PerformanceLevelSet{}).Where("subject_id = ? AND active = ?", set.SubjectID, true).
+			Update("active", false).Error
+		if err != nil {
+			return err
+		}
+		set.Version = version + 1
+		set.Active = true
+		return tx.Create(set).Error
+	})
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type pretestRepository struct {
+	db *gorm.DB
+}
+
+// NewPretestRepository 创建试测题仓储实例
+func NewPretestRepository(db *gorm.DB) repositories.PretestRepository {
+	return &pretestRepository{db: db}
+}
+
+// 科目配置实现
+func (r *pretestRepository) FindSetting(ctx context.Context, subjectID uint) (*models.PretestSetting, error) {
+	var setting models.PretestSetting
+	err := r.db.WithContext(ctx).Where("subject_id = ?", subjectID).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *pretestRepository) SaveSetting(ctx context.Context, setting *models.PretestSetting) error {
+	return r.db.WithContext(ctx).Save(setting).Error
+}
+
+// 试测题实现
+func (r *pretestRepository) SetPretest(ctx context.Context, subjectID uint, questionIDs []uint, pretest bool) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.Question{}).
+		Where("subject_id = ? AND id IN ?", subjectID, questionIDs).
+		Update("pretest", pretest)
+	return result.RowsAffected, result.Error
+}
+
+func (r *pretestRepository) ListPretestItems(ctx context.Context, subjectID uint, excludeIDs []uint) ([]*models.PretestItem, error) {
+	var items []*models.PretestItem
+	query := r.db.WithContext(ctx).Table("questions").
+		Select(`questions.id AS question_id,
+			(SELECT COUNT(*) FROM exam_responses WHERE exam_responses.question_id = questions.id AND exam_responses.deleted_at IS NULL) +
+			(SELECT COUNT(*) FROM question_responses WHERE question_responses.question_id = questions.id) AS response_count`).
+		Where("questions.subject_id = ? AND questions.pretest = ? AND questions.deleted_at IS NULL", subjectID, true)
+	if len(excludeIDs) > 0 {
+		query = query.Where("questions.id NOT IN ?", excludeIDs)
+	}
+	err := query.Order("questions.id").Scan(&items).Error
+	return items, err
+}
+
+func (r *pretestRepository) ListResponses(ctx context.Context, subjectID uint) ([]*models.PretestResponse, error) {
+	var records []*models.PretestResponse
+	err := r.db.WithContext(ctx).Table("exam_responses").
+		Select("'record:' || exam_responses.exam_record_id AS examinee, exam_responses.question_id, exam_responses.is_correct, questions.pretest").
+		Joins("JOIN exam_records ON exam_records.id = exam_responses.exam_record_id").
+		Joins("JOIN exam_papers ON exam_papers.id = exam_records.exam_paper_id").
+		Joins("JOIN questions ON questions.id = exam_responses.question_id").
+		Where("exam_papers.subject_id = ? AND exam_responses.deleted_at IS NULL", subjectID).
+		Order("exam_responses.exam_record_id, exam_responses.created_at").
+		Scan(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 会话作答只记录得分，得满分（未设置分值的题目按1分计）记为答对
+	var sessions []*models.PretestResponse
+	err = r.db.WithContext(ctx).Table("question_responses").
+		Select("'session:' || question_responses.exam_session_id AS examinee, question_responses.question_id, "+
+			"question_responses.score >= CASE WHEN questions.score > 0 THEN questions.score ELSE 1 END AS is_correct, "+
+			"questions.pretest").
+		Joins("JOIN exam_sessions ON exam_sessions.id = question_responses.exam_session_id").
+		Joins("JOIN exam_papers ON exam_papers.id = exam_sessions.exam_paper_id").
+		Joins("JOIN questions ON questions.id = question_responses.question_id").
+		Where("exam_papers.subject_id = ? AND exam_sessions.deleted_at IS NULL", subjectID).
+		Order("question_responses.exam_session_id, question_responses.created_at").
+		Scan(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return append(records, sessions...), nil
+}
+
+// 在线标定结果实现
+func (r *pretestRepository) SaveCalibrations(ctx context.Context, calibrations []*models.PretestCalibration) error {
+	if len(calibrations) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "question_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"method", "difficulty", "discrimination", "guessing", "upper_asymptote",
+			"difficulty_se", "discrimination_se", "guessing_se", "upper_asymptote_se",
+			"response_count", "promoted", "promoted_at", "updated_at",
+		}),
+	}).Create(calibrations).Error
+}
+
+func (r *pretestRepository) ListCalibrations(ctx context.Context, subjectID uint) ([]*models.PretestCalibration, error) {
+	var calibrations []*models.PretestCalibration
+	err := r.db.WithContext(ctx).Where("subject_id = ?", subjectID).Order("promoted, question_id").Find(&calibrations).Error
+	return calibrations, err
+}
+
+func (r *pretestRepository) Promote(ctx context.Context, calibrations []*models.PretestCalibration, params []*models.QuestionParameter) error {
+	if len(calibrations) == 0 {
+		return nil
+	}
+	now := time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, p := range params {
+			if err := tx.Save(p).Error; err != nil {
+				return err
+			}
+		}
+		questionIDs := make([]uint, len(calibrations))
+		for i, c := range calibrations {
+			questionIDs[i] = c.QuestionID
+		}
+		// 参数只写入题目参数表，题目转为正式题
+		if err := tx.Table("questions").Where("id IN ?", questionIDs).Update("pretest", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.PretestCalibration{}).Where("question_id IN ?", questionIDs).
+			Updates(map[string]interface{}{
+				"promoted":    true,
+				"promoted_at": now,
+			}).Error
+	})
+}
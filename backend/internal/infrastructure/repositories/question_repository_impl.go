@@ -177,6 +177,49 @@ func (r *QuestionRepositoryImpl) ListKnowledgePoints(ctx context.Context, questi
 	return points, err
 }
 
+// ListKnowledgePointLinks implements repositories.QuestionRepository
+func (r *QuestionRepositoryImpl) ListKnowledgePointLinks(ctx context.Context, questionIDs []uint) ([]*models.QuestionKnowledgePoint, error) {
+	var links []*models.QuestionKnowledgePoint
+	if len(questionIDs) == 0 {
+		return links, nil
+	}
+	err := r.db.WithContext(ctx).Where("question_id IN ?", questionIDs).Find(&links).Error
+	return links, err
+}
+
+// AddEnemy implements repositories.QuestionRepository
+func (r *QuestionRepositoryImpl) AddEnemy(ctx context.Context, questionID, enemyID uint) error {
+	// 统一按较小的题目ID在前存储，避免重复的反向记录
+	if questionID > enemyID {
+		questionID, enemyID = enemyID, questionID
+	}
+	return r.db.WithContext(ctx).
+		Where("question_id = ? AND enemy_id = ?", questionID, enemyID).
+		FirstOrCreate(&models.QuestionEnemy{QuestionID: questionID, EnemyID: enemyID}).Error
+}
+
+// RemoveEnemy implements repositories.QuestionRepository
+func (r *QuestionRepositoryImpl) RemoveEnemy(ctx context.Context, questionID, enemyID uint) error {
+	if questionID > enemyID {
+		questionID, enemyID = enemyID, questionID
+	}
+	return r.db.WithContext(ctx).
+		Where("question_id = ? AND enemy_id = ?", questionID, enemyID).
+		Delete(&models.QuestionEnemy{}).Error
+}
+
+// ListEnemyPairs implements repositories.QuestionRepository
+func (r *QuestionRepositoryImpl) ListEnemyPairs(ctx context.Context, questionIDs []uint) ([]*models.QuestionEnemy, error) {
+	var pairs []*models.QuestionEnemy
+	if len(questionIDs) == 0 {
+		return pairs, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("question_id IN ? OR enemy_id IN ?", questionIDs, questionIDs).
+		Find(&pairs).Error
+	return pairs, err
+}
+
 // UpdateParameters implements repositories.QuestionRepository
 func (r *QuestionRepositoryImpl) UpdateParameters(ctx context.Context, params *models.QuestionParameter) error {
 	return r.db.WithContext(ctx).Save(params).Error
@@ -199,14 +242,41 @@ func (r *QuestionRepositoryImpl) BatchGetParameters(ctx context.Context, questio
 	return params, err
 }
 
-// FindByDifficulty implements repositories.QuestionRepository
-func (r *QuestionRepositoryImpl) FindByDifficulty(ctx context.Context, difficulty float64) (*models.Question, error) {
-	var question models.Question
+// ListItemParameters implements repositories.QuestionRepository
+func (r *QuestionRepositoryImpl) ListItemParameters(ctx context.Context, questionIDs []uint) ([]*models.ItemParameter, error) {
+	var params []*models.ItemParameter
+	if len(questionIDs) == 0 {
+		return params, nil
+	}
 	err := r.db.WithContext(ctx).
-		Where("difficulty = ?", difficulty).
-		First(&question).Error
-	if err != nil {
-		return nil, err
+		Where("question_id IN ? AND deleted_at IS NULL", questionIDs).
+		Find(&params).Error
+	return params, err
+}
+
+// ListCategoryParameters implements repositories.QuestionRepository
+func (r *QuestionRepositoryImpl) ListCategoryParameters(ctx context.Context, questionIDs []uint) ([]*models.QuestionCategoryParameter, error) {
+	var params []*models.QuestionCategoryParameter
+	if len(questionIDs) == 0 {
+		return params, nil
 	}
-	return &question, nil
+	err := r.db.WithContext(ctx).
+		Select("question_category_parameters.*, question_parameters.item_model").
+		Joins("JOIN question_parameters ON question_parameters.question_id = question_category_parameters.question_id").
+		Where("question_category_parameters.question_id IN ?", questionIDs).
+		Where("question_category_parameters.deleted_at IS NULL").
+		Order("question_category_parameters.question_id, question_category_parameters.category").
+		Find(&params).Error
+	return params, err
+}
+
+// ListCandidates implements repositories.QuestionRepository
+func (r *QuestionRepositoryImpl) ListCandidates(ctx context.Context, subjectID uint, excludeIDs []uint) ([]*models.Question, error) {
+	var questions []*models.Question
+	query := r.db.WithContext(ctx).Where("subject_id = ? AND pretest = ?", subjectID, false)
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+	err := query.Find(&questions).Error
+	return questions, err
 }
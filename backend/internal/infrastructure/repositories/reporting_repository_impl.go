@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+)
+
+type reportingRepository struct {
+	db *gorm.DB
+}
+
+// NewReportingRepository 创建报告分数量表与常模仓储实例
+func NewReportingRepository(db *gorm.DB) repositories.ReportingRepository {
+	return &reportingRepository{db: db}
+}
+
+// 量表实现
+func (r *reportingRepository) FindActiveScale(ctx context.Context, subjectID uint) (*models.ReportingScale, error) {
+	var scale models.ReportingScale
+	err := r.db.WithContext(ctx).Preload("Points").
+		Where("subject_id = ? AND active = ?", subjectID, true).
+		Order("version DESC").First(&scale).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &scale, nil
+}
+
+func (r *reportingRepository) FindScale(ctx context.Context, scaleID uint) (*models.ReportingScale, error) {
+	var scale models.ReportingScale
+	err := r.db.WithContext(ctx).Preload("Points").First(&scale, scaleID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &scale, nil
+}
+
+func (r *reportingRepository) ListScales(ctx context.Context, subjectID uint) ([]*models.ReportingScale, error) {
+	var scales []*models.ReportingScale
+	err := r.db.WithContext(ctx).Preload("Points").Where("subject_id = ?", subjectID).Order("version DESC").Find(&scales).Error
+	return scales, err
+}
+
+func (r *reportingRepository) CreateScale(ctx context.Context, scale *models.ReportingScale) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var version int
+		err := tx.Model(&models.ReportingScale{}).Where("subject_id = ?", scale.SubjectID).
+			Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.ReportingScale{}).Where("subject_id = ? AND active = ?", scale.SubjectID, true).
+			Update("active", false).Error
+		if err != nil {
+			return err
+		}
+		scale.Version = version + 1
+		scale.Active = true
+		return tx.Create(scale).Error
+	})
+}
+
+// 常模表实现
+func (r *reportingRepository) FindActiveNormTable(ctx context.Context, scaleID uint) (*models.NormTable, error) {
+	var table models.NormTable
+	err := r.db.WithContext(ctx).Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("scaled_score")
+	}).Where("scale_id = ? AND active = ?", scaleID, true).Order("version DESC").First(&table).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (r *reportingRepository) FindNormTable(ctx context.Context, tableID uint) (*models.NormTable, error) {
+	var table models.NormTable
+	err := r.db.WithContext(ctx).Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("scaled_score")
+	}).First(&table, tableID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (r *reportingRepository) ListNormTables(ctx context.Context, scaleID uint) ([]*models.NormTable, error) {
+	var tables []*models.NormTable
+	err := r.db.WithContext(ctx).Where("scale_id = ?", scaleID).Order("version DESC").Find(&tables).Error
+	return tables, err
+}
+
+func (r *reportingRepository) CreateNormTable(ctx context.Context, table *models.NormTable) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var version int
+		err := tx.Model(&models.NormTable{}).Where("scale_id = ?", table.ScaleID).
+			Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.NormTable{}).Where("scale_id = ? AND active = ?", table.ScaleID, true).
+			Update("active", false).Error
+		if err != nil {
+			return err
+		}
+		table.Version = version + 1
+		table.Active = true
+		return tx.Create(table).Error
+	})
+}
+
+// 参照群体实现
+func (r *reportingRepository) ListCohortAbilities(ctx context.Context, subjectID uint, from, to *time.Time) ([]*models.UserAbility, error) {
+	var abilities []*models.UserAbility
+	query := r.db.WithContext(ctx).Where("subject_id = ?", subjectID)
+	if from != nil {
+		query = query.Where("updated_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("updated_at < ?", *to)
+	}
+	err := query.Find(&abilities).Error
+	return abilities, err
+}
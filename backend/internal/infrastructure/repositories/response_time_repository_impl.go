@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type responseTimeRepository struct {
+	db *gorm.DB
+}
+
+// NewResponseTimeRepository 创建作答时间模型仓储实例
+func NewResponseTimeRepository(db *gorm.DB) repositories.ResponseTimeRepository {
+	return &responseTimeRepository{db: db}
+}
+
+// 作答数据实现
+func (r *responseTimeRepository) ListTimedResponses(ctx context.Context, subjectID uint) ([]*models.TimedResponse, error) {
+	var records []*models.TimedResponse
+	err := r.db.WithContext(ctx).Table("exam_responses").
+		Select("'record:' || exam_responses.exam_record_id AS examinee, exam_responses.question_id, exam_responses.is_correct, questions.pretest, exam_responses.response_time AS seconds").
+		Joins("JOIN exam_records ON exam_records.id = exam_responses.exam_record_id").
+		Joins("JOIN exam_papers ON exam_papers.id = exam_records.exam_paper_id").
+		Joins("JOIN questions ON questions.id = exam_responses.question_id").
+		Where("exam_papers.subject_id = ? AND exam_responses.deleted_at IS NULL AND exam_responses.response_time > 0", subjectID).
+		Order("exam_responses.exam_record_id, exam_responses.created_at").
+		Scan(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 会话作答只记录得分，得满分（未设置分值的题目按1分计）记为答对
+	var sessions []*models.TimedResponse
+	err = r.db.WithContext(ctx).Table("question_responses").
+		Select("'session:' || question_responses.exam_session_id AS examinee, question_responses.question_id, "+
+			"question_responses.score >= CASE WHEN questions.score > 0 THEN questions.score ELSE 1 END AS is_correct, "+
+			"questions.pretest, question_responses.time_spent AS seconds").
+		Joins("JOIN exam_sessions ON exam_sessions.id = question_responses.exam_session_id").
+		Joins("JOIN exam_papers ON exam_papers.id = exam_sessions.exam_paper_id").
+		Joins("JOIN questions ON questions.id = question_responses.question_id").
+		Where("exam_papers.subject_id = ? AND exam_sessions.deleted_at IS NULL AND question_responses.time_spent > 0", subjectID).
+		Order("question_responses.exam_session_id, question_responses.created_at").
+		Scan(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return append(records, sessions...), nil
+}
+
+// 题目时间参数实现
+func (r *responseTimeRepository) ListParameters(ctx context.Context, questionIDs []uint) ([]*models.ResponseTimeParameter, error) {
+	var params []*models.ResponseTimeParameter
+	if len(questionIDs) == 0 {
+		return params, nil
+	}
+	err := r.db.WithContext(ctx).Where("question_id IN ?", questionIDs).Find(&params).Error
+	return params, err
+}
+
+func (r *responseTimeRepository) SaveCalibration(ctx context.Context, params []*models.ResponseTimeParameter, population *models.SpeedPopulation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(params) > 0 {
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "question_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"subject_id", "intensity", "discrimination", "intensity_se", "discrimination_se",
+					"response_count", "calibrated_at", "updated_at",
+				}),
+			}).Create(params).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "subject_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"mean_theta", "sd_theta", "mean_speed", "sd_speed", "correlation",
+				"examinees", "calibrated_at", "updated_at",
+			}),
+		}).Create(population).Error
+	})
+}
+
+// 考生总体分布实现
+func (r *responseTimeRepository) FindPopulation(ctx context.Context, subjectID uint) (*models.SpeedPopulation, error) {
+	var population models.SpeedPopulation
+	err := r.db.WithContext(ctx).Where("subject_id = ?", subjectID).First(&population).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &population, nil
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type standardSettingRepository struct {
+	db *gorm.DB
+}
+
+// NewStandardSettingRepository 创建标准设定会议仓储实例
+func NewStandardSettingRepository(db *gorm.DB) repositories.StandardSettingRepository {
+	return &standardSettingRepository{db: db}
+}
+
+// 会议实现
+func (r *standardSettingRepository) CreateSession(ctx context.Context, session *models.StandardSettingSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *standardSettingRepository) FindSession(ctx context.Context, sessionID uint) (*models.StandardSettingSession, error) {
+	var session models.StandardSettingSession
+	err := r.db.WithContext(ctx).Preload("Levels").First(&session, sessionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *standardSettingRepository) ListSessions(ctx context.Context, subjectID uint) ([]*models.StandardSettingSession, error) {
+	var sessions []*models.StandardSettingSession
+	err := r.db.WithContext(ctx).Preload("Levels").Where("subject_id = ?", subjectID).Order("created_at DESC").Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *standardSettingRepository) UpdateSession(ctx context.Context, session *models.StandardSettingSession) error {
+	return r.db.WithContext(ctx).Model(session).Select("round", "status").Updates(session).Error
+}
+
+// 评定实现
+func (r *standardSettingRepository) SaveRatings(ctx context.Context, ratings []*models.StandardSettingRating) error {
+	if len(ratings) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "session_id"}, {Name: "panelist_id"}, {Name: "round"}, {Name: "boundary"}, {Name: "question_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(ratings).Error
+}
+
+func (r *standardSettingRepository) ListRatings(ctx context.Context, sessionID uint, round int) ([]*models.StandardSettingRating, error) {
+	var ratings []*models.StandardSettingRating
+	err := r.db.WithContext(ctx).Where("session_id = ? AND round = ?", sessionID, round).
+		Order("boundary, panelist_id, question_id").Find(&ratings).Error
+	return ratings, err
+}
+
+// 试卷与参照群体实现
+func (r *standardSettingRepository) FindPaper(ctx context.Context, paperID uint) (*models.ExamPaper, error) {
+	var paper models.ExamPaper
+	err := r.db.WithContext(ctx).First(&paper, paperID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &paper, nil
+}
+
+func (r *standardSettingRepository) ListPaperQuestions(ctx context.Context, paperID uint) ([]*models.ExamPaperQuestion, error) {
+	var questions []*models.ExamPaperQuestion
+	err := r.db.WithContext(ctx).Preload("Question").Where("exam_paper_id = ?", paperID).Order(`"order"`).Find(&questions).Error
+	return questions, err
+}
+
+func (r *standardSettingRepository) ListSubjectThetas(ctx context.Context, subjectID uint) ([]float64, error) {
+	var thetas []float64
+	err := r.db.WithContext(ctx).Model(&models.UserAbility{}).Where("subject_id = ?", subjectID).Pluck("ability", &thetas).Error
+	return thetas, err
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/repositories"
+	"irt-exam-system/backend/models"
+
+	"gorm.io/gorm"
+)
+
+type statisticsRepository struct {
+	db *gorm.DB
+}
+
+// NewStatisticsRepository 创建试卷经典统计仓储实例
+func NewStatisticsRepository(db *gorm.DB) repositories.StatisticsRepository {
+	return &statisticsRepository{db: db}
+}
+
+func (r *statisticsRepository) FindPaper(ctx context.Context, paperID uint) (*models.ExamPaper, error) {
+	var paper models.ExamPaper
+	err := r.db.WithContext(ctx).First(&paper, paperID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &paper, nil
+}
+
+func (r *statisticsRepository) ListPaperQuestions(ctx context.Context, paperID uint) ([]*models.ExamPaperQuestion, error) {
+	var questions []*models.ExamPaperQuestion
+	err := r.db.WithContext(ctx).Preload("Question").Preload("Question.Options").Where("exam_paper_id = ?", paperID).Order(`"order"`).Find(&questions).Error
+	return questions, err
+}
+
+func (r *statisticsRepository) ListCompletedResponses(ctx context.Context, paperID uint) ([]*models.ExamResponse, error) {
+	var responses []*models.ExamResponse
+	err := r.db.WithContext(ctx).
+		Preload("Question").Preload("ExamRecord").
+		Joins("JOIN exam_records ON exam_records.id = exam_responses.exam_record_id").
+		Where("exam_records.exam_paper_id = ? AND exam_records.status = ?", paperID, "completed").
+		Order("exam_responses.exam_record_id, exam_responses.created_at").
+		Find(&responses).Error
+	return responses, err
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type subjectRepository struct {
+	db *gorm.DB
+}
+
+// NewSubjectRepository 创建科目仓储实例
+func NewSubjectRepository(db *gorm.DB) repositories.SubjectRepository {
+	return &subjectRepository{db: db}
+}
+
+func (r *subjectRepository) FindByID(ctx context.Context, id uint) (*models.Subject, error) {
+	var subject models.Subject
+	err := r.db.WithContext(ctx).First(&subject, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &subject, nil
+}
+
+func (r *subjectRepository) UpdateIRTModel(ctx context.Context, id uint, family string, scaling float64) error {
+	return r.db.WithContext(ctx).Model(&models.Subject{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"irt_model": family, "scaling_constant": scaling}).Error
+}
@@ -0,0 +1,72 @@
+package dto
+
+import "irt-exam-system/backend/internal/domain/models"
+
+// AdaptiveSettingsRequest 试卷自适应选题与终止规则配置请求
+type AdaptiveSettingsRequest struct {
+	SelectionStrategy   string  `json:"selection_strategy" binding:"omitempty,oneof=max_info kl a_stratified b_matching d_optimal"`
+	MinItems            int     `json:"min_items" binding:"min=0"`
+	MaxItems            int     `json:"max_items" binding:"required,min=1,gtefield=MinItems"`
+	TargetSE            float64 `json:"target_se" binding:"gte=0"`
+	ThetaChange         float64 `json:"theta_change" binding:"gte=0"`
+	Classification      string  `json:"classification" binding:"omitempty,oneof=sprt ci"`
+	CutScore            float64 `json:"cut_score" binding:"gte=-4,lte=4"`
+	IndifferenceRegion  float64 `json:"indifference_region" binding:"gte=0,lte=1"`
+	ClassificationError float64 `json:"classification_error" binding:"gte=0,lt=0.5"`
+	TimeAwareSelection  bool    `json:"time_aware_selection"`
+}
+
+// AdaptiveSettingsResponse 试卷自适应配置响应
+type AdaptiveSettingsResponse struct {
+	ExamPaperID         uint    `json:"exam_paper_id"`
+	SelectionStrategy   string  `json:"selection_strategy"`
+	MinItems            int     `json:"min_items"`
+	MaxItems            int     `json:"max_items"`
+	TargetSE            float64 `json:"target_se"`
+	TimeLimitMinutes    int     `json:"time_limit_minutes"`
+	ThetaChange         float64 `json:"theta_change"`
+	Classification      string  `json:"classification"`
+	CutScore            float64 `json:"cut_score"`
+	IndifferenceRegion  float64 `json:"indifference_region"`
+	ClassificationError float64 `json:"classification_error"`
+	TimeAwareSelection  bool    `json:"time_aware_selection"`
+}
+
+// ApplyTo 将配置写入试卷
+func (r *AdaptiveSettingsRequest) ApplyTo(paper *models.ExamPaper) {
+	if r.SelectionStrategy != "" {
+		paper.SelectionStrategy = r.SelectionStrategy
+	}
+	paper.MinItems = r.MinItems
+	paper.MaxItems = r.MaxItems
+	paper.TargetSE = r.TargetSE
+	paper.ThetaChange = r.ThetaChange
+	paper.Classification = r.Classification
+	paper.CutScore = r.CutScore
+	paper.IndifferenceRegion = r.IndifferenceRegion
+	paper.ClassificationError = r.ClassificationError
+	paper.TimeAwareSelection = r.TimeAwareSelection
+	if paper.IndifferenceRegion == 0 {
+		paper.IndifferenceRegion = 0.2
+	}
+	if paper.ClassificationError == 0 {
+		paper.ClassificationError = 0.05
+	}
+}
+
+func ToAdaptiveSettingsResponse(paper *models.ExamPaper) AdaptiveSettingsResponse {
+	return AdaptiveSettingsResponse{
+		ExamPaperID:         paper.ID,
+		SelectionStrategy:   paper.SelectionStrategy,
+		MinItems:            paper.MinItems,
+		MaxItems:            paper.MaxItems,
+		TargetSE:            paper.TargetSE,
+		TimeLimitMinutes:    paper.Duration,
+		ThetaChange:         paper.ThetaChange,
+		Classification:      paper.Classification,
+		CutScore:            paper.CutScore,
+		IndifferenceRegion:  paper.IndifferenceRegion,
+		ClassificationError: paper.ClassificationError,
+		TimeAwareSelection:  paper.TimeAwareSelection,
+	}
+}
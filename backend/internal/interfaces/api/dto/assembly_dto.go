@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+// AssemblyRequest 自动组卷请求
+type AssemblyRequest struct {
+	Title          string                       `json:"title" binding:"required"`
+	Description    string                       `json:"description"`
+	Duration       int                          `json:"duration" binding:"min=0"` // 考试时长（分钟），同时限制预计作答总时间
+	Forms          int                          `json:"forms" binding:"omitempty,min=1,max=20"`
+	Length         int                          `json:"length" binding:"required,min=1"`
+	Targets        []TargetPointRequest         `json:"targets" binding:"required,min=1,dive"`
+	Constraints    []BlueprintConstraintRequest `json:"constraints" binding:"dive"`
+	TotalScore     float64                      `json:"total_score" binding:"gte=0"`
+	ScoreTolerance float64                      `json:"score_tolerance" binding:"gte=0"`
+	SwapIterations *int                         `json:"swap_iterations" binding:"omitempty,min=0"`
+	DryRun         bool                         `json:"dry_run"`
+}
+
+// TargetPointRequest 目标测验信息函数上的一个点
+type TargetPointRequest struct {
+	Theta       float64 `json:"theta"`
+	Information float64 `json:"information" binding:"gt=0"`
+}
+
+// AssemblyResponse 自动组卷响应
+type AssemblyResponse struct {
+	SubjectID uint                    `json:"subject_id"`
+	PoolSize  int                     `json:"pool_size"`
+	Targets   []TargetPointRequest    `json:"targets"`
+	Forms     []AssembledFormResponse `json:"forms"`
+}
+
+// AssembledFormResponse 一份平行卷，dry_run 时 exam_paper_id 为0
+type AssembledFormResponse struct {
+	ExamPaperID uint      `json:"exam_paper_id"`
+	Title       string    `json:"title"`
+	QuestionIDs []uint    `json:"question_ids"`
+	Information []float64 `json:"information"` // 与 targets 一一对应
+	Deviation   float64   `json:"deviation"`
+	TotalScore  float64   `json:"total_score"`
+	Seconds     float64   `json:"expected_seconds"`
+	Violations  []string  `json:"violations"`
+
+	PretestQuestionIDs []uint `json:"pretest_question_ids"` // 嵌入的试测题，不计分
+}
+
+// ToAssemblyRequest 将请求转换为组卷服务的参数
+func (r *AssemblyRequest) ToAssemblyRequest(subjectID uint) *services.AssemblyRequest {
+	spec := irt.AssemblySpec{
+		Forms:          r.Forms,
+		Length:         r.Length,
+		Targets:        make([]irt.TargetPoint, len(r.Targets)),
+		Constraints:    make([]irt.ContentConstraint, len(r.Constraints)),
+		TotalScore:     r.TotalScore,
+		ScoreTolerance: r.ScoreTolerance,
+		SwapIterations: 10,
+	}
+	if spec.Forms == 0 {
+		spec.Forms = 1
+	}
+	if r.SwapIterations != nil {
+		spec.SwapIterations = *r.SwapIterations
+	}
+	for i, t := range r.Targets {
+		spec.Targets[i] = irt.TargetPoint{Theta: t.Theta, Information: t.Information}
+	}
+	for i, c := range r.Constraints {
+		spec.Constraints[i] = irt.ContentConstraint{
+			KnowledgePointID: c.KnowledgePointID,
+			QuestionType:     c.QuestionType,
+			MinCount:         c.MinCount,
+			MaxCount:         c.MaxCount,
+			TargetProportion: c.TargetProportion,
+			Weight:           c.Weight,
+		}
+	}
+	return &services.AssemblyRequest{
+		SubjectID:   subjectID,
+		Title:       r.Title,
+		Description: r.Description,
+		Duration:    r.Duration,
+		Spec:        spec,
+		DryRun:      r.DryRun,
+	}
+}
+
+func ToAssemblyResponse(result *services.AssemblyResult) AssemblyResponse {
+	resp := AssemblyResponse{
+		SubjectID: result.SubjectID,
+		PoolSize:  result.PoolSize,
+		Targets:   make([]TargetPointRequest, len(result.Targets)),
+		Forms:     make([]AssembledFormResponse, len(result.Forms)),
+	}
+	for i, t := range result.Targets {
+		resp.Targets[i] = TargetPointRequest{Theta: t.Theta, Information: t.Information}
+	}
+	for i, f := range result.Forms {
+		violations := f.Violations
+		if violations == nil {
+			violations = []string{}
+		}
+		resp.Forms[i] = AssembledFormResponse{
+			ExamPaperID: f.ExamPaperID,
+			Title:       f.Title,
+			QuestionIDs: f.QuestionIDs,
+			Information: f.Information,
+			Deviation:   f.Deviation,
+			TotalScore:  f.TotalScore,
+			Seconds:     f.Seconds,
+			Violations:  violations,
+
+			PretestQuestionIDs: f.PretestQuestionIDs,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,79 @@
+package dto
+
+import "irt-exam-system/backend/internal/domain/models"
+
+// BlueprintRequest 内容蓝图请求
+type BlueprintRequest struct {
+	Method      string                       `json:"method" binding:"required,oneof=mpi wdm"`
+	Constraints []BlueprintConstraintRequest `json:"constraints" binding:"required,min=1,dive"`
+}
+
+// BlueprintConstraintRequest 蓝图约束，knowledge_point_id 与 question_type 二选一
+type BlueprintConstraintRequest struct {
+	KnowledgePointID uint    `json:"knowledge_point_id"`
+	QuestionType     string  `json:"question_type"`
+	MinCount         int     `json:"min_count" binding:"min=0"`
+	MaxCount         int     `json:"max_count" binding:"min=0"`
+	TargetProportion float64 `json:"target_proportion" binding:"gte=0,lte=1"`
+	Weight           float64 `json:"weight" binding:"gte=0"`
+}
+
+// BlueprintResponse 内容蓝图响应
+type BlueprintResponse struct {
+	ExamPaperID uint                          `json:"exam_paper_id"`
+	Method      string                        `json:"method"`
+	Constraints []BlueprintConstraintResponse `json:"constraints"`
+}
+
+// BlueprintConstraintResponse 蓝图约束响应
+type BlueprintConstraintResponse struct {
+	KnowledgePointID uint    `json:"knowledge_point_id,omitempty"`
+	QuestionType     string  `json:"question_type,omitempty"`
+	MinCount         int     `json:"min_count"`
+	MaxCount         int     `json:"max_count"`
+	TargetProportion float64 `json:"target_proportion"`
+	Weight           float64 `json:"weight"`
+}
+
+// ToExamBlueprint 将请求转换为蓝图模型
+func (r *BlueprintRequest) ToExamBlueprint(examPaperID uint) *models.ExamBlueprint {
+	blueprint := &models.ExamBlueprint{
+		ExamPaperID: examPaperID,
+		Method:      r.Method,
+		Constraints: make([]models.BlueprintConstraint, len(r.Constraints)),
+	}
+	for i, c := range r.Constraints {
+		weight := c.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		blueprint.Constraints[i] = models.BlueprintConstraint{
+			KnowledgePointID: c.KnowledgePointID,
+			QuestionType:     c.QuestionType,
+			MinCount:         c.MinCount,
+			MaxCount:         c.MaxCount,
+			TargetProportion: c.TargetProportion,
+			Weight:           weight,
+		}
+	}
+	return blueprint
+}
+
+func ToBlueprintResponse(blueprint *models.ExamBlueprint) BlueprintResponse {
+	resp := BlueprintResponse{
+		ExamPaperID: blueprint.ExamPaperID,
+		Method:      blueprint.Method,
+		Constraints: make([]BlueprintConstraintResponse, len(blueprint.Constraints)),
+	}
+	for i, c := range blueprint.Constraints {
+		resp.Constraints[i] = BlueprintConstraintResponse{
+			KnowledgePointID: c.KnowledgePointID,
+			QuestionType:     c.QuestionType,
+			MinCount:         c.MinCount,
+			MaxCount:         c.MaxCount,
+			TargetProportion: c.TargetProportion,
+			Weight:           c.Weight,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,66 @@
+package dto
+
+import "irt-exam-system/backend/internal/domain/irt"
+
+// CalibrationRequest 题目参数标定请求，Model 为空时使用科目配置的模型
+type CalibrationRequest struct {
+	Model  string `json:"model" binding:"omitempty,oneof=Rasch 1PL 2PL 3PL 4PL"`
+	DryRun bool   `json:"dry_run"`
+}
+
+// CalibrationResponse 标定运行结果响应
+type CalibrationResponse struct {
+	Model         string                    `json:"model"`
+	D             float64                   `json:"d"`
+	Examinees     int                       `json:"examinees"`
+	Iterations    int                       `json:"iterations"`
+	Converged     bool                      `json:"converged"`
+	MaxChange     float64                   `json:"max_change"`
+	LogLikelihood float64                   `json:"log_likelihood"`
+	Items         []ItemCalibrationResponse `json:"items"`
+}
+
+// ItemCalibrationResponse 单题标定结果响应
+type ItemCalibrationResponse struct {
+	QuestionID       uint    `json:"question_id"`
+	Difficulty       float64 `json:"difficulty"`
+	Discrimination   float64 `json:"discrimination"`
+	Guessing         float64 `json:"guessing"`
+	UpperAsymptote   float64 `json:"upper_asymptote"`
+	DifficultySE     float64 `json:"difficulty_se"`
+	DiscriminationSE float64 `json:"discrimination_se"`
+	GuessingSE       float64 `json:"guessing_se"`
+	UpperAsymptoteSE float64 `json:"upper_asymptote_se"`
+	ResponseCount    int     `json:"response_count"`
+	Skipped          bool    `json:"skipped"`
+}
+
+func ToCalibrationResponse(result *irt.CalibrationResult) CalibrationResponse {
+	resp := CalibrationResponse{
+		Model:         string(result.Model),
+		D:             result.D,
+		Examinees:     result.Examinees,
+		Iterations:    result.Iterations,
+		Converged:     result.Converged,
+		MaxChange:     result.MaxChange,
+		LogLikelihood: result.LogLikelihood,
+	}
+
+	resp.Items = make([]ItemCalibrationResponse, len(result.Items))
+	for i, item := range result.Items {
+		resp.Items[i] = ItemCalibrationResponse{
+			QuestionID:       item.QuestionID,
+			Difficulty:       item.Difficulty,
+			Discrimination:   item.Discrimination,
+			Guessing:         item.Guessing,
+			UpperAsymptote:   item.UpperAsymptote,
+			DifficultySE:     item.DifficultySE,
+			DiscriminationSE: item.DiscriminationSE,
+			GuessingSE:       item.GuessingSE,
+			UpperAsymptoteSE: item.UpperAsymptoteSE,
+			ResponseCount:    item.ResponseCount,
+			Skipped:          item.Skipped,
+		}
+	}
+	return resp
+}
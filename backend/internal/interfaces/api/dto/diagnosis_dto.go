@@ -0,0 +1,23 @@
+package dto
+
+import "irt-exam-system/backend/internal/domain/analysis"
+
+// DiagnosisQuery 认知诊断查询参数
+type DiagnosisQuery struct {
+	Model             string `form:"model" binding:"omitempty,oneof=dina dino"`
+	KnowledgePointIDs string `form:"knowledge_point_ids"` // 逗号分隔，为空时使用科目全部知识点
+}
+
+// KnowledgeProgressQuery 考生知识点掌握进度查询参数
+type KnowledgeProgressQuery struct {
+	DiagnosisQuery
+	UserID uint `form:"user_id" binding:"required"`
+}
+
+// DiagnosisModel 返回查询的诊断模型，默认 DINA
+func (q *DiagnosisQuery) DiagnosisModel() analysis.DiagnosisModel {
+	if q.Model == "" {
+		return analysis.DINA
+	}
+	return analysis.DiagnosisModel(q.Model)
+}
@@ -0,0 +1,70 @@
+package dto
+
+import "irt-exam-system/backend/models"
+
+// DIFAnalysisRequest DIF 分析请求
+type DIFAnalysisRequest struct {
+	GroupAttribute string `json:"group_attribute" binding:"required,oneof=class region gender"`
+	ReferenceGroup string `json:"reference_group" binding:"required"`
+	FocalGroup     string `json:"focal_group" binding:"required,nefield=ReferenceGroup"`
+}
+
+// DIFAnalysisResponse DIF 分析结果响应
+type DIFAnalysisResponse struct {
+	Total       int                 `json:"total"`
+	Skipped     int                 `json:"skipped"`
+	ETSCounts   map[string]int      `json:"ets_counts"`
+	ResultItems []DIFResultResponse `json:"items"`
+}
+
+// DIFResultResponse 单题 DIF 结果响应
+type DIFResultResponse struct {
+	QuestionID     uint    `json:"question_id"`
+	ReferenceCount int     `json:"reference_count"`
+	FocalCount     int     `json:"focal_count"`
+	Skipped        bool    `json:"skipped"`
+	MHOddsRatio    float64 `json:"mh_odds_ratio"`
+	MHDelta        float64 `json:"mh_delta"`
+	MHDeltaSE      float64 `json:"mh_delta_se"`
+	MHChiSquare    float64 `json:"mh_chi_square"`
+	MHPValue       float64 `json:"mh_p_value"`
+	ETSClass       string  `json:"ets_class"`
+	LRUniform      float64 `json:"lr_uniform"`
+	LRNonUniform   float64 `json:"lr_non_uniform"`
+	LRPValue       float64 `json:"lr_p_value"`
+	LRDeltaR2      float64 `json:"lr_delta_r2"`
+	LRClass        string  `json:"lr_class"`
+}
+
+func ToDIFAnalysisResponse(results []*models.DIFResult) DIFAnalysisResponse {
+	resp := DIFAnalysisResponse{
+		Total:       len(results),
+		ETSCounts:   map[string]int{"A": 0, "B": 0, "C": 0},
+		ResultItems: make([]DIFResultResponse, len(results)),
+	}
+	for i, r := range results {
+		if r.Skipped {
+			resp.Skipped++
+		} else {
+			resp.ETSCounts[r.ETSClass]++
+		}
+		resp.ResultItems[i] = DIFResultResponse{
+			QuestionID:     r.QuestionID,
+			ReferenceCount: r.ReferenceCount,
+			FocalCount:     r.FocalCount,
+			Skipped:        r.Skipped,
+			MHOddsRatio:    r.MHOddsRatio,
+			MHDelta:        r.MHDelta,
+			MHDeltaSE:      r.MHDeltaSE,
+			MHChiSquare:    r.MHChiSquare,
+			MHPValue:       r.MHPValue,
+			ETSClass:       r.ETSClass,
+			LRUniform:      r.LRUniform,
+			LRNonUniform:   r.LRNonUniform,
+			LRPValue:       r.LRPValue,
+			LRDeltaR2:      r.LRDeltaR2,
+			LRClass:        r.LRClass,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,29 @@
+package dto
+
+import "irt-exam-system/backend/internal/domain/irt"
+
+// ExposureSettingRequest 曝光控制配置请求
+type ExposureSettingRequest struct {
+	Method          string  `json:"method" binding:"required,oneof=none randomesque sympson_hetter"`
+	RandomesqueSize int     `json:"randomesque_size" binding:"omitempty,min=1,max=50"`
+	MaxExposureRate float64 `json:"max_exposure_rate" binding:"omitempty,gt=0,lte=1"`
+}
+
+// SympsonHetterResponse Sympson–Hetter 曝光参数模拟结果响应
+type SympsonHetterResponse struct {
+	Iterations    int              `json:"iterations"`
+	Converged     bool             `json:"converged"`
+	MaxExposure   float64          `json:"max_exposure"`
+	Parameters    map[uint]float64 `json:"parameters"`
+	ExposureRates map[uint]float64 `json:"exposure_rates"`
+}
+
+func ToSympsonHetterResponse(result *irt.SympsonHetterResult) SympsonHetterResponse {
+	return SympsonHetterResponse{
+		Iterations:    result.Iterations,
+		Converged:     result.Converged,
+		MaxExposure:   result.MaxExposure,
+		Parameters:    result.Parameters,
+		ExposureRates: result.ExposureRates,
+	}
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "irt-exam-system/backend/internal/domain/analysis"
+
+// ItemFitResponse 单题拟合统计响应
+type ItemFitResponse struct {
+	QuestionID   uint                    `json:"question_id"`
+	Count        int                     `json:"count"`
+	Infit        float64                 `json:"infit"`
+	Outfit       float64                 `json:"outfit"`
+	SX2          *float64                `json:"s_x2"`
+	SX2DF        int                     `json:"s_x2_df,omitempty"`
+	SX2PValue    *float64                `json:"s_x2_p_value"`
+	Misfit       bool                    `json:"misfit"`
+	ResidualPlot []ResidualPointResponse `json:"residual_plot"`
+}
+
+// ResidualPointResponse 残差图数据点
+type ResidualPointResponse struct {
+	Theta                float64 `json:"theta"`
+	Count                int     `json:"count"`
+	Observed             float64 `json:"observed"`
+	Expected             float64 `json:"expected"`
+	StandardizedResidual float64 `json:"standardized_residual"`
+}
+
+func ToItemFitResponse(fit *analysis.ItemFit) ItemFitResponse {
+	resp := ItemFitResponse{
+		QuestionID:   fit.QuestionID,
+		Count:        fit.Count,
+		Infit:        fit.Infit,
+		Outfit:       fit.Outfit,
+		Misfit:       fit.Misfit,
+		ResidualPlot: make([]ResidualPointResponse, len(fit.Residuals)),
+	}
+	// 完整作答人数不足时 S-X² 不可用，返回 null
+	if fit.SX2Available {
+		sx2, p := fit.SX2, fit.SX2PValue
+		resp.SX2 = &sx2
+		resp.SX2DF = fit.SX2DF
+		resp.SX2PValue = &p
+	}
+	for i, point := range fit.Residuals {
+		resp.ResidualPlot[i] = ResidualPointResponse{
+			Theta:                point.Theta,
+			Count:                point.Count,
+			Observed:             point.Observed,
+			Expected:             point.Expected,
+			StandardizedResidual: point.StandardizedResidual,
+		}
+	}
+	return resp
+}
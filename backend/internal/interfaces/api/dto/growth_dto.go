@@ -0,0 +1,7 @@
+package dto
+
+// GrowthQuery 能力成长查询参数，预测以最近一次考试为起点
+type GrowthQuery struct {
+	HorizonDays int `form:"horizon_days,default=30" binding:"min=1,max=365"`
+	StepDays    int `form:"step_days,default=7" binding:"min=1,max=365"` // 成长曲线预测段的点间隔
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "irt-exam-system/backend/models"
+
+// ItemModelRequest 题目项目反应模型设置请求，grm/gpcm 需给出按类别排列的阈值
+type ItemModelRequest struct {
+	Model          string    `json:"model" binding:"required,oneof=3pl grm gpcm"`
+	Discrimination float64   `json:"discrimination" binding:"gt=0"`
+	Thresholds     []float64 `json:"thresholds"`
+}
+
+// ItemModelResponse 题目项目反应模型响应
+type ItemModelResponse struct {
+	QuestionID     uint      `json:"question_id"`
+	Model          string    `json:"model"`
+	Difficulty     float64   `json:"difficulty"` // 多级计分题为阈值均值
+	Discrimination float64   `json:"discrimination"`
+	Guessing       float64   `json:"guessing"`
+	Thresholds     []float64 `json:"thresholds"`
+}
+
+func ToItemModelResponse(params *models.QuestionParameter) ItemModelResponse {
+	resp := ItemModelResponse{
+		QuestionID:     params.QuestionID,
+		Model:          params.ItemModel,
+		Difficulty:     params.Difficulty,
+		Discrimination: params.Discrimination,
+		Guessing:       params.Guessing,
+		Thresholds:     make([]float64, len(params.Categories)),
+	}
+	if resp.Model == "" {
+		resp.Model = "3pl"
+	}
+	for i, c := range params.Categories {
+		resp.Thresholds[i] = c.Threshold
+	}
+	return resp
+}
@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"time"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/models"
+)
+
+// LinkingRequest 量尺连接请求，Apply 为 true 时将新试卷参数与能力值转换到基准量尺
+type LinkingRequest struct {
+	NewPaperID  uint   `json:"new_paper_id" binding:"required"`
+	BasePaperID uint   `json:"base_paper_id" binding:"required"`
+	Method      string `json:"method" binding:"required,oneof=mean_mean mean_sigma haebara stocking_lord"`
+	Apply       bool   `json:"apply"`
+}
+
+// ScaleTransformationResponse 量尺转换记录响应
+type ScaleTransformationResponse struct {
+	ID                uint       `json:"id"`
+	NewPaperID        uint       `json:"new_paper_id"`
+	BasePaperID       uint       `json:"base_paper_id"`
+	Method            string     `json:"method"`
+	Slope             float64    `json:"slope"`
+	Intercept         float64    `json:"intercept"`
+	AnchorCount       int        `json:"anchor_count"`
+	Applied           bool       `json:"applied"`
+	ItemsRescaled     int        `json:"items_rescaled"`
+	AbilitiesRescaled int        `json:"abilities_rescaled"`
+	AppliedAt         *time.Time `json:"applied_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// LinkingResponse 量尺连接结果响应
+type LinkingResponse struct {
+	Transformation ScaleTransformationResponse `json:"transformation"`
+	Anchors        []services.AnchorReport     `json:"anchors"`
+}
+
+// EquatingRequest 原始分等值请求，Scores 为空时等值 from 试卷的全部整数分
+type EquatingRequest struct {
+	FromPaperID uint      `json:"from_paper_id" binding:"required"`
+	ToPaperID   uint      `json:"to_paper_id" binding:"required"`
+	Method      string    `json:"method" binding:"required,oneof=true_score observed_score"`
+	Scores      []float64 `json:"scores"`
+}
+
+// EquatingResponse 原始分等值结果响应
+type EquatingResponse struct {
+	FromPaperID uint                   `json:"from_paper_id"`
+	ToPaperID   uint                   `json:"to_paper_id"`
+	Method      string                 `json:"method"`
+	Scores      []EquatedScoreResponse `json:"scores"`
+}
+
+// EquatedScoreResponse 单个原始分的等值结果响应
+type EquatedScoreResponse struct {
+	RawScore float64 `json:"raw_score"`
+	Theta    float64 `json:"theta"`
+	Equated  float64 `json:"equated"`
+}
+
+func ToScaleTransformationResponse(t *models.ScaleTransformation) ScaleTransformationResponse {
+	return ScaleTransformationResponse{
+		ID:                t.ID,
+		NewPaperID:        t.NewPaperID,
+		BasePaperID:       t.BasePaperID,
+		Method:            t.Method,
+		Slope:             t.Slope,
+		Intercept:         t.Intercept,
+		AnchorCount:       t.AnchorCount,
+		Applied:           t.Applied,
+		ItemsRescaled:     t.ItemsRescaled,
+		AbilitiesRescaled: t.AbilitiesRescaled,
+		AppliedAt:         t.AppliedAt,
+		CreatedAt:         t.CreatedAt,
+	}
+}
+
+func ToLinkingResponse(result *services.LinkingResult) LinkingResponse {
+	return LinkingResponse{
+		Transformation: ToScaleTransformationResponse(result.Transformation),
+		Anchors:        result.Anchors,
+	}
+}
+
+func ToEquatingResponse(result *services.EquatingResult) EquatingResponse {
+	resp := EquatingResponse{
+		FromPaperID: result.FromPaperID,
+		ToPaperID:   result.ToPaperID,
+		Method:      string(result.Method),
+		Scores:      make([]EquatedScoreResponse, len(result.Scores)),
+	}
+	for i, score := range result.Scores {
+		resp.Scores[i] = EquatedScoreResponse{
+			RawScore: score.RawScore,
+			Theta:    score.Theta,
+			Equated:  score.Equated,
+		}
+	}
+	return resp
+}
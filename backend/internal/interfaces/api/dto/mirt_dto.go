@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"sort"
+	"time"
+
+	"irt-exam-system/backend/models"
+)
+
+// MIRTCalibrationRequest 多维标定请求
+type MIRTCalibrationRequest struct {
+	DryRun bool `json:"dry_run"`
+}
+
+// MIRTCalibrationResponse 多维标定响应，correlations 与各题 discrimination 按 dimensions 的顺序排列
+type MIRTCalibrationResponse struct {
+	ID            uint                    `json:"id,omitempty"` // 试算时为空
+	SubjectID     uint                    `json:"subject_id"`
+	Version       int                     `json:"version,omitempty"`
+	Model         string                  `json:"model"`
+	D             float64                 `json:"d"`
+	Examinees     int                     `json:"examinees"`
+	Iterations    int                     `json:"iterations"`
+	Converged     bool                    `json:"converged"`
+	LogLikelihood float64                 `json:"log_likelihood"`
+	Active        bool                    `json:"active"`
+	Dimensions    []MIRTDimensionResponse `json:"dimensions"`
+	Correlations  [][]float64             `json:"correlations"`
+	Items         []MIRTItemResponse      `json:"items,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+}
+
+// MIRTDimensionResponse 维度，对应科目的一个顶层知识点
+type MIRTDimensionResponse struct {
+	KnowledgePointID uint   `json:"knowledge_point_id"`
+	Name             string `json:"name"`
+}
+
+// MIRTItemResponse 题目多维参数，未加载的维度区分度为0
+type MIRTItemResponse struct {
+	QuestionID          uint      `json:"question_id"`
+	Discrimination      []float64 `json:"discrimination"`
+	DiscriminationSE    []float64 `json:"discrimination_se"`
+	Intercept           float64   `json:"intercept"`
+	InterceptSE         float64   `json:"intercept_se"`
+	Guessing            float64   `json:"guessing"`
+	GuessingSE          float64   `json:"guessing_se"`
+	MultiDiscrimination float64   `json:"mdisc"`
+	MultiDifficulty     float64   `json:"mdiff"`
+	ResponseCount       int       `json:"response_count"`
+}
+
+// AbilityProfileResponse 考生多维能力剖面，covariance 按 dimensions 的顺序排列
+type AbilityProfileResponse struct {
+	UserID        uint                       `json:"user_id"`
+	SubjectID     uint                       `json:"subject_id"`
+	CalibrationID uint                       `json:"calibration_id"`
+	ResponseCount int                        `json:"response_count"`
+	Dimensions    []DimensionAbilityResponse `json:"dimensions"`
+	Covariance    [][]float64                `json:"covariance"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+}
+
+// DimensionAbilityResponse 一个维度上的能力估计
+type DimensionAbilityResponse struct {
+	KnowledgePointID uint    `json:"knowledge_point_id"`
+	Name             string  `json:"name"`
+	Theta            float64 `json:"theta"`
+	StandardError    float64 `json:"standard_error"`
+}
+
+// ToMIRTCalibrationResponse 将多维标定转换为响应，withItems 为 false 时省略题目参数
+func ToMIRTCalibrationResponse(calibration *models.MIRTCalibration, withItems bool) MIRTCalibrationResponse {
+	resp := MIRTCalibrationResponse{
+		ID:            calibration.ID,
+		SubjectID:     calibration.SubjectID,
+		Version:       calibration.Version,
+		Model:         calibration.Family,
+		D:             calibration.D,
+		Examinees:     calibration.Examinees,
+		Iterations:    calibration.Iterations,
+		Converged:     calibration.Converged,
+		LogLikelihood: calibration.LogLikelihood,
+		Active:        calibration.Active,
+		Correlations:  calibration.CorrelationMatrix(),
+		CreatedAt:     calibration.CreatedAt,
+	}
+	for _, dim := range calibration.OrderedDimensions() {
+		resp.Dimensions = append(resp.Dimensions, MIRTDimensionResponse{
+			KnowledgePointID: dim.KnowledgePointID,
+			Name:             dim.Name,
+		})
+	}
+	if !withItems {
+		return resp
+	}
+
+	dimensions := len(calibration.Dimensions)
+	for i := range calibration.Items {
+		p := &calibration.Items[i]
+		item := p.MultiItem(dimensions)
+		r := MIRTItemResponse{
+			QuestionID:          p.QuestionID,
+			Discrimination:      item.Discrimination,
+			DiscriminationSE:    make([]float64, dimensions),
+			Intercept:           p.Intercept,
+			InterceptSE:         p.InterceptSE,
+			Guessing:            p.Guessing,
+			GuessingSE:          p.GuessingSE,
+			MultiDiscrimination: item.MultiDiscrimination(),
+			MultiDifficulty:     item.MultiDifficulty(),
+			ResponseCount:       p.ResponseCount,
+		}
+		for _, l := range p.Loadings {
+			if l.Position < dimensions {
+				r.DiscriminationSE[l.Position] = l.DiscriminationSE
+			}
+		}
+		resp.Items = append(resp.Items, r)
+	}
+	sort.Slice(resp.Items, func(a, b int) bool { return resp.Items[a].QuestionID < resp.Items[b].QuestionID })
+	return resp
+}
+
+// ToAbilityProfileResponse 将能力剖面转换为响应
+func ToAbilityProfileResponse(profile *models.UserAbilityProfile) AbilityProfileResponse {
+	resp := AbilityProfileResponse{
+		UserID:        profile.UserID,
+		SubjectID:     profile.SubjectID,
+		CalibrationID: profile.CalibrationID,
+		ResponseCount: profile.ResponseCount,
+		Covariance:    profile.CovarianceMatrix(),
+		UpdatedAt:     profile.UpdatedAt,
+	}
+	for _, dim := range profile.OrderedDimensions() {
+		resp.Dimensions = append(resp.Dimensions, DimensionAbilityResponse{
+			KnowledgePointID: dim.KnowledgePointID,
+			Name:             dim.Name,
+			Theta:            dim.Theta,
+			StandardError:    dim.StandardError,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,138 @@
+package dto
+
+import (
+	"time"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/models"
+)
+
+// MSTPanelRequest 新建多阶段测验板请求，同一阶段的模块按由易到难的顺序给出
+type MSTPanelRequest struct {
+	Name    string               `json:"name" binding:"required"`
+	Modules []MSTModuleRequest   `json:"modules" binding:"required,min=3,dive"`
+	Rules   []RoutingRuleRequest `json:"routing_rules" binding:"required,min=1,dive"`
+}
+
+// MSTModuleRequest 测验板中的模块，题目按给出的顺序施测
+type MSTModuleRequest struct {
+	Label       string `json:"label" binding:"required"`
+	Stage       int    `json:"stage" binding:"required,gte=1"`
+	QuestionIDs []uint `json:"question_ids" binding:"required,min=1"`
+}
+
+// RoutingRuleRequest 模块完成后的路由规则：manual 使用 cuts，ami、dpi 由题目参数推导切点
+type RoutingRuleRequest struct {
+	Module         string    `json:"module" binding:"required"`
+	Method         string    `json:"method" binding:"required,oneof=number_correct theta"`
+	CutMethod      string    `json:"cut_method" binding:"required,oneof=manual ami dpi"`
+	Cuts           []float64 `json:"cuts"`
+	Proportions    []float64 `json:"proportions"` // dpi 进入各后续模块的目标比例，缺省均分
+	PopulationMean float64   `json:"population_mean"`
+	PopulationSD   float64   `json:"population_sd" binding:"omitempty,gt=0"` // 缺省为1
+}
+
+// MSTPanelActiveRequest 启用或停用测验板
+type MSTPanelActiveRequest struct {
+	Active *bool `json:"active" binding:"required"`
+}
+
+// MSTPanelResponse 测验板响应
+type MSTPanelResponse struct {
+	ID          uint                `json:"id"`
+	ExamPaperID uint                `json:"exam_paper_id"`
+	Name        string              `json:"name"`
+	Design      string              `json:"design"` // 如 1-3-3
+	Active      bool                `json:"active"`
+	Modules     []MSTModuleResponse `json:"modules"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// MSTModuleResponse 模块及其路由规则，最后阶段的模块没有路由规则
+type MSTModuleResponse struct {
+	ID          uint                 `json:"id"`
+	Label       string               `json:"label"`
+	Stage       int                  `json:"stage"`
+	Position    int                  `json:"position"`
+	QuestionIDs []uint               `json:"question_ids"`
+	Routing     *RoutingRuleResponse `json:"routing,omitempty"`
+}
+
+// RoutingRuleResponse 路由规则响应
+type RoutingRuleResponse struct {
+	Method    string    `json:"method"`
+	CutMethod string    `json:"cut_method"`
+	Cuts      []float64 `json:"cuts"`
+}
+
+// ToMSTPanel 将请求转换为测验板模型，模块在阶段内的位置取给出的顺序
+func (r *MSTPanelRequest) ToMSTPanel(examPaperID uint) *models.MSTPanel {
+	panel := &models.MSTPanel{ExamPaperID: examPaperID, Name: r.Name}
+	positions := make(map[int]int)
+	for _, m := range r.Modules {
+		module := models.MSTModule{
+			Stage:    m.Stage,
+			Position: positions[m.Stage],
+			Label:    m.Label,
+		}
+		positions[m.Stage]++
+		for i, id := range m.QuestionIDs {
+			module.Questions = append(module.Questions, models.MSTModuleQuestion{QuestionID: id, Order: int64(i + 1)})
+		}
+		panel.Modules = append(panel.Modules, module)
+	}
+	return panel
+}
+
+// ToRoutingRules 将请求转换为路由规则
+func (r *MSTPanelRequest) ToRoutingRules() []services.RoutingRuleSpec {
+	rules := make([]services.RoutingRuleSpec, len(r.Rules))
+	for i, rule := range r.Rules {
+		rules[i] = services.RoutingRuleSpec{
+			Module:         rule.Module,
+			Method:         irt.RoutingMethod(rule.Method),
+			CutMethod:      irt.RoutingCutMethod(rule.CutMethod),
+			Cuts:           rule.Cuts,
+			Proportions:    rule.Proportions,
+			PopulationMean: rule.PopulationMean,
+			PopulationSD:   rule.PopulationSD,
+		}
+	}
+	return rules
+}
+
+// ToMSTPanelResponse 将测验板转换为响应，模块按阶段与难度顺序排列
+func ToMSTPanelResponse(panel *models.MSTPanel) MSTPanelResponse {
+	resp := MSTPanelResponse{
+		ID:          panel.ID,
+		ExamPaperID: panel.ExamPaperID,
+		Name:        panel.Name,
+		Design:      panel.Design(),
+		Active:      panel.Active,
+		Modules:     make([]MSTModuleResponse, 0, len(panel.Modules)),
+		CreatedAt:   panel.CreatedAt,
+	}
+	for _, modules := range panel.Stages() {
+		for _, module := range modules {
+			m := MSTModuleResponse{
+				ID:       module.ID,
+				Label:    module.Label,
+				Stage:    module.Stage,
+				Position: module.Position,
+			}
+			for _, q := range module.OrderedQuestions() {
+				m.QuestionIDs = append(m.QuestionIDs, q.QuestionID)
+			}
+			if rule := panel.Rule(module); rule != nil {
+				m.Routing = &RoutingRuleResponse{
+					Method:    rule.Method,
+					CutMethod: rule.CutMethod,
+					Cuts:      rule.CutValues(),
+				}
+			}
+			resp.Modules = append(resp.Modules, m)
+		}
+	}
+	return resp
+}
@@ -0,0 +1,174 @@
+package dto
+
+import (
+	"time"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/models"
+)
+
+// PretestSettingRequest 试测题嵌入与在线标定配置请求，未填写的转正阈值取默认值
+type PretestSettingRequest struct {
+	Rate                float64  `json:"rate" binding:"gte=0,lt=1"`
+	MaxItems            int      `json:"max_items" binding:"gte=0"`
+	StartAfter          int      `json:"start_after" binding:"gte=0"`
+	Method              string   `json:"method" binding:"required,oneof=method_a oem mem"`
+	MinResponses        int      `json:"min_responses" binding:"omitempty,min=20"`
+	MaxDifficultySE     *float64 `json:"max_difficulty_se" binding:"omitempty,gte=0"`
+	MaxDiscriminationSE *float64 `json:"max_discrimination_se" binding:"omitempty,gte=0"`
+	MaxGuessingSE       *float64 `json:"max_guessing_se" binding:"omitempty,gte=0"`
+	MaxUpperAsymptoteSE *float64 `json:"max_upper_asymptote_se" binding:"omitempty,gte=0"`
+}
+
+// PretestSettingResponse 试测题配置响应
+type PretestSettingResponse struct {
+	SubjectID           uint    `json:"subject_id"`
+	Rate                float64 `json:"rate"`
+	MaxItems            int     `json:"max_items"`
+	StartAfter          int     `json:"start_after"`
+	Method              string  `json:"method"`
+	MinResponses        int     `json:"min_responses"`
+	MaxDifficultySE     float64 `json:"max_difficulty_se"`
+	MaxDiscriminationSE float64 `json:"max_discrimination_se"`
+	MaxGuessingSE       float64 `json:"max_guessing_se"`
+	MaxUpperAsymptoteSE float64 `json:"max_upper_asymptote_se"`
+}
+
+// PretestFlagRequest 标记或取消标记试测题请求
+type PretestFlagRequest struct {
+	QuestionIDs []uint `json:"question_ids" binding:"required,min=1"`
+	Pretest     bool   `json:"pretest"`
+}
+
+// OnlineCalibrationRequest 在线标定请求
+type OnlineCalibrationRequest struct {
+	DryRun bool `json:"dry_run"`
+}
+
+// OnlineCalibrationResponse 在线标定结果响应
+type OnlineCalibrationResponse struct {
+	SubjectID uint                     `json:"subject_id"`
+	Method    string                   `json:"method"`
+	Examinees int                      `json:"examinees"`
+	Items     []PretestItemCalibration `json:"items"`
+}
+
+// PretestItemCalibration 单道试测题的在线标定结果
+type PretestItemCalibration struct {
+	ItemCalibrationResponse
+	Ready    bool `json:"ready"`
+	Promoted bool `json:"promoted"`
+}
+
+// PretestCalibrationResponse 已保存的试测题在线标定记录响应
+type PretestCalibrationResponse struct {
+	QuestionID       uint       `json:"question_id"`
+	Method           string     `json:"method"`
+	Difficulty       float64    `json:"difficulty"`
+	Discrimination   float64    `json:"discrimination"`
+	Guessing         float64    `json:"guessing"`
+	UpperAsymptote   float64    `json:"upper_asymptote"`
+	DifficultySE     float64    `json:"difficulty_se"`
+	DiscriminationSE float64    `json:"discrimination_se"`
+	GuessingSE       float64    `json:"guessing_se"`
+	UpperAsymptoteSE float64    `json:"upper_asymptote_se"`
+	ResponseCount    int        `json:"response_count"`
+	Promoted         bool       `json:"promoted"`
+	PromotedAt       *time.Time `json:"promoted_at,omitempty"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// ToPretestSetting 将请求转换为科目配置，未填写的转正阈值取 defaults 中的值
+func (r *PretestSettingRequest) ToPretestSetting(subjectID uint, defaults *models.PretestSetting) *models.PretestSetting {
+	setting := &models.PretestSetting{
+		SubjectID:           subjectID,
+		Rate:                r.Rate,
+		MaxItems:            r.MaxItems,
+		StartAfter:          r.StartAfter,
+		Method:              r.Method,
+		MinResponses:        defaults.MinResponses,
+		MaxDifficultySE:     defaults.MaxDifficultySE,
+		MaxDiscriminationSE: defaults.MaxDiscriminationSE,
+		MaxGuessingSE:       defaults.MaxGuessingSE,
+		MaxUpperAsymptoteSE: defaults.MaxUpperAsymptoteSE,
+	}
+	if r.MinResponses > 0 {
+		setting.MinResponses = r.MinResponses
+	}
+	if r.MaxDifficultySE != nil {
+		setting.MaxDifficultySE = *r.MaxDifficultySE
+	}
+	if r.MaxDiscriminationSE != nil {
+		setting.MaxDiscriminationSE = *r.MaxDiscriminationSE
+	}
+	if r.MaxGuessingSE != nil {
+		setting.MaxGuessingSE = *r.MaxGuessingSE
+	}
+	if r.MaxUpperAsymptoteSE != nil {
+		setting.MaxUpperAsymptoteSE = *r.MaxUpperAsymptoteSE
+	}
+	return setting
+}
+
+func ToPretestSettingResponse(setting *models.PretestSetting) PretestSettingResponse {
+	return PretestSettingResponse{
+		SubjectID:           setting.SubjectID,
+		Rate:                setting.Rate,
+		MaxItems:            setting.MaxItems,
+		StartAfter:          setting.StartAfter,
+		Method:              setting.Method,
+		MinResponses:        setting.MinResponses,
+		MaxDifficultySE:     setting.MaxDifficultySE,
+		MaxDiscriminationSE: setting.MaxDiscriminationSE,
+		MaxGuessingSE:       setting.MaxGuessingSE,
+		MaxUpperAsymptoteSE: setting.MaxUpperAsymptoteSE,
+	}
+}
+
+func ToOnlineCalibrationResponse(result *services.OnlineCalibrationResult) OnlineCalibrationResponse {
+	resp := OnlineCalibrationResponse{
+		SubjectID: result.SubjectID,
+		Method:    string(result.Method),
+		Examinees: result.Examinees,
+		Items:     make([]PretestItemCalibration, len(result.Items)),
+	}
+	for i, item := range result.Items {
+		resp.Items[i] = PretestItemCalibration{
+			ItemCalibrationResponse: ItemCalibrationResponse{
+				QuestionID:       item.QuestionID,
+				Difficulty:       item.Difficulty,
+				Discrimination:   item.Discrimination,
+				Guessing:         item.Guessing,
+				UpperAsymptote:   item.UpperAsymptote,
+				DifficultySE:     item.DifficultySE,
+				DiscriminationSE: item.DiscriminationSE,
+				GuessingSE:       item.GuessingSE,
+				UpperAsymptoteSE: item.UpperAsymptoteSE,
+				ResponseCount:    item.ResponseCount,
+				Skipped:          item.Skipped,
+			},
+			Ready:    item.Ready,
+			Promoted: item.Promoted,
+		}
+	}
+	return resp
+}
+
+func ToPretestCalibrationResponse(c *models.PretestCalibration) PretestCalibrationResponse {
+	return PretestCalibrationResponse{
+		QuestionID:       c.QuestionID,
+		Method:           c.Method,
+		Difficulty:       c.Difficulty,
+		Discrimination:   c.Discrimination,
+		Guessing:         c.Guessing,
+		UpperAsymptote:   c.UpperAsymptote,
+		DifficultySE:     c.DifficultySE,
+		DiscriminationSE: c.DiscriminationSE,
+		GuessingSE:       c.GuessingSE,
+		UpperAsymptoteSE: c.UpperAsymptoteSE,
+		ResponseCount:    c.ResponseCount,
+		Promoted:         c.Promoted,
+		PromotedAt:       c.PromotedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
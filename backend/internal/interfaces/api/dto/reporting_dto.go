@@ -0,0 +1,185 @@
+package dto
+
+import (
+	"time"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/models"
+)
+
+// ReportingScaleRequest 新建报告分数量表请求；非线性量表按转换表分段线性插值
+type ReportingScaleRequest struct {
+	Name         string              `json:"name" binding:"required"`
+	Transform    string              `json:"transform" binding:"required,oneof=linear nonlinear"`
+	Slope        float64             `json:"slope"`
+	Intercept    float64             `json:"intercept"`
+	Points       []ScalePointRequest `json:"points" binding:"omitempty,dive"`
+	RoundingStep *float64            `json:"rounding_step" binding:"omitempty,gte=0"` // 缺省为1，0 表示不取整
+	Rounding     string              `json:"rounding" binding:"omitempty,oneof=nearest floor ceil"`
+	MinScore     float64             `json:"min_score"`
+	MaxScore     float64             `json:"max_score"` // 不高于 min_score 时不截断
+}
+
+// ScalePointRequest 转换表中的一个点
+type ScalePointRequest struct {
+	Theta float64 `json:"theta"`
+	Score float64 `json:"score"`
+}
+
+// ToModel 转换为量表模型
+func (r *ReportingScaleRequest) ToModel(subjectID uint) *models.ReportingScale {
+	scale := &models.ReportingScale{
+		SubjectID:    subjectID,
+		Name:         r.Name,
+		Transform:    r.Transform,
+		Slope:        r.Slope,
+		Intercept:    r.Intercept,
+		RoundingStep: 1,
+		Rounding:     r.Rounding,
+		MinScore:     r.MinScore,
+		MaxScore:     r.MaxScore,
+	}
+	if r.RoundingStep != nil {
+		scale.RoundingStep = *r.RoundingStep
+	}
+	if scale.Rounding == "" {
+		scale.Rounding = "nearest"
+	}
+	for _, p := range r.Points {
+		scale.Points = append(scale.Points, models.ScaleConversionPoint{Theta: p.Theta, Score: p.Score})
+	}
+	return scale
+}
+
+// ReportingScaleResponse 报告分数量表响应
+type ReportingScaleResponse struct {
+	ID           uint                `json:"id"`
+	SubjectID    uint                `json:"subject_id"`
+	Version      int                 `json:"version"`
+	Name         string              `json:"name"`
+	Transform    string              `json:"transform"`
+	Slope        float64             `json:"slope"`
+	Intercept    float64             `json:"intercept"`
+	Points       []ScalePointRequest `json:"points,omitempty"`
+	RoundingStep float64             `json:"rounding_step"`
+	Rounding     string              `json:"rounding"`
+	MinScore     float64             `json:"min_score"`
+	MaxScore     float64             `json:"max_score"`
+	Active       bool                `json:"active"`
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+// ConversionQuery θ–报告分数转换表的能力值网格
+type ConversionQuery struct {
+	MinTheta float64 `form:"min_theta,default=-3"`
+	MaxTheta float64 `form:"max_theta,default=3"`
+	Step     float64 `form:"step,default=0.1" binding:"gt=0"`
+}
+
+// NormTableRequest 建立常模表请求，参照群体为能力值更新时间在 [from, to) 内的考生
+type NormTableRequest struct {
+	Cohort string     `json:"cohort" binding:"required"`
+	From   *time.Time `json:"from"`
+	To     *time.Time `json:"to"`
+}
+
+// NormTableResponse 常模表响应，列表中不含常模条目
+type NormTableResponse struct {
+	ID         uint                     `json:"id"`
+	ScaleID    uint                     `json:"scale_id"`
+	SubjectID  uint                     `json:"subject_id"`
+	Version    int                      `json:"version"`
+	Cohort     string                   `json:"cohort"`
+	CohortFrom *time.Time               `json:"cohort_from,omitempty"`
+	CohortTo   *time.Time               `json:"cohort_to,omitempty"`
+	SampleSize int                      `json:"sample_size"`
+	MeanScore  float64                  `json:"mean_score"`
+	SDScore    float64                  `json:"sd_score"`
+	Active     bool                     `json:"active"`
+	CreatedAt  time.Time                `json:"created_at"`
+	Entries    []NormTableEntryResponse `json:"entries,omitempty"`
+}
+
+// NormTableEntryResponse 常模表中的一个报告分数
+type NormTableEntryResponse struct {
+	ScaledScore       float64 `json:"scaled_score"`
+	Frequency         int     `json:"frequency"`
+	PercentileRank    float64 `json:"percentile_rank"`
+	CumulativePercent float64 `json:"cumulative_percent"`
+}
+
+// AbilityResponse 科目能力值及其报告分数，科目未配置量表时不含报告分数字段
+type AbilityResponse struct {
+	*models.UserAbility
+	*services.ScoreReport
+}
+
+// AbilityEstimationResponse 能力估计历史及其报告分数
+type AbilityEstimationResponse struct {
+	*models.AbilityEstimation
+	*services.ScoreReport
+}
+
+func ToReportingScaleResponse(scale *models.ReportingScale) ReportingScaleResponse {
+	resp := ReportingScaleResponse{
+		ID:           scale.ID,
+		SubjectID:    scale.SubjectID,
+		Version:      scale.Version,
+		Name:         scale.Name,
+		Transform:    scale.Transform,
+		Slope:        scale.Slope,
+		Intercept:    scale.Intercept,
+		RoundingStep: scale.RoundingStep,
+		Rounding:     scale.Rounding,
+		MinScore:     scale.MinScore,
+		MaxScore:     scale.MaxScore,
+		Active:       scale.Active,
+		CreatedAt:    scale.CreatedAt,
+	}
+	for _, p := range scale.IRTScale().Points {
+		resp.Points = append(resp.Points, ScalePointRequest{Theta: p.Theta, Score: p.Score})
+	}
+	return resp
+}
+
+func ToNormTableResponse(table *models.NormTable) NormTableResponse {
+	resp := NormTableResponse{
+		ID:         table.ID,
+		ScaleID:    table.ScaleID,
+		SubjectID:  table.SubjectID,
+		Version:    table.Version,
+		Cohort:     table.Cohort,
+		CohortFrom: table.CohortFrom,
+		CohortTo:   table.CohortTo,
+		SampleSize: table.SampleSize,
+		MeanScore:  table.MeanScore,
+		SDScore:    table.SDScore,
+		Active:     table.Active,
+		CreatedAt:  table.CreatedAt,
+	}
+	for _, e := range table.NormEntries() {
+		resp.Entries = append(resp.Entries, NormTableEntryResponse{
+			ScaledScore:       e.Score,
+			Frequency:         e.Frequency,
+			PercentileRank:    e.PercentileRank,
+			CumulativePercent: e.CumulativePercent,
+		})
+	}
+	return resp
+}
+
+func ToAbilityResponses(abilities []*models.UserAbility, reports []*services.ScoreReport) []AbilityResponse {
+	resp := make([]AbilityResponse, len(abilities))
+	for i, a := range abilities {
+		resp[i] = AbilityResponse{UserAbility: a, ScoreReport: reports[i]}
+	}
+	return resp
+}
+
+func ToAbilityEstimationResponses(estimations []*models.AbilityEstimation, reports []*services.ScoreReport) []AbilityEstimationResponse {
+	resp := make([]AbilityEstimationResponse, len(estimations))
+	for i, e := range estimations {
+		resp[i] = AbilityEstimationResponse{AbilityEstimation: e, ScoreReport: reports[i]}
+	}
+	return resp
+}
@@ -0,0 +1,6 @@
+package dto
+
+// ResponseTimeCalibrationRequest 作答时间标定请求
+type ResponseTimeCalibrationRequest struct {
+	DryRun bool `json:"dry_run"`
+}
@@ -0,0 +1,155 @@
+package dto
+
+import (
+	"sort"
+	"time"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/models"
+)
+
+// StandardSettingSessionRequest 新建标准设定会议请求，levels 按由低到高排列
+type StandardSettingSessionRequest struct {
+	ExamPaperID         uint     `json:"exam_paper_id" binding:"required"`
+	Name                string   `json:"name" binding:"required"`
+	Method              string   `json:"method" binding:"required,oneof=angoff bookmark"`
+	ResponseProbability float64  `json:"response_probability" binding:"omitempty,gt=0,lt=1"` // 缺省为 0.67
+	Levels              []string `json:"levels" binding:"required,min=2,dive,required"`
+}
+
+// ToModel 转换为会议模型
+func (r *StandardSettingSessionRequest) ToModel() *models.StandardSettingSession {
+	session := &models.StandardSettingSession{
+		ExamPaperID:         r.ExamPaperID,
+		Name:                r.Name,
+		Method:              r.Method,
+		ResponseProbability: r.ResponseProbability,
+	}
+	for i, name := range r.Levels {
+		session.Levels = append(session.Levels, models.StandardSettingLevel{Order: i, Name: name})
+	}
+	return session
+}
+
+// StandardSettingSessionResponse 标准设定会议响应
+type StandardSettingSessionResponse struct {
+	ID                  uint      `json:"id"`
+	SubjectID           uint      `json:"subject_id"`
+	ExamPaperID         uint      `json:"exam_paper_id"`
+	Name                string    `json:"name"`
+	Method              string    `json:"method"`
+	ResponseProbability float64   `json:"response_probability"`
+	Round               int       `json:"round"`
+	Status              string    `json:"status"`
+	Levels              []string  `json:"levels"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// RatingsRequest 评委提交的一组评定
+type RatingsRequest struct {
+	Ratings []RatingRequest `json:"ratings" binding:"required,min=1,dive"`
+}
+
+// RatingRequest 一条评定：boundary 为第几个分界点（从1开始）；
+// Angoff 法给出 question_id 与临界考生答对概率，书签法 value 为书签页码
+type RatingRequest struct {
+	Boundary   int     `json:"boundary" binding:"required,gte=1"`
+	QuestionID uint    `json:"question_id"`
+	Value      float64 `json:"value"`
+}
+
+// ToRatings 转换为服务层评定
+func (r *RatingsRequest) ToRatings() []services.SubmittedRating {
+	ratings := make([]services.SubmittedRating, len(r.Ratings))
+	for i, rating := range r.Ratings {
+		ratings[i] = services.SubmittedRating{
+			Boundary:   rating.Boundary,
+			QuestionID: rating.QuestionID,
+			Value:      rating.Value,
+		}
+	}
+	return ratings
+}
+
+// StandardSettingResultsQuery 查询的评定轮次，0 为当前轮次
+type StandardSettingResultsQuery struct {
+	Round int `form:"round,default=0" binding:"gte=0"`
+}
+
+// AdoptRequest 采用划界分数请求
+type AdoptRequest struct {
+	Name string `json:"name" binding:"required"` // 表现水平版本名称
+}
+
+// PerformanceLevelSetRequest 手工设定表现水平请求，levels 按由低到高排列，最低水平不需要划界分数
+type PerformanceLevelSetRequest struct {
+	Name   string                    `json:"name" binding:"required"`
+	Levels []PerformanceLevelRequest `json:"levels" binding:"required,min=2,dive"`
+}
+
+// PerformanceLevelRequest 一个表现水平
+type PerformanceLevelRequest struct {
+	Name     string   `json:"name" binding:"required"`
+	CutScore *float64 `json:"cut_score"`
+}
+
+// ToModel 转换为表现水平模型
+func (r *PerformanceLevelSetRequest) ToModel(subjectID uint) *models.PerformanceLevelSet {
+	set := &models.PerformanceLevelSet{SubjectID: subjectID, Name: r.Name}
+	for i, level := range r.Levels {
+		set.Levels = append(set.Levels, models.PerformanceLevel{Order: i, Name: level.Name, CutScore: level.CutScore})
+	}
+	return set
+}
+
+// PerformanceLevelSetResponse 科目表现水平响应
+type PerformanceLevelSetResponse struct {
+	ID        uint                       `json:"id"`
+	SubjectID uint                       `json:"subject_id"`
+	Version   int                        `json:"version"`
+	Name      string                     `json:"name"`
+	SessionID *uint                      `json:"session_id,omitempty"`
+	Active    bool                       `json:"active"`
+	Levels    []PerformanceLevelResponse `json:"levels"`
+	CreatedAt time.Time                  `json:"created_at"`
+}
+
+// PerformanceLevelResponse 一个表现水平及其划界分数
+type PerformanceLevelResponse struct {
+	Order    int      `json:"order"`
+	Name     string   `json:"name"`
+	CutScore *float64 `json:"cut_score,omitempty"`
+}
+
+func ToStandardSettingSessionResponse(session *models.StandardSettingSession) StandardSettingSessionResponse {
+	return StandardSettingSessionResponse{
+		ID:                  session.ID,
+		SubjectID:           session.SubjectID,
+		ExamPaperID:         session.ExamPaperID,
+		Name:                session.Name,
+		Method:              session.Method,
+		ResponseProbability: session.ResponseProbability,
+		Round:               session.Round,
+		Status:              session.Status,
+		Levels:              session.OrderedLevels(),
+		CreatedAt:           session.CreatedAt,
+	}
+}
+
+func ToPerformanceLevelSetResponse(set *models.PerformanceLevelSet) PerformanceLevelSetResponse {
+	resp := PerformanceLevelSetResponse{
+		ID:        set.ID,
+		SubjectID: set.SubjectID,
+		Version:   set.Version,
+		Name:      set.Name,
+		SessionID: set.SessionID,
+		Active:    set.Active,
+		CreatedAt: set.CreatedAt,
+		Levels:    make([]PerformanceLevelResponse, len(set.Levels)),
+	}
+	for i, level := range set.Levels {
+		resp.Levels[i] = PerformanceLevelResponse{Order: level.Order, Name: level.Name, CutScore: level.CutScore}
+	}
+	sort.Slice(resp.Levels, func(a, b int) bool { return resp.Levels[a].Order < resp.Levels[b].Order })
+	return resp
+}
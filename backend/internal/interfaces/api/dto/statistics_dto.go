@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/analysis"
+)
+
+// StatisticsQuery 试卷经典统计查询参数
+type StatisticsQuery struct {
+	Format  string `form:"format,default=json" binding:"omitempty,oneof=json csv"`
+	Section string `form:"section,default=items" binding:"omitempty,oneof=items distribution summary"` // 仅用于 CSV 导出
+}
+
+// DistractorQuery 干扰项分析查询参数
+type DistractorQuery struct {
+	Groups           int     `form:"groups,default=5" binding:"min=2,max=10"`
+	MinSelectionRate float64 `form:"min_selection_rate,default=0.05" binding:"gte=0,lt=1"`
+}
+
+// DistractorConfig 转换为干扰项分析配置
+func (q *DistractorQuery) DistractorConfig() analysis.DistractorConfig {
+	cfg := analysis.DefaultDistractorConfig()
+	cfg.Groups = q.Groups
+	cfg.MinSelectionRate = q.MinSelectionRate
+	return cfg
+}
+
+// StatisticsCSV 按导出部分生成 CSV 记录，首行为表头
+func StatisticsCSV(stats *services.PaperStatistics, section string) [][]string {
+	switch section {
+	case "distribution":
+		records := [][]string{{"score", "count", "percent", "cumulative_percent"}}
+		for _, f := range stats.Distribution {
+			records = append(records, []string{
+				strconv.Itoa(f.Score),
+				strconv.Itoa(f.Count),
+				formatFloat(f.Percent),
+				formatFloat(f.CumulativePercent),
+			})
+		}
+		return records
+	case "summary":
+		return [][]string{
+			{"exam_paper_id", "examinees", "item_count", "mean", "sd", "min", "max", "alpha", "sem"},
+			{
+				strconv.FormatUint(uint64(stats.ExamPaperID), 10),
+				strconv.Itoa(stats.Examinees),
+				strconv.Itoa(stats.ItemCount),
+				formatFloat(stats.Mean),
+				formatFloat(stats.SD),
+				strconv.Itoa(stats.Min),
+				strconv.Itoa(stats.Max),
+				formatFloat(stats.Alpha),
+				formatFloat(stats.SEM),
+			},
+		}
+	default:
+		records := [][]string{{"order", "question_id", "question_type", "answered", "p_value", "point_biserial", "biserial", "item_rest", "alpha_if_deleted"}}
+		for _, item := range stats.Items {
+			records = append(records, []string{
+				strconv.FormatInt(item.Order, 10),
+				strconv.FormatUint(uint64(item.QuestionID), 10),
+				item.QuestionType,
+				strconv.Itoa(item.Answered),
+				formatFloat(item.PValue),
+				formatFloat(item.PointBiserial),
+				formatFloat(item.Biserial),
+				formatFloat(item.ItemRest),
+				formatFloat(item.AlphaIfDeleted),
+			})
+		}
+		return records
+	}
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
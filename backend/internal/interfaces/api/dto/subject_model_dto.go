@@ -0,0 +1,24 @@
+package dto
+
+import "irt-exam-system/backend/internal/domain/irt"
+
+// SubjectModelRequest 科目项目反应模型设置请求
+type SubjectModelRequest struct {
+	Model           string  `json:"model" binding:"required,oneof=Rasch 1PL 2PL 3PL 4PL"`
+	ScalingConstant float64 `json:"scaling_constant" binding:"required"` // 1 或 1.7，由 irt.Model.Validate 校验
+}
+
+// SubjectModelResponse 科目项目反应模型响应
+type SubjectModelResponse struct {
+	SubjectID       uint    `json:"subject_id"`
+	Model           string  `json:"model"`
+	ScalingConstant float64 `json:"scaling_constant"`
+}
+
+func ToSubjectModelResponse(subjectID uint, model irt.Model) SubjectModelResponse {
+	return SubjectModelResponse{
+		SubjectID:       subjectID,
+		Model:           string(model.Family),
+		ScalingConstant: model.D,
+	}
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"math"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+)
+
+// TestInformationQuery 测验信息曲线查询参数
+type TestInformationQuery struct {
+	MinTheta  float64 `form:"min_theta,default=-4"`
+	MaxTheta  float64 `form:"max_theta,default=4"`
+	Step      float64 `form:"step,default=0.1" binding:"gt=0"`
+	Thetas    string  `form:"theta"`     // 逗号分隔的能力值
+	RawScores string  `form:"raw_score"` // 逗号分隔的原始分
+}
+
+// TestInformationResponse 试卷测验函数响应
+type TestInformationResponse struct {
+	ExamPaperID     uint                 `json:"exam_paper_id"`
+	ItemCount       int                  `json:"item_count"`
+	MaxScore        float64              `json:"max_score"`
+	CutScore        float64              `json:"cut_score"`
+	CutPoint        CurvePointResponse   `json:"cut_point"`
+	PeakTheta       float64              `json:"peak_theta"`
+	PeakInformation float64              `json:"peak_information"`
+	Curve           []CurvePointResponse `json:"curve"`
+	ExpectedScores  []CurvePointResponse `json:"expected_scores"`
+	ScoreThetas     []ScoreThetaResponse `json:"score_thetas"`
+}
+
+// CurvePointResponse 曲线上的一个点，信息量为0时 sem 为 null
+type CurvePointResponse struct {
+	Theta                 float64  `json:"theta"`
+	Information           float64  `json:"information"`
+	SEM                   *float64 `json:"sem"`
+	ExpectedScore         float64  `json:"expected_score"`
+	ExpectedNumberCorrect float64  `json:"expected_number_correct"`
+}
+
+// ScoreThetaResponse 原始分对应的能力值
+type ScoreThetaResponse struct {
+	RawScore float64 `json:"raw_score"`
+	Theta    float64 `json:"theta"`
+}
+
+func ToTestInformationResponse(curves *services.TestCurves) TestInformationResponse {
+	resp := TestInformationResponse{
+		ExamPaperID:     curves.ExamPaperID,
+		ItemCount:       curves.ItemCount,
+		MaxScore:        curves.MaxScore,
+		CutScore:        curves.CutScore,
+		CutPoint:        toCurvePointResponse(curves.CutPoint),
+		PeakTheta:       curves.PeakTheta,
+		PeakInformation: curves.PeakInformation,
+		Curve:           make([]CurvePointResponse, len(curves.Curve)),
+		ExpectedScores:  make([]CurvePointResponse, len(curves.Expected)),
+		ScoreThetas:     make([]ScoreThetaResponse, len(curves.ScoreThetas)),
+	}
+	for i, point := range curves.Curve {
+		resp.Curve[i] = toCurvePointResponse(point)
+	}
+	for i, point := range curves.Expected {
+		resp.ExpectedScores[i] = toCurvePointResponse(point)
+	}
+	for i, st := range curves.ScoreThetas {
+		resp.ScoreThetas[i] = ScoreThetaResponse{RawScore: st.RawScore, Theta: st.Theta}
+	}
+	return resp
+}
+
+func toCurvePointResponse(point irt.CurvePoint) CurvePointResponse {
+	resp := CurvePointResponse{
+		Theta:                 point.Theta,
+		Information:           point.Information,
+		ExpectedScore:         point.ExpectedScore,
+		ExpectedNumberCorrect: point.ExpectedNumberCorrect,
+	}
+	if !math.IsInf(point.SEM, 0) {
+		sem := point.SEM
+		resp.SEM = &sem
+	}
+	return resp
+}
@@ -12,17 +12,19 @@ import (
 
 // AbilityHandler handles ability related requests
 type AbilityHandler struct {
-	abilityService services.AbilityService
+	abilityService   services.AbilityService
+	reportingService services.ReportingService
 }
 
 // NewAbilityHandler creates a new ability handler
-func NewAbilityHandler(abilityService services.AbilityService) *AbilityHandler {
+func NewAbilityHandler(abilityService services.AbilityService, reportingService services.ReportingService) *AbilityHandler {
 	return &AbilityHandler{
-		abilityService: abilityService,
+		abilityService:   abilityService,
+		reportingService: reportingService,
 	}
 }
 
-// List returns a list of abilities
+// List returns a list of abilities with their scaled scores, percentile ranks and conditional SEM
 func (h *AbilityHandler) List(c *gin.Context) {
 	userID, err := strconv.ParseUint(c.Query("user_id"), 10, 32)
 	if err != nil {
@@ -35,11 +37,16 @@ func (h *AbilityHandler) List(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get abilities", err.Error()))
 		return
 	}
+	reports, err := h.reportingService.ReportAbilities(c, abilities)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to report scaled scores", err.Error()))
+		return
+	}
 
-	c.JSON(http.StatusOK, abilities)
+	c.JSON(http.StatusOK, dto.ToAbilityResponses(abilities, reports))
 }
 
-// GetBySubject returns ability by subject
+// GetBySubject returns ability by subject with its scaled score, percentile rank and conditional SEM
 func (h *AbilityHandler) GetBySubject(c *gin.Context) {
 	userID, err := strconv.ParseUint(c.Query("user_id"), 10, 32)
 	if err != nil {
@@ -63,11 +70,16 @@ func (h *AbilityHandler) GetBySubject(c *gin.Context) {
 		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Ability not found", nil))
 		return
 	}
+	report, err := h.reportingService.ScoreReport(c, ability.SubjectID, ability.Ability, ability.StandardError)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to report scaled score", err.Error()))
+		return
+	}
 
-	c.JSON(http.StatusOK, ability)
+	c.JSON(http.StatusOK, dto.AbilityResponse{UserAbility: ability, ScoreReport: report})
 }
 
-// GetHistory returns ability estimation history
+// GetHistory returns ability estimation history with scaled scores on the current reporting scale
 func (h *AbilityHandler) GetHistory(c *gin.Context) {
 	userID, err := strconv.ParseUint(c.Query("user_id"), 10, 32)
 	if err != nil {
@@ -86,6 +98,11 @@ func (h *AbilityHandler) GetHistory(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get ability history", err.Error()))
 		return
 	}
+	reports, err := h.reportingService.ReportEstimations(c, history)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to report scaled scores", err.Error()))
+		return
+	}
 
-	c.JSON(http.StatusOK, dto.NewPageResponse(history, total, query.Page, query.PageSize))
+	c.JSON(http.StatusOK, dto.NewPageResponse(dto.ToAbilityEstimationResponses(history, reports), total, query.Page, query.PageSize))
 }
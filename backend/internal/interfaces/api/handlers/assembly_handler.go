@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AssemblyHandler handles automated test assembly requests
+type AssemblyHandler struct {
+	assemblyService services.AssemblyService
+}
+
+// NewAssemblyHandler creates a new test assembly handler
+func NewAssemblyHandler(assemblyService services.AssemblyService) *AssemblyHandler {
+	return &AssemblyHandler{
+		assemblyService: assemblyService,
+	}
+}
+
+// Assemble builds one or more parallel exam papers from a subject's question pool
+func (h *AssemblyHandler) Assemble(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.AssemblyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+	for _, constraint := range req.Constraints {
+		if (constraint.KnowledgePointID == 0) == (constraint.QuestionType == "") {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid constraint", services.ErrInvalidConstraint.Error()))
+			return
+		}
+	}
+
+	result, err := h.assemblyService.Assemble(c, req.ToAssemblyRequest(uint(subjectID)))
+	if err != nil {
+		if errors.Is(err, irt.ErrPoolTooSmall) || errors.Is(err, irt.ErrInvalidAssemblySpec) || errors.Is(err, services.ErrEmptyPool) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Cannot assemble exam paper", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to assemble exam paper", err.Error()))
+		return
+	}
+
+	status := http.StatusCreated
+	if req.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, dto.ToAssemblyResponse(result))
+}
+
+// AddEnemy marks two questions as enemies that must not appear on the same paper
+func (h *AssemblyHandler) AddEnemy(c *gin.Context) {
+	questionID, enemyID, ok := parseEnemyPair(c)
+	if !ok {
+		return
+	}
+
+	if err := h.assemblyService.AddEnemy(c, questionID, enemyID); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Failed to add enemy question", err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveEnemy removes an enemy relation between two questions
+func (h *AssemblyHandler) RemoveEnemy(c *gin.Context) {
+	questionID, enemyID, ok := parseEnemyPair(c)
+	if !ok {
+		return
+	}
+
+	if err := h.assemblyService.RemoveEnemy(c, questionID, enemyID); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to remove enemy question", err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseEnemyPair 解析路径中的题目ID与互斥题目ID
+func parseEnemyPair(c *gin.Context) (uint, uint, bool) {
+	questionID, err := strconv.ParseUint(c.Param("question_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid question ID", err.Error()))
+		return 0, 0, false
+	}
+	enemyID, err := strconv.ParseUint(c.Param("enemy_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid enemy question ID", err.Error()))
+		return 0, 0, false
+	}
+	return uint(questionID), uint(enemyID), true
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BlueprintHandler handles exam paper content blueprint requests
+type BlueprintHandler struct {
+	blueprintService services.BlueprintService
+}
+
+// NewBlueprintHandler creates a new blueprint handler
+func NewBlueprintHandler(blueprintService services.BlueprintService) *BlueprintHandler {
+	return &BlueprintHandler{
+		blueprintService: blueprintService,
+	}
+}
+
+// GetBlueprint returns the content blueprint of an exam paper
+func (h *BlueprintHandler) GetBlueprint(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	blueprint, err := h.blueprintService.GetBlueprint(c, uint(paperID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get blueprint", err.Error()))
+		return
+	}
+	if blueprint == nil {
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Blueprint not found", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToBlueprintResponse(blueprint))
+}
+
+// SaveBlueprint creates or replaces the content blueprint of an exam paper
+func (h *BlueprintHandler) SaveBlueprint(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	var req dto.BlueprintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	blueprint := req.ToExamBlueprint(uint(paperID))
+	if err := h.blueprintService.SaveBlueprint(c, blueprint); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Failed to save blueprint", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToBlueprintResponse(blueprint))
+}
+
+// DeleteBlueprint removes the content blueprint of an exam paper
+func (h *BlueprintHandler) DeleteBlueprint(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	if err := h.blueprintService.DeleteBlueprint(c, uint(paperID)); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to delete blueprint", err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
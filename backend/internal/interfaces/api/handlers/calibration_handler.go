@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalibrationHandler handles item calibration requests
+type CalibrationHandler struct {
+	calibrationService services.CalibrationService
+}
+
+// NewCalibrationHandler creates a new calibration handler
+func NewCalibrationHandler(calibrationService services.CalibrationService) *CalibrationHandler {
+	return &CalibrationHandler{
+		calibrationService: calibrationService,
+	}
+}
+
+// Calibrate runs an EM calibration over all responses of a subject
+func (h *CalibrationHandler) Calibrate(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.CalibrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	result, err := h.calibrationService.CalibrateSubject(c, uint(subjectID), irt.ModelFamily(req.Model), req.DryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to calibrate item parameters", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCalibrationResponse(result))
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DiagnosisHandler handles cognitive diagnosis requests
+type DiagnosisHandler struct {
+	diagnosisService services.DiagnosisService
+}
+
+// NewDiagnosisHandler creates a new diagnosis handler
+func NewDiagnosisHandler(diagnosisService services.DiagnosisService) *DiagnosisHandler {
+	return &DiagnosisHandler{
+		diagnosisService: diagnosisService,
+	}
+}
+
+// Diagnose fits a DINA or DINO model over the knowledge point Q-matrix of a subject
+// and returns slip/guess parameters and per-exam mastery probabilities
+func (h *DiagnosisHandler) Diagnose(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var query dto.DiagnosisQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid query parameters", err.Error()))
+		return
+	}
+	pointIDs, err := parseUintList(query.KnowledgePointIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid knowledge point IDs", err.Error()))
+		return
+	}
+
+	report, err := h.diagnosisService.Diagnose(c, uint(subjectID), query.DiagnosisModel(), pointIDs)
+	if err != nil {
+		respondDiagnosisError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// KnowledgeProgress returns the posterior mastery of every knowledge point of a subject for a user
+func (h *DiagnosisHandler) KnowledgeProgress(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var query dto.KnowledgeProgressQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid query parameters", err.Error()))
+		return
+	}
+	pointIDs, err := parseUintList(query.KnowledgePointIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid knowledge point IDs", err.Error()))
+		return
+	}
+
+	progress, err := h.diagnosisService.KnowledgeProgress(c, query.UserID, uint(subjectID), query.DiagnosisModel(), pointIDs)
+	if err != nil {
+		respondDiagnosisError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// respondDiagnosisError 将 Q 矩阵不可用的情况映射为 400
+func respondDiagnosisError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, analysis.ErrEmptyQMatrix):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "No answered questions are linked to the knowledge points", err.Error()))
+	case errors.Is(err, analysis.ErrTooManyAttributes):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Too many knowledge points, select a subset with knowledge_point_ids", err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to run cognitive diagnosis", err.Error()))
+	}
+}
+
+// parseUintList 解析逗号分隔的ID列表
+func parseUintList(value string) ([]uint, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ",")
+	values := make([]uint, 0, len(parts))
+	for _, part := range parts {
+		v, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, uint(v))
+	}
+	return values, nil
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DIFHandler handles differential item functioning requests
+type DIFHandler struct {
+	difService services.DIFService
+}
+
+// NewDIFHandler creates a new DIF handler
+func NewDIFHandler(difService services.DIFService) *DIFHandler {
+	return &DIFHandler{
+		difService: difService,
+	}
+}
+
+// Analyze runs DIF analysis for every question in a subject between two examinee groups
+func (h *DIFHandler) Analyze(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.DIFAnalysisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	results, err := h.difService.Analyze(c, uint(subjectID), req.GroupAttribute, req.ReferenceGroup, req.FocalGroup)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to analyze DIF", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToDIFAnalysisResponse(results))
+}
+
+// GetReport returns saved DIF results of a subject,
+// flagged_only=true limits the report to C-level questions
+func (h *DIFHandler) GetReport(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	report, err := h.difService.GetReport(c, uint(subjectID), c.Query("group_attribute"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get DIF report", err.Error()))
+		return
+	}
+
+	if c.Query("flagged_only") == "true" {
+		flagged := make([]*services.DIFItemReport, 0, report.FlaggedCount)
+		for _, item := range report.Items {
+			if item.Flagged {
+				flagged = append(flagged, item)
+			}
+		}
+		report.Items = flagged
+	}
+
+	c.JSON(http.StatusOK, report)
+}
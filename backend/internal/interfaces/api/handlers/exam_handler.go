@@ -116,3 +116,57 @@ func (h *ExamHandler) SubmitExam(c *gin.Context) {
 
 	c.JSON(http.StatusOK, record)
 }
+
+// GetAdaptiveSettings returns the item selection and stopping rules of an exam paper
+func (h *ExamHandler) GetAdaptiveSettings(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	paper, err := h.examService.GetExamPaper(c, uint(paperID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get exam", err.Error()))
+		return
+	}
+	if paper == nil {
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam not found", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAdaptiveSettingsResponse(paper))
+}
+
+// UpdateAdaptiveSettings updates the item selection and stopping rules of an exam paper
+func (h *ExamHandler) UpdateAdaptiveSettings(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	var req dto.AdaptiveSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	paper, err := h.examService.GetExamPaper(c, uint(paperID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get exam", err.Error()))
+		return
+	}
+	if paper == nil {
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam not found", nil))
+		return
+	}
+
+	req.ApplyTo(paper)
+	if err := h.examService.UpdateExamPaper(c, paper); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to update adaptive settings", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAdaptiveSettingsResponse(paper))
+}
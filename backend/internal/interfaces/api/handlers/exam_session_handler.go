@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/domain/models"
+	domainservices "irt-exam-system/backend/internal/domain/services"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExamSessionHandler handles adaptive exam session requests of examinees
+type ExamSessionHandler struct {
+	examService domainservices.ExamService
+}
+
+// NewExamSessionHandler creates a new exam session handler
+func NewExamSessionHandler(examService domainservices.ExamService) *ExamSessionHandler {
+	return &ExamSessionHandler{
+		examService: examService,
+	}
+}
+
+// Start starts an adaptive exam session on an exam paper for the current user
+func (h *ExamSessionHandler) Start(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	session, err := h.examService.StartExam(c, middleware.GetUserID(c), uint(paperID))
+	if err != nil {
+		h.handleError(c, err, "Failed to start exam session")
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// NextQuestion selects the next question of an exam session; the session is
+// finished when the item bank is exhausted
+func (h *ExamSessionHandler) NextQuestion(c *gin.Context) {
+	sessionID, ok := h.parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	question, err := h.examService.GetNextQuestion(c, sessionID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get next question")
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// SubmitAnswer scores an answer, updates the ability estimate and reports
+// whether the session has finished
+func (h *ExamSessionHandler) SubmitAnswer(c *gin.Context) {
+	sessionID, ok := h.parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	var req models.AnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	result, err := h.examService.SubmitAnswer(c, sessionID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to submit answer")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ExamSessionHandler) parseSessionID(c *gin.Context) (uint, bool) {
+	sessionID, err := strconv.ParseUint(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam session ID", err.Error()))
+		return 0, false
+	}
+	return uint(sessionID), true
+}
+
+func (h *ExamSessionHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domainservices.ErrExamPaperNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam paper not found", nil))
+	case errors.Is(err, domainservices.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam session not found", nil))
+	case errors.Is(err, irt.ErrNoCandidates):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "No question left, exam session finished", err.Error()))
+	case errors.Is(err, domainservices.ErrSessionNotInProgress),
+		errors.Is(err, domainservices.ErrQuestionNotInModule):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", message, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", message, err.Error()))
+	}
+}
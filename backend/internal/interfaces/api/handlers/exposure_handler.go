@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/models"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExposureHandler handles item exposure control requests
+type ExposureHandler struct {
+	exposureService services.ExposureService
+}
+
+// NewExposureHandler creates a new exposure handler
+func NewExposureHandler(exposureService services.ExposureService) *ExposureHandler {
+	return &ExposureHandler{
+		exposureService: exposureService,
+	}
+}
+
+// GetSetting returns the exposure control setting of a subject
+func (h *ExposureHandler) GetSetting(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	setting, err := h.exposureService.GetSetting(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get exposure setting", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, setting)
+}
+
+// UpdateSetting updates the exposure control setting of a subject
+func (h *ExposureHandler) UpdateSetting(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.ExposureSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	setting := &models.ExposureSetting{
+		SubjectID:       uint(subjectID),
+		Method:          req.Method,
+		RandomesqueSize: req.RandomesqueSize,
+		MaxExposureRate: req.MaxExposureRate,
+	}
+	if setting.RandomesqueSize == 0 {
+		setting.RandomesqueSize = 5
+	}
+	if setting.MaxExposureRate == 0 {
+		setting.MaxExposureRate = 0.25
+	}
+
+	if err := h.exposureService.UpdateSetting(c, setting); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to update exposure setting", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, setting)
+}
+
+// ComputeSympsonHetter simulates adaptive sessions to derive exposure parameters
+func (h *ExposureHandler) ComputeSympsonHetter(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	result, err := h.exposureService.ComputeSympsonHetter(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to compute exposure parameters", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSympsonHetterResponse(result))
+}
+
+// GetReport returns the exposure rate of every question in a subject
+func (h *ExposureHandler) GetReport(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	report, err := h.exposureService.GetExposureReport(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get exposure report", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FitHandler handles item and person fit requests
+type FitHandler struct {
+	fitService services.FitService
+}
+
+// NewFitHandler creates a new fit handler
+func NewFitHandler(fitService services.FitService) *FitHandler {
+	return &FitHandler{
+		fitService: fitService,
+	}
+}
+
+// ItemFit returns fit statistics of every question in a subject,
+// misfit_only=true limits the result to misfitting questions
+func (h *FitHandler) ItemFit(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	fits, err := h.fitService.ItemFit(c, uint(subjectID))
+	if err != nil {
+		if errors.Is(err, services.ErrUncalibratedItem) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Subject has uncalibrated questions", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to compute item fit", err.Error()))
+		return
+	}
+
+	misfitOnly := c.Query("misfit_only") == "true"
+	responses := make([]dto.ItemFitResponse, 0, len(fits))
+	for _, fit := range fits {
+		if misfitOnly && !fit.Misfit {
+			continue
+		}
+		responses = append(responses, dto.ToItemFitResponse(fit))
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// PersonFit returns lz* person fit of every exam record in a subject,
+// misfit_only=true limits the result to aberrant response patterns
+func (h *FitHandler) PersonFit(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	reports, err := h.fitService.PersonFit(c, uint(subjectID))
+	if err != nil {
+		if errors.Is(err, services.ErrUncalibratedItem) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Subject has uncalibrated questions", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to compute person fit", err.Error()))
+		return
+	}
+
+	if c.Query("misfit_only") == "true" {
+		filtered := make([]*services.PersonFitReport, 0, len(reports))
+		for _, report := range reports {
+			if report.Misfit {
+				filtered = append(filtered, report)
+			}
+		}
+		reports = filtered
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ForensicsHandler handles aberrant response and cheating detection requests
+type ForensicsHandler struct {
+	forensicsService services.ForensicsService
+}
+
+// NewForensicsHandler creates a new forensics handler
+func NewForensicsHandler(forensicsService services.ForensicsService) *ForensicsHandler {
+	return &ForensicsHandler{
+		forensicsService: forensicsService,
+	}
+}
+
+// Report returns the suspicious examinee pairs, person-fit, fast correct and
+// answer change flags of an exam paper for proctor review
+func (h *ForensicsHandler) Report(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	report, err := h.forensicsService.Report(c, uint(paperID))
+	if err != nil {
+		if errors.Is(err, services.ErrPaperNotFound) {
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam paper not found", nil))
+			return
+		}
+		if errors.Is(err, services.ErrUncalibratedItem) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Exam paper has uncalibrated questions", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to build forensics report", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GrowthHandler handles longitudinal ability growth requests
+type GrowthHandler struct {
+	growthService services.GrowthService
+}
+
+// NewGrowthHandler creates a new growth handler
+func NewGrowthHandler(growthService services.GrowthService) *GrowthHandler {
+	return &GrowthHandler{
+		growthService: growthService,
+	}
+}
+
+// GetGrowth returns the smoothed current ability, growth rate, forecast and
+// significant declines of a user in a subject
+func (h *GrowthHandler) GetGrowth(c *gin.Context) {
+	subjectID, userID, query, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.growthService.TrackGrowth(c, userID, subjectID, query.HorizonDays)
+	if err != nil {
+		h.handleError(c, err, "Failed to track ability growth")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetGrowthCurve returns the smoothed ability curve with 95% bands and forecast points for charts
+func (h *GrowthHandler) GetGrowthCurve(c *gin.Context) {
+	subjectID, userID, query, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	curve, err := h.growthService.GrowthCurve(c, userID, subjectID, query.HorizonDays, query.StepDays)
+	if err != nil {
+		h.handleError(c, err, "Failed to compute growth curve")
+		return
+	}
+
+	c.JSON(http.StatusOK, curve)
+}
+
+func (h *GrowthHandler) parseRequest(c *gin.Context) (uint, uint, dto.GrowthQuery, bool) {
+	var query dto.GrowthQuery
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return 0, 0, query, false
+	}
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid user ID", err.Error()))
+		return 0, 0, query, false
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid query parameters", err.Error()))
+		return 0, 0, query, false
+	}
+	return uint(subjectID), uint(userID), query, true
+}
+
+func (h *GrowthHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrNoEstimations):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "No ability estimations found", nil))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", message, err.Error()))
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ItemModelHandler handles item response model settings of questions
+type ItemModelHandler struct {
+	abilityService services.AbilityService
+}
+
+// NewItemModelHandler creates a new item model handler
+func NewItemModelHandler(abilityService services.AbilityService) *ItemModelHandler {
+	return &ItemModelHandler{
+		abilityService: abilityService,
+	}
+}
+
+// GetItemModel returns the item response model and category thresholds of a question
+func (h *ItemModelHandler) GetItemModel(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("question_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid question ID", err.Error()))
+		return
+	}
+
+	params, err := h.abilityService.GetQuestionParameters(c, uint(questionID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get item parameters", err.Error()))
+		return
+	}
+	if params == nil {
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Item parameters not found", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToItemModelResponse(params))
+}
+
+// UpdateItemModel switches a question between 3PL and the polytomous GRM/GPCM models
+func (h *ItemModelHandler) UpdateItemModel(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("question_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid question ID", err.Error()))
+		return
+	}
+
+	var req dto.ItemModelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	params, err := h.abilityService.UpdateItemModel(c, uint(questionID), irt.ItemModel(req.Model), req.Discrimination, req.Thresholds)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Failed to update item model", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToItemModelResponse(params))
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LinkingHandler handles scale linking and score equating between exam papers
+type LinkingHandler struct {
+	linkingService services.LinkingService
+}
+
+// NewLinkingHandler creates a new linking handler
+func NewLinkingHandler(linkingService services.LinkingService) *LinkingHandler {
+	return &LinkingHandler{
+		linkingService: linkingService,
+	}
+}
+
+// LinkPapers calibrates a new paper separately and links its scale to a base paper through common anchor items
+func (h *LinkingHandler) LinkPapers(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.LinkingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	result, err := h.linkingService.LinkPapers(c, &services.LinkingRequest{
+		SubjectID:   uint(subjectID),
+		NewPaperID:  req.NewPaperID,
+		BasePaperID: req.BasePaperID,
+		Method:      irt.LinkingMethod(req.Method),
+		Apply:       req.Apply,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to link exam papers")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToLinkingResponse(result))
+}
+
+// ListTransformations returns the scale transformation history of a subject
+func (h *LinkingHandler) ListTransformations(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	transformations, err := h.linkingService.ListTransformations(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to list scale transformations", err.Error()))
+		return
+	}
+
+	resp := make([]dto.ScaleTransformationResponse, len(transformations))
+	for i, t := range transformations {
+		resp[i] = dto.ToScaleTransformationResponse(t)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Equate converts raw scores of one paper to the raw score scale of another
+func (h *LinkingHandler) Equate(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.EquatingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	result, err := h.linkingService.Equate(c, &services.EquatingRequest{
+		SubjectID:   uint(subjectID),
+		FromPaperID: req.FromPaperID,
+		ToPaperID:   req.ToPaperID,
+		Method:      irt.EquatingMethod(req.Method),
+		Scores:      req.Scores,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to equate exam papers")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEquatingResponse(result))
+}
+
+func (h *LinkingHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrSubjectNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Subject not found", nil))
+	case errors.Is(err, services.ErrPaperNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam paper not found", nil))
+	case errors.Is(err, services.ErrPaperSubjectMismatch),
+		errors.Is(err, services.ErrSamePaper),
+		errors.Is(err, services.ErrNoPaperResponses),
+		errors.Is(err, services.ErrUncalibratedItem),
+		errors.Is(err, irt.ErrTooFewAnchors),
+		errors.Is(err, irt.ErrDegenerateAnchors),
+		errors.Is(err, irt.ErrEmptyForm),
+		errors.Is(err, irt.ErrEmptyMatrix):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", message, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", message, err.Error()))
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MIRTHandler handles multidimensional IRT calibration and ability profile requests
+type MIRTHandler struct {
+	mirtService services.MIRTService
+}
+
+// NewMIRTHandler creates a new multidimensional IRT handler
+func NewMIRTHandler(mirtService services.MIRTService) *MIRTHandler {
+	return &MIRTHandler{
+		mirtService: mirtService,
+	}
+}
+
+// Calibrate fits a compensatory multidimensional model over the top-level knowledge points of a subject
+func (h *MIRTHandler) Calibrate(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.MIRTCalibrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	calibration, err := h.mirtService.Calibrate(c, uint(subjectID), req.DryRun)
+	if err != nil {
+		h.handleError(c, err, "Failed to calibrate multidimensional model")
+		return
+	}
+
+	status := http.StatusCreated
+	if req.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, dto.ToMIRTCalibrationResponse(calibration, true))
+}
+
+// ListCalibrations returns all multidimensional calibrations of a subject without item parameters
+func (h *MIRTHandler) ListCalibrations(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	calibrations, err := h.mirtService.ListCalibrations(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to list calibrations", err.Error()))
+		return
+	}
+
+	resp := make([]dto.MIRTCalibrationResponse, len(calibrations))
+	for i, calibration := range calibrations {
+		resp[i] = dto.ToMIRTCalibrationResponse(calibration, false)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCalibration returns a multidimensional calibration with its item parameters
+func (h *MIRTHandler) GetCalibration(c *gin.Context) {
+	calibrationID, err := strconv.ParseUint(c.Param("calibration_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid calibration ID", err.Error()))
+		return
+	}
+
+	calibration, err := h.mirtService.GetCalibration(c, uint(calibrationID))
+	if err != nil {
+		h.handleError(c, err, "Failed to get calibration")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMIRTCalibrationResponse(calibration, true))
+}
+
+// EstimateProfile re-estimates a user's ability vector from all their responses in a subject
+func (h *MIRTHandler) EstimateProfile(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid user ID", err.Error()))
+		return
+	}
+
+	profile, err := h.mirtService.EstimateProfile(c, uint(userID), uint(subjectID))
+	if err != nil {
+		h.handleError(c, err, "Failed to estimate ability profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAbilityProfileResponse(profile))
+}
+
+// GetProfile returns a user's multidimensional ability profile in a subject
+func (h *MIRTHandler) GetProfile(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid user ID", err.Error()))
+		return
+	}
+
+	profile, err := h.mirtService.GetProfile(c, uint(userID), uint(subjectID))
+	if err != nil {
+		h.handleError(c, err, "Failed to get ability profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAbilityProfileResponse(profile))
+}
+
+func (h *MIRTHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrMIRTCalibrationNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Multidimensional calibration not found", nil))
+	case errors.Is(err, services.ErrAbilityProfileNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Ability profile not found", nil))
+	case errors.Is(err, services.ErrTooFewDimensions),
+		errors.Is(err, irt.ErrTooManyDimensions),
+		errors.Is(err, irt.ErrEmptyMatrix),
+		errors.Is(err, irt.ErrNoResponses):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", message, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", message, err.Error()))
+	}
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MSTHandler handles multistage testing panel requests
+type MSTHandler struct {
+	mstService services.MSTService
+}
+
+// NewMSTHandler creates a new multistage testing handler
+func NewMSTHandler(mstService services.MSTService) *MSTHandler {
+	return &MSTHandler{
+		mstService: mstService,
+	}
+}
+
+// CreatePanel assembles a multistage panel for an exam paper and derives its routing cuts
+func (h *MSTHandler) CreatePanel(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	var req dto.MSTPanelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	panel, err := h.mstService.CreatePanel(c, req.ToMSTPanel(uint(paperID)), req.ToRoutingRules())
+	if err != nil {
+		h.handleError(c, err, "Failed to create panel")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToMSTPanelResponse(panel))
+}
+
+// ListPanels returns all multistage panels of an exam paper
+func (h *MSTHandler) ListPanels(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	panels, err := h.mstService.ListPanels(c, uint(paperID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to list panels", err.Error()))
+		return
+	}
+
+	resp := make([]dto.MSTPanelResponse, len(panels))
+	for i, panel := range panels {
+		resp[i] = dto.ToMSTPanelResponse(panel)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetPanel returns a multistage panel with its modules and routing rules
+func (h *MSTHandler) GetPanel(c *gin.Context) {
+	panelID, err := strconv.ParseUint(c.Param("panel_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid panel ID", err.Error()))
+		return
+	}
+
+	panel, err := h.mstService.GetPanel(c, uint(panelID))
+	if err != nil {
+		h.handleError(c, err, "Failed to get panel")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMSTPanelResponse(panel))
+}
+
+// SetPanelActive activates or retires a multistage panel for new sessions
+func (h *MSTHandler) SetPanelActive(c *gin.Context) {
+	panelID, err := strconv.ParseUint(c.Param("panel_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid panel ID", err.Error()))
+		return
+	}
+
+	var req dto.MSTPanelActiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	panel, err := h.mstService.SetPanelActive(c, uint(panelID), *req.Active)
+	if err != nil {
+		h.handleError(c, err, "Failed to update panel")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMSTPanelResponse(panel))
+}
+
+func (h *MSTHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrPaperNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam paper not found", nil))
+	case errors.Is(err, services.ErrPanelNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Panel not found", nil))
+	case errors.Is(err, services.ErrInvalidPanelDesign),
+		errors.Is(err, services.ErrDuplicateModule),
+		errors.Is(err, services.ErrEmptyPanelModule),
+		errors.Is(err, services.ErrDuplicatePanelItem),
+		errors.Is(err, services.ErrPretestModuleItem),
+		errors.Is(err, services.ErrUncalibratedItem),
+		errors.Is(err, services.ErrMissingRoutingRule),
+		errors.Is(err, services.ErrUnknownRoutingModule),
+		errors.Is(err, irt.ErrUnknownRoutingMethod),
+		errors.Is(err, irt.ErrUnknownRoutingCutMethod),
+		errors.Is(err, irt.ErrInvalidRoutingCuts),
+		errors.Is(err, irt.ErrInvalidRoutingProportions),
+		errors.Is(err, irt.ErrModulesNotOrdered),
+		errors.Is(err, irt.ErrEmptyModule):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", message, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", message, err.Error()))
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PretestHandler handles pretest seeding settings and online calibration requests
+type PretestHandler struct {
+	pretestService services.PretestService
+}
+
+// NewPretestHandler creates a new pretest handler
+func NewPretestHandler(pretestService services.PretestService) *PretestHandler {
+	return &PretestHandler{
+		pretestService: pretestService,
+	}
+}
+
+// GetSetting returns the pretest seeding and promotion setting of a subject
+func (h *PretestHandler) GetSetting(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	setting, err := h.pretestService.GetSetting(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get pretest setting", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPretestSettingResponse(setting))
+}
+
+// UpdateSetting updates the pretest seeding and promotion setting of a subject
+func (h *PretestHandler) UpdateSetting(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.PretestSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	current, err := h.pretestService.GetSetting(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get pretest setting", err.Error()))
+		return
+	}
+	setting := req.ToPretestSetting(uint(subjectID), current)
+	if err := h.pretestService.UpdateSetting(c, setting); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to update pretest setting", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPretestSettingResponse(setting))
+}
+
+// FlagQuestions marks questions of a subject as pretest items, or returns them to operational use
+func (h *PretestHandler) FlagQuestions(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.PretestFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	updated, err := h.pretestService.FlagQuestions(c, uint(subjectID), req.QuestionIDs, req.Pretest)
+	if err != nil {
+		if errors.Is(err, services.ErrNoQuestionsFlagged) {
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Questions not found in subject", nil))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to flag pretest questions", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+// CalibrateOnline estimates pretest item parameters on the fixed operational scale and promotes items that are precise enough
+func (h *PretestHandler) CalibrateOnline(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.OnlineCalibrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	result, err := h.pretestService.CalibrateOnline(c, uint(subjectID), req.DryRun)
+	if err != nil {
+		if errors.Is(err, irt.ErrUnknownOnlineMethod) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid online calibration method", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to calibrate pretest items", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToOnlineCalibrationResponse(result))
+}
+
+// ListCalibrations returns the latest online calibration of every pretest item in a subject
+func (h *PretestHandler) ListCalibrations(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	calibrations, err := h.pretestService.ListCalibrations(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to list pretest calibrations", err.Error()))
+		return
+	}
+
+	resp := make([]dto.PretestCalibrationResponse, len(calibrations))
+	for i, calibration := range calibrations {
+		resp[i] = dto.ToPretestCalibrationResponse(calibration)
+	}
+	c.JSON(http.StatusOK, resp)
+}
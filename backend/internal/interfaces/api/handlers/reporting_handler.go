@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportingHandler handles reporting scale and norm table requests
+type ReportingHandler struct {
+	reportingService services.ReportingService
+}
+
+// NewReportingHandler creates a new reporting handler
+func NewReportingHandler(reportingService services.ReportingService) *ReportingHandler {
+	return &ReportingHandler{
+		reportingService: reportingService,
+	}
+}
+
+// CreateScale creates a new version of a subject's reporting scale and makes it active
+func (h *ReportingHandler) CreateScale(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.ReportingScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	scale, err := h.reportingService.CreateScale(c, req.ToModel(uint(subjectID)))
+	if err != nil {
+		h.handleError(c, err, "Failed to create reporting scale")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToReportingScaleResponse(scale))
+}
+
+// ListScales returns all reporting scale versions of a subject, newest first
+func (h *ReportingHandler) ListScales(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	scales, err := h.reportingService.ListScales(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to list reporting scales", err.Error()))
+		return
+	}
+
+	resp := make([]dto.ReportingScaleResponse, len(scales))
+	for i, scale := range scales {
+		resp[i] = dto.ToReportingScaleResponse(scale)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetConversionTable returns the theta to scaled score conversion table of a reporting scale
+func (h *ReportingHandler) GetConversionTable(c *gin.Context) {
+	scaleID, err := strconv.ParseUint(c.Param("scale_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid reporting scale ID", err.Error()))
+		return
+	}
+
+	var query dto.ConversionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid query parameters", err.Error()))
+		return
+	}
+	grid, err := irt.ThetaGrid(query.MinTheta, query.MaxTheta, query.Step)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid theta grid", err.Error()))
+		return
+	}
+
+	table, err := h.reportingService.ConversionTable(c, uint(scaleID), grid)
+	if err != nil {
+		h.handleError(c, err, "Failed to build conversion table")
+		return
+	}
+
+	c.JSON(http.StatusOK, table)
+}
+
+// BuildNormTable computes a new percentile norm table version from a reference
+// cohort on the subject's active reporting scale
+func (h *ReportingHandler) BuildNormTable(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.NormTableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	table, err := h.reportingService.BuildNormTable(c, uint(subjectID), services.NormCohort{
+		Name: req.Cohort,
+		From: req.From,
+		To:   req.To,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to build norm table")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToNormTableResponse(table))
+}
+
+// ListNormTables returns the norm table versions of a reporting scale without their entries
+func (h *ReportingHandler) ListNormTables(c *gin.Context) {
+	scaleID, err := strconv.ParseUint(c.Param("scale_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid reporting scale ID", err.Error()))
+		return
+	}
+
+	tables, err := h.reportingService.ListNormTables(c, uint(scaleID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to list norm tables", err.Error()))
+		return
+	}
+
+	resp := make([]dto.NormTableResponse, len(tables))
+	for i, table := range tables {
+		resp[i] = dto.ToNormTableResponse(table)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetNormTable returns a norm table with its percentile entries
+func (h *ReportingHandler) GetNormTable(c *gin.Context) {
+	tableID, err := strconv.ParseUint(c.Param("table_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid norm table ID", err.Error()))
+		return
+	}
+
+	table, err := h.reportingService.GetNormTable(c, uint(tableID))
+	if err != nil {
+		h.handleError(c, err, "Failed to get norm table")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToNormTableResponse(table))
+}
+
+func (h *ReportingHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrSubjectNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Subject not found", nil))
+	case errors.Is(err, services.ErrScaleNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Reporting scale not found", nil))
+	case errors.Is(err, services.ErrNormTableNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Norm table not found", nil))
+	case errors.Is(err, services.ErrNoReportingScale),
+		errors.Is(err, analysis.ErrNoExaminees),
+		errors.Is(err, irt.ErrUnknownScaleTransform),
+		errors.Is(err, irt.ErrUnknownRoundingMode),
+		errors.Is(err, irt.ErrInvalidScaleSlope),
+		errors.Is(err, irt.ErrInvalidScalePoints),
+		errors.Is(err, irt.ErrInvalidScaleRange):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", message, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", message, err.Error()))
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseTimeHandler handles response time modeling requests
+type ResponseTimeHandler struct {
+	responseTimeService services.ResponseTimeService
+}
+
+// NewResponseTimeHandler creates a new response time handler
+func NewResponseTimeHandler(responseTimeService services.ResponseTimeService) *ResponseTimeHandler {
+	return &ResponseTimeHandler{
+		responseTimeService: responseTimeService,
+	}
+}
+
+// Calibrate fits the lognormal response time model of a subject and the joint
+// ability-speed population, storing the parameters used by time-aware item selection
+func (h *ResponseTimeHandler) Calibrate(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.ResponseTimeCalibrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	result, err := h.responseTimeService.Calibrate(c, uint(subjectID), req.DryRun)
+	if err != nil {
+		if errors.Is(err, irt.ErrNoTimedResponses) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Not enough timed responses to calibrate", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to calibrate response times", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Speededness returns rapid-guessing responses, low-effort and speeded examinees
+// and speeded sections of a subject
+func (h *ResponseTimeHandler) Speededness(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	report, err := h.responseTimeService.Speededness(c, uint(subjectID))
+	if err != nil {
+		if errors.Is(err, services.ErrResponseTimesNotCalibrated) {
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Response time parameters not calibrated", nil))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to detect speededness", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StandardSettingHandler handles standard setting session and performance level requests
+type StandardSettingHandler struct {
+	standardSettingService services.StandardSettingService
+}
+
+// NewStandardSettingHandler creates a new standard setting handler
+func NewStandardSettingHandler(standardSettingService services.StandardSettingService) *StandardSettingHandler {
+	return &StandardSettingHandler{
+		standardSettingService: standardSettingService,
+	}
+}
+
+// CreateSession opens a modified Angoff or Bookmark standard setting session on an exam paper
+func (h *StandardSettingHandler) CreateSession(c *gin.Context) {
+	var req dto.StandardSettingSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	session, err := h.standardSettingService.CreateSession(c, req.ToModel())
+	if err != nil {
+		h.handleError(c, err, "Failed to create standard setting session")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToStandardSettingSessionResponse(session))
+}
+
+// ListSessions returns the standard setting sessions of a subject, newest first
+func (h *StandardSettingHandler) ListSessions(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	sessions, err := h.standardSettingService.ListSessions(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to list standard setting sessions", err.Error()))
+		return
+	}
+
+	resp := make([]dto.StandardSettingSessionResponse, len(sessions))
+	for i, session := range sessions {
+		resp[i] = dto.ToStandardSettingSessionResponse(session)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetSession returns a standard setting session
+func (h *StandardSettingHandler) GetSession(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid standard setting session ID", err.Error()))
+		return
+	}
+
+	session, err := h.standardSettingService.GetSession(c, uint(sessionID))
+	if err != nil {
+		h.handleError(c, err, "Failed to get standard setting session")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToStandardSettingSessionResponse(session))
+}
+
+// GetBooklet returns the session paper's items ordered by their response probability location
+func (h *StandardSettingHandler) GetBooklet(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid standard setting session ID", err.Error()))
+		return
+	}
+
+	booklet, err := h.standardSettingService.Booklet(c, uint(sessionID))
+	if err != nil {
+		h.handleError(c, err, "Failed to build ordered item booklet")
+		return
+	}
+
+	c.JSON(http.StatusOK, booklet)
+}
+
+// SubmitRatings records the authenticated panelist's ratings for the session's current round
+func (h *StandardSettingHandler) SubmitRatings(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid standard setting session ID", err.Error()))
+		return
+	}
+	panelistID := middleware.GetUserID(c)
+	if panelistID == 0 {
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse("401", "Unauthorized", "User not found"))
+		return
+	}
+
+	var req dto.RatingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	if err := h.standardSettingService.SubmitRatings(c, uint(sessionID), panelistID, req.ToRatings()); err != nil {
+		h.handleError(c, err, "Failed to submit ratings")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// NextRound closes the current rating round and starts the next one
+func (h *StandardSettingHandler) NextRound(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid standard setting session ID", err.Error()))
+		return
+	}
+
+	session, err := h.standardSettingService.NextRound(c, uint(sessionID))
+	if err != nil {
+		h.handleError(c, err, "Failed to start next round")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToStandardSettingSessionResponse(session))
+}
+
+// GetResults returns the cut scores, panelist feedback and impact analysis of a rating round
+func (h *StandardSettingHandler) GetResults(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid standard setting session ID", err.Error()))
+		return
+	}
+
+	var query dto.StandardSettingResultsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid query parameters", err.Error()))
+		return
+	}
+
+	report, err := h.standardSettingService.Results(c, uint(sessionID), query.Round)
+	if err != nil {
+		h.handleError(c, err, "Failed to compute cut scores")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Adopt stores the current round's cut scores as the subject's new performance level version
+func (h *StandardSettingHandler) Adopt(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid standard setting session ID", err.Error()))
+		return
+	}
+
+	var req dto.AdoptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	set, err := h.standardSettingService.Adopt(c, uint(sessionID), req.Name)
+	if err != nil {
+		h.handleError(c, err, "Failed to adopt cut scores")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToPerformanceLevelSetResponse(set))
+}
+
+// CreateLevelSet stores manually set cut scores as the subject's new performance level version
+func (h *StandardSettingHandler) CreateLevelSet(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.PerformanceLevelSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	set, err := h.standardSettingService.CreateLevelSet(c, req.ToModel(uint(subjectID)))
+	if err != nil {
+		h.handleError(c, err, "Failed to create performance levels")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToPerformanceLevelSetResponse(set))
+}
+
+// ListLevelSets returns all performance level versions of a subject, newest first
+func (h *StandardSettingHandler) ListLevelSets(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	sets, err := h.standardSettingService.ListLevelSets(c, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to list performance levels", err.Error()))
+		return
+	}
+
+	resp := make([]dto.PerformanceLevelSetResponse, len(sets))
+	for i, set := range sets {
+		resp[i] = dto.ToPerformanceLevelSetResponse(set)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *StandardSettingHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrSubjectNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Subject not found", nil))
+	case errors.Is(err, services.ErrPaperNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam paper not found", nil))
+	case errors.Is(err, services.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Standard setting session not found", nil))
+	case errors.Is(err, services.ErrSessionClosed),
+		errors.Is(err, services.ErrTooFewLevels),
+		errors.Is(err, services.ErrInvalidBoundary),
+		errors.Is(err, services.ErrQuestionNotOnPaper),
+		errors.Is(err, services.ErrDisorderedCutScores),
+		errors.Is(err, services.ErrInvalidBookmarkPage),
+		errors.Is(err, services.ErrInvalidAngoffRating),
+		errors.Is(err, services.ErrNoOperationalQuestion),
+		errors.Is(err, services.ErrUncalibratedItem),
+		errors.Is(err, analysis.ErrUnknownStandardSettingMethod),
+		errors.Is(err, analysis.ErrNoRatings),
+		errors.Is(err, analysis.ErrInvalidRating):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", message, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", message, err.Error()))
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatisticsHandler handles classical test theory statistics requests
+type StatisticsHandler struct {
+	statisticsService services.StatisticsService
+}
+
+// NewStatisticsHandler creates a new statistics handler
+func NewStatisticsHandler(statisticsService services.StatisticsService) *StatisticsHandler {
+	return &StatisticsHandler{
+		statisticsService: statisticsService,
+	}
+}
+
+// GetPaperStatistics returns the item p-values, point-biserial, biserial and
+// item-rest correlations, alpha if item deleted, KR-20, SEM and score
+// distribution of an exam paper, as JSON or as a CSV attachment
+func (h *StatisticsHandler) GetPaperStatistics(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	var query dto.StatisticsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid query parameters", err.Error()))
+		return
+	}
+
+	stats, err := h.statisticsService.PaperStatistics(c, uint(paperID))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPaperNotFound):
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam paper not found", nil))
+		case errors.Is(err, analysis.ErrNoExaminees):
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Exam paper has no completed exam records", err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to compute paper statistics", err.Error()))
+		}
+		return
+	}
+
+	if query.Format != "csv" {
+		c.JSON(http.StatusOK, stats)
+		return
+	}
+
+	filename := fmt.Sprintf("exam_paper_%d_%s.csv", paperID, query.Section)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	if err := w.WriteAll(dto.StatisticsCSV(stats, query.Section)); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// GetPaperDistractors returns the per-option selection rates by ability
+// group, option point-biserials, non-functioning distractors and likely
+// miskeyed questions of an exam paper
+func (h *StatisticsHandler) GetPaperDistractors(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	var query dto.DistractorQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid query parameters", err.Error()))
+		return
+	}
+
+	report, err := h.statisticsService.PaperDistractors(c, uint(paperID), query.DistractorConfig())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPaperNotFound):
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Exam paper not found", nil))
+		case errors.Is(err, analysis.ErrNoExaminees):
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Exam paper has no completed exam records", err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to analyze distractors", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubjectModelHandler handles the IRT model configuration of subjects
+type SubjectModelHandler struct {
+	subjectService services.SubjectService
+}
+
+// NewSubjectModelHandler creates a new subject model handler
+func NewSubjectModelHandler(subjectService services.SubjectService) *SubjectModelHandler {
+	return &SubjectModelHandler{
+		subjectService: subjectService,
+	}
+}
+
+// GetIRTModel returns the IRT model family and scaling constant of a subject
+func (h *SubjectModelHandler) GetIRTModel(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	model, err := h.subjectService.GetIRTModel(c, uint(subjectID))
+	if err != nil {
+		if errors.Is(err, services.ErrSubjectNotFound) {
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Subject not found", nil))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to get subject IRT model", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSubjectModelResponse(uint(subjectID), model))
+}
+
+// UpdateIRTModel switches the IRT model used for all probability, information and estimation computations of a subject
+func (h *SubjectModelHandler) UpdateIRTModel(c *gin.Context) {
+	subjectID, err := strconv.ParseUint(c.Param("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid subject ID", err.Error()))
+		return
+	}
+
+	var req dto.SubjectModelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid request body", err.Error()))
+		return
+	}
+
+	model, err := h.subjectService.UpdateIRTModel(c, uint(subjectID), irt.Model{
+		Family: irt.ModelFamily(req.Model),
+		D:      req.ScalingConstant,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSubjectNotFound):
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("404", "Subject not found", nil))
+		case errors.Is(err, irt.ErrUnknownModel), errors.Is(err, irt.ErrInvalidScaling):
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid IRT model", err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to update subject IRT model", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSubjectModelResponse(uint(subjectID), model))
+}
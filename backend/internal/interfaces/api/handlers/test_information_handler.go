@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"irt-exam-system/backend/internal/application/services"
+	"irt-exam-system/backend/internal/domain/irt"
+	"irt-exam-system/backend/internal/interfaces/api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestInformationHandler handles test information and test characteristic curve requests
+type TestInformationHandler struct {
+	testInformationService services.TestInformationService
+}
+
+// NewTestInformationHandler creates a new test information handler
+func NewTestInformationHandler(testInformationService services.TestInformationService) *TestInformationHandler {
+	return &TestInformationHandler{
+		testInformationService: testInformationService,
+	}
+}
+
+// GetCurves returns the test information function, conditional SEM and
+// test characteristic curve of an exam paper over a theta grid
+func (h *TestInformationHandler) GetCurves(c *gin.Context) {
+	paperID, err := strconv.ParseUint(c.Param("paper_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid exam paper ID", err.Error()))
+		return
+	}
+
+	var query dto.TestInformationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid query parameters", err.Error()))
+		return
+	}
+	grid, err := irt.ThetaGrid(query.MinTheta, query.MaxTheta, query.Step)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid theta grid", err.Error()))
+		return
+	}
+	thetas, err := parseFloatList(query.Thetas)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid theta values", err.Error()))
+		return
+	}
+	rawScores, err := parseFloatList(query.RawScores)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Invalid raw scores", err.Error()))
+		return
+	}
+
+	curves, err := h.testInformationService.GetTestCurves(c, uint(paperID), grid, thetas, rawScores)
+	if errors.Is(err, services.ErrUncalibratedItem) {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("400", "Exam paper has uncalibrated questions", err.Error()))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("500", "Failed to compute test curves", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTestInformationResponse(curves))
+}
+
+// parseFloatList 解析逗号分隔的数值列表
+func parseFloatList(value string) ([]float64, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ",")
+	values := make([]float64, 0, len(parts))
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
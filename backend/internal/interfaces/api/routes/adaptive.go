@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdaptiveRoutes(router *gin.Engine, examHandler *handlers.ExamHandler) {
+	admin := router.Group("/admin/exam-papers/:paper_id/adaptive-settings")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", examHandler.GetAdaptiveSettings)
+		admin.PUT("", examHandler.UpdateAdaptiveSettings)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAssemblyRoutes(router *gin.Engine, assemblyHandler *handlers.AssemblyHandler) {
+	admin := router.Group("/admin")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.POST("/subjects/:subject_id/assemblies", assemblyHandler.Assemble)
+		admin.PUT("/questions/:question_id/enemies/:enemy_id", assemblyHandler.AddEnemy)
+		admin.DELETE("/questions/:question_id/enemies/:enemy_id", assemblyHandler.RemoveEnemy)
+	}
+}
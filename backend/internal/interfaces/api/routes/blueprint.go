@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBlueprintRoutes(router *gin.Engine, blueprintHandler *handlers.BlueprintHandler) {
+	admin := router.Group("/admin/exam-papers/:paper_id/blueprint")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", blueprintHandler.GetBlueprint)
+		admin.PUT("", blueprintHandler.SaveBlueprint)
+		admin.DELETE("", blueprintHandler.DeleteBlueprint)
+	}
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupCalibrationRoutes(router *gin.Engine, calibrationHandler *handlers.CalibrationHandler) {
+	admin := router.Group("/admin")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.POST("/subjects/:subject_id/calibrations", calibrationHandler.Calibrate)
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupDiagnosisRoutes(router *gin.Engine, diagnosisHandler *handlers.DiagnosisHandler) {
+	admin := router.Group("/admin/subjects/:subject_id/diagnosis")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", diagnosisHandler.Diagnose)
+		admin.GET("/progress", diagnosisHandler.KnowledgeProgress)
+	}
+}
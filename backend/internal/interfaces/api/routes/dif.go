@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupDIFRoutes(router *gin.Engine, difHandler *handlers.DIFHandler) {
+	admin := router.Group("/admin/subjects/:subject_id/dif")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.POST("", difHandler.Analyze)
+		admin.GET("", difHandler.GetReport)
+	}
+
+	teacher := router.Group("/teacher/subjects/:subject_id/dif")
+	teacher.Use(middleware.RequireRole(models.RoleTeacher))
+	{
+		teacher.GET("", difHandler.GetReport)
+	}
+}
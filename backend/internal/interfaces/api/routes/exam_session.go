@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupExamSessionRoutes(router *gin.Engine, examSessionHandler *handlers.ExamSessionHandler) {
+	student := router.Group("/student")
+	student.Use(middleware.RequireRole(models.RoleStudent))
+	{
+		student.POST("/exam-papers/:paper_id/sessions", examSessionHandler.Start)
+		student.GET("/exam-sessions/:session_id/next-question", examSessionHandler.NextQuestion)
+		student.POST("/exam-sessions/:session_id/answers", examSessionHandler.SubmitAnswer)
+	}
+}
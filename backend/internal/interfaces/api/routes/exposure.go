@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupExposureRoutes(router *gin.Engine, exposureHandler *handlers.ExposureHandler) {
+	admin := router.Group("/admin/subjects/:subject_id/exposure")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", exposureHandler.GetReport)
+		admin.GET("/settings", exposureHandler.GetSetting)
+		admin.PUT("/settings", exposureHandler.UpdateSetting)
+		admin.POST("/sympson-hetter", exposureHandler.ComputeSympsonHetter)
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupFitRoutes(router *gin.Engine, fitHandler *handlers.FitHandler) {
+	admin := router.Group("/admin/subjects/:subject_id/fit")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("/items", fitHandler.ItemFit)
+		admin.GET("/persons", fitHandler.PersonFit)
+	}
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupForensicsRoutes(router *gin.Engine, forensicsHandler *handlers.ForensicsHandler) {
+	admin := router.Group("/admin/exam-papers/:paper_id/forensics")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", forensicsHandler.Report)
+	}
+
+	// 监考教师复核
+	teacher := router.Group("/teacher/exam-papers/:paper_id/forensics")
+	teacher.Use(middleware.RequireRole(models.RoleTeacher))
+	{
+		teacher.GET("", forensicsHandler.Report)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupGrowthRoutes(router *gin.Engine, growthHandler *handlers.GrowthHandler) {
+	admin := router.Group("/admin/subjects/:subject_id/growth/:user_id")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", growthHandler.GetGrowth)
+		admin.GET("/curve", growthHandler.GetGrowthCurve)
+	}
+
+	teacher := router.Group("/teacher/subjects/:subject_id/growth/:user_id")
+	teacher.Use(middleware.RequireRole(models.RoleTeacher))
+	{
+		teacher.GET("", growthHandler.GetGrowth)
+		teacher.GET("/curve", growthHandler.GetGrowthCurve)
+	}
+}
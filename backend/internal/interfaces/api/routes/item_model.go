@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupItemModelRoutes(router *gin.Engine, itemModelHandler *handlers.ItemModelHandler) {
+	admin := router.Group("/admin/questions/:question_id/item-model")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", itemModelHandler.GetItemModel)
+		admin.PUT("", itemModelHandler.UpdateItemModel)
+	}
+}
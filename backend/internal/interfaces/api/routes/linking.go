@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupLinkingRoutes(router *gin.Engine, linkingHandler *handlers.LinkingHandler) {
+	admin := router.Group("/admin/subjects/:subject_id")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.POST("/linking", linkingHandler.LinkPapers)
+		admin.GET("/linking", linkingHandler.ListTransformations)
+		admin.POST("/equating", linkingHandler.Equate)
+	}
+}
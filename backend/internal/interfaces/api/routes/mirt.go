@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupMIRTRoutes(router *gin.Engine, mirtHandler *handlers.MIRTHandler) {
+	subject := router.Group("/admin/subjects/:subject_id")
+	subject.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		subject.POST("/mirt-calibrations", mirtHandler.Calibrate)
+		subject.GET("/mirt-calibrations", mirtHandler.ListCalibrations)
+		subject.GET("/ability-profiles/:user_id", mirtHandler.GetProfile)
+		subject.POST("/ability-profiles/:user_id", mirtHandler.EstimateProfile)
+	}
+
+	admin := router.Group("/admin")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("/mirt-calibrations/:calibration_id", mirtHandler.GetCalibration)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupMSTRoutes(router *gin.Engine, mstHandler *handlers.MSTHandler) {
+	paper := router.Group("/admin/exam-papers/:paper_id/mst-panels")
+	paper.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		paper.POST("", mstHandler.CreatePanel)
+		paper.GET("", mstHandler.ListPanels)
+	}
+
+	admin := router.Group("/admin/mst-panels")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("/:panel_id", mstHandler.GetPanel)
+		admin.PUT("/:panel_id/active", mstHandler.SetPanelActive)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupPretestRoutes(router *gin.Engine, pretestHandler *handlers.PretestHandler) {
+	admin := router.Group("/admin/subjects/:subject_id/pretest")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("/settings", pretestHandler.GetSetting)
+		admin.PUT("/settings", pretestHandler.UpdateSetting)
+		admin.PUT("/questions", pretestHandler.FlagQuestions)
+		admin.POST("/calibrate", pretestHandler.CalibrateOnline)
+		admin.GET("/calibrations", pretestHandler.ListCalibrations)
+	}
+}
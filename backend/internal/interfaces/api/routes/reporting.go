@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReportingRoutes(router *gin.Engine, reportingHandler *handlers.ReportingHandler) {
+	subject := router.Group("/admin/subjects/:subject_id")
+	subject.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		subject.POST("/reporting-scales", reportingHandler.CreateScale)
+		subject.GET("/reporting-scales", reportingHandler.ListScales)
+		subject.POST("/norm-tables", reportingHandler.BuildNormTable)
+	}
+
+	admin := router.Group("/admin")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("/reporting-scales/:scale_id/conversion", reportingHandler.GetConversionTable)
+		admin.GET("/reporting-scales/:scale_id/norm-tables", reportingHandler.ListNormTables)
+		admin.GET("/norm-tables/:table_id", reportingHandler.GetNormTable)
+	}
+}
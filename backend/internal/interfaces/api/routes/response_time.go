@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupResponseTimeRoutes(router *gin.Engine, responseTimeHandler *handlers.ResponseTimeHandler) {
+	admin := router.Group("/admin/subjects/:subject_id/response-times")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.POST("/calibrate", responseTimeHandler.Calibrate)
+		admin.GET("/speededness", responseTimeHandler.Speededness)
+	}
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupStandardSettingRoutes(router *gin.Engine, standardSettingHandler *handlers.StandardSettingHandler) {
+	subject := router.Group("/admin/subjects/:subject_id")
+	subject.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		subject.GET("/standard-setting/sessions", standardSettingHandler.ListSessions)
+		subject.POST("/performance-levels", standardSettingHandler.CreateLevelSet)
+		subject.GET("/performance-levels", standardSettingHandler.ListLevelSets)
+	}
+
+	admin := router.Group("/admin/standard-setting/sessions")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.POST("", standardSettingHandler.CreateSession)
+		admin.GET("/:session_id", standardSettingHandler.GetSession)
+		admin.GET("/:session_id/booklet", standardSettingHandler.GetBooklet)
+		admin.POST("/:session_id/rounds", standardSettingHandler.NextRound)
+		admin.GET("/:session_id/results", standardSettingHandler.GetResults)
+		admin.POST("/:session_id/adopt", standardSettingHandler.Adopt)
+	}
+
+	// 教师作为评委查看题册并提交评定
+	teacher := router.Group("/teacher/standard-setting/sessions/:session_id")
+	teacher.Use(middleware.RequireRole(models.RoleTeacher))
+	{
+		teacher.GET("", standardSettingHandler.GetSession)
+		teacher.GET("/booklet", standardSettingHandler.GetBooklet)
+		teacher.POST("/ratings", standardSettingHandler.SubmitRatings)
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupStatisticsRoutes(router *gin.Engine, statisticsHandler *handlers.StatisticsHandler) {
+	admin := router.Group("/admin/exam-papers/:paper_id/statistics")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", statisticsHandler.GetPaperStatistics)
+		admin.GET("/distractors", statisticsHandler.GetPaperDistractors)
+	}
+
+	// 教师查看所出试卷的题目质量
+	teacher := router.Group("/teacher/exam-papers/:paper_id/statistics")
+	teacher.Use(middleware.RequireRole(models.RoleTeacher))
+	{
+		teacher.GET("", statisticsHandler.GetPaperStatistics)
+		teacher.GET("/distractors", statisticsHandler.GetPaperDistractors)
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSubjectModelRoutes(router *gin.Engine, subjectModelHandler *handlers.SubjectModelHandler) {
+	admin := router.Group("/admin/subjects/:subject_id/irt-model")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", subjectModelHandler.GetIRTModel)
+		admin.PUT("", subjectModelHandler.UpdateIRTModel)
+	}
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"irt-exam-system/backend/internal/interfaces/api/handlers"
+	"irt-exam-system/backend/internal/interfaces/api/middleware"
+	"irt-exam-system/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTestInformationRoutes(router *gin.Engine, testInformationHandler *handlers.TestInformationHandler) {
+	admin := router.Group("/admin/exam-papers/:paper_id/test-information")
+	admin.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.GET("", testInformationHandler.GetCurves)
+	}
+
+	teacher := router.Group("/teacher/exam-papers/:paper_id/test-information")
+	teacher.Use(middleware.RequireRole(models.RoleTeacher))
+	{
+		teacher.GET("", testInformationHandler.GetCurves)
+	}
+}
@@ -24,11 +24,32 @@ type UserAbility struct {
 // QuestionParameter IRT题目参数表
 type QuestionParameter struct {
 	gorm.Model
-	QuestionID     uint     `gorm:"not null;uniqueIndex"`
-	Difficulty     float64  `gorm:"not null;default:0.5;type:numeric"` // b参数：难度
-	Discrimination float64  `gorm:"not null;default:1.0;type:numeric"` // a参数：区分度
-	Guessing       float64  `gorm:"not null;default:0.0;type:numeric"` // c参数：猜测参数
-	Question       Question `gorm:"foreignKey:QuestionID"`
+	QuestionID     uint    `gorm:"not null;uniqueIndex"`
+	Difficulty     float64 `gorm:"not null;default:0.5;type:numeric"` // b参数：难度
+	Discrimination float64 `gorm:"not null;default:1.0;type:numeric"` // a参数：区分度
+	Guessing       float64 `gorm:"not null;default:0.0;type:numeric"` // c参数：猜测参数
+	UpperAsymptote float64 `gorm:"not null;default:1.0;type:numeric"` // d参数：上渐近线（4PL）
+	// 标定结果
+	DifficultySE     float64    `gorm:"not null;default:0;type:numeric"` // b参数标准误
+	DiscriminationSE float64    `gorm:"not null;default:0;type:numeric"` // a参数标准误
+	GuessingSE       float64    `gorm:"not null;default:0;type:numeric"` // c参数标准误
+	UpperAsymptoteSE float64    `gorm:"not null;default:0;type:numeric"` // d参数标准误
+	CalibrationModel string     `gorm:"type:text"`                       // 标定模型（Rasch/1PL/2PL/3PL/4PL）
+	CalibratedAt     *time.Time `gorm:"type:timestamptz"`                // 最近一次标定时间
+	Question         Question   `gorm:"foreignKey:QuestionID"`
+
+	// 多级计分模型，GRM/GPCM 的类别阈值见 QuestionCategoryParameter
+	ItemModel  string                      `gorm:"not null;default:'3pl';type:text"` // 3pl、grm、gpcm
+	Categories []QuestionCategoryParameter `gorm:"foreignKey:QuestionID;references:QuestionID"`
+}
+
+// QuestionCategoryParameter 多级计分题的类别阈值参数，Category 从1开始
+type QuestionCategoryParameter struct {
+	gorm.Model
+	QuestionID  uint    `gorm:"not null;uniqueIndex:idx_question_category"`
+	Category    int     `gorm:"not null;uniqueIndex:idx_question_category"`
+	Threshold   float64 `gorm:"not null;type:numeric"`           // b_k：得分达到第 k 类的阈值
+	ThresholdSE float64 `gorm:"not null;default:0;type:numeric"` // 阈值标准误
 }
 
 // AbilityEstimation 能力值估计历史记录
@@ -37,9 +58,10 @@ type AbilityEstimation struct {
 	UserID        uint       `gorm:"not null;index"`
 	SubjectID     uint       `gorm:"not null;index"`
 	ExamRecordID  uint       `gorm:"not null;index"`
-	Ability       float64    `gorm:"not null;type:numeric"` // 估计的能力值
-	StandardError float64    `gorm:"not null;type:numeric"` // 标准误
-	Method        string     `gorm:"not null;type:text"`    // 估计方法（如：MLE, EAP, MAP等）
+	ExamSessionID uint       `gorm:"not null;default:0;index"` // 自适应考试会话结束时的估计，按考试记录估计时为0
+	Ability       float64    `gorm:"not null;type:numeric"`    // 估计的能力值
+	StandardError float64    `gorm:"not null;type:numeric"`    // 标准误
+	Method        string     `gorm:"not null;type:text"`       // 估计方法（如：MLE, EAP, MAP等）
 	User          User       `gorm:"foreignKey:UserID"`
 	Subject       Subject    `gorm:"foreignKey:SubjectID"`
 	ExamRecord    ExamRecord `gorm:"foreignKey:ExamRecordID"`
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DIFResult 题目功能差异（DIF）分析结果
+type DIFResult struct {
+	gorm.Model
+	SubjectID      uint   `gorm:"not null;index:idx_dif_analysis"`
+	QuestionID     uint   `gorm:"not null;index"`
+	GroupAttribute string `gorm:"not null;type:text;index:idx_dif_analysis"` // 分组属性：class、region、gender
+	ReferenceGroup string `gorm:"not null;type:text;index:idx_dif_analysis"` // 参照组取值
+	FocalGroup     string `gorm:"not null;type:text;index:idx_dif_analysis"` // 焦点组取值
+	ReferenceCount int    `gorm:"not null;default:0"`
+	FocalCount     int    `gorm:"not null;default:0"`
+	Skipped        bool   `gorm:"not null;default:false"` // 任一组人数不足，未做分析
+
+	// Mantel–Haenszel
+	MHOddsRatio float64 `gorm:"not null;default:0;type:numeric"`
+	MHDelta     float64 `gorm:"not null;default:0;type:numeric"` // MH D-DIF
+	MHDeltaSE   float64 `gorm:"not null;default:0;type:numeric"`
+	MHChiSquare float64 `gorm:"not null;default:0;type:numeric"`
+	MHPValue    float64 `gorm:"not null;default:1;type:numeric"`
+	ETSClass    string  `gorm:"not null;default:'A';type:varchar(1)"` // ETS 等级：A、B、C
+
+	// logistic 回归
+	LRUniform    float64 `gorm:"not null;default:0;type:numeric"`
+	LRNonUniform float64 `gorm:"not null;default:0;type:numeric"`
+	LRPValue     float64 `gorm:"not null;default:1;type:numeric"`
+	LRDeltaR2    float64 `gorm:"not null;default:0;type:numeric"`
+	LRClass      string  `gorm:"not null;default:'A';type:varchar(1)"`
+
+	AnalyzedAt time.Time `gorm:"not null;type:timestamptz"`
+	Question   Question  `gorm:"foreignKey:QuestionID"`
+}
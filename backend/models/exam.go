@@ -21,6 +21,19 @@ type ExamPaper struct {
 	Subject     Subject             `gorm:"foreignKey:SubjectID"`
 	Questions   []ExamPaperQuestion `gorm:"foreignKey:ExamPaperID"`
 	Records     []ExamRecord        `gorm:"foreignKey:ExamPaperID"`
+
+	// 自适应考试配置
+	SelectionStrategy string `gorm:"not null;default:'max_info';type:text"` // 选题策略：max_info、kl、a_stratified、b_matching、d_optimal
+
+	// 终止规则，阈值为0表示不启用
+	MinItems            int     `gorm:"not null;default:5"`
+	MaxItems            int     `gorm:"not null;default:30"`
+	TargetSE            float64 `gorm:"not null;default:0.3;type:numeric"`
+	ThetaChange         float64 `gorm:"not null;default:0;type:numeric"`
+	Classification      string  `gorm:"not null;default:'';type:text"`      // 分类终止方法：sprt、ci，空表示不启用
+	CutScore            float64 `gorm:"not null;default:0;type:numeric"`    // 能力量尺上的划界分数
+	IndifferenceRegion  float64 `gorm:"not null;default:0.2;type:numeric"`  // SPRT 无差异区间半宽
+	ClassificationError float64 `gorm:"not null;default:0.05;type:numeric"` // 分类错误率
 }
 
 // ExamPaperQuestion 定义试卷题目关联
@@ -32,6 +45,9 @@ type ExamPaperQuestion struct {
 	Order       int64     `gorm:"not null;type:bigint"`
 	ExamPaper   ExamPaper `gorm:"foreignKey:ExamPaperID"`
 	Question    Question  `gorm:"foreignKey:QuestionID"`
+
+	// 嵌入的试测题位置，分值为0，不计入试卷总分
+	Pretest bool `gorm:"not null;default:false"`
 }
 
 // ExamRecord 定义考试记录
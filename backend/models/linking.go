@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ScaleTransformation 量尺转换历史：将新试卷单独标定的量尺连接到基准试卷的量尺
+// θ* = Slope·θ + Intercept
+type ScaleTransformation struct {
+	gorm.Model
+	SubjectID   uint    `gorm:"not null;index"`
+	NewPaperID  uint    `gorm:"not null;index"`                  // 待转换试卷
+	BasePaperID uint    `gorm:"not null;index"`                  // 基准试卷
+	Method      string  `gorm:"not null;type:text"`              // mean_mean、mean_sigma、haebara、stocking_lord
+	Slope       float64 `gorm:"not null;default:1;type:numeric"` // A
+	Intercept   float64 `gorm:"not null;default:0;type:numeric"` // B
+	AnchorCount int     `gorm:"not null;default:0"`
+
+	// 应用到题目参数与能力值的情况，未应用时只是一次试算记录
+	Applied           bool       `gorm:"not null;default:false"`
+	ItemsRescaled     int        `gorm:"not null;default:0"`
+	AbilitiesRescaled int        `gorm:"not null;default:0"`
+	AppliedAt         *time.Time `gorm:"type:timestamptz"`
+}
@@ -0,0 +1,208 @@
+package models
+
+import (
+	"sort"
+
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"gorm.io/gorm"
+)
+
+// MIRTCalibration 科目的补偿型多维标定：以顶层知识点为维度，同一科目按版本递增，最新启用版本用于能力剖面与 D 最优选题
+type MIRTCalibration struct {
+	gorm.Model
+	SubjectID     uint                `gorm:"not null;index"`
+	Version       int                 `gorm:"not null"`
+	Family        string              `gorm:"not null;type:text"` // M2PL、M3PL
+	D             float64             `gorm:"not null;default:1.7;type:numeric"`
+	Examinees     int                 `gorm:"not null;default:0"`
+	Iterations    int                 `gorm:"not null;default:0"`
+	Converged     bool                `gorm:"not null;default:false"`
+	LogLikelihood float64             `gorm:"not null;default:0;type:numeric"`
+	Active        bool                `gorm:"not null;default:true"`
+	Dimensions    []MIRTDimension     `gorm:"foreignKey:CalibrationID"`
+	Correlations  []MIRTCorrelation   `gorm:"foreignKey:CalibrationID"`
+	Items         []MIRTItemParameter `gorm:"foreignKey:CalibrationID"`
+}
+
+// MIRTDimension 标定的一个维度，对应科目的一个顶层知识点，Position 从0开始
+type MIRTDimension struct {
+	gorm.Model
+	CalibrationID    uint   `gorm:"not null;index"`
+	Position         int    `gorm:"not null"`
+	KnowledgePointID uint   `gorm:"not null"`
+	Name             string `gorm:"not null;type:text"` // 标定时的知识点名称
+}
+
+// MIRTCorrelation 维度间能力相关，只保存 Row < Col 的上三角元素
+type MIRTCorrelation struct {
+	gorm.Model
+	CalibrationID uint    `gorm:"not null;index"`
+	Row           int     `gorm:"not null"`
+	Col           int     `gorm:"not null"`
+	Value         float64 `gorm:"not null;type:numeric"`
+}
+
+// MIRTItemParameter 题目的多维参数：截距、猜测参数与各维度的区分度
+type MIRTItemParameter struct {
+	gorm.Model
+	CalibrationID uint          `gorm:"not null;uniqueIndex:idx_mirt_item"`
+	QuestionID    uint          `gorm:"not null;uniqueIndex:idx_mirt_item"`
+	Intercept     float64       `gorm:"not null;type:numeric"`           // d
+	Guessing      float64       `gorm:"not null;default:0;type:numeric"` // c，M2PL 为0
+	InterceptSE   float64       `gorm:"not null;default:0;type:numeric"`
+	GuessingSE    float64       `gorm:"not null;default:0;type:numeric"`
+	ResponseCount int           `gorm:"not null;default:0"`
+	Loadings      []MIRTLoading `gorm:"foreignKey:ItemParameterID"`
+}
+
+// MIRTLoading 题目在一个维度上的区分度，只保存加载的维度
+type MIRTLoading struct {
+	gorm.Model
+	ItemParameterID  uint    `gorm:"not null;index"`
+	Position         int     `gorm:"not null"` // 维度位置
+	Discrimination   float64 `gorm:"not null;type:numeric"`
+	DiscriminationSE float64 `gorm:"not null;default:0;type:numeric"`
+}
+
+// ResponseModel 标定使用的项目反应模型
+func (c *MIRTCalibration) ResponseModel() irt.Model {
+	family := irt.Model2PL
+	if c.Family == "M3PL" {
+		family = irt.Model3PL
+	}
+	return irt.Model{Family: family, D: c.D}
+}
+
+// CorrelationMatrix 维度间能力相关矩阵
+func (c *MIRTCalibration) CorrelationMatrix() [][]float64 {
+	dim := len(c.Dimensions)
+	matrix := make([][]float64, dim)
+	for k := range matrix {
+		matrix[k] = make([]float64, dim)
+		matrix[k][k] = 1
+	}
+	for _, r := range c.Correlations {
+		if r.Row < dim && r.Col < dim {
+			matrix[r.Row][r.Col] = r.Value
+			matrix[r.Col][r.Row] = r.Value
+		}
+	}
+	return matrix
+}
+
+// OrderedDimensions 按位置排列的维度
+func (c *MIRTCalibration) OrderedDimensions() []MIRTDimension {
+	dims := append([]MIRTDimension(nil), c.Dimensions...)
+	sort.Slice(dims, func(a, b int) bool { return dims[a].Position < dims[b].Position })
+	return dims
+}
+
+// MultiItems 按题目索引的多维题目参数，区分度向量长度等于维数
+func (c *MIRTCalibration) MultiItems() map[uint]irt.MultiItem {
+	items := make(map[uint]irt.MultiItem, len(c.Items))
+	for _, p := range c.Items {
+		items[p.QuestionID] = p.MultiItem(len(c.Dimensions))
+	}
+	return items
+}
+
+// Estimator 以维度相关矩阵为先验协方差的多维能力估计器
+func (c *MIRTCalibration) Estimator() *irt.MultiEstimator {
+	estimator := irt.NewMultiEstimator(c.ResponseModel(), len(c.Dimensions))
+	estimator.PriorCovariance = c.CorrelationMatrix()
+	return estimator
+}
+
+// Profile 由按本标定得到的多维能力估计构造考生的能力剖面
+func (c *MIRTCalibration) Profile(userID uint, estimate *irt.MultiAbilityEstimate, responses int) *UserAbilityProfile {
+	profile := &UserAbilityProfile{
+		UserID:        userID,
+		SubjectID:     c.SubjectID,
+		CalibrationID: c.ID,
+		ResponseCount: responses,
+	}
+	for k, dim := range c.OrderedDimensions() {
+		profile.Dimensions = append(profile.Dimensions, AbilityProfileDimension{
+			Position:         k,
+			KnowledgePointID: dim.KnowledgePointID,
+			Name:             dim.Name,
+			Theta:            estimate.Theta[k],
+			StandardError:    estimate.StandardErrors[k],
+		})
+		for l := k; l < len(estimate.Covariance); l++ {
+			profile.Covariance = append(profile.Covariance, AbilityProfileCovariance{Row: k, Col: l, Value: estimate.Covariance[k][l]})
+		}
+	}
+	return profile
+}
+
+// MultiItem 转换为多维题目参数
+func (p *MIRTItemParameter) MultiItem(dimensions int) irt.MultiItem {
+	item := irt.MultiItem{
+		QuestionID:     p.QuestionID,
+		Discrimination: make([]float64, dimensions),
+		Intercept:      p.Intercept,
+		Guessing:       p.Guessing,
+	}
+	for _, l := range p.Loadings {
+		if l.Position < dimensions {
+			item.Discrimination[l.Position] = l.Discrimination
+		}
+	}
+	return item
+}
+
+// UserAbilityProfile 考生在科目各维度上的能力剖面，每个考生每科目一条，按最近一次估计覆盖
+type UserAbilityProfile struct {
+	gorm.Model
+	UserID        uint                       `gorm:"not null;uniqueIndex:idx_user_ability_profile"`
+	SubjectID     uint                       `gorm:"not null;uniqueIndex:idx_user_ability_profile"`
+	CalibrationID uint                       `gorm:"not null;index"` // 估计所用的多维标定
+	ResponseCount int                        `gorm:"not null;default:0"`
+	Dimensions    []AbilityProfileDimension  `gorm:"foreignKey:ProfileID"`
+	Covariance    []AbilityProfileCovariance `gorm:"foreignKey:ProfileID"`
+}
+
+// AbilityProfileDimension 能力剖面在一个维度上的能力估计
+type AbilityProfileDimension struct {
+	gorm.Model
+	ProfileID        uint    `gorm:"not null;index"`
+	Position         int     `gorm:"not null"`
+	KnowledgePointID uint    `gorm:"not null"`
+	Name             string  `gorm:"not null;type:text"`
+	Theta            float64 `gorm:"not null;type:numeric"`
+	StandardError    float64 `gorm:"not null;type:numeric"`
+}
+
+// AbilityProfileCovariance 能力向量后验协方差，只保存 Row <= Col 的上三角元素
+type AbilityProfileCovariance struct {
+	gorm.Model
+	ProfileID uint    `gorm:"not null;index"`
+	Row       int     `gorm:"not null"`
+	Col       int     `gorm:"not null"`
+	Value     float64 `gorm:"not null;type:numeric"`
+}
+
+// OrderedDimensions 按位置排列的维度能力
+func (p *UserAbilityProfile) OrderedDimensions() []AbilityProfileDimension {
+	dims := append([]AbilityProfileDimension(nil), p.Dimensions...)
+	sort.Slice(dims, func(a, b int) bool { return dims[a].Position < dims[b].Position })
+	return dims
+}
+
+// CovarianceMatrix 能力向量后验协方差矩阵
+func (p *UserAbilityProfile) CovarianceMatrix() [][]float64 {
+	dim := len(p.Dimensions)
+	matrix := make([][]float64, dim)
+	for k := range matrix {
+		matrix[k] = make([]float64, dim)
+	}
+	for _, c := range p.Covariance {
+		if c.Row < dim && c.Col < dim {
+			matrix[c.Row][c.Col] = c.Value
+			matrix[c.Col][c.Row] = c.Value
+		}
+	}
+	return matrix
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PretestSetting 科目级试测题嵌入与在线标定配置
+type PretestSetting struct {
+	gorm.Model
+	SubjectID  uint    `gorm:"not null;uniqueIndex"`
+	Rate       float64 `gorm:"not null;default:0;type:numeric"`  // 试测题占施测题目的目标比例，0表示不嵌入
+	MaxItems   int     `gorm:"not null;default:2"`               // 每场考试（每份试卷）最多嵌入的试测题数，0表示不限
+	StartAfter int     `gorm:"not null;default:3"`               // 前若干道计分题不嵌入试测题
+	Method     string  `gorm:"not null;default:'mem';type:text"` // 在线标定方法：method_a、oem、mem
+
+	// 转正条件，标准误阈值为0表示不检查该参数
+	MinResponses        int     `gorm:"not null;default:200"`
+	MaxDifficultySE     float64 `gorm:"not null;default:0.3;type:numeric"`
+	MaxDiscriminationSE float64 `gorm:"not null;default:0.3;type:numeric"`
+	MaxGuessingSE       float64 `gorm:"not null;default:0.1;type:numeric"`
+	MaxUpperAsymptoteSE float64 `gorm:"not null;default:0.1;type:numeric"`
+}
+
+// PretestCalibration 试测题最近一次在线标定结果
+type PretestCalibration struct {
+	gorm.Model
+	QuestionID       uint       `gorm:"not null;uniqueIndex"`
+	SubjectID        uint       `gorm:"not null;index"`
+	Method           string     `gorm:"not null;type:text"`
+	Difficulty       float64    `gorm:"not null;type:numeric"`
+	Discrimination   float64    `gorm:"not null;type:numeric"`
+	Guessing         float64    `gorm:"not null;default:0;type:numeric"`
+	UpperAsymptote   float64    `gorm:"not null;default:1;type:numeric"`
+	DifficultySE     float64    `gorm:"not null;default:0;type:numeric"`
+	DiscriminationSE float64    `gorm:"not null;default:0;type:numeric"`
+	GuessingSE       float64    `gorm:"not null;default:0;type:numeric"`
+	UpperAsymptoteSE float64    `gorm:"not null;default:0;type:numeric"`
+	ResponseCount    int        `gorm:"not null;default:0"`
+	Promoted         bool       `gorm:"not null;default:false"`
+	PromotedAt       *time.Time `gorm:"type:timestamptz"`
+}
+
+// PretestResponse 在线标定使用的作答，来自固定卷考试记录或自适应考试会话；
+// Examinee 形如 record:12、session:7，用于按考生归并作答
+type PretestResponse struct {
+	Examinee   string
+	QuestionID uint
+	IsCorrect  bool
+	Pretest    bool // 题目当前是否为试测题
+}
+
+// PretestItem 试测题及其已收集的作答数
+type PretestItem struct {
+	QuestionID    uint
+	ResponseCount int64
+}
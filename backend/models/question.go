@@ -22,6 +22,8 @@ type Question struct {
 	IRTDifficulty     float64 `gorm:"type:decimal(5,2);not null;default:0.5" json:"irt_difficulty"`     // b参数：难度
 	IRTDiscrimination float64 `gorm:"type:decimal(5,2);not null;default:1.0" json:"irt_discrimination"` // a参数：区分度
 	IRTGuessing       float64 `gorm:"type:decimal(3,2);not null;default:0.0" json:"irt_guessing"`       // c参数：猜测参数
+	// 试测题：参数未标定，随考试施测但不计分、不参与能力估计与正式标定，在线标定达标后转正
+	Pretest bool `gorm:"not null;default:false;index" json:"pretest"`
 }
 
 // QuestionResponse represents the response for a question
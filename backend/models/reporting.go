@@ -0,0 +1,95 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"irt-exam-system/backend/internal/domain/analysis"
+	"irt-exam-system/backend/internal/domain/irt"
+
+	"gorm.io/gorm"
+)
+
+// ReportingScale 科目报告分数量表：能力值 θ 到报告分数的转换，同一科目按版本递增，最新启用版本用于报告
+type ReportingScale struct {
+	gorm.Model
+	SubjectID    uint                   `gorm:"not null;index"`
+	Version      int                    `gorm:"not null"`
+	Name         string                 `gorm:"not null;type:text"`
+	Transform    string                 `gorm:"not null;type:text"`              // linear、nonlinear
+	Slope        float64                `gorm:"not null;default:1;type:numeric"` // 线性转换斜率
+	Intercept    float64                `gorm:"not null;default:0;type:numeric"` // 线性转换截距
+	RoundingStep float64                `gorm:"not null;default:1;type:numeric"` // 取整步长，0 表示不取整
+	Rounding     string                 `gorm:"not null;default:'nearest';type:text"`
+	MinScore     float64                `gorm:"not null;default:0;type:numeric"`
+	MaxScore     float64                `gorm:"not null;default:0;type:numeric"` // 不高于 MinScore 时不截断
+	Active       bool                   `gorm:"not null;default:true"`
+	Points       []ScaleConversionPoint `gorm:"foreignKey:ScaleID"`
+}
+
+// IRTScale 转换为报告分数换算参数，转换表按 θ 升序
+func (s *ReportingScale) IRTScale() irt.ReportingScale {
+	scale := irt.ReportingScale{
+		Transform:    irt.ScaleTransform(s.Transform),
+		Slope:        s.Slope,
+		Intercept:    s.Intercept,
+		RoundingStep: s.RoundingStep,
+		Rounding:     irt.RoundingMode(s.Rounding),
+		Min:          s.MinScore,
+		Max:          s.MaxScore,
+	}
+	for _, p := range s.Points {
+		scale.Points = append(scale.Points, irt.ScalePoint{Theta: p.Theta, Score: p.Score})
+	}
+	sort.Slice(scale.Points, func(a, b int) bool { return scale.Points[a].Theta < scale.Points[b].Theta })
+	return scale
+}
+
+// ScaleConversionPoint 非线性量表转换表中的一个点
+type ScaleConversionPoint struct {
+	gorm.Model
+	ScaleID uint    `gorm:"not null;index"`
+	Theta   float64 `gorm:"not null;type:numeric"`
+	Score   float64 `gorm:"not null;type:numeric"`
+}
+
+// NormTable 报告分数的百分位常模表，由参照群体在某一量表版本上的报告分数计算，同一量表按版本递增
+type NormTable struct {
+	gorm.Model
+	ScaleID    uint             `gorm:"not null;index"`
+	SubjectID  uint             `gorm:"not null;index"`
+	Version    int              `gorm:"not null"`
+	Cohort     string           `gorm:"not null;type:text"` // 参照群体说明
+	CohortFrom *time.Time       `gorm:"type:timestamptz"`   // 参照群体能力值的更新时间范围
+	CohortTo   *time.Time       `gorm:"type:timestamptz"`
+	SampleSize int              `gorm:"not null;default:0"`
+	MeanScore  float64          `gorm:"not null;default:0;type:numeric"`
+	SDScore    float64          `gorm:"not null;default:0;type:numeric"`
+	Active     bool             `gorm:"not null;default:true"`
+	Entries    []NormTableEntry `gorm:"foreignKey:NormTableID"`
+}
+
+// NormEntries 转换为按分数升序的常模表
+func (t *NormTable) NormEntries() []analysis.NormEntry {
+	entries := make([]analysis.NormEntry, len(t.Entries))
+	for i, e := range t.Entries {
+		entries[i] = analysis.NormEntry{
+			Score:             e.ScaledScore,
+			Frequency:         e.Frequency,
+			PercentileRank:    e.PercentileRank,
+			CumulativePercent: e.CumulativePercent,
+		}
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].Score < entries[b].Score })
+	return entries
+}
+
+// NormTableEntry 常模表中的一个报告分数
+type NormTableEntry struct {
+	gorm.Model
+	NormTableID       uint    `gorm:"not null;index"`
+	ScaledScore       float64 `gorm:"not null;type:numeric"`
+	Frequency         int     `gorm:"not null;default:0"`
+	PercentileRank    float64 `gorm:"not null;type:numeric"`
+	CumulativePercent float64 `gorm:"not null;type:numeric"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ResponseTimeParameter 题目的对数正态作答时间模型参数：ln T ~ N(β − τ, 1/α²)
+type ResponseTimeParameter struct {
+	gorm.Model
+	QuestionID       uint       `gorm:"not null;uniqueIndex"`
+	SubjectID        uint       `gorm:"not null;index"`
+	Intensity        float64    `gorm:"not null;type:numeric"` // β：时间强度
+	Discrimination   float64    `gorm:"not null;type:numeric"` // α：时间区分度
+	IntensitySE      float64    `gorm:"not null;default:0;type:numeric"`
+	DiscriminationSE float64    `gorm:"not null;default:0;type:numeric"`
+	ResponseCount    int        `gorm:"not null;default:0"`
+	CalibratedAt     *time.Time `gorm:"type:timestamptz"`
+}
+
+// SpeedPopulation 科目考生能力与速度的二元正态总体分布，速度均值固定为0
+type SpeedPopulation struct {
+	gorm.Model
+	SubjectID    uint       `gorm:"not null;uniqueIndex"`
+	MeanTheta    float64    `gorm:"not null;default:0;type:numeric"`
+	SDTheta      float64    `gorm:"not null;default:1;type:numeric"`
+	MeanSpeed    float64    `gorm:"not null;default:0;type:numeric"`
+	SDSpeed      float64    `gorm:"not null;default:1;type:numeric"`
+	Correlation  float64    `gorm:"not null;default:0;type:numeric"` // 能力与速度的相关
+	Examinees    int        `gorm:"not null;default:0"`
+	CalibratedAt *time.Time `gorm:"type:timestamptz"`
+}
+
+// TimedResponse 带作答用时的作答，来源与 PretestResponse 相同
+type TimedResponse struct {
+	PretestResponse
+	Seconds float64
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// StandardSettingSession 一次标准设定会议：评委组针对一份试卷以修正 Angoff 或书签法评定各表现水平的分界点
+type StandardSettingSession struct {
+	gorm.Model
+	SubjectID           uint                   `gorm:"not null;index"`
+	ExamPaperID         uint                   `gorm:"not null;index"`
+	Name                string                 `gorm:"not null;type:text"`
+	Method              string                 `gorm:"not null;type:text"`                 // angoff、bookmark
+	ResponseProbability float64                `gorm:"not null;default:0.67;type:numeric"` // 书签法掌握标准
+	Round               int                    `gorm:"not null;default:1"`                 // 当前评定轮次
+	Status              string                 `gorm:"not null;default:'open';type:text"`  // open、closed
+	Levels              []StandardSettingLevel `gorm:"foreignKey:SessionID"`
+}
+
+// StandardSettingLevel 会议要划分的表现水平，Order 由低到高从0开始
+type StandardSettingLevel struct {
+	gorm.Model
+	SessionID uint   `gorm:"not null;index"`
+	Order     int    `gorm:"not null"`
+	Name      string `gorm:"not null;type:text"`
+}
+
+// StandardSettingRating 评委的一条评定；Boundary 为第 Boundary 个水平与其下一水平的分界，从1开始；
+// Angoff 法 Value 为临界考生答对 QuestionID 的概率，书签法 QuestionID 为0、Value 为书签页码
+type StandardSettingRating struct {
+	gorm.Model
+	SessionID  uint    `gorm:"not null;uniqueIndex:idx_standard_setting_rating"`
+	PanelistID uint    `gorm:"not null;uniqueIndex:idx_standard_setting_rating"`
+	Round      int     `gorm:"not null;uniqueIndex:idx_standard_setting_rating"`
+	Boundary   int     `gorm:"not null;uniqueIndex:idx_standard_setting_rating"`
+	QuestionID uint    `gorm:"not null;default:0;uniqueIndex:idx_standard_setting_rating"`
+	Value      float64 `gorm:"not null;type:numeric"`
+}
+
+// OrderedLevels 按顺序排列的水平名称
+func (s *StandardSettingSession) OrderedLevels() []string {
+	levels := append([]StandardSettingLevel(nil), s.Levels...)
+	sort.Slice(levels, func(a, b int) bool { return levels[a].Order < levels[b].Order })
+	names := make([]string, len(levels))
+	for i, l := range levels {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// PerformanceLevelSet 科目表现水平划分，同一科目按版本递增，最新启用版本用于报告
+type PerformanceLevelSet struct {
+	gorm.Model
+	SubjectID uint               `gorm:"not null;index"`
+	Version   int                `gorm:"not null"`
+	Name      string             `gorm:"not null;type:text"`
+	SessionID *uint              `gorm:"index"` // 来源标准设定会议，手工设定时为空
+	Active    bool               `gorm:"not null;default:true"`
+	Levels    []PerformanceLevel `gorm:"foreignKey:LevelSetID"`
+}
+
+// PerformanceLevel 一个表现水平，Order 由低到高从0开始
+type PerformanceLevel struct {
+	gorm.Model
+	LevelSetID uint     `gorm:"not null;index"`
+	Order      int      `gorm:"not null"`
+	Name       string   `gorm:"not null;type:text"`
+	CutScore   *float64 `gorm:"type:numeric"` // 进入该水平的最低能力值，最低水平为空
+}
+
+// Classify 能力值所属的表现水平：划界分数不高于能力值的最高水平
+func (s *PerformanceLevelSet) Classify(theta float64) *PerformanceLevel {
+	levels := make([]*PerformanceLevel, len(s.Levels))
+	for i := range s.Levels {
+		levels[i] = &s.Levels[i]
+	}
+	sort.Slice(levels, func(a, b int) bool { return levels[a].Order < levels[b].Order })
+	var level *PerformanceLevel
+	for _, l := range levels {
+		if l.CutScore == nil || theta >= *l.CutScore {
+			level = l
+		}
+	}
+	return level
+}
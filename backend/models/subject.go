@@ -9,6 +9,8 @@ type Subject struct {
 	gorm.Model
 	Name            string           `gorm:"uniqueIndex;not null;type:text"`
 	Description     string           `gorm:"type:text"`
+	IRTModel        string           `gorm:"not null;default:'3PL';type:text"`  // 项目反应模型：Rasch、1PL、2PL、3PL、4PL
+	ScalingConstant float64          `gorm:"not null;default:1.7;type:numeric"` // 量尺因子 D：1.0 或 1.7
 	Questions       []Question       `gorm:"foreignKey:SubjectID"`
 	KnowledgePoints []KnowledgePoint `gorm:"foreignKey:SubjectID"`
 	ExamPapers      []ExamPaper      `gorm:"foreignKey:SubjectID"`
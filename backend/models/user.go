@@ -19,6 +19,11 @@ type User struct {
 	ExamRecords  []ExamRecord `gorm:"foreignKey:UserID"`
 	Permissions  []string     `json:"permissions,omitempty" gorm:"-"`
 	LastLoginAt  *time.Time   `json:"last_login_at,omitempty"`
+
+	// 分组属性，用于 DIF 分析
+	ClassName string `gorm:"type:varchar(64);index" json:"class_name,omitempty"` // 班级
+	Region    string `gorm:"type:varchar(64);index" json:"region,omitempty"`     // 地区
+	Gender    string `gorm:"type:varchar(16)" json:"gender,omitempty"`           // 性别
 }
 
 // Role 角色表